@@ -6,3 +6,6 @@
 
 mkdir -p bin
 go build -o bin ./...
+
+# Generate the man pages and markdown references of the commands for the packages
+bin/happycompta gen-docs bin/docs
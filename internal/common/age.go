@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// ageVersionLine is the first line of the binary age files.
+const ageVersionLine = "age-encryption.org/v1"
+
+// ageScryptWorkFactor is the scrypt work factor of the files encrypted with a passphrase, the age tool one.
+var ageScryptWorkFactor = 18
+
+// ageKeys are the secrets able to decrypt age files.
+type ageKeys struct {
+	// identities are the identities read from the age identity files.
+	identities []age.Identity
+	// passphrase returns the passphrase of the files encrypted with one, like by prompting it.
+	passphrase func() (string, error)
+	// identityErrors are the problems of the identities that couldn't be loaded, reported if none matches.
+	identityErrors []error
+}
+
+// isAgeEncrypted returns whether the data is an age encrypted file, binary or armored.
+func isAgeEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageVersionLine+"\n")) ||
+		bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte(armor.Header))
+}
+
+// decryptAge decrypts an age file, binary or armored, with the keys.
+func decryptAge(data []byte, keys ageKeys) ([]byte, error) {
+	var src io.Reader = bytes.NewReader(data)
+	if !bytes.HasPrefix(data, []byte(ageVersionLine+"\n")) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimLeft(data, " \t\r\n")))
+	}
+
+	identities := keys.identities
+	if keys.passphrase != nil {
+		identities = append(slices.Clone(identities), &passphraseIdentity{passphrase: keys.passphrase})
+	}
+	r, err := age.Decrypt(src, identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		err = errors.New("no age identity matches the recipients of the file")
+		return nil, errors.Join(append([]error{err}, keys.identityErrors...)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the age payload: %s", err)
+	}
+	return plaintext, nil
+}
+
+// passphraseIdentity decrypts the files encrypted with a passphrase.
+// The passphrase is only asked for the files having an scrypt stanza.
+type passphraseIdentity struct {
+	passphrase func() (string, error)
+}
+
+func (i *passphraseIdentity) Unwrap(stanzas []*age.Stanza) ([]byte, error) {
+	if !slices.ContainsFunc(stanzas, func(s *age.Stanza) bool { return s.Type == "scrypt" }) {
+		return nil, age.ErrIncorrectIdentity
+	}
+	passphrase, err := i.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	fileKey, err := identity.Unwrap(stanzas)
+	if errors.Is(err, age.ErrIncorrectIdentity) {
+		return nil, errors.New("invalid passphrase")
+	}
+	return fileKey, err
+}
+
+// parseAgeIdentities reads the identities of an age identity file.
+// The empty lines and comments starting with # are ignored.
+func parseAgeIdentities(content string) ([]age.Identity, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid age identities: %s", err)
+	}
+	return identities, nil
+}
+
+// ageRecipients returns the recipients of the identities, to encrypt the files they can decrypt.
+func ageRecipients(identities []age.Identity) []age.Recipient {
+	var recipients []age.Recipient
+	for _, identity := range identities {
+		switch identity := identity.(type) {
+		case *age.X25519Identity:
+			recipients = append(recipients, identity.Recipient())
+		case *age.HybridIdentity:
+			recipients = append(recipients, identity.Recipient())
+		}
+	}
+	return recipients
+}
+
+// encryptAge encrypts the data in the binary age format for the recipients or,
+// without recipient, with the passphrase.
+func encryptAge(plaintext []byte, recipients []age.Recipient, passphrase string) ([]byte, error) {
+	if len(recipients) == 0 {
+		if passphrase == "" {
+			return nil, errors.New("no age recipient or passphrase to encrypt with")
+		}
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		recipient.SetWorkFactor(ageScryptWorkFactor)
+		recipients = []age.Recipient{recipient}
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// ageChunkSizeForTest is the size of the age payload chunks, to test the data spanning several ones.
+const ageChunkSizeForTest = 64 * 1024
+
+// encryptAgeForTest encrypts the plaintext for the recipients like the age tool.
+func encryptAgeForTest(t *testing.T, plaintext []byte, recipients ...age.Recipient) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// scryptRecipientForTest returns a passphrase recipient with a low work factor to keep the tests fast.
+func scryptRecipientForTest(t *testing.T, passphrase string) age.Recipient {
+	t.Helper()
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient.SetWorkFactor(10)
+	return recipient
+}
+
+func newAgeIdentityForTest(t *testing.T) *age.X25519Identity {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+// armorAgeForTest wraps an age file in its armor.
+func armorAgeForTest(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := armor.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecryptAge(t *testing.T) {
+	identity := newAgeIdentityForTest(t)
+	other := newAgeIdentityForTest(t)
+	keys := ageKeys{
+		identities: []age.Identity{identity},
+		passphrase: func() (string, error) { return "correct horse", nil },
+	}
+
+	small := []byte("email: treasurer@example.com\npassword: secret\n")
+	large := bytes.Repeat([]byte("0123456789"), 2*ageChunkSizeForTest/10+1)
+	exact := bytes.Repeat([]byte("a"), ageChunkSizeForTest)
+	tamperedMAC := encryptAgeForTest(t, small, identity.Recipient())
+	macStart := bytes.Index(tamperedMAC, []byte("\n--- ")) + len("\n--- ")
+	// Change the first MAC character while keeping it valid base64.
+	if tamperedMAC[macStart] == 'A' {
+		tamperedMAC[macStart] = 'B'
+	} else {
+		tamperedMAC[macStart] = 'A'
+	}
+	tamperedPayload := encryptAgeForTest(t, small, identity.Recipient())
+	tamperedPayload[len(tamperedPayload)-1] ^= 1
+
+	tests := []struct {
+		name      string
+		data      []byte
+		keys      ageKeys
+		plaintext []byte
+		wantErr   string
+	}{
+		{"x25519", encryptAgeForTest(t, small, identity.Recipient()), keys, small, ""},
+		{
+			"several recipients",
+			encryptAgeForTest(t, small, other.Recipient(), identity.Recipient()),
+			keys, small, "",
+		},
+		{"chunks", encryptAgeForTest(t, large, identity.Recipient()), keys, large, ""},
+		{"full chunk", encryptAgeForTest(t, exact, identity.Recipient()), keys, exact, ""},
+		{"empty", encryptAgeForTest(t, nil, identity.Recipient()), keys, nil, ""},
+		{"armored", armorAgeForTest(t, encryptAgeForTest(t, small, identity.Recipient())), keys, small, ""},
+		{"passphrase", encryptAgeForTest(t, small, scryptRecipientForTest(t, "correct horse")), keys, small, ""},
+		{
+			"wrong passphrase",
+			encryptAgeForTest(t, small, scryptRecipientForTest(t, "wrong")),
+			keys, nil, "invalid passphrase",
+		},
+		{
+			"no passphrase",
+			encryptAgeForTest(t, small, scryptRecipientForTest(t, "correct horse")),
+			ageKeys{passphrase: func() (string, error) { return "", errors.New("not a terminal") }},
+			nil, "not a terminal",
+		},
+		{
+			"other recipient",
+			encryptAgeForTest(t, small, other.Recipient()),
+			ageKeys{
+				identities:     []age.Identity{identity},
+				passphrase:     func() (string, error) { return "", errors.New("unexpected prompt") },
+				identityErrors: []error{errors.New("bad keys.txt")},
+			},
+			nil, "no age identity matches the recipients of the file\nbad keys.txt",
+		},
+		{"tampered MAC", tamperedMAC, keys, nil, "bad header MAC"},
+		{"tampered payload", tamperedPayload, keys, nil, "failed to decrypt the age payload"},
+		{"not age", []byte("email: treasurer@example.com\n"), keys, nil, "invalid first line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !isAgeEncrypted(tt.data) && tt.name != "not age" {
+				t.Errorf("Expected the data to be detected as age encrypted")
+			}
+			plaintext, err := decryptAge(tt.data, tt.keys)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Error mismatch. Got: %v, Want: %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decryptAge failed: %v", err)
+			}
+			if !bytes.Equal(plaintext, tt.plaintext) {
+				t.Errorf("Plaintext mismatch. Got %d bytes, Want %d bytes", len(plaintext), len(tt.plaintext))
+			}
+		})
+	}
+}
+
+func TestParseAgeIdentities(t *testing.T) {
+	identity := newAgeIdentityForTest(t)
+	content := fmt.Sprintf("# created: 2025-03-14T12:00:00Z\n# public key: %s\n\n%s\n", identity.Recipient(), identity)
+
+	identities, err := parseAgeIdentities(content)
+	if err != nil {
+		t.Fatalf("parseAgeIdentities failed: %v", err)
+	}
+	if len(identities) != 1 || identities[0].(*age.X25519Identity).String() != identity.String() {
+		t.Errorf("Identities mismatch. Got: %d identities", len(identities))
+	}
+
+	if _, err := parseAgeIdentities("# nothing\n"); err == nil || !strings.Contains(err.Error(), "no identities found") {
+		t.Errorf("Expected a missing identity error, got: %v", err)
+	}
+	if _, err := parseAgeIdentities("AGE-PLUGIN-YUBIKEY-1QQQQQQ\n"); err == nil ||
+		!strings.Contains(err.Error(), "line 1: unknown identity type") {
+		t.Errorf("Expected an unsupported identity error, got: %v", err)
+	}
+}
+
+func TestEncryptAge(t *testing.T) {
+	workFactor := ageScryptWorkFactor
+	ageScryptWorkFactor = 10
+	t.Cleanup(func() { ageScryptWorkFactor = workFactor })
+
+	identity := newAgeIdentityForTest(t)
+	other := newAgeIdentityForTest(t)
+	recipients := ageRecipients([]age.Identity{other, identity})
+	identityKeys := ageKeys{identities: []age.Identity{identity}}
+	passphraseKeys := ageKeys{passphrase: func() (string, error) { return "correct horse", nil }}
+
+	tests := []struct {
+		name       string
+		plaintext  []byte
+		recipients int
+		passphrase string
+		keys       ageKeys
+	}{
+		{"x25519", []byte("iban: FR76\n"), 1, "", identityKeys},
+		{"several recipients", []byte("iban: FR76\n"), 2, "correct horse", identityKeys},
+		{"empty", nil, 1, "", identityKeys},
+		{"full chunk", bytes.Repeat([]byte("a"), ageChunkSizeForTest), 1, "", identityKeys},
+		{"chunks", bytes.Repeat([]byte("a"), ageChunkSizeForTest+1), 1, "", identityKeys},
+		{"passphrase", []byte("iban: FR76\n"), 0, "correct horse", passphraseKeys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := encryptAge(tt.plaintext, recipients[len(recipients)-tt.recipients:], tt.passphrase)
+			if err != nil {
+				t.Fatalf("encryptAge failed: %v", err)
+			}
+			if !isAgeEncrypted(encrypted) {
+				t.Errorf("Expected the data to be detected as age encrypted")
+			}
+			plaintext, err := decryptAge(encrypted, tt.keys)
+			if err != nil {
+				t.Fatalf("decryptAge failed: %v", err)
+			}
+			if !bytes.Equal(plaintext, tt.plaintext) {
+				t.Errorf("Plaintext mismatch. Got %d bytes, Want %d bytes", len(plaintext), len(tt.plaintext))
+			}
+		})
+	}
+
+	if _, err := encryptAge([]byte("iban: FR76\n"), nil, ""); err == nil ||
+		!strings.Contains(err.Error(), "no age recipient or passphrase") {
+		t.Errorf("Expected an error without recipient nor passphrase, got: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import "github.com/spf13/cobra"
+
+// AddFlagCompletion completes the values of a flag of the command with a fixed list of values.
+// The flag needs to be defined on the command before, either as local or persistent flag.
+func AddFlagCompletion(cmd *cobra.Command, name string, values ...string) {
+	completion := cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+	if err := cmd.RegisterFlagCompletionFunc(name, completion); err != nil {
+		// This is a programming error: the flag is misspelled or registered twice.
+		panic(err)
+	}
+}
@@ -6,7 +6,11 @@
 package common
 
 import (
-	"log"
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,37 +18,133 @@ import (
 	"github.com/spf13/viper"
 )
 
+// SharedEnvPrefix is the prefix of the environment variables shared by all the tools.
+const SharedEnvPrefix = "HAPPYCOMPTA"
+
+// BindEnv reads the configuration values from the environment variables starting with the tool prefix.
+// The dots and dashes of the keys are replaced by underscores in the variable names.
+//
+// The sharedKeys can also be set using the SharedEnvPrefix to avoid repeating the credentials for each tool.
+// The tool variable has precedence over the shared one.
+// Since the sharedKeys are secrets, they can also be read from files: see LoadSecretFile.
+func BindEnv(prefix string, sharedKeys ...string) {
+	viper.SetEnvPrefix(prefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viper.AutomaticEnv()
+
+	for _, key := range sharedKeys {
+		name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+		for _, variable := range []string{prefix + "_" + name, SharedEnvPrefix + "_" + name} {
+			if err := LoadSecretFile(variable); err != nil {
+				Exit(WithExitCode(ExitConfig, err))
+			}
+		}
+		if err := viper.BindEnv(key, prefix+"_"+name, SharedEnvPrefix+"_"+name); err != nil {
+			Fatal("error binding environment variables", "key", key, "error", err)
+		}
+	}
+}
+
 // BindFlagsToViper is a helper function to bind a flag to a Viper key.
 func BindFlagsToViper(flag *pflag.Flag) {
 	key := strings.ReplaceAll(flag.Name, "-", ".")
 
-	if flag.Name == "config" {
+	// These flags are not configuration values.
+	if flag.Name == "config" || flag.Name == "help" || flag.Name == "lang" {
 		return
 	}
 
 	if err := viper.BindPFlag(key, flag); err != nil {
-		log.Fatalf("error binding flag '%s' to viper key '%s': %v\n", flag.Name, key, err)
+		Fatal("error binding flag to viper", "flag", flag.Name, "key", key, "error", err)
+	}
+}
+
+// UnmarshalConfig decodes the configuration into cfg, a pointer to a struct, like viper.Unmarshal.
+// BindFlagsToViper turns the dashes of the flag names into nested keys: the fields of those flags
+// are named after them in a flag tag, like `flag:"dry-run"` for the dry.run key, and read from the key.
+func UnmarshalConfig(cfg any) error {
+	if err := viper.Unmarshal(cfg); err != nil {
+		return err
+	}
+	return unmarshalFlagFields(reflect.ValueOf(cfg).Elem())
+}
+
+// unmarshalFlagFields reads the fields with a flag tag of the struct value and its nested structs.
+func unmarshalFlagFields(value reflect.Value) error {
+	var errs []error
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if name, found := field.Tag.Lookup("flag"); found {
+			key := strings.ReplaceAll(name, "-", ".")
+			if err := viper.UnmarshalKey(key, value.Field(i).Addr().Interface()); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s value: %s", name, err))
+			}
+		} else if field.Type.Kind() == reflect.Struct {
+			errs = append(errs, unmarshalFlagFields(value.Field(i)))
+		}
 	}
+	return errors.Join(errs...)
 }
 
+// SetupCommand reads the configuration file and binds the flags and environment variables of a tool command to viper.
+// It is meant to run right before the command: binding the flags when creating the commands would mix those of
+// all the tools when they are the subcommands of the same program.
+//
+// The configuration file is checked against the command flags and the keys only set in the configuration file.
+func SetupCommand(cmd *cobra.Command, envPrefix string, keys ConfigKeys) {
+	InitConfig(cmd)
+	cmd.PersistentFlags().VisitAll(BindFlagsToViper)
+	cmd.Flags().VisitAll(BindFlagsToViper)
+	BindEnv(envPrefix, "email", "password")
+
+	err := SetupLogging(viper.GetString("log.level"), viper.GetString("log.format"), viper.GetString("log.file"))
+	if err != nil {
+		Exit(WithExitCode(ExitConfig, fmt.Errorf("failed to set up the logging: %s", err)))
+	}
+
+	if path := viper.ConfigFileUsed(); path != "" {
+		warnings, err := checkConfigFile(path, keys, cmd)
+		if err != nil {
+			Exit(WithExitCode(ExitConfig, fmt.Errorf("invalid configuration file %s:\n%s", path, err)))
+		}
+		for _, warning := range warnings {
+			slog.Warn("unknown configuration key", "path", path, "line", warning.Line, "problem", warning.Message)
+		}
+	}
+}
+
+// InitConfig reads the configuration file set with the config flag of the command,
+// or the optional config.yaml one in the current directory.
+// The files encrypted with age or sops are decrypted: see ReadConfigFile.
 func InitConfig(cmd *cobra.Command) {
 	configPath, err := cmd.PersistentFlags().GetString("config")
 	if err != nil {
-		log.Fatalf("error reading config flag: %s", err)
+		Fatal("error reading config flag", "error", err)
 	}
 
-	if configPath != "" {
-		viper.SetConfigFile(configPath)
-	} else {
+	if configPath == "" {
 		viper.SetConfigName("config")
 		viper.AddConfigPath(".")
-	}
-
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok && configPath == "" {
-			return
+		if err := viper.ReadInConfig(); err != nil {
+			if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+				return
+			}
+			Exit(WithExitCode(ExitConfig, fmt.Errorf("error loading configuration: %s", err)))
 		}
+		configPath = viper.ConfigFileUsed()
+	}
 
-		log.Fatalf("error loading configuration: %s\n", err)
+	// The file is read again by viper once decrypted, if needed.
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType(ConfigFileType(configPath))
+	content, err := ReadConfigFile(configPath)
+	if err == nil {
+		err = viper.ReadConfig(bytes.NewReader(content))
+	}
+	if err != nil {
+		Exit(WithExitCode(ExitConfig, fmt.Errorf("error loading configuration: %s", err)))
 	}
 }
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestBindEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		key  string
+		want string
+	}{
+		{
+			name: "tool variable",
+			env:  map[string]string{"DUMPER_EMAIL": "dumper@example.com"},
+			key:  "email",
+			want: "dumper@example.com",
+		},
+		{
+			name: "shared variable",
+			env:  map[string]string{"HAPPYCOMPTA_EMAIL": "shared@example.com"},
+			key:  "email",
+			want: "shared@example.com",
+		},
+		{
+			name: "tool variable has precedence",
+			env:  map[string]string{"HAPPYCOMPTA_PASSWORD": "shared", "DUMPER_PASSWORD": "dumper"},
+			key:  "password",
+			want: "dumper",
+		},
+		{
+			name: "other tool variable is ignored",
+			env:  map[string]string{"LOADER_EMAIL": "loader@example.com"},
+			key:  "email",
+			want: "",
+		},
+		{
+			name: "nested key",
+			env:  map[string]string{"DUMPER_COLUMN_WIDTH": "20"},
+			key:  "column.width",
+			want: "20",
+		},
+		{
+			name: "not shared key",
+			env:  map[string]string{"HAPPYCOMPTA_FORMAT": "yaml"},
+			key:  "format",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for _, name := range []string{
+				"DUMPER_EMAIL", "DUMPER_PASSWORD", "HAPPYCOMPTA_EMAIL", "HAPPYCOMPTA_PASSWORD", "LOADER_EMAIL",
+			} {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			BindEnv("DUMPER", "email", "password")
+			if got := viper.GetString(tt.key); got != tt.want {
+				t.Errorf("%s value mismatch. Got: %q, Want: %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindEnvFlagPrecedence(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	t.Setenv("DUMPER_EMAIL", "env@example.com")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("email", "", "")
+	flags.VisitAll(BindFlagsToViper)
+	BindEnv("DUMPER", "email")
+
+	if got := viper.GetString("email"); got != "env@example.com" {
+		t.Errorf("Environment value mismatch. Got: %q, Want: %q", got, "env@example.com")
+	}
+
+	if err := flags.Parse([]string{"--email", "flag@example.com"}); err != nil {
+		t.Fatalf("failed to parse the flags: %v", err)
+	}
+	if got := viper.GetString("email"); got != "flag@example.com" {
+		t.Errorf("Flag value mismatch. Got: %q, Want: %q", got, "flag@example.com")
+	}
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader("csv:\n  comma: ';'\n  ultimate:\n    debtor: payer\n")); err != nil {
+		t.Fatalf("failed to read the configuration: %v", err)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("dry-run", false, "")
+	flags.Int("max-transactions", 100, "")
+	flags.String("csv-ultimate-debtor", "", "")
+	flags.VisitAll(BindFlagsToViper)
+	if err := flags.Parse([]string{"--dry-run"}); err != nil {
+		t.Fatalf("failed to parse the flags: %v", err)
+	}
+
+	type csvConfig struct {
+		Comma          string
+		UltimateDebtor string `flag:"csv-ultimate-debtor"`
+	}
+	var cfg struct {
+		DryRun          bool `flag:"dry-run"`
+		MaxTransactions int  `flag:"max-transactions"`
+		CSV             csvConfig
+	}
+	if err := UnmarshalConfig(&cfg); err != nil {
+		t.Fatalf("UnmarshalConfig failed: %v", err)
+	}
+
+	if !cfg.DryRun {
+		t.Errorf("DryRun mismatch. Got: %t, Want: true", cfg.DryRun)
+	}
+	if cfg.MaxTransactions != 100 {
+		t.Errorf("MaxTransactions mismatch. Got: %d, Want: 100", cfg.MaxTransactions)
+	}
+	if cfg.CSV.Comma != ";" {
+		t.Errorf("Comma mismatch. Got: %q, Want: %q", cfg.CSV.Comma, ";")
+	}
+	if cfg.CSV.UltimateDebtor != "payer" {
+		t.Errorf("UltimateDebtor mismatch. Got: %q, Want: %q", cfg.CSV.UltimateDebtor, "payer")
+	}
+}
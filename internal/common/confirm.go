@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Confirm asks a yes/no question and returns true only if the user answered yes.
+func Confirm(in io.Reader, out io.Writer, question string) bool {
+	if _, err := fmt.Fprintf(out, "%s [y/N] ", question); err != nil {
+		return false
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
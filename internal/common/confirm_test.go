@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]bool{
+		"y\n":   true,
+		"Yes\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+	}
+	for answer, want := range tests {
+		var out bytes.Buffer
+		if got := Confirm(strings.NewReader(answer), &out, "Continue?"); got != want {
+			t.Errorf("Confirm(%q) got = %t, want %t", answer, got, want)
+		}
+		if out.String() != "Continue? [y/N] " {
+			t.Errorf("Unexpected question: %q", out.String())
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// KeyringService is the service name of the passwords stored in the system keyring.
+//
+// On Linux, the password can be stored with:
+//
+//	secret-tool store --label happy-compta service happycompta-tools username user@example.com
+//
+// On macOS, it can be stored with:
+//
+//	security add-generic-password -s happycompta-tools -a user@example.com -w
+const KeyringService = "happycompta-tools"
+
+// keyringLookup reads the password stored in the system keyring for an email.
+// It is a variable to be replaced in the tests.
+var keyringLookup = lookupKeyring
+
+// Credentials are the happy-compta user email and password.
+type Credentials struct {
+	Email    string
+	Password string
+}
+
+// ReadCredentials reads the happy-compta credentials once the command has been set up with SetupCommand.
+//
+// The values are taken from the first of the following sources defining them:
+//   - the email and password flags,
+//   - the environment variables with the tool prefix, then with the SharedEnvPrefix,
+//     each of them possibly read from the file referenced by the variable with a _FILE suffix,
+//   - the configuration file,
+//   - the system keyring for the password of the email.
+func ReadCredentials() Credentials {
+	credentials := Credentials{Email: viper.GetString("email"), Password: viper.GetString("password")}
+	if credentials.Email != "" && credentials.Password == "" {
+		// A missing keyring entry is the same as no password: Validate reports it.
+		credentials.Password, _ = keyringLookup(credentials.Email)
+	}
+	return credentials
+}
+
+// LoadSecretFile sets an environment variable from the file referenced by the same variable with a _FILE suffix,
+// like LOADER_PASSWORD_FILE=/run/secrets/password. This is how the secrets are mounted in Docker or Kubernetes.
+// A variable set directly has precedence over the file.
+func LoadSecretFile(name string) error {
+	path := os.Getenv(name + "_FILE")
+	if path == "" || os.Getenv(name) != "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the %s_FILE secret: %s", name, err)
+	}
+	return os.Setenv(name, strings.TrimRight(string(content), "\r\n"))
+}
+
+// Validate ensures that both the email and the password are set, returning an authentication error otherwise.
+func (c Credentials) Validate() error {
+	var allErrors []error
+	if c.Email == "" {
+		allErrors = append(allErrors, errors.New(Tr("email parameter or config value is required")))
+	}
+	if c.Password == "" {
+		allErrors = append(allErrors, errors.New(Tr("password parameter, config value or keyring entry is required")))
+	}
+	return WithExitCode(ExitAuth, errors.Join(allErrors...))
+}
+
+func lookupKeyring(email string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeyringService, "-a", email, "-w")
+	case "windows":
+		return "", errors.New("the system keyring is not supported on Windows")
+	default:
+		cmd = exec.Command("secret-tool", "lookup", "service", KeyringService, "username", email)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the password from the keyring: %s", err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestReadCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		secrets  map[string]string
+		config   string
+		keyring  map[string]string
+		expected Credentials
+	}{
+		{
+			name:     "flags over everything",
+			args:     []string{"--email", "flag@example.com", "--password", "flag"},
+			env:      map[string]string{"TOOL_EMAIL": "env@example.com", "TOOL_PASSWORD": "env"},
+			config:   "email: config@example.com\npassword: config\n",
+			keyring:  map[string]string{"flag@example.com": "keyring"},
+			expected: Credentials{Email: "flag@example.com", Password: "flag"},
+		},
+		{
+			name:     "tool environment over shared one",
+			env:      map[string]string{"TOOL_PASSWORD": "tool", "HAPPYCOMPTA_PASSWORD": "shared"},
+			config:   "email: config@example.com\npassword: config\n",
+			expected: Credentials{Email: "config@example.com", Password: "tool"},
+		},
+		{
+			name:     "shared environment over config",
+			env:      map[string]string{"HAPPYCOMPTA_EMAIL": "shared@example.com"},
+			config:   "email: config@example.com\npassword: config\n",
+			expected: Credentials{Email: "shared@example.com", Password: "config"},
+		},
+		{
+			name:     "config over keyring",
+			config:   "email: config@example.com\npassword: config\n",
+			keyring:  map[string]string{"config@example.com": "keyring"},
+			expected: Credentials{Email: "config@example.com", Password: "config"},
+		},
+		{
+			name:     "keyring password",
+			args:     []string{"--email", "flag@example.com"},
+			keyring:  map[string]string{"flag@example.com": "keyring"},
+			expected: Credentials{Email: "flag@example.com", Password: "keyring"},
+		},
+		{
+			name:     "password file",
+			secrets:  map[string]string{"TOOL_PASSWORD": "file\n"},
+			config:   "email: config@example.com\npassword: config\n",
+			expected: Credentials{Email: "config@example.com", Password: "file"},
+		},
+		{
+			name:     "variable over file",
+			env:      map[string]string{"TOOL_PASSWORD": "env"},
+			secrets:  map[string]string{"TOOL_PASSWORD": "file"},
+			config:   "email: config@example.com\n",
+			expected: Credentials{Email: "config@example.com", Password: "env"},
+		},
+		{
+			name:     "tool file over shared environment",
+			env:      map[string]string{"HAPPYCOMPTA_PASSWORD": "shared"},
+			secrets:  map[string]string{"TOOL_PASSWORD": "tool", "HAPPYCOMPTA_EMAIL": "shared@example.com"},
+			expected: Credentials{Email: "shared@example.com", Password: "tool"},
+		},
+		{
+			name:     "no keyring entry",
+			args:     []string{"--email", "flag@example.com"},
+			expected: Credentials{Email: "flag@example.com"},
+		},
+	}
+
+	lookup := keyringLookup
+	defer func() { keyringLookup = lookup }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for _, name := range []string{"TOOL_EMAIL", "TOOL_PASSWORD", "HAPPYCOMPTA_EMAIL", "HAPPYCOMPTA_PASSWORD"} {
+				t.Setenv(name, "")
+				t.Setenv(name+"_FILE", "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			for name, value := range tt.secrets {
+				path := filepath.Join(t.TempDir(), name)
+				if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				t.Setenv(name+"_FILE", path)
+			}
+			keyringLookup = func(email string) (string, error) {
+				if password, ok := tt.keyring[email]; ok {
+					return password, nil
+				}
+				return "", errors.New("not found")
+			}
+
+			cmd := &cobra.Command{Use: "tool"}
+			cmd.PersistentFlags().String("config", "", "")
+			cmd.PersistentFlags().String("email", "", "")
+			cmd.PersistentFlags().String("password", "", "")
+			args := tt.args
+			if tt.config != "" {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				if err := os.WriteFile(path, []byte(tt.config), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				args = append(args, "--config", path)
+			}
+			if err := cmd.ParseFlags(args); err != nil {
+				t.Fatalf("failed to parse the flags: %v", err)
+			}
+
+			SetupCommand(cmd, "TOOL", nil)
+			if actual := ReadCredentials(); actual != tt.expected {
+				t.Errorf("Credentials mismatch. Got: %v, Want: %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFileMissing(t *testing.T) {
+	t.Setenv("TOOL_TOKEN", "")
+	t.Setenv("TOOL_TOKEN_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	if err := LoadSecretFile("TOOL_TOKEN"); err == nil {
+		t.Error("Expected an error for the missing secret file")
+	}
+}
+
+func TestCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		credentials Credentials
+		expected    string
+	}{
+		{Credentials{Email: "user@example.com", Password: "secret"}, ""},
+		{Credentials{Password: "secret"}, "email parameter or config value is required"},
+		{
+			Credentials{},
+			"email parameter or config value is required\n" +
+				"password parameter, config value or keyring entry is required",
+		},
+	}
+
+	for _, test := range tests {
+		actual := ""
+		if err := test.credentials.Validate(); err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expected {
+			t.Errorf("Validation error mismatch. Got: %q, Want: %q", actual, test.expected)
+		}
+	}
+}
@@ -6,17 +6,37 @@
 package common
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
 )
 
 // CSVParams holds the configuration for the CSV reader's low-level parameters.
 type CSVParams struct {
-	Comma   string `mapstructure:"comma"`
-	Comment string `mapstructure:"comment"`
+	Comma    string `mapstructure:"comma"`
+	Comment  string `mapstructure:"comment"`
+	Encoding string `mapstructure:"encoding"`
+	// Sheet is the name of the worksheet to read in XLSX files.
+	Sheet string     `mapstructure:"sheet"`
+	Date  DateParams `mapstructure:"date"`
 }
 
+// Stdin is the data path reading the standard input.
+const Stdin = "-"
+
+// encodingSniffSize is the amount of data read to guess the encoding of a file.
+const encodingSniffSize = 64 * 1024
+
 // getSingleRune converts a string field to a rune, validating that it's a single character.
 // If the field is empty, it returns 0.
 func getSingleRune(value, fieldName string) (rune, error) {
@@ -41,15 +61,29 @@ func (c *CSVParams) GetCommentRune() (rune, error) {
 }
 
 // GetCSVReader opens the file, creates a csv.Reader, and applies the given CSV configuration parameters.
+// The standard input is read if dataPath is Stdin: it can only be read once.
 // The returned cleaner function must be called when the reader is no longer needed.
 func GetCSVReader(params CSVParams, dataPath string) (*csv.Reader, func(), error) {
-	file, err := os.Open(dataPath)
+	file := os.Stdin
+	cleaner := func() {}
+	if dataPath != Stdin {
+		var err error
+		if file, err = os.Open(dataPath); err != nil {
+			return nil, nil, fmt.Errorf("failed to open CSV file %s: %w", dataPath, err)
+		}
+		cleaner = func() { _ = file.Close() }
+	}
+
+	decoded, err := NewDecodingReader(file, params.Encoding)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open CSV file %s: %w", dataPath, err)
+		cleaner()
+		return nil, nil, fmt.Errorf("CSV encoding config error: %w", err)
 	}
-	cleaner := func() { _ = file.Close() }
 
-	r := csv.NewReader(file)
+	r := csv.NewReader(decoded)
+	// Hand-edited spreadsheets often miss trailing fields: let the callers pad the rows
+	// and report the rows longer than the header with TrimRow.
+	r.FieldsPerRecord = -1
 
 	commaRune, err := params.GetCommaRune()
 	if err != nil {
@@ -71,3 +105,151 @@ func GetCSVReader(params CSVParams, dataPath string) (*csv.Reader, func(), error
 
 	return r, cleaner, nil
 }
+
+// PadRow appends empty fields to row until it has size fields.
+// The second returned value indicates whether the row needed to be padded.
+func PadRow(row []string, size int) ([]string, bool) {
+	if len(row) >= size {
+		return row, false
+	}
+	padded := make([]string, size)
+	copy(padded, row)
+	return padded, true
+}
+
+// TrimRow removes the empty fields after the size first ones, like the trailing separators of some exports.
+// It fails if the row has more than size fields with a value.
+func TrimRow(row []string, size int) ([]string, error) {
+	for i := len(row) - 1; i >= size; i-- {
+		if strings.TrimSpace(row[i]) != "" {
+			return row, fmt.Errorf(Tr("%d fields, more than the %d columns of the header"), i+1, size)
+		}
+	}
+	return row[:min(len(row), size)], nil
+}
+
+// NewDecodingReader transcodes the content of r from the named encoding to UTF-8.
+// An empty or "auto" encoding detects the byte order mark and falls back to Windows-1252
+// if the beginning of the content is not valid UTF-8.
+// The byte order mark is always removed from the content.
+func NewDecodingReader(r io.Reader, encodingName string) (io.Reader, error) {
+	buffered := bufio.NewReaderSize(r, encodingSniffSize)
+
+	var fallback transform.Transformer
+	switch strings.ToLower(encodingName) {
+	case "", "auto":
+		fallback = unicode.UTF8.NewDecoder()
+		// Peek errors only mean there are less data than requested.
+		head, _ := buffered.Peek(encodingSniffSize)
+		if !hasBOM(head) && !isValidUTF8Prefix(head) {
+			fallback = charmap.Windows1252.NewDecoder()
+		}
+	default:
+		enc, err := htmlindex.Get(encodingName)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported encoding '%s'", encodingName)
+		}
+		fallback = enc.NewDecoder()
+	}
+
+	return transform.NewReader(buffered, unicode.BOMOverride(fallback)), nil
+}
+
+func hasBOM(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}) ||
+		bytes.HasPrefix(data, []byte{0xFF, 0xFE}) ||
+		bytes.HasPrefix(data, []byte{0xFE, 0xFF})
+}
+
+// isValidUTF8Prefix checks if data is valid UTF-8, ignoring a possibly truncated rune at the end.
+func isValidUTF8Prefix(data []byte) bool {
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 {
+			return len(data) < utf8.UTFMax && !utf8.FullRune(data)
+		}
+		data = data[size:]
+	}
+	return true
+}
+
+// CSVWriterParams holds the configuration of the written CSV files.
+type CSVWriterParams struct {
+	Comma    string `mapstructure:"comma"`
+	Encoding string `mapstructure:"encoding"`
+	// BOM starts the UTF-8 files with a byte order mark for Excel to detect their encoding.
+	BOM bool `mapstructure:"bom"`
+}
+
+// CSVWriter writes CSV rows in the configured encoding.
+// The rows shorter than the header are padded with empty fields.
+type CSVWriter struct {
+	encoder io.WriteCloser
+	writer  *csv.Writer
+	columns int
+}
+
+// NewCSVWriter creates a CSV writer and writes the header, if any.
+// An empty or "auto" encoding writes UTF-8. The Close method needs to be called to flush the data.
+func NewCSVWriter(w io.Writer, params CSVWriterParams, header []string) (*CSVWriter, error) {
+	encoder := transform.NewWriter(w, transform.Nop)
+	isUTF8 := true
+	if encoding := strings.ToLower(params.Encoding); encoding != "" && encoding != "auto" {
+		enc, err := htmlindex.Get(encoding)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported encoding '%s'", params.Encoding)
+		}
+		if name, _ := htmlindex.Name(enc); name != "utf-8" {
+			isUTF8 = false
+			encoder = transform.NewWriter(w, enc.NewEncoder())
+		}
+	}
+
+	if params.BOM && isUTF8 {
+		if _, err := encoder.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+			return nil, err
+		}
+	}
+
+	writer := csv.NewWriter(encoder)
+	comma, err := getSingleRune(params.Comma, "comma separator")
+	if err != nil {
+		return nil, err
+	}
+	if comma != 0 {
+		writer.Comma = comma
+	}
+
+	csvWriter := &CSVWriter{encoder: encoder, writer: writer, columns: len(header)}
+	if len(header) > 0 {
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+	}
+	return csvWriter, nil
+}
+
+// Write writes a row, padded to the header size.
+func (w *CSVWriter) Write(row []string) error {
+	row, _ = PadRow(row, w.columns)
+	return w.writer.Write(row)
+}
+
+// WriteAll writes several rows, padded to the header size.
+func (w *CSVWriter) WriteAll(rows [][]string) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes the written rows. The underlying writer is not closed.
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	return w.encoder.Close()
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewDecodingReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		encoding string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:  "UTF-8 without BOM",
+			input: []byte("Libellé;Montant"),
+			want:  "Libellé;Montant",
+		},
+		{
+			name:  "UTF-8 with BOM",
+			input: append([]byte{0xEF, 0xBB, 0xBF}, []byte("Libellé;Montant")...),
+			want:  "Libellé;Montant",
+		},
+		{
+			name:  "Windows-1252 detected",
+			input: []byte("Libell\xe9;Montant \x80"),
+			want:  "Libellé;Montant €",
+		},
+		{
+			name:  "UTF-16 little endian with BOM",
+			input: []byte{0xFF, 0xFE, 'L', 0, 'i', 0, 0xE9, 0},
+			want:  "Lié",
+		},
+		{
+			name:     "Explicit latin1",
+			input:    []byte("Fran\xe7ois"),
+			encoding: "latin1",
+			want:     "François",
+		},
+		{
+			name:     "Explicit UTF-8 keeps the content",
+			input:    []byte("François"),
+			encoding: "UTF-8",
+			want:     "François",
+		},
+		{
+			name:     "Unknown encoding",
+			input:    []byte("data"),
+			encoding: "klingon",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewDecodingReader(bytes.NewReader(tt.input), tt.encoding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDecodingReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("failed to read decoded content: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("NewDecodingReader() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidUTF8Prefix(t *testing.T) {
+	// "é" is encoded as 0xC3 0xA9: a data chunk may be cut in the middle of it.
+	if !isValidUTF8Prefix([]byte("Libell\xc3")) {
+		t.Error("Truncated rune at the end should be accepted")
+	}
+	if isValidUTF8Prefix([]byte("Libell\xe9 et")) {
+		t.Error("Latin1 content should not be valid UTF-8")
+	}
+}
+
+func TestGetCSVReader_Encoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	content := []byte("\xef\xbb\xbfname;amount\nCaf\xc3\xa9;12,50\n")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+
+	r, cleaner, err := GetCSVReader(CSVParams{Comma: ";"}, path)
+	if err != nil {
+		t.Fatalf("GetCSVReader failed unexpectedly: %v", err)
+	}
+	defer cleaner()
+
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	want := [][]string{{"name", "amount"}, {"Café", "12,50"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("GetCSVReader() records = %q, want %q", records, want)
+	}
+}
+
+func TestPadRow(t *testing.T) {
+	row, padded := PadRow([]string{"a", "b"}, 4)
+	if !padded || !reflect.DeepEqual(row, []string{"a", "b", "", ""}) {
+		t.Errorf("PadRow() got = %q, %t", row, padded)
+	}
+
+	row, padded = PadRow([]string{"a", "b", "c"}, 2)
+	if padded || !reflect.DeepEqual(row, []string{"a", "b", "c"}) {
+		t.Errorf("PadRow() should not change long rows, got = %q, %t", row, padded)
+	}
+}
+
+func TestTrimRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      []string
+		expected []string
+		wantErr  string
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, ""},
+		{"short", []string{"a"}, []string{"a"}, ""},
+		{"empty trailing fields", []string{"a", "b", "", " "}, []string{"a", "b"}, ""},
+		{"extra value", []string{"a", "b", "c", ""}, nil, "3 fields, more than the 2 columns of the header"},
+	}
+	for _, test := range tests {
+		row, err := TrimRow(test.row, 2)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("%s: error mismatch. Got: %v, Want: %s", test.name, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(row, test.expected) {
+			t.Errorf("%s: row mismatch. Got: %q, %v, Want: %q", test.name, row, err, test.expected)
+		}
+	}
+}
+
+func TestGetCSVReader_RaggedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("name,amount,comment\nfirst,12\nsecond,13,ok\n"), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+
+	r, cleaner, err := GetCSVReader(CSVParams{}, path)
+	if err != nil {
+		t.Fatalf("GetCSVReader failed unexpectedly: %v", err)
+	}
+	defer cleaner()
+
+	if _, err := r.ReadAll(); err != nil {
+		t.Errorf("Ragged rows should not fail: %v", err)
+	}
+}
+
+func TestGetCSVReader_Stdin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("name,amount\nfirst,12\n"), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open CSV: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	stdin := os.Stdin
+	os.Stdin = file
+	defer func() { os.Stdin = stdin }()
+
+	r, cleaner, err := GetCSVReader(CSVParams{}, Stdin)
+	if err != nil {
+		t.Fatalf("GetCSVReader failed unexpectedly: %v", err)
+	}
+	// The cleaner must not close the standard input.
+	cleaner()
+
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	want := [][]string{{"name", "amount"}, {"first", "12"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("GetCSVReader() records = %q, want %q", records, want)
+	}
+}
+
+func TestNewCSVWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   CSVWriterParams
+		expected []byte
+	}{
+		{"defaults", CSVWriterParams{}, []byte("Nom,Montant\nCafé,\n")},
+		{"comma", CSVWriterParams{Comma: ";"}, []byte("Nom;Montant\nCafé;\n")},
+		{"bom", CSVWriterParams{BOM: true, Encoding: "UTF-8"}, []byte("\xef\xbb\xbfNom,Montant\nCafé,\n")},
+		{"windows-1252", CSVWriterParams{Encoding: "windows-1252", BOM: true}, []byte("Nom,Montant\nCaf\xe9,\n")},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w, err := NewCSVWriter(&buf, test.params, []string{"Nom", "Montant"})
+		if err != nil {
+			t.Fatalf("%s: NewCSVWriter failed: %s", test.name, err)
+		}
+		if err := w.Write([]string{"Café"}); err != nil {
+			t.Fatalf("%s: Write failed: %s", test.name, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %s", test.name, err)
+		}
+		if !bytes.Equal(buf.Bytes(), test.expected) {
+			t.Errorf("%s: CSV mismatch. Got: %q, Want: %q", test.name, buf.Bytes(), test.expected)
+		}
+	}
+}
+
+func TestNewCSVWriter_Invalid(t *testing.T) {
+	tests := []CSVWriterParams{
+		{Encoding: "klingon"},
+		{Comma: ";;"},
+	}
+
+	for _, params := range tests {
+		if _, err := NewCSVWriter(io.Discard, params, nil); err == nil {
+			t.Errorf("Expected an error for %v", params)
+		}
+	}
+}
@@ -3,7 +3,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package common
 
 import (
 	"errors"
@@ -13,19 +13,30 @@ import (
 	"strings"
 )
 
-// parseAmount reads a currency in either US or European format into a float.
-func parseAmount(input string) (float64, error) {
+// ParseAmount reads a currency in either US or European format into a float.
+// A dot followed by one or two digits is a decimal separator, like in the raw values of the XLSX cells.
+func ParseAmount(input string) (float64, error) {
 	if input == "" {
 		return 0, errors.New("amount is missing or empty")
 	}
 
-	const usCurrencyPattern = `^€?\s?(\d{1,3}(,\d{3})*|\d+)(\.\d{2})?\s?€?$`
+	const usCurrencyPattern = `^€?\s?(\d{1,3}(,\d{3})*|\d+)(\.\d{1,2})?\s?€?$`
 	var usCurrencyRegex = regexp.MustCompile(usCurrencyPattern)
 
-	cleanInput := input
+	// Bank exports often have signed amounts
+	sign := 1.0
+	cleanInput := strings.TrimSpace(input)
+	if strings.HasPrefix(cleanInput, "-") {
+		sign = -1.0
+		cleanInput = strings.TrimSpace(cleanInput[1:])
+	} else if strings.HasPrefix(cleanInput, "+") {
+		cleanInput = strings.TrimSpace(cleanInput[1:])
+	}
+	unsignedInput := cleanInput
+
 	// We only handle Euros for now since happy-compta doesn't handle any other currency.
 	cleanInput = strings.ReplaceAll(cleanInput, "€", "")
-	if usCurrencyRegex.MatchString(input) {
+	if usCurrencyRegex.MatchString(unsignedInput) {
 		cleanInput = strings.ReplaceAll(cleanInput, ",", "")
 		cleanInput = strings.TrimSpace(cleanInput)
 	} else {
@@ -40,5 +51,5 @@ func parseAmount(input string) (float64, error) {
 		return 0, fmt.Errorf("failed to parse amount '%s' (cleaned: '%s'): %w", input, cleanInput, err)
 	}
 
-	return amount, nil
+	return sign * amount, nil
 }
@@ -3,7 +3,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package common
 
 import (
 	"strings"
@@ -47,12 +47,42 @@ func TestParseAmount(t *testing.T) {
 			want:    1234.56,
 			wantErr: false,
 		},
+		{
+			name:    "One decimal",
+			input:   "12.5",
+			want:    12.5,
+			wantErr: false,
+		},
+		{
+			name:    "European Format with Dot Thousand Separator",
+			input:   "1.234",
+			want:    1234,
+			wantErr: false,
+		},
 		{
 			name:    "No decimal",
 			input:   "1000",
 			want:    1000.00,
 			wantErr: false,
 		},
+		{
+			name:    "Negative US Format",
+			input:   "-1,234.56",
+			want:    -1234.56,
+			wantErr: false,
+		},
+		{
+			name:    "Negative European Format",
+			input:   "-12,50 €",
+			want:    -12.50,
+			wantErr: false,
+		},
+		{
+			name:    "Explicit Positive Sign",
+			input:   "+12.50",
+			want:    12.50,
+			wantErr: false,
+		},
 		{
 			name:    "Empty String",
 			input:   "",
@@ -69,17 +99,17 @@ func TestParseAmount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseAmount(tt.input)
+			got, err := ParseAmount(tt.input)
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("parseAmount() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !tt.wantErr && got != tt.want {
-				t.Errorf("parseAmount() got = %f, want %f", got, tt.want)
+				t.Errorf("ParseAmount() got = %f, want %f", got, tt.want)
 			}
 			if tt.wantErr && !strings.Contains(err.Error(), "failed to parse amount") && !strings.Contains(err.Error(), "missing or empty") {
-				t.Errorf("parseAmount() got unexpected error message: %v", err)
+				t.Errorf("ParseAmount() got unexpected error message: %v", err)
 			}
 		})
 	}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultDateLayouts are the Go layouts of the dates accepted by default: DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY.
+var DefaultDateLayouts = []string{"02/01/2006", "2006-01-02", "02-01-06"}
+
+// timeLayouts are the optional times of the day following the dates.
+var timeLayouts = []string{"", " 15:04", " 15:04:05", "T15:04:05", "T15:04:05Z07:00"}
+
+// DateParams holds the accepted date layouts.
+type DateParams struct {
+	// Layouts are the Go layouts of the accepted dates, tried in order. Defaults to DefaultDateLayouts.
+	Layouts []string `mapstructure:"layouts"`
+}
+
+// Parse parses a date in the first matching layout.
+func (p DateParams) Parse(value string) (time.Time, error) {
+	return ParseDate(value, p.Layouts)
+}
+
+// ParseDate parses a date in the first matching layout, optionally followed by a time of the day.
+// The DefaultDateLayouts are used without layouts.
+// The serial numbers of the XLSX date cells, read without their number format, are also accepted.
+func ParseDate(value string, layouts []string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		for _, timeLayout := range timeLayouts {
+			if date, err := time.Parse(layout+timeLayout, value); err == nil {
+				return date, nil
+			}
+		}
+	}
+	if date, ok := parseExcelDate(value); ok {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s', expected a date like %s", value, strings.Join(layouts, " or "))
+}
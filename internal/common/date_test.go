@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		layouts []string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "French date",
+			value: "14/03/2025",
+			want:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "ISO date",
+			value: " 2025-03-14 ",
+			want:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Short year",
+			value: "14-03-25",
+			want:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Date with time",
+			value: "14/03/2025 10:30",
+			want:  time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "ISO date and time",
+			value: "2025-03-14T10:30:15",
+			want:  time.Date(2025, 3, 14, 10, 30, 15, 0, time.UTC),
+		},
+		{
+			name:    "Custom layout",
+			value:   "14.03.2025",
+			layouts: []string{"02.01.2006"},
+			want:    time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Default layout not in custom ones",
+			value:   "14/03/2025",
+			layouts: []string{"02.01.2006"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown layout",
+			value:   "14.03.2025",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid day",
+			value:   "31/02/2025",
+			wantErr: true,
+		},
+		{
+			name:  "Spreadsheet serial number",
+			value: "45730",
+			want:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Spreadsheet serial number with time",
+			value: "45730.4375",
+			want:  time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "Negative number",
+			value:   "-45730",
+			wantErr: true,
+		},
+		{
+			name:    "Empty value",
+			value:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.value, tt.layouts)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error for %q, got: %v", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Date mismatch. Got: %v, Want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateError(t *testing.T) {
+	_, err := DateParams{}.Parse("14.03.2025")
+	if err == nil || !strings.Contains(err.Error(), "02/01/2006 or 2006-01-02 or 02-01-06") {
+		t.Errorf("Error mismatch. Got: %v, Want the default layouts", err)
+	}
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// DocsFormatMan generates the man pages in the section 1.
+	DocsFormatMan = "man"
+	// DocsFormatMarkdown generates the markdown references.
+	DocsFormatMarkdown = "markdown"
+)
+
+// NewGenDocsCommand creates the hidden command generating the manuals of all the commands of its root.
+func NewGenDocsCommand() *cobra.Command {
+	genDocsCmd := &cobra.Command{
+		Use:    "gen-docs [path/to/dir]",
+		Short:  "Generate the man pages and markdown references of the commands",
+		Hidden: true,
+		Args:   UsageArgs(cobra.MaximumNArgs(1)),
+		// The configuration is not needed to generate the documentation.
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "docs"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			formats, err := cmd.Flags().GetStringSlice("format")
+			if err != nil {
+				return err
+			}
+			return GenerateDocs(cmd.Root(), dir, formats, docsDate())
+		},
+	}
+	genDocsCmd.Flags().StringSlice("format", []string{DocsFormatMan, DocsFormatMarkdown},
+		`Formats of the documentation to generate: man, markdown or both.
+The man pages are written in the man folder and the markdown files in the markdown one.`)
+	AddFlagCompletion(genDocsCmd, "format", DocsFormatMan, DocsFormatMarkdown)
+	return genDocsCmd
+}
+
+// docsDate returns the date of the man pages.
+// SOURCE_DATE_EPOCH is honored for the packages to be built reproducibly.
+func docsDate() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now()
+}
+
+// GenerateDocs writes the documentation of the root command and all its available sub commands in dir.
+func GenerateDocs(root *cobra.Command, dir string, formats []string, date time.Time) error {
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+
+	for _, format := range formats {
+		var ext string
+		var write func(io.Writer, *cobra.Command) error
+		switch format {
+		case DocsFormatMan:
+			ext = "1"
+			write = func(w io.Writer, cmd *cobra.Command) error { return writeManPage(w, cmd, date) }
+		case DocsFormatMarkdown:
+			ext = "md"
+			write = writeMarkdown
+		default:
+			return WithExitCode(ExitUsage, fmt.Errorf("unsupported documentation format: %s", format))
+		}
+
+		formatDir := filepath.Join(dir, format)
+		if err := os.MkdirAll(formatDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create the documentation directory %s: %s", formatDir, err)
+		}
+		for _, cmd := range documentedCommands(root) {
+			cmd.InitDefaultHelpFlag()
+			path := filepath.Join(formatDir, docsName(cmd)+"."+ext)
+			if err := writeDocsFile(path, cmd, write); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeDocsFile(path string, cmd *cobra.Command, write func(io.Writer, *cobra.Command) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", path, err)
+	}
+	if err := write(file, cmd); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %s", path, err)
+	}
+	return file.Close()
+}
+
+// documentedCommands returns the command and its available sub commands, recursively.
+// The hidden commands, like gen-docs, and the help topics are skipped.
+func documentedCommands(cmd *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{cmd}
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		commands = append(commands, documentedCommands(child)...)
+	}
+	return commands
+}
+
+// docsName returns the base name of the documentation files of a command, like happycompta-load-snapshot.
+func docsName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// relatedCommands returns the parent and the available children of a command.
+func relatedCommands(cmd *cobra.Command) []*cobra.Command {
+	var related []*cobra.Command
+	if cmd.HasParent() {
+		related = append(related, cmd.Parent())
+	}
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() && !child.IsAdditionalHelpTopicCommand() {
+			related = append(related, child)
+		}
+	}
+	return related
+}
+
+// visibleFlags returns the flags of the set which are not hidden, sorted by name.
+func visibleFlags(flags *pflag.FlagSet) []*pflag.Flag {
+	var result []*pflag.Flag
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			result = append(result, flag)
+		}
+	})
+	slices.SortFunc(result, func(a, b *pflag.Flag) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// roffEscape escapes the text for the man pages.
+func roffEscape(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\e`)
+	text = strings.ReplaceAll(text, "-", `\-`)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffParagraphs converts the empty lines separating paragraphs into roff paragraphs.
+func roffParagraphs(text string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		// Keep the line breaks of the help texts
+		paragraphs = append(paragraphs, strings.ReplaceAll(roffEscape(paragraph), "\n", "\n.br\n"))
+	}
+	return strings.Join(paragraphs, "\n.PP\n")
+}
+
+func writeManPage(w io.Writer, cmd *cobra.Command, date time.Time) error {
+	var errs []error
+	printf := func(format string, args ...any) {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	root := cmd.Root()
+	printf(".TH \"%s\" \"1\" \"%s\" \"%s %s\" \"happy-compta tools\"\n",
+		strings.ToUpper(docsName(cmd)), date.Format("Jan 2006"), root.Name(), roffEscape(Version()))
+	printf(".SH NAME\n%s \\- %s\n", roffEscape(docsName(cmd)), roffEscape(cmd.Short))
+	printf(".SH SYNOPSIS\n\\fB%s\\fP\n", roffEscape(cmd.UseLine()))
+	printf(".SH DESCRIPTION\n%s\n", roffParagraphs(cmp.Or(cmd.Long, cmd.Short)))
+
+	writeFlags := func(title string, flags []*pflag.Flag) {
+		if len(flags) == 0 {
+			return
+		}
+		printf(".SH %s\n", title)
+		for _, flag := range flags {
+			name, usage := pflag.UnquoteUsage(flag)
+			printf(".TP\n")
+			if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+				printf("\\fB\\-%s\\fP, ", roffEscape(flag.Shorthand))
+			}
+			printf("\\fB\\-\\-%s\\fP", roffEscape(flag.Name))
+			if name != "" {
+				printf(" \\fI%s\\fP", roffEscape(name))
+			}
+			printf("\n%s", roffParagraphs(usage))
+			if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0" {
+				printf("\n.br\nDefault: %s", roffEscape(flag.DefValue))
+			}
+			printf("\n")
+		}
+	}
+	writeFlags("OPTIONS", visibleFlags(cmd.NonInheritedFlags()))
+	writeFlags("OPTIONS INHERITED FROM PARENT COMMANDS", visibleFlags(cmd.InheritedFlags()))
+
+	if related := relatedCommands(cmd); len(related) > 0 {
+		var names []string
+		for _, other := range related {
+			names = append(names, fmt.Sprintf("\\fB%s\\fP(1)", roffEscape(docsName(other))))
+		}
+		printf(".SH SEE ALSO\n%s\n", strings.Join(names, ", "))
+	}
+	return errors.Join(errs...)
+}
+
+func writeMarkdown(w io.Writer, cmd *cobra.Command) error {
+	var errs []error
+	printf := func(format string, args ...any) {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	printf("## %s\n\n%s\n\n", cmd.CommandPath(), cmd.Short)
+	if cmd.Long != "" {
+		printf("### Synopsis\n\n%s\n\n", cmd.Long)
+	}
+	if cmd.Runnable() {
+		printf("```\n%s\n```\n\n", cmd.UseLine())
+	}
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		printf("### Options\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		printf("### Options inherited from parent commands\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+
+	if related := relatedCommands(cmd); len(related) > 0 {
+		printf("### See also\n\n")
+		for _, other := range related {
+			printf("* [%s](%s.md) - %s\n", other.CommandPath(), docsName(other), other.Short)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newDocsTestCommand() *cobra.Command {
+	root := &cobra.Command{Use: "tool", Short: "A tool"}
+	root.PersistentFlags().String("config", "", "Configuration file path")
+
+	sub := &cobra.Command{
+		Use:   "sub path/to/file.csv",
+		Short: "Do something",
+		Long:  "Do something with a file.\n.starting with a dot\n\nSecond paragraph.",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	sub.Flags().StringP("output", "o", "out.csv", "Path of the file to write.")
+	sub.Flags().Bool("secret", false, "Hidden flag")
+	_ = sub.Flags().MarkHidden("secret")
+	root.AddCommand(sub)
+	root.AddCommand(NewGenDocsCommand())
+	return root
+}
+
+func TestGenerateDocs(t *testing.T) {
+	dir := t.TempDir()
+	date := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	if err := GenerateDocs(newDocsTestCommand(), dir, []string{DocsFormatMan, DocsFormatMarkdown}, date); err != nil {
+		t.Fatalf("GenerateDocs failed: %s", err)
+	}
+
+	for _, format := range []string{DocsFormatMan, DocsFormatMarkdown} {
+		entries, err := os.ReadDir(filepath.Join(dir, format))
+		if err != nil {
+			t.Fatalf("failed to read the %s directory: %s", format, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		if slices.ContainsFunc(names, func(name string) bool { return strings.Contains(name, "gen-docs") }) {
+			t.Errorf("The hidden gen-docs command is documented in %s: %v", format, names)
+		}
+		if !slices.ContainsFunc(names, func(name string) bool { return strings.HasPrefix(name, "tool-sub.") }) {
+			t.Errorf("The sub command is not documented in %s: %v", format, names)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, DocsFormatMan, "tool-sub.1"))
+	if err != nil {
+		t.Fatalf("failed to read the man page: %s", err)
+	}
+	man := string(content)
+	for _, expected := range []string{
+		`.TH "TOOL-SUB" "1" "Oct 2025"`,
+		`tool\-sub \- Do something`,
+		"\\fBtool sub path/to/file.csv [flags]\\fP",
+		"\\&.starting with a dot",
+		".PP\nSecond paragraph.",
+		"\\fB\\-o\\fP, \\fB\\-\\-output\\fP \\fIstring\\fP\nPath of the file to write.\n.br\nDefault: out.csv",
+		".SH OPTIONS INHERITED FROM PARENT COMMANDS",
+		"\\fBtool\\fP(1)",
+	} {
+		if !strings.Contains(man, expected) {
+			t.Errorf("Man page mismatch. Got: %s, Want it to contain: %s", man, expected)
+		}
+	}
+	if strings.Contains(man, "secret") {
+		t.Errorf("The hidden flag is in the man page: %s", man)
+	}
+
+	content, err = os.ReadFile(filepath.Join(dir, DocsFormatMarkdown, "tool.md"))
+	if err != nil {
+		t.Fatalf("failed to read the markdown reference: %s", err)
+	}
+	if !strings.Contains(string(content), "* [tool sub](tool-sub.md) - Do something") {
+		t.Errorf("Markdown mismatch. Got: %s, Want a link to the sub command", content)
+	}
+}
+
+func TestGenerateDocsInvalidFormat(t *testing.T) {
+	err := GenerateDocs(newDocsTestCommand(), t.TempDir(), []string{"html"}, time.Now())
+	if ExitCode(err) != ExitUsage {
+		t.Errorf("Exit code mismatch. Got: %d, Want: %d", ExitCode(err), ExitUsage)
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// ageIdentityVariable is the variable pointing to the age identity file decrypting the configuration.
+const ageIdentityVariable = SharedEnvPrefix + "_AGE_IDENTITY"
+
+// passphrases are the passphrases prompted for the files, to encrypt them again with the same one.
+var passphrases = map[string]string{}
+
+// ConfigFileType returns the format of a configuration file from its extension, ignoring the .age one.
+func ConfigFileType(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".age") {
+		path = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// ReadConfigFile reads a configuration file, decrypting it when it is encrypted with age or sops.
+//
+// The files encrypted with age can use the recipients of the age identities or a passphrase, prompted on the terminal.
+// The age identities are read from the file pointed by HAPPYCOMPTA_AGE_IDENTITY,
+// or the SOPS_AGE_KEY and SOPS_AGE_KEY_FILE variables and the default sops keys file.
+// The YAML files encrypted with sops are decrypted by the sops tool, which needs to be installed.
+func ReadConfigFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case isAgeEncrypted(content):
+		content, err = decryptAge(content, loadAgeKeys(path))
+	case (ConfigFileType(path) == "yaml" || ConfigFileType(path) == "yml") && isSOPSEncrypted(content):
+		content, err = decryptSOPS(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %s", path, err)
+	}
+	return content, nil
+}
+
+// loadAgeKeys returns the age keys available to decrypt the configuration file.
+// The identities that can't be read are only reported if the file can't be decrypted:
+// it may be encrypted with a passphrase or for another identity.
+func loadAgeKeys(path string) ageKeys {
+	keys := ageKeys{passphrase: func() (string, error) {
+		passphrase, err := promptPassphrase(path, "Passphrase of %s: ")
+		if err == nil {
+			passphrases[path] = passphrase
+		}
+		return passphrase, err
+	}}
+
+	var sources []string
+	if value := os.Getenv("SOPS_AGE_KEY"); value != "" {
+		sources = append(sources, value)
+	}
+	var files []string
+	for _, variable := range []string{ageIdentityVariable, "SOPS_AGE_KEY_FILE"} {
+		if file := os.Getenv(variable); file != "" {
+			files = append(files, file)
+		}
+	}
+	if dir, err := os.UserConfigDir(); err == nil && len(files) == 0 {
+		if file := filepath.Join(dir, "sops", "age", "keys.txt"); fileExists(file) {
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			keys.identityErrors = append(keys.identityErrors, fmt.Errorf("failed to read %s: %s", file, err))
+			continue
+		}
+		sources = append(sources, string(content))
+	}
+
+	for _, source := range sources {
+		identities, err := parseAgeIdentities(source)
+		if err != nil {
+			keys.identityErrors = append(keys.identityErrors, err)
+			continue
+		}
+		keys.identities = append(keys.identities, identities...)
+	}
+	return keys
+}
+
+// WriteEncryptedFile encrypts the content with age and writes it to the file.
+// A new file is only readable by its owner.
+//
+// The content is encrypted for the age identities ReadConfigFile decrypts with or, without any,
+// with the passphrase the file has been read with or a new one prompted on the terminal.
+func WriteEncryptedFile(path string, content []byte) error {
+	keys := loadAgeKeys(path)
+	recipients := ageRecipients(keys.identities)
+
+	passphrase := passphrases[path]
+	if len(recipients) == 0 && passphrase == "" {
+		var err error
+		if passphrase, err = promptNewPassphrase(path); err != nil {
+			return err
+		}
+	}
+	encrypted, err := encryptAge(content, recipients, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %s", path, err)
+	}
+	return os.WriteFile(path, encrypted, 0o600)
+}
+
+// promptNewPassphrase asks a passphrase to encrypt the file twice on the terminal to avoid typos.
+func promptNewPassphrase(path string) (string, error) {
+	passphrase, err := promptPassphrase(path, "New passphrase of %s: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase can't be empty")
+	}
+	confirmation, err := promptPassphrase(path, "Confirm the passphrase of %s: ")
+	if err != nil {
+		return "", err
+	}
+	if confirmation != passphrase {
+		return "", errors.New("the passphrases don't match")
+	}
+	passphrases[path] = passphrase
+	return passphrase, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// promptPassphrase asks a passphrase of the file on the terminal with the message.
+func promptPassphrase(path string, message string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", errors.New("a passphrase is needed for the file, but the standard input is not a terminal")
+	}
+	if _, err := fmt.Fprintf(os.Stderr, Tr(message), path); err != nil {
+		return "", err
+	}
+	passphrase, err := term.ReadPassword(os.Stdin.Fd())
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %s", err)
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// The exit codes shared by all the tools for the scripts running them to react to the failures.
+const (
+	// ExitFailure is returned for the errors not falling in any other category.
+	ExitFailure = 1
+	// ExitUsage is returned for invalid flags or arguments.
+	ExitUsage = 2
+	// ExitConfig is returned when the configuration file cannot be read or is invalid.
+	ExitConfig = 3
+	// ExitAuth is returned when the credentials are missing or rejected by happy-compta.
+	ExitAuth = 4
+	// ExitValidation is returned when the input data is invalid and nothing has been done.
+	ExitValidation = 5
+	// ExitPartial is returned when only a part of the work could be done.
+	ExitPartial = 6
+	// ExitRemote is returned when happy-compta cannot be reached or fails to handle a request.
+	ExitRemote = 7
+)
+
+// ExitError is an error with the exit code of the tool.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode sets the exit code of an error. A nil error stays nil.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+// FlagError marks the flag parsing errors as usage errors. It is meant for the commands SetFlagErrorFunc.
+func FlagError(_ *cobra.Command, err error) error {
+	return WithExitCode(ExitUsage, err)
+}
+
+// UsageArgs marks the errors of a positional arguments validator as usage errors.
+func UsageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		return WithExitCode(ExitUsage, validate(cmd, args))
+	}
+}
+
+// ExitCode returns the exit code matching an error, 0 for no error.
+// The network errors without exit code are remote errors.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ExitRemote
+	}
+	return ExitFailure
+}
+
+// Execute runs a tool command in the language requested by the user and exits with the code matching its error.
+func Execute(cmd *cobra.Command) {
+	SetLanguage(DetectLanguage(os.Args[1:]))
+	Localize(cmd)
+	if err := cmd.Execute(); err != nil {
+		Exit(err)
+	}
+}
+
+// Exit logs the error returned by a tool and exits with the matching code.
+func Exit(err error) {
+	if err == nil {
+		os.Exit(0)
+	}
+	slog.Error(err.Error())
+	os.Exit(ExitCode(err))
+}
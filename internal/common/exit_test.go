@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"no error", nil, 0},
+		{"plain", errors.New("failure"), ExitFailure},
+		{"with code", WithExitCode(ExitConfig, errors.New("bad config")), ExitConfig},
+		{"joined", errors.Join(errors.New("other"), WithExitCode(ExitValidation, errors.New("bad row"))), ExitValidation},
+		{"network", &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("timeout")}, ExitRemote},
+		{"credentials", Credentials{}.Validate(), ExitAuth},
+	}
+
+	for _, test := range tests {
+		if actual := ExitCode(test.err); actual != test.expected {
+			t.Errorf("%s: exit code mismatch. Got: %d, Want: %d", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestWithExitCodeNil(t *testing.T) {
+	if err := WithExitCode(ExitUsage, nil); err != nil {
+		t.Errorf("Error mismatch. Got: %v, Want: nil", err)
+	}
+}
+
+func TestUsageArgs(t *testing.T) {
+	cmd := &cobra.Command{Use: "test", Args: UsageArgs(cobra.NoArgs), Run: func(*cobra.Command, []string) {}}
+	cmd.SetFlagErrorFunc(FlagError)
+
+	tests := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{}, 0},
+		{[]string{"extra"}, ExitUsage},
+		{[]string{"--unknown"}, ExitUsage},
+	}
+
+	for _, test := range tests {
+		cmd.SetArgs(test.args)
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		if actual := ExitCode(cmd.Execute()); actual != test.expected {
+			t.Errorf("Exit code mismatch for %v. Got: %d, Want: %d", test.args, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// LanguageEnglish is the language the messages are written in.
+	LanguageEnglish = "en"
+	// LanguageFrench translates the help and error messages in French.
+	LanguageFrench = "fr"
+)
+
+// catalogs holds the translations of the user-facing messages, keyed by their English text.
+// The log messages are not translated to keep them stable for the scripts processing them.
+var catalogs = map[string]map[string]string{
+	LanguageFrench: frenchMessages,
+}
+
+var language = LanguageEnglish
+
+// translation is a message with its translation.
+type translation struct {
+	English    string
+	Translated string
+}
+
+func newCatalog(translations []translation) map[string]string {
+	catalog := make(map[string]string, len(translations))
+	for _, item := range translations {
+		catalog[item.English] = item.Translated
+	}
+	return catalog
+}
+
+// SetLanguage sets the language of the messages, falling back to English for the unsupported ones.
+func SetLanguage(lang string) {
+	if _, found := catalogs[lang]; !found {
+		lang = LanguageEnglish
+	}
+	language = lang
+}
+
+// Language returns the language of the messages.
+func Language() string {
+	return language
+}
+
+// DetectLanguage returns the language requested by the lang flag in the arguments or the locale variables.
+// The flag needs to be read before parsing the arguments since the help is localized before running the command.
+func DetectLanguage(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, found := strings.CutPrefix(arg, "--lang="); found {
+			return value
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	// Same precedence as the C library
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			// Only keep the language of values like fr_FR.UTF-8
+			lang, _, _ := strings.Cut(value, "_")
+			lang, _, _ = strings.Cut(lang, ".")
+			return strings.ToLower(lang)
+		}
+	}
+	return LanguageEnglish
+}
+
+// Tr translates a message in the current language, returning it unchanged if there is no translation.
+func Tr(msg string) string {
+	if translated, found := catalogs[language][msg]; found {
+		return translated
+	}
+	return msg
+}
+
+// HasTranslation tells whether a message is translated in a language.
+func HasTranslation(lang string, msg string) bool {
+	_, found := catalogs[lang][msg]
+	return found
+}
+
+// AddLanguageFlag adds the flag selecting the language of the messages to a program root command.
+func AddLanguageFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("lang", "", `Language of the help and error messages: en or fr.
+Defaults to the language of the LC_ALL, LC_MESSAGES or LANG variables.`)
+	AddFlagCompletion(cmd, "lang", LanguageEnglish, LanguageFrench)
+}
+
+// Localize translates the help of a command and all its subcommands in the current language.
+func Localize(cmd *cobra.Command) {
+	if language == LanguageEnglish {
+		return
+	}
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultCompletionCmd()
+	localizeCommand(cmd)
+}
+
+func localizeCommand(cmd *cobra.Command) {
+	cmd.InitDefaultHelpFlag()
+	cmd.InitDefaultVersionFlag()
+	cmd.Short = Tr(cmd.Short)
+	cmd.Long = Tr(cmd.Long)
+	cmd.SetUsageTemplate(Tr(usageTemplate))
+	cmd.SetErrPrefix(Tr("Error:"))
+
+	localizeFlag := func(flag *pflag.Flag) {
+		if name, found := strings.CutPrefix(flag.Usage, "help for "); found {
+			flag.Usage = Tr("help for ") + name
+			return
+		}
+		if name, found := strings.CutPrefix(flag.Usage, "version for "); found {
+			flag.Usage = Tr("version for ") + name
+			return
+		}
+		flag.Usage = Tr(flag.Usage)
+	}
+	cmd.LocalFlags().VisitAll(localizeFlag)
+
+	for _, child := range cmd.Commands() {
+		localizeCommand(child)
+	}
+}
+
+// usageTemplate is the cobra default usage template without the command groups, unused in the tools.
+const usageTemplate = `Usage:{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+Aliases:
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+Examples:
+{{.Example}}{{end}}{{if .HasAvailableSubCommands}}
+
+Available Commands:{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+Flags:
+{{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+Global Flags:
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
+`
@@ -0,0 +1,2021 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+// frenchMessages are the French translations, for the CSE treasurers using the tools.
+var frenchMessages = newCatalog([]translation{
+	// Help of the commands
+	{
+		English:    "Tools to work with happy-compta",
+		Translated: "Outils pour travailler avec happy-compta",
+	},
+	{
+		English: `Tools to work with happy-compta.
+
+All the commands read the same config.yaml configuration file.
+The credentials can be shared between the commands using the HAPPYCOMPTA_EMAIL and HAPPYCOMPTA_PASSWORD variables.
+The other environment variables are prefixed with the name of the former tool: LOADER_, DUMPER_ or CSV_SEPA_.`,
+		Translated: `Outils pour travailler avec happy-compta.
+
+Toutes les commandes lisent le même fichier de configuration config.yaml.
+Les identifiants peuvent être partagés entre les commandes avec les variables HAPPYCOMPTA_EMAIL
+et HAPPYCOMPTA_PASSWORD.
+Les autres variables d'environnement sont préfixées par le nom de l'ancien outil : LOADER_, DUMPER_ ou CSV_SEPA_.`,
+	},
+	{
+		English:    "Print the configuration",
+		Translated: "Afficher la configuration",
+	},
+	{
+		English: `Print the configuration read from the configuration file and the shared environment variables.
+The password is masked.
+
+With the check flag, the configuration file is validated against the options of all the commands instead.
+The unknown keys, values of the wrong type and flag names used instead of nested keys are reported.`,
+		Translated: `Afficher la configuration lue dans le fichier de configuration
+et les variables d'environnement partagées.
+Le mot de passe est masqué.
+
+Avec l'option check, le fichier de configuration est plutôt vérifié par rapport aux options de toutes les commandes.
+Les clés inconnues, les valeurs du mauvais type et les noms d'options utilisés à la place des clés imbriquées
+sont signalés.`,
+	},
+	{
+		English:    "Check the happy-compta credentials",
+		Translated: "Vérifier les identifiants happy-compta",
+	},
+	{
+		English: `Log in to happy-compta to check the credentials.
+The credentials are read from the flags, the HAPPYCOMPTA_EMAIL and HAPPYCOMPTA_PASSWORD variables,
+the configuration file or the system keyring for the password, like for the other commands.`,
+		Translated: `Se connecter à happy-compta pour vérifier les identifiants.
+Les identifiants sont lus dans les options, les variables HAPPYCOMPTA_EMAIL et HAPPYCOMPTA_PASSWORD,
+le fichier de configuration ou le trousseau du système pour le mot de passe, comme pour les autres commandes.`,
+	},
+	{
+		English:    "A program loading entries from a CSV file as entries into happy-compta",
+		Translated: "Un programme chargeant les lignes d'un fichier CSV comme écritures dans happy-compta",
+	},
+	{
+		English:    "Save the happy-compta reference data to a file for offline dry runs",
+		Translated: "Enregistrer les données de référence de happy-compta pour les simulations hors ligne",
+	},
+	{
+		English:    "Run an HTTP server importing the uploaded CSV files",
+		Translated: "Lancer un serveur HTTP important les fichiers CSV envoyés",
+	},
+	{
+		English:    "Create one receipts folder per row of a CSV file",
+		Translated: "Créer un dossier de justificatifs par ligne d'un fichier CSV",
+	},
+	{
+		English:    "Attach receipts to the entries already in happy-compta",
+		Translated: "Joindre des justificatifs aux opérations déjà dans happy-compta",
+	},
+	{
+		English:    "A program dumping data from happy-compta",
+		Translated: "Un programme extrayant les données de happy-compta",
+	},
+	{
+		English:    "Save all the data and receipts in an archive",
+		Translated: "Sauvegarder toutes les données et les justificatifs dans une archive",
+	},
+	{
+		English:    "Download the receipts of a period",
+		Translated: "Télécharger les justificatifs d'un exercice",
+	},
+	{
+		English:    "Match a bank statement against the entries",
+		Translated: "Rapprocher un relevé bancaire des écritures",
+	},
+	{
+		English:    "List entries details",
+		Translated: "Lister le détail des écritures",
+	},
+	{
+		English:    "Write the closing report of a month",
+		Translated: "Écrire le rapport de clôture d'un mois",
+	},
+	{
+		English:    "Report the changes since the previous run",
+		Translated: "Signaler les modifications depuis l'exécution précédente",
+	},
+	{
+		English:    "Export the entries of a period for the loader",
+		Translated: "Exporter les écritures d'un exercice pour les charger ailleurs",
+	},
+	{
+		English:    "Export the providers and employees as contacts",
+		Translated: "Exporter les fournisseurs et salariés comme contacts",
+	},
+	{
+		English:    "Convert CSV or XLSX files to a SEPA transfer file",
+		Translated: "Convertir des fichiers CSV ou XLSX en fichier de virements SEPA",
+	},
+	{
+		English:    "Generate the SEPA file from the happy-compta employee reimbursements",
+		Translated: "Générer le fichier SEPA des remboursements de notes de frais de happy-compta",
+	},
+	{
+		English:    "Periodically import the inbox files and refresh the local mirror",
+		Translated: "Importer régulièrement les fichiers de la boîte de réception et rafraîchir le miroir local",
+	},
+	{
+		English:    "Run an HTTP server exposing happy-compta as a JSON API",
+		Translated: "Lancer un serveur HTTP exposant happy-compta sous forme d'API JSON",
+	},
+	{
+		English:    "Print the version and check for updates",
+		Translated: "Afficher la version et rechercher les mises à jour",
+	},
+	{
+		English: `Print the version of the tools.
+
+With the check flag, the latest release is looked up on GitHub to report whether a newer version exists.
+With the download flag, the archive of the newer release for this system is downloaded in the given directory
+and verified against the SHA-256 checksums of the release.`,
+		Translated: `Afficher la version des outils.
+
+Avec l'option check, la dernière version publiée est recherchée sur GitHub pour signaler si elle est plus récente.
+Avec l'option download, l'archive de la nouvelle version pour ce système est téléchargée dans le dossier indiqué
+et vérifiée avec les sommes de contrôle SHA-256 de la version.`,
+	},
+	{
+		English:    "Generate the man pages and markdown references of the commands",
+		Translated: "Générer les pages de manuel et les références markdown des commandes",
+	},
+	{
+		English: `Formats of the documentation to generate: man, markdown or both.
+The man pages are written in the man folder and the markdown files in the markdown one.`,
+		Translated: `Formats de la documentation à générer : man, markdown ou les deux.
+Les pages de manuel sont écrites dans le dossier man et les fichiers markdown dans le dossier markdown.`,
+	},
+	{
+		English:    "Help about any command",
+		Translated: "Aide sur une commande",
+	},
+	{
+		English:    "Generate the autocompletion script for the specified shell",
+		Translated: "Générer le script d'autocomplétion pour le shell indiqué",
+	},
+
+	{
+		English: `A program dumping data from happy-compta.
+
+The balances are computed from the entries of all the accounting periods, per budget and per bank account.
+The stock report counts the items bought and handed out for the categories with stock enabled.
+The spending report sums the spending entries per category. Set the spending limits in the limits map
+of the configuration file, indexed by category ID or name, to get the consumption percentages.
+Since all the entries need to be read for them, skip those when not needed to speed up the dump.
+The sites and users, with their roles and the sites they can access, help auditing the accesses
+of multi-site organizations.`,
+		Translated: `Un programme extrayant les données de happy-compta.
+
+Les soldes sont calculés à partir des écritures de tous les exercices, par budget et par compte bancaire.
+Le rapport de stock compte les articles achetés et distribués pour les catégories avec un stock.
+Le rapport de dépenses additionne les dépenses par catégorie. Renseignez les plafonds de dépenses
+dans la table limits du fichier de configuration, indexée par ID ou nom de catégorie,
+pour obtenir les pourcentages de consommation.
+Comme toutes les écritures doivent être lues pour eux, ignorez-les quand ils ne sont pas nécessaires
+pour accélérer l'extraction.
+Les sites et les utilisateurs, avec leurs rôles et les sites auxquels ils ont accès,
+aident à auditer les accès des organisations multi-sites.`,
+	},
+	{
+		English:    "Only dump the active employees.",
+		Translated: "Extraire uniquement les salariés actifs.",
+	},
+	{
+		English:    "Only dump the categories and accounts of a budget. Can be one of FON or ASC.",
+		Translated: "Extraire uniquement les catégories et comptes d'un budget. Peut être FON ou ASC.",
+	},
+	{
+		English: `Colorize the table format.
+Can be one of auto, always or never. auto only colorizes when writing to a terminal.`,
+		Translated: `Coloriser le format tableau.
+Peut être auto, always ou never. auto ne colorise que lors de l'écriture dans un terminal.`,
+	},
+	{
+		English:    "Maximum width of the table format cells, 0 to disable the truncation.",
+		Translated: "Largeur maximale des cellules du format tableau, 0 pour ne pas les tronquer.",
+	},
+	{
+		English:    "Start the written UTF-8 CSV files with a byte order mark for Excel to detect their encoding.",
+		Translated: "Commencer les fichiers CSV UTF-8 écrits par une marque d'ordre des octets pour qu'Excel détecte leur encodage.",
+	},
+	{
+		English: `Field separator of the written CSV files.
+Defaults to a comma, or a semicolon for the export command.`,
+		Translated: `Séparateur de champs des fichiers CSV écrits.
+Par défaut une virgule, ou un point-virgule pour la commande export.`,
+	},
+	{
+		English:    "Encoding of the written CSV files. Defaults to utf-8.",
+		Translated: "Encodage des fichiers CSV écrits. Par défaut utf-8.",
+	},
+	{
+		English:    "Output format. Can be one of text, table or yaml.",
+		Translated: "Format de sortie. Peut être text, table ou yaml.",
+	},
+	{
+		English:    "Dump the archived providers.",
+		Translated: "Extraire les fournisseurs archivés.",
+	},
+	{
+		English: `Go template of the posted JSON notification.
+The template gets the tool, status, text, summary and report fields and a json function quoting values,
+like {"text": {{json .Text}}}. Defaults to the whole notification as JSON.`,
+		Translated: `Modèle Go de la notification JSON envoyée.
+Le modèle reçoit les champs tool, status, text, summary et report et une fonction json échappant les valeurs,
+comme {"text": {{json .Text}}}. Par défaut, toute la notification en JSON.`,
+	},
+	{
+		English: `URL to post a JSON notification to at the end of the run,
+like a Slack, Teams or Matrix incoming webhook.`,
+		Translated: `URL à laquelle envoyer une notification JSON à la fin de l'exécution,
+comme un webhook entrant Slack, Teams ou Matrix.`,
+	},
+	{
+		English: `Comma-separated list of the object types to dump.
+Can contain employees, providers, periods, accounts, categories, balances, stock, spending, sites, users.
+All the types but balances, stock, spending, sites, users are dumped by default:
+these ones need to be listed as they download all the entries of all the periods
+or, for the sites and users, need an administrator account.`,
+		Translated: `Liste séparée par des virgules des types d'objets à extraire.
+Peut contenir employees, providers, periods, accounts, categories, balances, stock, spending, sites, users.
+Tous les types sauf balances, stock, spending, sites, users sont extraits par défaut :
+ceux-ci doivent être listés car ils téléchargent toutes les écritures de tous les exercices
+ou, pour les sites et les utilisateurs, nécessitent un compte administrateur.`,
+	},
+	{
+		English: `File to write the dump to instead of the standard output.
+If the path is a directory or ends with a separator, each object type is written in a timestamped file in it.`,
+		Translated: `Fichier dans lequel écrire l'extraction au lieu de la sortie standard.
+Si le chemin est un dossier ou se termine par un séparateur, chaque type d'objet y est écrit
+dans un fichier horodaté.`,
+	},
+	{
+		English:    "Comma-separated list of the object types not to dump.",
+		Translated: "Liste séparée par des virgules des types d'objets à ne pas extraire.",
+	},
+	{
+		English: `Save all the data, the entries of all the accounting periods and their receipts in a tar.gz archive.
+
+The archive contains:
+  data.json                          the employees, providers, periods, accounts and categories
+  entries/<period>.json              the entries of each period
+  receipts/<period>/<entry>/<file>   the receipts of the entries
+  manifest.json                      the size and SHA-256 checksum of all the other files`,
+		Translated: `Enregistrer toutes les données, les écritures de tous les exercices et leurs justificatifs
+dans une archive tar.gz.
+
+L'archive contient :
+  data.json                          les salariés, fournisseurs, exercices, comptes et catégories
+  entries/<period>.json              les écritures de chaque exercice
+  receipts/<period>/<entry>/<file>   les justificatifs des écritures
+  manifest.json                      la taille et la somme de contrôle SHA-256 de tous les autres fichiers`,
+	},
+	{
+		English:    "Path of the archive to create. Defaults to backup-<timestamp>.tar.gz.",
+		Translated: "Chemin de l'archive à créer. Par défaut backup-<horodatage>.tar.gz.",
+	},
+	{
+		English:    "Include the receipts in the archive.",
+		Translated: "Inclure les justificatifs dans l'archive.",
+	},
+	{
+		English: `Export the providers and employees as contacts to import in a mail client.
+
+The vcard format writes a vCard 3.0 file and the google format a CSV file for the Google Contacts import.
+The providers are grouped in the Fournisseurs category or label and the employees in the Salariés one.
+The employees only have their names as happy-compta doesn't give their other details.
+The vCard UIDs are derived from the happy-compta IDs for the mail clients to update the existing contacts.`,
+		Translated: `Exporter les fournisseurs et les salariés comme contacts à importer dans un client de messagerie.
+
+Le format vcard écrit un fichier vCard 3.0 et le format google un fichier CSV pour l'import de Google Contacts.
+Les fournisseurs sont regroupés dans la catégorie ou le libellé Fournisseurs et les salariés dans Salariés.
+Les salariés n'ont que leurs noms car happy-compta ne donne pas leurs autres informations.
+Les UID des vCards sont dérivés des ID happy-compta pour que les clients de messagerie
+mettent à jour les contacts existants.`,
+	},
+	{
+		English:    "Only export the active employees.",
+		Translated: "Exporter uniquement les salariés actifs.",
+	},
+	{
+		English:    "Format of the contacts. Can be one of vcard or google.",
+		Translated: "Format des contacts. Peut être vcard ou google.",
+	},
+	{
+		English:    "Export the archived providers.",
+		Translated: "Exporter les fournisseurs archivés.",
+	},
+	{
+		English:    "File to write the contacts to instead of the standard output.",
+		Translated: "Fichier dans lequel écrire les contacts au lieu de la sortie standard.",
+	},
+	{
+		English: `List the entries of an accounting period with their allocations, party, payment method and receipts.
+The text format is a CSV table with one line per entry.`,
+		Translated: `Lister les écritures d'un exercice avec leurs ventilations, tiers, moyen de paiement et justificatifs.
+Le format texte est un tableau CSV avec une ligne par écriture.`,
+	},
+	{
+		English:    "Only list the entries dated on or after this day, like DD/MM/YYYY or YYYY-MM-DD.",
+		Translated: "Lister uniquement les écritures datées de ce jour ou après, comme JJ/MM/AAAA ou AAAA-MM-JJ.",
+	},
+	{
+		English: `File to write the entries to instead of the standard output.
+If the path is a directory or ends with a separator, the entries are written in a timestamped file in it.`,
+		Translated: `Fichier dans lequel écrire les écritures au lieu de la sortie standard.
+Si le chemin est un dossier ou se termine par un séparateur, les écritures y sont écrites dans un fichier horodaté.`,
+	},
+	{
+		English: `Accounting period of the entries to list.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`,
+		Translated: `Exercice des écritures à lister.
+Soit l'ID de l'exercice, soit une année correspondant au début de l'exercice. Par défaut l'exercice en cours.`,
+	},
+	{
+		English:    "Only list the entries dated on or before this day, like DD/MM/YYYY or YYYY-MM-DD.",
+		Translated: "Lister uniquement les écritures datées de ce jour ou avant, comme JJ/MM/AAAA ou AAAA-MM-JJ.",
+	},
+	{
+		English: `Export the entries of an accounting period in a folder to load them in another organization.
+
+The folder contains:
+  entries.csv              the entries in the layout of the loader happy-compta profile
+  receipts/<row>/<file>    the receipts of the entries, in folders named after their CSV row number
+
+Load them in the other organization with:
+  happycompta-loader --profile happy-compta --receipts <folder>/receipts <folder>/entries.csv
+
+The loader only takes one category per row: the entries with several allocation lines
+are exported as one row per line and their receipts are attached to the first one.
+The periods, categories, employees, providers and accounts need to exist in the other organization.`,
+		Translated: `Exporter les écritures d'un exercice dans un dossier pour les charger dans une autre organisation.
+
+Le dossier contient :
+  entries.csv              les écritures au format du profil happy-compta du chargeur
+  receipts/<row>/<file>    les justificatifs des écritures, dans des dossiers nommés d'après leur ligne CSV
+
+Chargez-les dans l'autre organisation avec :
+  happycompta-loader --profile happy-compta --receipts <folder>/receipts <folder>/entries.csv
+
+Le chargeur ne prend qu'une catégorie par ligne : les écritures avec plusieurs lignes de ventilation
+sont exportées avec une ligne CSV par ligne de ventilation et leurs justificatifs sont joints à la première.
+Les exercices, catégories, salariés, fournisseurs et comptes doivent exister dans l'autre organisation.`,
+	},
+	{
+		English:    "Folder to write the CSV file and the receipts to.",
+		Translated: "Dossier dans lequel écrire le fichier CSV et les justificatifs.",
+	},
+	{
+		English: `Accounting period of the entries to export.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`,
+		Translated: `Exercice des écritures à exporter.
+Soit l'ID de l'exercice, soit une année correspondant au début de l'exercice. Par défaut l'exercice en cours.`,
+	},
+	{
+		English: `List the entries of an accounting period without any receipt attached.
+
+With --by-employee, the entries are grouped by employee with a mailto link opening a reminder
+asking for the missing receipts. happy-compta doesn't know the employees email addresses:
+set them in the emails map of the configuration file, indexed by employee ID or "Firstname Lastname".
+The entries of the providers and those without party are listed in a last group without reminder.`,
+		Translated: `Lister les écritures d'un exercice sans aucun justificatif joint.
+
+Avec --by-employee, les écritures sont regroupées par salarié avec un lien mailto ouvrant un rappel
+demandant les justificatifs manquants. happy-compta ne connaît pas les adresses e-mail des salariés :
+renseignez-les dans la table emails du fichier de configuration, indexée par ID de salarié
+ou "Prénom Nom".
+Les écritures des fournisseurs et celles sans tiers sont listées dans un dernier groupe sans rappel.`,
+	},
+	{
+		English:    "Group the entries by employee with a reminder to send them.",
+		Translated: "Regrouper les écritures par salarié avec un rappel à leur envoyer.",
+	},
+	{
+		English: `File to write the list to instead of the standard output.
+If the path is a directory or ends with a separator, the list is written in a timestamped file in it.`,
+		Translated: `Fichier dans lequel écrire la liste au lieu de la sortie standard.
+Si le chemin est un dossier ou se termine par un séparateur, la liste y est écrite dans un fichier horodaté.`,
+	},
+	{
+		English: `Accounting period of the entries to check.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`,
+		Translated: `Exercice des écritures à vérifier.
+Soit l'ID de l'exercice, soit une année correspondant au début de l'exercice. Par défaut l'exercice en cours.`,
+	},
+	{
+		English: `Download the receipts of all the entries of an accounting period in a zip archive.
+The receipts are stored in a folder per entry named after the entry number.`,
+		Translated: `Télécharger les justificatifs de toutes les écritures d'un exercice dans une archive zip.
+Les justificatifs sont rangés dans un dossier par écriture nommé d'après le numéro de l'écriture.`,
+	},
+	{
+		English:    "Path of the zip archive to create.",
+		Translated: "Chemin de l'archive zip à créer.",
+	},
+	{
+		English: `Accounting period of the entries.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`,
+		Translated: `Exercice des écritures.
+Soit l'ID de l'exercice, soit une année correspondant au début de l'exercice. Par défaut l'exercice en cours.`,
+	},
+	{
+		English: `Match the lines of a bank statement file against the entries of an account.
+
+The statement is a CSV file, an ISO 20022 camt.053 XML file with a .xml extension
+or an OFX file with a .ofx or .qfx extension. The column, date and CSV flags only apply to the CSV files.
+
+A line matches an entry with the same amount and a date close enough. The spending entries
+match negative amounts and the income ones positive amounts. When several entries match,
+the one with its ID or the most words of its name in the line label is preferred, then the closest one.
+
+The statement lines and entries without match are written to the exceptions report.
+With --mark, the matches are printed and, after confirmation, a "Reconciled with the bank statement line" line
+is added to the comment of their entries. The entries already having it are not changed.`,
+		Translated: `Rapprocher les lignes d'un relevé bancaire des écritures d'un compte.
+
+Le relevé est un fichier CSV, un fichier XML ISO 20022 camt.053 avec une extension .xml
+ou un fichier OFX avec une extension .ofx ou .qfx. Les options de colonnes, de date et CSV
+ne s'appliquent qu'aux fichiers CSV.
+
+Une ligne correspond à une écriture de même montant et de date assez proche. Les dépenses
+correspondent aux montants négatifs et les recettes aux montants positifs. Quand plusieurs écritures
+correspondent, celle dont l'ID ou le plus de mots du nom figurent dans le libellé de la ligne
+est préférée, puis la plus proche.
+
+Les lignes du relevé et les écritures sans correspondance sont écrites dans le rapport d'exceptions.
+Avec --mark, les correspondances sont affichées et, après confirmation, une ligne
+"Reconciled with the bank statement line" est ajoutée au commentaire de leurs écritures.
+Les écritures l'ayant déjà ne sont pas modifiées.`,
+	},
+	{
+		English:    "Bank account of the statement: its ID, bank name or abbreviation (REQUIRED)",
+		Translated: "Compte bancaire du relevé : son ID, le nom de sa banque ou son abréviation (REQUIS)",
+	},
+	{
+		English:    "Name of the statement column containing the signed amount.",
+		Translated: "Nom de la colonne du relevé contenant le montant signé.",
+	},
+	{
+		English:    "Field separator of the statement. Defaults to a comma.",
+		Translated: "Séparateur de champs du relevé. Par défaut une virgule.",
+	},
+	{
+		English:    "Encoding of the statement. Guessed by default.",
+		Translated: "Encodage du relevé. Deviné par défaut.",
+	},
+	{
+		English:    "Name of the statement column containing the date.",
+		Translated: "Nom de la colonne du relevé contenant la date.",
+	},
+	{
+		English: `Go layout of the statement dates.
+Defaults to DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY: 02/01/2006, 2006-01-02 or 02-01-06.`,
+		Translated: `Format Go des dates du relevé.
+Par défaut JJ/MM/AAAA, AAAA-MM-JJ et JJ-MM-AA : 02/01/2006, 2006-01-02 ou 02-01-06.`,
+	},
+	{
+		English:    "Maximum number of days between a line and its matching entry.",
+		Translated: "Nombre maximal de jours entre une ligne et l'écriture correspondante.",
+	},
+	{
+		English:    "Name of the statement column containing the line label.",
+		Translated: "Nom de la colonne du relevé contenant le libellé de la ligne.",
+	},
+	{
+		English:    "Mark the matching entries as reconciled in happy-compta.",
+		Translated: "Marquer les écritures correspondantes comme rapprochées dans happy-compta.",
+	},
+	{
+		English: `File to write the exceptions report to instead of the standard output.
+If the path is a directory or ends with a separator, the report is written in a timestamped file in it.`,
+		Translated: `Fichier dans lequel écrire le rapport d'exceptions au lieu de la sortie standard.
+Si le chemin est un dossier ou se termine par un séparateur, le rapport y est écrit dans un fichier horodaté.`,
+	},
+	{
+		English:    "Mark the matching entries without asking for confirmation.",
+		Translated: "Marquer les écritures correspondantes sans demander de confirmation.",
+	},
+	{
+		English: `Dump the employees, providers, periods, accounts and categories and report the changes since the previous run.
+
+The data of the previous run are stored in the state file. The first run only creates it.
+Without interval, the command runs once and can be scheduled with cron.`,
+		Translated: `Extraire les salariés, fournisseurs, exercices, comptes et catégories
+et signaler les changements depuis l'exécution précédente.
+
+Les données de l'exécution précédente sont stockées dans le fichier d'état. La première exécution ne fait que le créer.
+Sans intervalle, la commande s'exécute une fois et peut être planifiée avec cron.`,
+	},
+	{
+		English:    "Time between two checks, like 1h or 30m. Runs only once by default.",
+		Translated: "Temps entre deux vérifications, comme 1h ou 30m. Ne s'exécute qu'une fois par défaut.",
+	},
+	{
+		English:    "Path of the file storing the data of the previous run.",
+		Translated: "Chemin du fichier stockant les données de l'exécution précédente.",
+	},
+	{
+		English:    "URL to post the changes to as JSON.",
+		Translated: "URL à laquelle envoyer les changements en JSON.",
+	},
+	{
+		English: `A program loading entries from a CSV file as entries into happy-compta.
+
+Files with a .xlsx extension are read as spreadsheets with the same columns as the CSV files.
+Files with a .yaml, .yml or .json extension are read as manifests listing the entries,
+with their allocation lines and receipts.`,
+		Translated: `Un programme chargeant les écritures d'un fichier CSV dans happy-compta.
+
+Les fichiers avec une extension .xlsx sont lus comme des classeurs avec les mêmes colonnes que les fichiers CSV.
+Les fichiers avec une extension .yaml, .yml ou .json sont lus comme des manifestes listant les écritures,
+avec leurs lignes de ventilation et leurs justificatifs.`,
+	},
+	{
+		English:    "Default value for bank column.",
+		Translated: "Valeur par défaut de la colonne de la banque.",
+	},
+	{
+		English:    "Default value for budget column.",
+		Translated: "Valeur par défaut de la colonne du budget.",
+	},
+	{
+		English: `Fail the import when a category would go over its limit.
+The limits are set in the budgets.limits map of the configuration file. Without this flag, only a warning is logged.`,
+		Translated: `Faire échouer l'import quand une catégorie dépasserait son plafond.
+Les plafonds sont renseignés dans la table budgets.limits du fichier de configuration.
+Sans cette option, seul un avertissement est journalisé.`,
+	},
+	{
+		English: `Folder caching the happy-compta pages between the runs.
+The cached pages are only downloaded again if they changed. Empty disables the cache.`,
+		Translated: `Dossier gardant les pages happy-compta en cache entre les exécutions.
+Les pages en cache ne sont téléchargées à nouveau que si elles ont changé. Vide désactive le cache.`,
+	},
+	{
+		English:    "Default value for category column.",
+		Translated: "Valeur par défaut de la colonne de la catégorie.",
+	},
+	{
+		English:    "CSV column name for amount.",
+		Translated: "Nom de la colonne CSV du montant.",
+	},
+	{
+		English: `CSV column name for the running balance.
+When present, the balances are checked against the amounts before uploading anything.`,
+		Translated: `Nom de la colonne CSV du solde courant.
+Quand elle est présente, les soldes sont vérifiés par rapport aux montants avant tout envoi.`,
+	},
+	{
+		English: `CSV column name for the name of the bank holding the account.
+This is used in conjunction with the budget to identify the target account.`,
+		Translated: `Nom de la colonne CSV du nom de la banque tenant le compte.
+Il est utilisé avec le budget pour identifier le compte cible.`,
+	},
+	{
+		English:    "CSV column name for budget ID.",
+		Translated: "Nom de la colonne CSV de l'ID du budget.",
+	},
+	{
+		English:    "CSV column name for category.",
+		Translated: "Nom de la colonne CSV de la catégorie.",
+	},
+	{
+		English:    "CSV column name for the check number.",
+		Translated: "Nom de la colonne CSV du numéro de chèque.",
+	},
+	{
+		English:    "CSV column name for comment.",
+		Translated: "Nom de la colonne CSV du commentaire.",
+	},
+	{
+		English: `CSV column name for the credit amount.
+The entry is an income when this column is filled and no amount is set.`,
+		Translated: `Nom de la colonne CSV du montant au crédit.
+L'écriture est une recette quand cette colonne est remplie et qu'aucun montant n'est renseigné.`,
+	},
+	{
+		English: `CSV column name for the ISO 4217 currency code of the amount.
+Amounts in other currencies than EUR are converted to euros.`,
+		Translated: `Nom de la colonne CSV du code devise ISO 4217 du montant.
+Les montants dans d'autres devises que l'EUR sont convertis en euros.`,
+	},
+	{
+		English:    "CSV column name for date.",
+		Translated: "Nom de la colonne CSV de la date.",
+	},
+	{
+		English: `CSV column name for the debit amount.
+The entry is a spending when this column is filled and no amount is set.`,
+		Translated: `Nom de la colonne CSV du montant au débit.
+L'écriture est une dépense quand cette colonne est remplie et qu'aucun montant n'est renseigné.`,
+	},
+	{
+		English:    "CSV column name for employee.",
+		Translated: "Nom de la colonne CSV du salarié.",
+	},
+	{
+		English:    "CSV column name for transaction name.",
+		Translated: "Nom de la colonne CSV du nom de l'opération.",
+	},
+	{
+		English:    "CSV column name for payment type.",
+		Translated: "Nom de la colonne CSV du moyen de paiement.",
+	},
+	{
+		English:    "CSV column name for the period.",
+		Translated: "Nom de la colonne CSV de l'exercice.",
+	},
+	{
+		English:    "CSV column name for provider.",
+		Translated: "Nom de la colonne CSV du fournisseur.",
+	},
+	{
+		English: `CSV column name for the exchange rate.
+The rate is the amount in the row currency for one euro.`,
+		Translated: `Nom de la colonne CSV du taux de change.
+Le taux est le montant dans la devise de la ligne pour un euro.`,
+	},
+	{
+		English: `CSV column name for the stock.
+This is usually needed for check allocations and orders.`,
+		Translated: `Nom de la colonne CSV du stock.
+Elle est généralement nécessaire pour les attributions de chèques et les commandes.`,
+	},
+	{
+		English: `CSV column name for the entry template.
+The templates are defined in the templates map of the configuration file.`,
+		Translated: `Nom de la colonne CSV du modèle d'écriture.
+Les modèles sont définis dans la table templates du fichier de configuration.`,
+	},
+	{
+		English: `CSV column name for the declared total of the expense claims.
+The value can be set on all the rows of the claim or only on one of them.`,
+		Translated: `Nom de la colonne CSV du total déclaré des notes de frais.
+La valeur peut être renseignée sur toutes les lignes de la note ou sur une seule d'entre elles.`,
+	},
+	{
+		English:    "CSV field separator character.",
+		Translated: "Caractère séparateur de champs du CSV.",
+	},
+	{
+		English:    "CSV comment character.",
+		Translated: "Caractère de commentaire du CSV.",
+	},
+	{
+		English: `Comma-separated list of the accepted date formats, as Go layouts.
+Defaults to DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY: 02/01/2006,2006-01-02,02-01-06.`,
+		Translated: `Liste séparée par des virgules des formats de date acceptés, en formats Go.
+Par défaut JJ/MM/AAAA, AAAA-MM-JJ et JJ-MM-AA : 02/01/2006,2006-01-02,02-01-06.`,
+	},
+	{
+		English: `CSV file encoding, like utf-8 or windows-1252.
+auto detects the byte order mark and falls back to windows-1252 for non UTF-8 content.`,
+		Translated: `Encodage du fichier CSV, comme utf-8 ou windows-1252.
+auto détecte la marque d'ordre des octets et utilise windows-1252 pour les contenus non UTF-8.`,
+	},
+	{
+		English: `Name of the worksheet to read in .xlsx files.
+Defaults to the first one. The same column names as in CSV files are used.`,
+		Translated: `Nom de la feuille à lire dans les fichiers .xlsx.
+Par défaut la première. Les mêmes noms de colonnes que dans les fichiers CSV sont utilisés.`,
+	},
+	{
+		English: `Path of the file to write the deposit slip of the received checks to.
+The slip lists the check number, drawer and amount of the entries paid by check, per bank account.`,
+		Translated: `Chemin du fichier dans lequel écrire le bordereau de remise des chèques reçus.
+Le bordereau liste le numéro de chèque, le tireur et le montant des écritures payées par chèque,
+par compte bancaire.`,
+	},
+	{
+		English:    "Parse and validate the CSV file and print the entries without adding them.",
+		Translated: "Analyser et valider le fichier CSV et afficher les écritures sans les ajouter.",
+	},
+	{
+		English: `Path of the copy of the CSV file with an additional import_error column written on failures.
+If some entries have been added, only the failed rows are written so the file can be fixed and imported again.
+Defaults to the CSV file path with an -errors suffix.`,
+		Translated: `Chemin de la copie du fichier CSV avec une colonne import_error supplémentaire, écrite en cas d'échec.
+Si des écritures ont été ajoutées, seules les lignes en échec sont écrites pour que le fichier
+puisse être corrigé et importé à nouveau.
+Par défaut le chemin du fichier CSV avec un suffixe -errors.`,
+	},
+	{
+		English: `Import the rows as employee expense claims.
+The rows are grouped per employee and each claim amount is checked against the declared one in the total column.
+The receipts of a claim are attached to all its entries and a comment summarizes the claim.`,
+		Translated: `Importer les lignes comme des notes de frais de salariés.
+Les lignes sont regroupées par salarié et le montant de chaque note est comparé à celui déclaré
+dans la colonne du total.
+Les justificatifs d'une note sont joints à toutes ses écritures et un commentaire résume la note.`,
+	},
+	{
+		English: `Guess the CSV columns mapping from the header names.
+The guessed mapping is printed for confirmation.`,
+		Translated: `Deviner la correspondance des colonnes CSV à partir des noms de l'en-tête.
+La correspondance devinée est affichée pour confirmation.`,
+	},
+	{
+		English: `Shell command or webhook URL to run after the import finished.
+The hook gets the same data as the pre-import one.`,
+		Translated: `Commande shell ou URL de webhook à exécuter une fois l'import terminé.
+Le hook reçoit les mêmes données que celui d'avant l'import.`,
+	},
+	{
+		English: `Shell command or webhook URL to run before the import starts.
+The import is aborted if the hook fails.
+Commands get the JSON summary on standard input and in LOADER_HOOK_SUMMARY,
+the report path in LOADER_HOOK_REPORT and the event in LOADER_HOOK_EVENT.
+Webhooks get the same JSON document posted.`,
+		Translated: `Commande shell ou URL de webhook à exécuter avant le début de l'import.
+L'import est annulé si le hook échoue.
+Les commandes reçoivent le résumé JSON sur l'entrée standard et dans LOADER_HOOK_SUMMARY,
+le chemin du rapport dans LOADER_HOOK_REPORT et l'événement dans LOADER_HOOK_EVENT.
+Les webhooks reçoivent le même document JSON.`,
+	},
+	{
+		English: `Default value for kind column.
+Can be one of depenses, recettes, attributions`,
+		Translated: `Valeur par défaut de la colonne du type.
+Peut être depenses, recettes, attributions`,
+	},
+	{
+		English:    "Default value for name column.",
+		Translated: "Valeur par défaut de la colonne du nom.",
+	},
+	{
+		English:    "Pause the import after this number of entries.",
+		Translated: "Mettre l'import en pause après ce nombre d'écritures.",
+	},
+	{
+		English:    "Number of seconds to pause the import for.",
+		Translated: "Nombre de secondes de pause de l'import.",
+	},
+	{
+		English: `Default value for payment column.
+Can be one of check received, cash, card, transfer, direct debit, check emitted, check allocation`,
+		Translated: `Valeur par défaut de la colonne du moyen de paiement.
+Peut être check received, cash, card, transfer, direct debit, check emitted, check allocation`,
+	},
+	{
+		English:    "Accounting period to add the entries to. Defaults to the current one.",
+		Translated: "Exercice auquel ajouter les écritures. Par défaut l'exercice en cours.",
+	},
+	{
+		English: `Preset of CSV settings for a known file layout.
+Can be one of happy-compta, membership.
+happy-compta reads the CSV and XLSX files exported from happy-compta operations list.
+membership reads the lists of membership fees with Date, Nom, Montant, Paiement and Chèque columns.`,
+		Translated: `Préréglage des paramètres CSV pour une disposition de fichier connue.
+Peut être happy-compta, membership.
+happy-compta lit les fichiers CSV et XLSX exportés de la liste des opérations de happy-compta.
+membership lit les listes de cotisations avec les colonnes Date, Nom, Montant, Paiement et Chèque.`,
+	},
+	{
+		English: `Source of the exchange rates when not defined in the CSV file.
+Can be ecb to use the European Central Bank reference rates.`,
+		Translated: `Source des taux de change quand ils ne sont pas définis dans le fichier CSV.
+Peut être ecb pour utiliser les taux de référence de la Banque centrale européenne.`,
+	},
+	{
+		English:    "Folder containing the receipts",
+		Translated: "Dossier contenant les justificatifs",
+	},
+	{
+		English: `Reference data file written by the snapshot command.
+When set, the CSV file is validated against this file without connecting to happy-compta.
+This requires --dry-run.`,
+		Translated: `Fichier de données de référence écrit par la commande snapshot.
+Quand il est renseigné, le fichier CSV est validé par rapport à ce fichier sans se connecter à happy-compta.
+Cela nécessite --dry-run.`,
+	},
+	{
+		English:    "Path of the JSON report of the import to write.",
+		Translated: "Chemin du rapport JSON de l'import à écrire.",
+	},
+	{
+		English: `Review the parsed entries in an interactive terminal UI before uploading them.
+The category, employee, provider and account bank can be fixed and rows can be excluded.`,
+		Translated: `Relire les écritures analysées dans une interface interactive en terminal avant de les envoyer.
+La catégorie, le salarié, le fournisseur et la banque du compte peuvent être corrigés
+et des lignes peuvent être exclues.`,
+	},
+	{
+		English: `Daily time window during which the entries are uploaded, like 22:00-06:00.
+The import waits for the window to open before uploading the next entry.`,
+		Translated: `Plage horaire quotidienne pendant laquelle les écritures sont envoyées, comme 22:00-06:00.
+L'import attend l'ouverture de la plage avant d'envoyer l'écriture suivante.`,
+	},
+	{
+		English: `Path of the file storing the hashes of the receipts uploaded by the previous runs.
+A warning is logged when a receipt has already been uploaded. Empty disables the tracking.`,
+		Translated: `Chemin du fichier stockant les empreintes des justificatifs envoyés par les exécutions précédentes.
+Un avertissement est journalisé quand un justificatif a déjà été envoyé. Vide désactive le suivi.`,
+	},
+	{
+		English: `Validate, match the receipts of and add the rows one at a time, for large imports.
+The memory use doesn't grow with the file size and the invalid rows are reported as soon as they are read.
+The valid rows are added even if other rows are invalid.
+Only the failed rows are written to the errors CSV.
+This can't be used with manifests, --review, --expense-claims, --suggest-categories or --budgets-block.`,
+		Translated: `Valider les lignes, leur associer les justificatifs et les ajouter une par une, pour les gros imports.
+La mémoire utilisée ne croît pas avec la taille du fichier et les lignes invalides sont signalées
+dès qu'elles sont lues.
+Les lignes valides sont ajoutées même si d'autres lignes sont invalides.
+Seules les lignes en échec sont écrites dans le CSV des erreurs.
+Ne peut pas être utilisé avec les manifestes, --review, --expense-claims, --suggest-categories
+ou --budgets-block.`,
+	},
+	{
+		English: `Suggest the category of the rows without one from the past entries with a similar name.
+The entries of the two latest accounting periods are fetched to learn from.
+The suggestions are listed by --dry-run and need to be accepted in the --review interface.`,
+		Translated: `Suggérer la catégorie des lignes qui n'en ont pas à partir des écritures passées de nom similaire.
+Les écritures des deux derniers exercices sont récupérées pour l'apprentissage.
+Les suggestions sont listées par --dry-run et doivent être acceptées dans l'interface --review.`,
+	},
+	{
+		English:    "Do not ask for confirmations",
+		Translated: "Ne pas demander de confirmation",
+	},
+	{
+		English: `Attach the receipts of a folder to the entries already in happy-compta, without creating any entry.
+
+Each folder or file of the receipts folder is matched against the entries of the accounting period:
+- by entry number, like 12, FON12 or FON000012 - Taxi,
+- by employee name, like Doe John, if only one entry of the employee has no receipt,
+- by date and amount, like 2025-03-14 42.50.
+The files of a matched folder are all attached to the entry. The files already attached are skipped.`,
+		Translated: `Joindre les justificatifs d'un dossier aux écritures déjà dans happy-compta, sans créer d'écriture.
+
+Chaque dossier ou fichier du dossier des justificatifs est associé aux écritures de l'exercice :
+- par numéro d'écriture, comme 12, FON12 ou FON000012 - Taxi,
+- par nom de salarié, comme Doe John, si une seule écriture du salarié n'a pas de justificatif,
+- par date et montant, comme 2025-03-14 42.50.
+Les fichiers d'un dossier associé sont tous joints à l'écriture. Les fichiers déjà joints sont ignorés.`,
+	},
+	{
+		English:    "Print the receipts to attach without uploading them.",
+		Translated: "Afficher les justificatifs à joindre sans les envoyer.",
+	},
+	{
+		English: `Accounting period of the entries, as an ID or the year of its start.
+Defaults to the current one.`,
+		Translated: `Exercice des écritures, par son ID ou l'année de son début.
+Par défaut l'exercice en cours.`,
+	},
+	{
+		English: `Create one empty folder per row of the CSV file to sort the receipts before the import.
+
+The folders are named after the entry number, name and employee, like 003 - Gifts - John Doe.
+Drop the receipts of each entry in its folder and pass the parent folder to --receipts when importing.
+The existing folders are kept. The CSV structure is read from the configuration.`,
+		Translated: `Créer un dossier vide par ligne du fichier CSV pour trier les justificatifs avant l'import.
+
+Les dossiers sont nommés d'après le numéro, le nom et le salarié de l'écriture, comme 003 - Gifts - John Doe.
+Déposez les justificatifs de chaque écriture dans son dossier et passez le dossier parent à --receipts
+lors de l'import.
+Les dossiers existants sont conservés. La structure du CSV est lue dans la configuration.`,
+	},
+	{
+		English:    "Folder to create the receipt folders in.",
+		Translated: "Dossier dans lequel créer les dossiers des justificatifs.",
+	},
+	{
+		English: `Run an HTTP server importing the uploaded CSV files in the background.
+
+POST /imports takes a multipart form with the following fields:
+  csv       the CSV file to import (required)
+  receipts  a zip archive with the receipts folders
+  email     the happy-compta user email, defaults to the configured one when the server has a token
+  password  the happy-compta user password
+  dry_run   true to only validate the CSV file
+
+The response contains the import ID to get its status from GET /imports/<id>.
+When rows have failed, their errors can be downloaded from GET /imports/<id>/errors.csv.
+
+The server listens on the loopback interface by default. A token is required to listen on other addresses.
+The finished imports are forgotten after an hour.
+
+The CSV structure, default values and other import settings are read from the configuration.`,
+		Translated: `Lancer un serveur HTTP important les fichiers CSV envoyés en arrière-plan.
+
+POST /imports prend un formulaire multipart avec les champs suivants :
+  csv       le fichier CSV à importer (requis)
+  receipts  une archive zip avec les dossiers des justificatifs
+  email     l'e-mail de l'utilisateur happy-compta, par défaut celui configuré quand le serveur a un jeton
+  password  le mot de passe de l'utilisateur happy-compta
+  dry_run   true pour seulement valider le fichier CSV
+
+La réponse contient l'ID de l'import pour obtenir son état avec GET /imports/<id>.
+Quand des lignes ont échoué, leurs erreurs peuvent être téléchargées avec GET /imports/<id>/errors.csv.
+
+Le serveur écoute sur l'interface de bouclage par défaut. Un jeton est requis pour écouter
+sur d'autres adresses.
+Les imports terminés sont oubliés au bout d'une heure.
+
+La structure du CSV, les valeurs par défaut et les autres paramètres d'import sont lus dans la configuration.`,
+	},
+	{
+		English:    "Address to listen on.",
+		Translated: "Adresse sur laquelle écouter.",
+	},
+	{
+		English:    "Token the clients need to pass as an Authorization bearer header.",
+		Translated: "Jeton que les clients doivent passer dans un en-tête Authorization bearer.",
+	},
+	{
+		English: `Save the accounts, categories, employees, providers and accounting periods to a JSON file.
+The file can then be passed to --reference-snapshot to validate a CSV file with --dry-run
+without connecting to happy-compta.`,
+		Translated: `Enregistrer les comptes, catégories, salariés, fournisseurs et exercices dans un fichier JSON.
+Le fichier peut ensuite être passé à --reference-snapshot pour valider un fichier CSV avec --dry-run
+sans se connecter à happy-compta.`,
+	},
+	{
+		English: `Pay the employee reimbursements of an accounting period in happy-compta.
+
+The command chains the steps of the monthly reimbursements:
+  1. read the spend entries paid by transfer to an employee that have a receipt and are not paid yet,
+  2. get the employees IBAN and BIC from the roster, managed by the sepa roster commands,
+  3. write the SEPA file transferring the reimbursements,
+  4. after confirmation, mark the entries as paid by adding a line to their comment.
+
+The entries without receipt are not validated and only reported.
+The entries with a "Paid by SEPA transfer" line in their comment are already paid and skipped.
+The SEPA file flags are the same as for the sepa command.`,
+		Translated: `Payer les remboursements des salariés d'un exercice dans happy-compta.
+
+La commande enchaîne les étapes des remboursements mensuels :
+  1. lire les dépenses payées par virement à un salarié qui ont un justificatif et ne sont pas encore payées,
+  2. obtenir l'IBAN et le BIC des salariés dans le registre, géré par les commandes sepa roster,
+  3. écrire le fichier SEPA virant les remboursements,
+  4. après confirmation, marquer les écritures comme payées en ajoutant une ligne à leur commentaire.
+
+Les écritures sans justificatif ne sont pas validées et sont seulement signalées.
+Les écritures avec une ligne "Paid by SEPA transfer" dans leur commentaire sont déjà payées et ignorées.
+Les options du fichier SEPA sont les mêmes que pour la commande sepa.`,
+	},
+	{
+		English: `Merge the transactions to the same IBAN in one payment.
+The amounts are summed and the information texts concatenated to save the bank fees per transaction.
+The end to end ID of the first transaction is kept and the transactions with a creditor reference are not merged.`,
+		Translated: `Regrouper les virements vers le même IBAN en un seul paiement.
+Les montants sont additionnés et les textes d'information concaténés pour économiser les frais bancaires
+par virement.
+L'ID de bout en bout du premier virement est conservé et les virements avec une référence créancier
+ne sont pas regroupés.`,
+	},
+	{
+		English: `Unique identifier of the transfer initiation.
+Defaults to a generated one made of the debtor name, the date and a random suffix.`,
+		Translated: `Identifiant unique de l'initiation de virement.
+Par défaut un identifiant généré à partir du nom du débiteur, de la date et d'un suffixe aléatoire.`,
+	},
+	{
+		English: `Party paying the transfer fees: SLEV, DEBT, CRED or SHAR.
+SEPA transfers require SLEV, the others are only for transfers outside of the SEPA zone.`,
+		Translated: `Partie payant les frais de virement : SLEV, DEBT, CRED ou SHAR.
+Les virements SEPA nécessitent SLEV, les autres ne concernent que les virements hors de la zone SEPA.`,
+	},
+	{
+		English: `Write the SHA-256 checksum of the SEPA file in a .sha256 file.
+The checksum file is next to the SEPA file and helps checking the integrity of the file handed to the bank.`,
+		Translated: `Écrire la somme de contrôle SHA-256 du fichier SEPA dans un fichier .sha256.
+Le fichier de somme de contrôle est à côté du fichier SEPA et aide à vérifier l'intégrité
+du fichier remis à la banque.`,
+	},
+	{
+		English:    "Write the SEPA file even if the same transfers have been generated recently, only warning about it.",
+		Translated: "Écrire le fichier SEPA même si les mêmes virements ont été générés récemment, en avertissant seulement.",
+	},
+	{
+		English:    "Write the SEPA file even if amounts are over the limits, only warning about them.",
+		Translated: "Écrire le fichier SEPA même si des montants dépassent les plafonds, en avertissant seulement.",
+	},
+	{
+		English: `ISO 4217 code of the amounts currency.
+The currency column overrides it for each row. Instant transfers are only in EUR.`,
+		Translated: `Code ISO 4217 de la devise des montants.
+La colonne de la devise le remplace pour chaque ligne. Les virements instantanés ne sont qu'en EUR.`,
+	},
+	{
+		English:    "Debtor BIC",
+		Translated: "BIC du débiteur",
+	},
+	{
+		English:    "Debtor IBAN",
+		Translated: "IBAN du débiteur",
+	},
+	{
+		English:    "Debtor name",
+		Translated: "Nom du débiteur",
+	},
+	{
+		English: `Name of the debtor profile to use.
+It replaces the debtor-* flags.
+The profiles are defined in the debtors section of the configuration file with a name, iban and bic.`,
+		Translated: `Nom du profil de débiteur à utiliser.
+Il remplace les options debtor-*.
+Les profils sont définis dans la section debtors du fichier de configuration avec un nom, un iban et un bic.`,
+	},
+	{
+		English:    "Add a -<n> suffix to the duplicate end to end IDs instead of failing.",
+		Translated: "Ajouter un suffixe -<n> aux ID de bout en bout en double au lieu d'échouer.",
+	},
+	{
+		English:    "happy-compta user email address, needed to read the reimbursements",
+		Translated: "Adresse e-mail de l'utilisateur happy-compta, nécessaire pour lire les remboursements",
+	},
+	{
+		English: `Requested execution date of the transfers.
+The date is in one of the csv-date-layouts formats and defaults to today.
+The date can't be in the past or on a TARGET2 closing day.`,
+		Translated: `Date d'exécution demandée des virements.
+La date est dans l'un des formats de csv-date-layouts et vaut aujourd'hui par défaut.
+La date ne peut pas être dans le passé ou un jour de fermeture de TARGET2.`,
+	},
+	{
+		English: `Expected number of transactions.
+The SEPA file is not written if it doesn't match. 0 means no check.`,
+		Translated: `Nombre attendu de virements.
+Le fichier SEPA n'est pas écrit s'il ne correspond pas. 0 signifie aucune vérification.`,
+	},
+	{
+		English: `Expected total amount of the transactions.
+The SEPA file is not written if it doesn't match. Empty means no check.`,
+		Translated: `Montant total attendu des virements.
+Le fichier SEPA n'est pas écrit s'il ne correspond pas. Vide signifie aucune vérification.`,
+	},
+	{
+		English:    "Overwrite the SEPA file if it already exists.",
+		Translated: "Écraser le fichier SEPA s'il existe déjà.",
+	},
+	{
+		English: `Only transfer the entries dated on or after this day.
+The date is in one of the csv-date-layouts formats.`,
+		Translated: `Virer uniquement les écritures datées de ce jour ou après.
+La date est dans l'un des formats de csv-date-layouts.`,
+	},
+	{
+		English:    "Number of days during which the generated batches are recorded.",
+		Translated: "Nombre de jours pendant lesquels les lots générés sont enregistrés.",
+	},
+	{
+		English: `File recording the generated batches.
+The SEPA file is not written if the same transfers have already been generated recently. Empty disables the check.`,
+		Translated: `Fichier enregistrant les lots générés.
+Le fichier SEPA n'est pas écrit si les mêmes virements ont déjà été générés récemment.
+Vide désactive la vérification.`,
+	},
+	{
+		English: `Request SEPA instant credit transfers (SCT Inst).
+The debtor bank needs to support them. They can be executed on TARGET2 closing days.`,
+		Translated: `Demander des virements SEPA instantanés (SCT Inst).
+La banque du débiteur doit les prendre en charge. Ils peuvent être exécutés les jours de fermeture de TARGET2.`,
+	},
+	{
+		English: `Total amount above which the transfers need to be confirmed.
+Empty means no limit.`,
+		Translated: `Montant total au-delà duquel les virements doivent être confirmés.
+Vide signifie aucun plafond.`,
+	},
+	{
+		English: `Amount above which a transaction needs to be confirmed.
+Empty means no limit.`,
+		Translated: `Montant au-delà duquel un virement doit être confirmé.
+Vide signifie aucun plafond.`,
+	},
+	{
+		English: `Maximum number of transactions per payment.
+The bigger payments are split. 0 means no limit.`,
+		Translated: `Nombre maximal de virements par paiement.
+Les paiements plus gros sont découpés. 0 signifie aucune limite.`,
+	},
+	{
+		English: `SEPA file to write to. Defaults to stdout, also used for -.
+The {{date}} and {{batchid}} placeholders are replaced by the current date and the batch ID.
+For example: sepa-{{date}}-{{batchid}}.xml. Existing files are only overwritten with the force flag.`,
+		Translated: `Fichier SEPA dans lequel écrire. Par défaut la sortie standard, également utilisée pour -.
+Les marqueurs {{date}} et {{batchid}} sont remplacés par la date du jour et l'ID du lot.
+Par exemple : sepa-{{date}}-{{batchid}}.xml. Les fichiers existants ne sont écrasés qu'avec l'option force.`,
+	},
+	{
+		English:    "happy-compta user password, needed to read the reimbursements",
+		Translated: "Mot de passe de l'utilisateur happy-compta, nécessaire pour lire les remboursements",
+	},
+	{
+		English: `Accounting period of the reimbursements.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`,
+		Translated: `Exercice des remboursements.
+Soit l'ID de l'exercice, soit une année correspondant au début de l'exercice. Par défaut l'exercice en cours.`,
+	},
+	{
+		English: `Print the transfers and ask for confirmation before writing the file.
+The IBANs are masked in the printed table.`,
+		Translated: `Afficher les virements et demander confirmation avant d'écrire le fichier.
+Les IBAN sont masqués dans le tableau affiché.`,
+	},
+	{
+		English:    "CSV, XLSX or YAML file with the employees bank accounts.",
+		Translated: "Fichier CSV, XLSX ou YAML avec les comptes bancaires des salariés.",
+	},
+	{
+		English: `CSV file to write the transactions count and amount per creditor.
+The same summary is always printed on the standard error.`,
+		Translated: `Fichier CSV dans lequel écrire le nombre et le montant des virements par créancier.
+Le même résumé est toujours affiché sur la sortie d'erreur.`,
+	},
+	{
+		English: `Only transfer the entries dated on or before this day.
+The date is in one of the csv-date-layouts formats.`,
+		Translated: `Virer uniquement les écritures datées de ce jour ou avant.
+La date est dans l'un des formats de csv-date-layouts.`,
+	},
+	{
+		English: `Do not ask for confirmations.
+It is needed for the preview when the data is read from the standard input.`,
+		Translated: `Ne pas demander de confirmation.
+C'est nécessaire pour l'aperçu quand les données sont lues sur l'entrée standard.`,
+	},
+	{
+		English: `Write the closing report of a month for the board meetings.
+
+The report lists the income and spending of the month per budget, bank account and category,
+the entries without receipt and, when the bank statement of an account is given,
+the entries not matching any of its lines like with the dump reconcile command.
+Without statement, the entries are not reconciled.`,
+		Translated: `Écrire le rapport de clôture d'un mois pour les réunions du bureau.
+
+Le rapport liste les recettes et les dépenses du mois par budget, compte bancaire et catégorie,
+les écritures sans justificatif et, quand le relevé bancaire d'un compte est donné,
+les écritures ne correspondant à aucune de ses lignes comme avec la commande dump reconcile.
+Sans relevé, les écritures ne sont pas rapprochées.`,
+	},
+	{
+		English:    "Bank account of the statement: its ID, bank name or abbreviation. Required with a statement.",
+		Translated: "Compte bancaire du relevé : son ID, le nom de sa banque ou son abréviation. Requis avec un relevé.",
+	},
+	{
+		English:    "Format of the report. Can be one of markdown or html.",
+		Translated: "Format du rapport. Peut être markdown ou html.",
+	},
+	{
+		English:    "Month of the report, like 2025-03. Defaults to the previous month.",
+		Translated: "Mois du rapport, comme 2025-03. Par défaut le mois précédent.",
+	},
+	{
+		English: `File to write the report to instead of the standard output.
+If the path is a directory or ends with a separator, the report is written in a timestamped file in it.`,
+		Translated: `Fichier dans lequel écrire le rapport au lieu de la sortie standard.
+Si le chemin est un dossier ou se termine par un séparateur, le rapport y est écrit dans un fichier horodaté.`,
+	},
+	{
+		English:    "Bank statement CSV, camt.053 or OFX file of the month to reconcile the entries of an account.",
+		Translated: "Relevé bancaire CSV, camt.053 ou OFX du mois pour rapprocher les écritures d'un compte.",
+	},
+	{
+		English: `Convert CSV or XLSX files to a SEPA transfer file.
+The transactions of all the files are merged in one transfer initiation.
+A - path reads a CSV file from the standard input.
+Without output flag, the SEPA file is written to the standard output and all the messages go to the standard error.`,
+		Translated: `Convertir des fichiers CSV ou XLSX en un fichier de virements SEPA.
+Les virements de tous les fichiers sont regroupés dans une seule initiation de virement.
+Un chemin - lit un fichier CSV sur l'entrée standard.
+Sans l'option output, le fichier SEPA est écrit sur la sortie standard et tous les messages
+vont sur la sortie d'erreur.`,
+	},
+	{
+		English: `Only validate all the rows and report their problems.
+The SEPA file is not written and the command fails if any row is invalid.`,
+		Translated: `Seulement valider toutes les lignes et signaler leurs problèmes.
+Le fichier SEPA n'est pas écrit et la commande échoue si une ligne est invalide.`,
+	},
+	{
+		English:    "Name of the column for the transaction amount",
+		Translated: "Nom de la colonne du montant du virement",
+	},
+	{
+		English:    "Name of the column for the creditor's BIC",
+		Translated: "Nom de la colonne du BIC du créancier",
+	},
+	{
+		English:    "Name of the optional column for the creditor town",
+		Translated: "Nom de la colonne facultative de la ville du créancier",
+	},
+	{
+		English: `Name of the optional column for the creditor ISO 3166 country code.
+The town and country are required for the rows with an address.`,
+		Translated: `Nom de la colonne facultative du code pays ISO 3166 du créancier.
+La ville et le pays sont requis pour les lignes avec une adresse.`,
+	},
+	{
+		English:    "Name of the column for the creditor name",
+		Translated: "Nom de la colonne du nom du créancier",
+	},
+	{
+		English: `Name of the optional column for the ISO 4217 currency code.
+The rows without value use the currency flag value.`,
+		Translated: `Nom de la colonne facultative du code devise ISO 4217.
+Les lignes sans valeur utilisent la valeur de l'option currency.`,
+	},
+	{
+		English: `Name of the optional column for the requested execution date.
+The dates are in one of the csv-date-layouts formats and the transactions are grouped in one payment per date.
+Rows without date use the execution-date flag value.`,
+		Translated: `Nom de la colonne facultative de la date d'exécution demandée.
+Les dates sont dans l'un des formats de csv-date-layouts et les virements sont regroupés en un paiement par date.
+Les lignes sans date utilisent la valeur de l'option execution-date.`,
+	},
+	{
+		English: `Name of the optional column for the debtor profile name.
+The rows without value use the default debtor.`,
+		Translated: `Nom de la colonne facultative du nom du profil de débiteur.
+Les lignes sans valeur utilisent le débiteur par défaut.`,
+	},
+	{
+		English: `Name of the optional column to group the transactions in payments.
+The transactions are grouped in one payment per group and execution date.`,
+		Translated: `Nom de la colonne facultative pour regrouper les virements en paiements.
+Les virements sont regroupés en un paiement par groupe et date d'exécution.`,
+	},
+	{
+		English:    "Name of the column for the creditor's IBAN",
+		Translated: "Nom de la colonne de l'IBAN du créancier",
+	},
+	{
+		English: `Name of the column for the end to end id.
+Without this column, the IDs are generated from the batch ID and the row number.`,
+		Translated: `Nom de la colonne de l'ID de bout en bout.
+Sans cette colonne, les ID sont générés à partir de l'ID du lot et du numéro de ligne.`,
+	},
+	{
+		English:    "Name of the column for the transaction information",
+		Translated: "Nom de la colonne de l'information du virement",
+	},
+	{
+		English:    "Name of the optional column for the creditor postal code",
+		Translated: "Nom de la colonne facultative du code postal du créancier",
+	},
+	{
+		English: `Name of the optional column for the ISO 11649 creditor reference.
+The reference is sent as structured information instead of the transaction information.`,
+		Translated: `Nom de la colonne facultative de la référence créancier ISO 11649.
+La référence est envoyée comme information structurée à la place de l'information du virement.`,
+	},
+	{
+		English:    "Name of the optional column for the creditor street and number",
+		Translated: "Nom de la colonne facultative du numéro et de la rue du créancier",
+	},
+	{
+		English: `Name of the optional column for the ultimate creditor name.
+It is the party for which the creditor receives the money, like the child of a reimbursed parent.`,
+		Translated: `Nom de la colonne facultative du nom du créancier final.
+C'est la partie pour laquelle le créancier reçoit l'argent, comme l'enfant d'un parent remboursé.`,
+	},
+	{
+		English: `Name of the optional column for the ultimate debtor name.
+It is the party on behalf of which the transfer is paid, like a section of the association.`,
+		Translated: `Nom de la colonne facultative du nom du débiteur final.
+C'est la partie pour le compte de laquelle le virement est payé, comme une section de l'association.`,
+	},
+	{
+		English: `Creditor value of the optional trailer row of the files.
+The trailer row is not a transaction: its amount is the expected total of the file.`,
+		Translated: `Valeur du créancier de la ligne de fin facultative des fichiers.
+La ligne de fin n'est pas un virement : son montant est le total attendu du fichier.`,
+	},
+	{
+		English: `CSV file listing the end to end IDs generated when there is no id column.
+Defaults to the input file name with an -ids suffix.`,
+		Translated: `Fichier CSV listant les ID de bout en bout générés quand il n'y a pas de colonne id.
+Par défaut le nom du fichier d'entrée avec un suffixe -ids.`,
+	},
+	{
+		English: `Put the transactions of each input file in separate payments.
+By default, the transactions of all the files are grouped together.`,
+		Translated: `Mettre les virements de chaque fichier d'entrée dans des paiements séparés.
+Par défaut, les virements de tous les fichiers sont regroupés.`,
+	},
+	{
+		English: `CSV, XLSX or YAML file with the creditors bank accounts.
+The rows without IBAN get the one of their creditor in the roster, ignoring the names case and accents.
+CSV and XLSX rosters have name, iban and optional bic columns, YAML ones map the names to their iban and bic.
+The YAML rosters can be encrypted, like the one managed by the roster command.`,
+		Translated: `Fichier CSV, XLSX ou YAML avec les comptes bancaires des créanciers.
+Les lignes sans IBAN reçoivent celui de leur créancier dans le registre, sans tenir compte
+de la casse et des accents des noms.
+Les registres CSV et XLSX ont des colonnes name, iban et bic facultative, les YAML associent les noms
+à leurs iban et bic.
+Les registres YAML peuvent être chiffrés, comme celui géré par la commande roster.`,
+	},
+	{
+		English: `Generate the SEPA file from the employee reimbursements of an accounting period in happy-compta.
+
+The reimbursements are the spend entries paid by transfer to an employee.
+happy-compta doesn't tell which of them have already been paid: use the from and to flags
+to only select the entries that have not been transferred yet.
+
+The employees bank accounts are read from a roster CSV or XLSX file with the following columns:
+  name       the employee name as "Lastname Firstname" in happy-compta, the column can also be named employee
+  iban       the employee IBAN
+  bic        the employee BIC, optional
+The roster can also be a YAML file mapping the names to their iban and bic.
+
+The end to end IDs of the transfers are the entry IDs and their information is the entry title.`,
+		Translated: `Générer le fichier SEPA à partir des remboursements des salariés d'un exercice dans happy-compta.
+
+Les remboursements sont les dépenses payées par virement à un salarié.
+happy-compta n'indique pas lesquels ont déjà été payés : utilisez les options from et to
+pour ne sélectionner que les écritures qui n'ont pas encore été virées.
+
+Les comptes bancaires des salariés sont lus dans un fichier de registre CSV ou XLSX avec les colonnes suivantes :
+  name       le nom du salarié sous la forme "Nom Prénom" dans happy-compta, la colonne peut aussi s'appeler employee
+  iban       l'IBAN du salarié
+  bic        le BIC du salarié, facultatif
+Le registre peut aussi être un fichier YAML associant les noms à leurs iban et bic.
+
+Les ID de bout en bout des virements sont les ID des écritures et leur information est le titre de l'écriture.`,
+	},
+	{
+		English: `Manage the encrypted roster of the creditors bank accounts, like the employees IBAN and BIC.
+
+happy-compta doesn't store the employees bank accounts: the roster keeps them for the SEPA files.
+It is a YAML file mapping the names to their iban and bic, encrypted with age and usable as --roster value.
+The roster is encrypted for the age identities of the HAPPYCOMPTA_AGE_IDENTITY, SOPS_AGE_KEY
+or SOPS_AGE_KEY_FILE variables or, without any, with a passphrase prompted on the terminal.
+The names are matched regardless of their case and accents.`,
+		Translated: `Gérer le registre chiffré des comptes bancaires des créanciers, comme l'IBAN et le BIC des salariés.
+
+happy-compta ne stocke pas les comptes bancaires des salariés : le registre les garde pour les fichiers SEPA.
+C'est un fichier YAML associant les noms à leurs iban et bic, chiffré avec age et utilisable
+comme valeur de --roster.
+Le registre est chiffré pour les identités age des variables HAPPYCOMPTA_AGE_IDENTITY, SOPS_AGE_KEY
+ou SOPS_AGE_KEY_FILE ou, sans aucune d'elles, avec une phrase secrète demandée dans le terminal.
+Les noms sont comparés sans tenir compte de leur casse et de leurs accents.`,
+	},
+	{
+		English:    "Encrypted roster file.",
+		Translated: "Fichier du registre chiffré.",
+	},
+	{
+		English:    "BIC of the creditor bank",
+		Translated: "BIC de la banque du créancier",
+	},
+	{
+		English:    "IBAN of the creditor (REQUIRED)",
+		Translated: "IBAN du créancier (REQUIS)",
+	},
+	{
+		English: `Change a creditor bank account in the roster.
+
+Changing the IBAN without --bic removes the BIC of the previous bank.`,
+		Translated: `Modifier le compte bancaire d'un créancier du registre.
+
+Modifier l'IBAN sans --bic retire le BIC de la banque précédente.`,
+	},
+	{
+		English:    "New BIC of the creditor bank",
+		Translated: "Nouveau BIC de la banque du créancier",
+	},
+	{
+		English:    "New IBAN of the creditor",
+		Translated: "Nouvel IBAN du créancier",
+	},
+	{
+		English: `Run an HTTP server exposing happy-compta as a JSON API for the tools that can't use the Go library.
+
+The following endpoints are available:
+  GET  /employees    the employees
+  GET  /providers    the providers, including the archived ones
+  GET  /categories   the categories of the entries
+  GET  /accounts     the bank accounts
+  GET  /periods      the accounting periods
+  POST /entries      creates an entry from a multipart form with the following fields:
+    date          the date of the entry, like 2025-03-14 or 14/03/2025 (required)
+    name          the name of the entry (required)
+    budget        FON or ASC (required)
+    kind          depenses, recettes or attributions, defaults to depenses
+    category_id   the category ID (required), repeated for each allocation line
+    amount        the amount, repeated for each allocation line
+    stock         the stock of the categories having one, repeated for each allocation line
+    payment       the payment method, like card or transfer (required)
+    account_id    the bank account ID (required)
+    period_id     the accounting period ID, defaults to the current one
+    employee_id   the employee ID
+    provider_id   the provider ID
+    comment       the comment
+    check         the check number
+    receipts      the receipt files, up to 3
+
+The happy-compta sessions are kept between the requests and logged in again when they expire or fail.
+The errors are returned as a JSON object with an error field.
+
+The server listens on the loopback interface by default. A token is required to listen on other addresses.`,
+		Translated: `Lancer un serveur HTTP exposant happy-compta sous forme d'API JSON pour les outils
+ne pouvant pas utiliser la bibliothèque Go.
+
+Les points d'accès suivants sont disponibles :
+  GET  /employees    les salariés
+  GET  /providers    les fournisseurs, y compris les archivés
+  GET  /categories   les catégories des écritures
+  GET  /accounts     les comptes bancaires
+  GET  /periods      les exercices
+  POST /entries      crée une écriture à partir d'un formulaire multipart avec les champs suivants :
+    date          la date de l'écriture, comme 2025-03-14 ou 14/03/2025 (requis)
+    name          le nom de l'écriture (requis)
+    budget        FON ou ASC (requis)
+    kind          depenses, recettes ou attributions, par défaut depenses
+    category_id   l'ID de la catégorie (requis), répété pour chaque ligne de ventilation
+    amount        le montant, répété pour chaque ligne de ventilation
+    stock         le stock des catégories en ayant un, répété pour chaque ligne de ventilation
+    payment       le moyen de paiement, comme card ou transfer (requis)
+    account_id    l'ID du compte bancaire (requis)
+    period_id     l'ID de l'exercice, par défaut l'exercice en cours
+    employee_id   l'ID du salarié
+    provider_id   l'ID du fournisseur
+    comment       le commentaire
+    check         le numéro de chèque
+    receipts      les fichiers justificatifs, jusqu'à 3
+
+Les sessions happy-compta sont conservées entre les requêtes et reconnectées quand elles expirent ou échouent.
+Les erreurs sont renvoyées sous forme d'objet JSON avec un champ error.
+
+Le serveur écoute sur l'interface de bouclage par défaut. Un jeton est requis pour écouter
+sur d'autres adresses.`,
+	},
+	{
+		English:    "Maximum number of happy-compta sessions used at the same time.",
+		Translated: "Nombre maximal de sessions happy-compta utilisées en même temps.",
+	},
+	{
+		English: `Token the clients need to pass as an Authorization bearer header.
+Can also be set with the HAPPYCOMPTA_API_TOKEN variable or the file of the HAPPYCOMPTA_API_TOKEN_FILE one.`,
+		Translated: `Jeton que les clients doivent passer dans un en-tête Authorization bearer.
+Peut aussi être renseigné avec la variable HAPPYCOMPTA_API_TOKEN ou le fichier de la variable
+HAPPYCOMPTA_API_TOKEN_FILE.`,
+	},
+	{
+		English: `Periodically import the files dropped in the inbox folder and refresh the local mirror of the happy-compta data.
+
+At each run:
+  - the CSV files and YAML or JSON manifests of the inbox are imported like with the load command and --yes,
+    using the load settings of the configuration file, like the profile of the bank statements,
+  - the imported files are moved to the imported subfolder and the failed ones to the failed subfolder,
+    with their errors CSV file listing the rows to fix and import again,
+  - the mirror file is refreshed with the data of the dump command in YAML.
+
+Without interval, the command runs once and can be scheduled with cron.
+With an interval, the GET /healthz endpoint answers 200 when the last successful run is recent enough and 503 otherwise.
+It listens on the loopback interface by default as the errors of the runs are returned.
+
+The camt.053 and OFX bank statements, IMAP mailboxes and SQLite mirrors are not supported.`,
+		Translated: `Importer périodiquement les fichiers déposés dans le dossier d'entrée et rafraîchir le miroir local
+des données happy-compta.
+
+À chaque exécution :
+  - les fichiers CSV et les manifestes YAML ou JSON du dossier d'entrée sont importés comme avec
+    la commande load et --yes, avec les paramètres load du fichier de configuration,
+    comme le profil des relevés bancaires,
+  - les fichiers importés sont déplacés dans le sous-dossier imported et ceux en échec dans le sous-dossier
+    failed, avec leur fichier CSV des erreurs listant les lignes à corriger et importer à nouveau,
+  - le fichier miroir est rafraîchi avec les données de la commande dump en YAML.
+
+Sans intervalle, la commande s'exécute une fois et peut être planifiée avec cron.
+Avec un intervalle, le point d'accès GET /healthz répond 200 quand la dernière exécution réussie
+est assez récente et 503 sinon.
+Il écoute sur l'interface de bouclage par défaut car les erreurs des exécutions sont renvoyées.
+
+Les relevés bancaires camt.053 et OFX, les boîtes IMAP et les miroirs SQLite ne sont pas pris en charge.`,
+	},
+	{
+		English:    "Folder to import the new files from (REQUIRED).",
+		Translated: "Dossier depuis lequel importer les nouveaux fichiers (REQUIS).",
+	},
+	{
+		English:    "Time between two runs, like 1h or 30m. 0 runs only once.",
+		Translated: "Temps entre deux exécutions, comme 1h ou 30m. 0 ne s'exécute qu'une fois.",
+	},
+	{
+		English:    "Address of the health endpoint. Empty disables it.",
+		Translated: "Adresse du point d'accès de santé. Vide le désactive.",
+	},
+	{
+		English:    "Path of the file to write the dumped data to. Empty disables it.",
+		Translated: "Chemin du fichier dans lequel écrire les données extraites. Vide le désactive.",
+	},
+
+	// Shared flags
+	{
+		English:    "Configuration file path",
+		Translated: "Chemin du fichier de configuration",
+	},
+	{
+		English:    "User email address",
+		Translated: "Adresse e-mail de l'utilisateur",
+	},
+	{
+		English:    "User password",
+		Translated: "Mot de passe de l'utilisateur",
+	},
+	{
+		English:    "User email address (REQUIRED)",
+		Translated: "Adresse e-mail de l'utilisateur (OBLIGATOIRE)",
+	},
+	{
+		English:    "User password (REQUIRED)",
+		Translated: "Mot de passe de l'utilisateur (OBLIGATOIRE)",
+	},
+	{
+		English:    "happy-compta user email address, needed by the happycompta command",
+		Translated: "Adresse e-mail de l'utilisateur happy-compta, nécessaire pour la commande happycompta",
+	},
+	{
+		English:    "happy-compta user password, needed by the happycompta command",
+		Translated: "Mot de passe de l'utilisateur happy-compta, nécessaire pour la commande happycompta",
+	},
+	{
+		English:    "Minimum level of the logged messages: debug, info, warn or error.",
+		Translated: "Niveau minimum des messages journalisés : debug, info, warn ou error.",
+	},
+	{
+		English: `Format of the log messages: text or json.
+The json format is easier to process when running the tools in cron jobs.`,
+		Translated: `Format des messages journalisés : text ou json.
+Le format json est plus simple à traiter quand les outils sont lancés par des tâches cron.`,
+	},
+	{
+		English:    "File to append the log messages to. Defaults to the standard error.",
+		Translated: "Fichier auquel ajouter les messages journalisés. Par défaut, la sortie d'erreur standard.",
+	},
+	{
+		English: `Language of the help and error messages: en or fr.
+Defaults to the language of the LC_ALL, LC_MESSAGES or LANG variables.`,
+		Translated: `Langue des messages d'aide et d'erreur : en ou fr.
+Par défaut, la langue des variables LC_ALL, LC_MESSAGES ou LANG.`,
+	},
+	{
+		English:    "Validate the configuration file instead of printing it.",
+		Translated: "Vérifier le fichier de configuration au lieu de l'afficher.",
+	},
+	{
+		English:    "Check on GitHub whether a newer release is available.",
+		Translated: "Vérifier sur GitHub si une nouvelle version est disponible.",
+	},
+	{
+		English: `Directory to download the newer release for this system to.
+It implies the check flag. The installed program is not replaced.`,
+		Translated: `Dossier dans lequel télécharger la nouvelle version pour ce système.
+Elle implique l'option check. Le programme installé n'est pas remplacé.`,
+	},
+	{
+		English:    "help for ",
+		Translated: "aide de ",
+	},
+	{
+		English:    "version for ",
+		Translated: "version de ",
+	},
+
+	// Usage template
+	{
+		English:    "Error:",
+		Translated: "Erreur :",
+	},
+	{
+		English: usageTemplate,
+		Translated: `Utilisation :{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [commande]{{end}}{{if gt (len .Aliases) 0}}
+
+Alias :
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+Exemples :
+{{.Example}}{{end}}{{if .HasAvailableSubCommands}}
+
+Commandes disponibles :{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+Options :
+{{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+Options globales :
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+Autres sujets d'aide :{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Utilisez "{{.CommandPath}} [commande] --help" pour plus d'informations sur une commande.{{end}}
+`,
+	},
+
+	// Error messages
+	{
+		English:    "email parameter or config value is required",
+		Translated: "l'adresse e-mail est requise en option ou dans la configuration",
+	},
+	{
+		English:    "password parameter, config value or keyring entry is required",
+		Translated: "le mot de passe est requis en option, dans la configuration ou dans le trousseau",
+	},
+	{
+		English:    "the CSV file has invalid data",
+		Translated: "le fichier CSV contient des données invalides",
+	},
+	{
+		English:    "check the amounts and confirm them with the confirm-over-limit flag",
+		Translated: "vérifiez les montants et confirmez-les avec l'option confirm-over-limit",
+	},
+	{
+		English:    "%s: check they have not been paid already and confirm with the confirm-duplicate flag",
+		Translated: "%s : vérifiez qu'ils n'ont pas déjà été payés et confirmez avec l'option confirm-duplicate",
+	},
+	{
+		English:    "the SEPA file %s already exists, use the force flag to overwrite it",
+		Translated: "le fichier SEPA %s existe déjà, utilisez l'option force pour l'écraser",
+	},
+	{
+		English:    "%d of %d entries failed to be added",
+		Translated: "%d écritures sur %d n'ont pas pu être ajoutées",
+	},
+	{
+		English:    "problems found in the configuration file: %d",
+		Translated: "problèmes trouvés dans le fichier de configuration : %d",
+	},
+	{
+		English:    "Manage the encrypted roster of the creditors bank accounts",
+		Translated: "Gérer le registre chiffré des comptes bancaires des créanciers",
+	},
+	{
+		English:    "Add a creditor bank account to the roster",
+		Translated: "Ajouter le compte bancaire d'un créancier au registre",
+	},
+	{
+		English:    "Change a creditor bank account in the roster",
+		Translated: "Modifier le compte bancaire d'un créancier du registre",
+	},
+	{
+		English:    "Remove a creditor from the roster",
+		Translated: "Retirer un créancier du registre",
+	},
+	{
+		English:    "List the creditors of the roster with their masked IBAN",
+		Translated: "Lister les créanciers du registre avec leur IBAN masqué",
+	},
+	{
+		English:    "Pay the employee reimbursements and mark them as paid in happy-compta",
+		Translated: "Payer les remboursements des salariés et les marquer comme payés dans happy-compta",
+	},
+	{
+		English:    "No employee reimbursement to pay.",
+		Translated: "Aucun remboursement de salarié à payer.",
+	},
+	{
+		English:    "List the entries without receipt",
+		Translated: "Lister les écritures sans justificatif",
+	},
+	{
+		English:    "Missing receipts",
+		Translated: "Justificatifs manquants",
+	},
+	{
+		English:    "Hello %s,",
+		Translated: "Bonjour %s,",
+	},
+	{
+		English:    "The receipts of the following entries are missing:",
+		Translated: "Les justificatifs des écritures suivantes sont manquants :",
+	},
+	{
+		English:    "Could you send them to me?",
+		Translated: "Pourriez-vous me les envoyer ?",
+	},
+	{
+		English:    "Thank you.",
+		Translated: "Merci.",
+	},
+	{
+		English:    "Passphrase of %s: ",
+		Translated: "Phrase secrète de %s : ",
+	},
+	{
+		English:    "New passphrase of %s: ",
+		Translated: "Nouvelle phrase secrète de %s : ",
+	},
+	{
+		English:    "Confirm the passphrase of %s: ",
+		Translated: "Confirmez la phrase secrète de %s : ",
+	},
+	{
+		English:    "The version is up to date, the latest release is %s\n",
+		Translated: "La version est à jour, la dernière version publiée est %s\n",
+	},
+	{
+		English:    "The version is unknown and can't be compared to the latest release: %s\n%s\n",
+		Translated: "La version est inconnue et ne peut pas être comparée à la dernière version publiée : %s\n%s\n",
+	},
+	{
+		English:    "A newer release is available: %s\n%s\n",
+		Translated: "Une nouvelle version est disponible : %s\n%s\n",
+	},
+	{
+		English:    "Downloaded %s\n",
+		Translated: "%s téléchargé\n",
+	},
+	{
+		English:    "Logged in to happy-compta as %s\n",
+		Translated: "Connecté à happy-compta en tant que %s\n",
+	},
+	{
+		English:    "failed to parse balance on row %d: %s",
+		Translated: "impossible de lire le solde de la ligne %d : %s",
+	},
+	{
+		English:    "failed to process entry on row %d: %s",
+		Translated: "impossible de traiter l'écriture de la ligne %d : %s",
+	},
+	{
+		English:    "failed to convert amount on row %d: %s",
+		Translated: "impossible de convertir le montant de la ligne %d : %s",
+	},
+	{
+		English:    "CSV file is empty",
+		Translated: "le fichier CSV est vide",
+	},
+	{
+		English:    "failed to read CSV header: %s",
+		Translated: "impossible de lire l'en-tête du CSV : %s",
+	},
+	{
+		English:    "failed to read row %d: %s",
+		Translated: "impossible de lire la ligne %d : %s",
+	},
+	{
+		English:    "invalid row %d: %s",
+		Translated: "ligne %d invalide : %s",
+	},
+	{
+		English:    "date column is missing or empty",
+		Translated: "la colonne de la date est absente ou vide",
+	},
+	{
+		English:    "failed to parse date: %w",
+		Translated: "impossible de lire la date : %w",
+	},
+	{
+		English:    "failed to build the name: %s",
+		Translated: "impossible de construire le nom : %s",
+	},
+	{
+		English:    "has both debit ('%s') and credit ('%s') specified",
+		Translated: "a à la fois un débit ('%s') et un crédit ('%s')",
+	},
+	{
+		English:    "failed to parse amount '%s': %s",
+		Translated: "impossible de lire le montant '%s' : %s",
+	},
+	{
+		English:    "failed to build the comment: %s",
+		Translated: "impossible de construire le commentaire : %s",
+	},
+	{
+		English:    "invalid entry type '%s', accepted values are %s, %s and %s",
+		Translated: "type d'écriture '%s' invalide, les valeurs acceptées sont %s, %s et %s",
+	},
+	{
+		English:    "invalid budget '%s'",
+		Translated: "budget '%s' invalide",
+	},
+	{
+		English:    "invalid payment method '%s'",
+		Translated: "moyen de paiement '%s' invalide",
+	},
+	{
+		English:    "missing payment method",
+		Translated: "moyen de paiement manquant",
+	},
+	{
+		English:    "invalid category '%s' name / '%s' budget combination",
+		Translated: "combinaison de la catégorie '%s' et du budget '%s' invalide",
+	},
+	{
+		English:    "missing required amount value for row %d",
+		Translated: "montant requis manquant pour la ligne %d",
+	},
+	{
+		English:    "has both employee ('%s') and provider ('%s') specified",
+		Translated: "a à la fois un salarié ('%s') et un fournisseur ('%s')",
+	},
+	{
+		English:    "unknown employee '%s', the value needs to be in the <Lastname> <Firstname> format",
+		Translated: "salarié '%s' inconnu, la valeur doit être au format <Nom> <Prénom>",
+	},
+	{
+		English:    "unknown provider '%s', the value needs to match the name of an existing provider",
+		Translated: "fournisseur '%s' inconnu, la valeur doit correspondre au nom d'un fournisseur existant",
+	},
+	{
+		English:    "couldn't find the '%s' period. Is there a current one defined?",
+		Translated: "impossible de trouver l'exercice '%s'. Y a-t-il un exercice en cours ?",
+	},
+	{
+		English:    "failed to find account: %w",
+		Translated: "impossible de trouver le compte : %w",
+	},
+	{
+		English:    "no stock defined but %s category needs it",
+		Translated: "aucun stock renseigné alors que la catégorie %s en a besoin",
+	},
+	{
+		English:    "failed to parse '%s' stock as an integer",
+		Translated: "impossible de lire le stock '%s' comme un entier",
+	},
+	{
+		English:    "stock needs to be a positive integer, got %d",
+		Translated: "le stock doit être un entier positif, %d reçu",
+	},
+	{
+		English:    "more than one bank found, you have to provide the name of the bank holding the account",
+		Translated: "plusieurs banques trouvées, vous devez indiquer le nom de la banque tenant le compte",
+	},
+	{
+		English:    "more than one account found for the %s budget at %s bank. This is not supported yet",
+		Translated: "plusieurs comptes trouvés pour le budget %s à la banque %s. Ce n'est pas encore pris en charge",
+	},
+	{
+		English:    "more than one account found for the both budgets at %s bank. This is not supported yet",
+		Translated: "plusieurs comptes trouvés pour les deux budgets à la banque %s. Ce n'est pas encore pris en charge",
+	},
+	{
+		English:    "no account found matching the %s budget at %s bank",
+		Translated: "aucun compte trouvé pour le budget %s à la banque %s",
+	},
+	{
+		English:    "%d fields, more than the %d columns of the header",
+		Translated: "%d champs, plus que les %d colonnes de l'en-tête",
+	},
+	{
+		English:    "invalid amount: %s",
+		Translated: "montant invalide : %s",
+	},
+	{
+		English:    "invalid currency: %s",
+		Translated: "devise invalide : %s",
+	},
+	{
+		English:    "invalid currency: instant transfers are only in %s",
+		Translated: "devise invalide : les virements instantanés ne sont qu'en %s",
+	},
+	{
+		English:    "invalid information: %s",
+		Translated: "information invalide : %s",
+	},
+	{
+		English:    "invalid creditor: %s",
+		Translated: "créancier invalide : %s",
+	},
+	{
+		English:    "invalid creditor: %s not found in the roster",
+		Translated: "créancier invalide : %s introuvable dans le registre",
+	},
+	{
+		English:    "invalid IBAN: %s",
+		Translated: "IBAN invalide : %s",
+	},
+	{
+		English:    "invalid BIC: %s",
+		Translated: "BIC invalide : %s",
+	},
+	{
+		English:    "invalid end to end ID: %s",
+		Translated: "ID de bout en bout invalide : %s",
+	},
+	{
+		English:    "invalid address: %s",
+		Translated: "adresse invalide : %s",
+	},
+	{
+		English:    "invalid creditor reference: %s",
+		Translated: "référence créancier invalide : %s",
+	},
+	{
+		English:    "invalid ultimate debtor: %s",
+		Translated: "débiteur final invalide : %s",
+	},
+	{
+		English:    "invalid ultimate creditor: %s",
+		Translated: "créancier final invalide : %s",
+	},
+	{
+		English:    "invalid date: %s",
+		Translated: "date invalide : %s",
+	},
+	{
+		English:    "invalid debtor: %s",
+		Translated: "débiteur invalide : %s",
+	},
+	{
+		English:    "%s: ok\n",
+		Translated: "%s : ok\n",
+	},
+	{
+		English:    "%s: invalid\n    %s\n",
+		Translated: "%s : invalide\n    %s\n",
+	},
+	{
+		English:    "batch: invalid\n    %s\n",
+		Translated: "lot : invalide\n    %s\n",
+	},
+	{
+		English:    "%d rows checked, %d invalid\n",
+		Translated: "%d lignes vérifiées, %d invalides\n",
+	},
+	{
+		English:    "invalid %s: %s",
+		Translated: "%s invalide : %s",
+	},
+	{
+		English:    "row %d",
+		Translated: "ligne %d",
+	},
+	{
+		English:    "row %d of %s",
+		Translated: "ligne %d de %s",
+	},
+	{
+		English:    "error parsing %s: %s",
+		Translated: "erreur de lecture de %s : %s",
+	},
+	{
+		English:    "invalid header in %s: %s",
+		Translated: "en-tête invalide dans %s : %s",
+	},
+	{
+		English:    "column not found in CSV file: %s",
+		Translated: "colonne introuvable dans le fichier CSV : %s",
+	},
+	{
+		English:    "'%s' is not a positive amount with at most two decimals",
+		Translated: "'%s' n'est pas un montant positif avec au plus deux décimales",
+	},
+	{
+		English:    "'%s' is not an 8 or 11 characters BIC",
+		Translated: "'%s' n'est pas un BIC de 8 ou 11 caractères",
+	},
+	{
+		English:    "'%s' is not an ISO 11649 RF reference",
+		Translated: "'%s' n'est pas une référence RF ISO 11649",
+	},
+	{
+		English:    "'%s' has invalid check digits",
+		Translated: "'%s' a des chiffres de contrôle invalides",
+	},
+	{
+		English:    "%s is not an ISO 4217 currency code",
+		Translated: "%s n'est pas un code devise ISO 4217",
+	},
+	{
+		English:    "invalid charge bearer %s, expected one of %s",
+		Translated: "partie payant les frais %s invalide, valeurs attendues : %s",
+	},
+	{
+		English:    "invalid street: %s",
+		Translated: "rue invalide : %s",
+	},
+	{
+		English:    "invalid postal code: %s",
+		Translated: "code postal invalide : %s",
+	},
+	{
+		English:    "invalid town: %s",
+		Translated: "ville invalide : %s",
+	},
+	{
+		English:    "missing town in the address",
+		Translated: "ville manquante dans l'adresse",
+	},
+	{
+		English:    "'%s' is not a 2 letters ISO 3166 country code",
+		Translated: "'%s' n'est pas un code pays ISO 3166 à 2 lettres",
+	},
+	{
+		English:    "'%s' is not an IBAN",
+		Translated: "'%s' n'est pas un IBAN",
+	},
+	{
+		English:    "string can only contain unaccented letter, digits and /-?:().,'+: '%s'",
+		Translated: "le texte ne peut contenir que des lettres non accentuées, des chiffres et /-?:().,'+ : '%s'",
+	},
+	{
+		English:    "string cannot contain more than %d characters: '%s'",
+		Translated: "le texte ne peut pas contenir plus de %d caractères : '%s'",
+	},
+})
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		lcAll    string
+		lang     string
+		expected string
+	}{
+		{"flag", []string{"load", "--lang", "fr", "data.csv"}, "", "en_US.UTF-8", "fr"},
+		{"flag with value", []string{"--lang=fr"}, "", "", "fr"},
+		{"flag after separator", []string{"--", "--lang=fr"}, "", "", "en"},
+		{"LANG", []string{"load"}, "", "fr_FR.UTF-8", "fr"},
+		{"LC_ALL first", []string{}, "fr_BE", "en_GB.UTF-8", "fr"},
+		{"C locale", []string{}, "", "C.UTF-8", "c"},
+		{"no locale", []string{}, "", "", "en"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("LC_ALL", test.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", test.lang)
+		if actual := DetectLanguage(test.args); actual != test.expected {
+			t.Errorf("%s: language mismatch. Got: %s, Want: %s", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestTr(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(LanguageEnglish) })
+
+	tests := []struct {
+		lang     string
+		msg      string
+		expected string
+	}{
+		{"fr", "Print the configuration", "Afficher la configuration"},
+		{"fr", "not translated", "not translated"},
+		{"fr", "invalid %s: %s", "%s invalide : %s"},
+		{"de", "Print the configuration", "Print the configuration"},
+		{"en", "Print the configuration", "Print the configuration"},
+	}
+
+	for _, test := range tests {
+		SetLanguage(test.lang)
+		if actual := Tr(test.msg); actual != test.expected {
+			t.Errorf("Translation mismatch in %s. Got: %s, Want: %s", test.lang, actual, test.expected)
+		}
+	}
+}
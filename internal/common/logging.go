@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// LogFormatText writes the log messages as key=value pairs.
+	LogFormatText = "text"
+	// LogFormatJSON writes one JSON object per log message, easier to process by log collectors.
+	LogFormatJSON = "json"
+)
+
+// AddLogFlags adds the flags configuring the logging to a tool command.
+func AddLogFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("log-level", "info", "Minimum level of the logged messages: debug, info, warn or error.")
+	cmd.PersistentFlags().String("log-format", LogFormatText, `Format of the log messages: text or json.
+The json format is easier to process when running the tools in cron jobs.`)
+	cmd.PersistentFlags().String("log-file", "", "File to append the log messages to. Defaults to the standard error.")
+	AddFlagCompletion(cmd, "log-level", "debug", "info", "warn", "error")
+	AddFlagCompletion(cmd, "log-format", LogFormatText, LogFormatJSON)
+}
+
+// SetupLogging sets the default slog logger for the given level, format and file.
+// The standard log package messages also go to this logger.
+func SetupLogging(level string, format string, path string) error {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(cmp.Or(level, "info"))); err != nil {
+		return fmt.Errorf("invalid log level %s: use one of debug, info, warn or error", level)
+	}
+
+	var w io.Writer = os.Stderr
+	if path != "" {
+		// The file is appended to so that the runs of a cron job can share it.
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open the log file: %s", err)
+		}
+		w = file
+	}
+
+	options := &slog.HandlerOptions{Level: slogLevel}
+	var handler slog.Handler
+	switch cmp.Or(format, LogFormatText) {
+	case LogFormatText:
+		handler = slog.NewTextHandler(w, options)
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return fmt.Errorf("invalid log format %s: use one of %s or %s", format, LogFormatText, LogFormatJSON)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Fatal logs an error message and exits with a failure status.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
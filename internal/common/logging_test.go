@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetupLogging(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	path := filepath.Join(t.TempDir(), "tools.log")
+	if err := SetupLogging("warn", LogFormatJSON, path); err != nil {
+		t.Fatalf("SetupLogging failed: %s", err)
+	}
+	slog.Info("hidden")
+	slog.Warn("shown", "row", 3)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the log file: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Log lines mismatch. Got: %q, Want: 1 line", lines)
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("failed to parse the log line: %s", err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "shown" || record["row"] != float64(3) {
+		t.Errorf("Log record mismatch. Got: %v", record)
+	}
+}
+
+func TestSetupLoggingInvalid(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	tests := []struct {
+		level    string
+		format   string
+		expected string
+	}{
+		{"verbose", "", "invalid log level verbose: use one of debug, info, warn or error"},
+		{"", "xml", "invalid log format xml: use one of text or json"},
+	}
+
+	for _, test := range tests {
+		err := SetupLogging(test.level, test.format, "")
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("Error mismatch. Got: %v, Want: %s", err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// Notification statuses.
+const (
+	NotifySuccess = "success"
+	NotifyFailure = "failure"
+)
+
+// DefaultNotifyTemplate posts the whole notification as JSON.
+const DefaultNotifyTemplate = "{{json .}}"
+
+// NotifyConfig holds the settings of the notification sent at the end of a run.
+type NotifyConfig struct {
+	URL      string `mapstructure:"url"`
+	Template string `mapstructure:"template"`
+}
+
+// Notification is the data passed to the notification template.
+type Notification struct {
+	// Tool is the name of the tool sending the notification, like loader or dumper.
+	Tool string `json:"tool"`
+	// Status is success or failure.
+	Status string `json:"status"`
+	// Text is a one line description of the outcome, ready to post in a chat channel.
+	Text string `json:"text"`
+	// Summary is the JSON summary of the run. The template accesses its fields with their JSON names.
+	Summary any `json:"summary"`
+	// Report is the path of the written report, if any.
+	Report string `json:"report,omitempty"`
+}
+
+// AddNotifyFlags adds the flags configuring the end of run notification to a tool command.
+func AddNotifyFlags(cmd *cobra.Command) {
+	cmd.Flags().String("notify-url", "", `URL to post a JSON notification to at the end of the run,
+like a Slack, Teams or Matrix incoming webhook.`)
+	cmd.Flags().String("notify-template", "", `Go template of the posted JSON notification.
+The template gets the tool, status, text, summary and report fields and a json function quoting values,
+like {"text": {{json .Text}}}. Defaults to the whole notification as JSON.`)
+}
+
+// Notify renders the notification with the template and posts it to the URL, if any.
+// The failures are only logged as they should not change the outcome of the run.
+func Notify(cfg NotifyConfig, notification Notification) {
+	if cfg.URL == "" {
+		return
+	}
+	if err := sendNotification(cfg, notification); err != nil {
+		slog.Warn("failed to send the notification", "url", cfg.URL, "error", err)
+	}
+}
+
+func sendNotification(cfg NotifyConfig, notification Notification) error {
+	payload, err := RenderNotification(cfg.Template, notification)
+	if err != nil {
+		return err
+	}
+
+	return PostWebhook(cfg.URL, payload)
+}
+
+// RenderNotification renders the notification payload with the template, or the default one if empty.
+// The summary is converted to its JSON representation for the template to use the documented field names.
+func RenderNotification(text string, notification Notification) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		text = DefaultNotifyTemplate
+	}
+	tmpl, err := template.New("notify").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification template: %s", err)
+	}
+
+	if notification.Summary != nil {
+		data, err := json.Marshal(notification.Summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize the notification summary: %s", err)
+		}
+		var summary map[string]any
+		if err := json.Unmarshal(data, &summary); err != nil {
+			return nil, fmt.Errorf("failed to serialize the notification summary: %s", err)
+		}
+		notification.Summary = summary
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notification); err != nil {
+		return nil, fmt.Errorf("failed to render the notification template: %s", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("the notification template doesn't render valid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// toJSON serializes a value for the notification templates.
+func toJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
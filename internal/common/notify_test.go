@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testSummary struct {
+	Added  int    `json:"added"`
+	Failed int    `json:"failed"`
+	Error  string `json:"error,omitempty"`
+}
+
+func TestRenderNotification(t *testing.T) {
+	notification := Notification{
+		Tool: "loader", Status: NotifySuccess, Text: `Import of "march.csv": 3 of 3 entries added`,
+		Summary: testSummary{Added: 3}, Report: "report.json",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		wantErr  string
+	}{
+		{
+			"default",
+			"",
+			`{"tool":"loader","status":"success","text":"Import of \"march.csv\": 3 of 3 entries added",` +
+				`"summary":{"added":3,"failed":0},"report":"report.json"}`,
+			"",
+		},
+		{"slack", `{"text": {{json .Text}}}`, `{"text": "Import of \"march.csv\": 3 of 3 entries added"}`, ""},
+		{
+			"summary fields",
+			`{"msgtype": "m.text", "body": "{{.Tool}}: {{.Summary.added}} added, {{.Summary.failed}} failed"}`,
+			`{"msgtype": "m.text", "body": "loader: 3 added, 0 failed"}`,
+			"",
+		},
+		{"invalid template", `{"text": {{.Text}`, "", "invalid notification template"},
+		{"invalid JSON", `{"text": {{.Text}}}`, "", "doesn't render valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := RenderNotification(tt.template, notification)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Error mismatch. Got: %v, Want: %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderNotification failed: %v", err)
+			}
+			if string(actual) != tt.expected {
+				t.Errorf("Payload mismatch. Got: %s, Want: %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type mismatch. Got: %s, Want: application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+	}))
+	defer server.Close()
+
+	Notify(NotifyConfig{URL: server.URL, Template: `{"text": {{json .Text}}}`}, Notification{Text: "Dump done"})
+	if got != `{"text": "Dump done"}` {
+		t.Errorf("Posted payload mismatch. Got: %s", got)
+	}
+
+	// Without URL, nothing is sent.
+	got = ""
+	Notify(NotifyConfig{Template: `{"text": {{json .Text}}}`}, Notification{Text: "Dump done"})
+	if got != "" {
+		t.Errorf("Expected no notification to be posted, got: %s", got)
+	}
+}
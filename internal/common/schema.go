@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v3"
+)
+
+// ConfigKeys maps the keys only set in the configuration file, and not with flags, to their type.
+// The types are the pflag ones, like string, bool, int, float64 or stringSlice. An empty type accepts any value.
+// The * parts of the keys match any name, like the names of the debtor profiles.
+type ConfigKeys map[string]string
+
+// ConfigProblem is a problem found in a configuration file.
+type ConfigProblem struct {
+	Line    int
+	Message string
+	// Unknown is true when the key is not known: it may be used by another tool reading the same file.
+	Unknown bool
+}
+
+func (p ConfigProblem) Error() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// configSchema holds the known configuration keys with their type.
+type configSchema map[string]string
+
+// newConfigSchema builds the schema of the configuration of commands from their flags and additional keys.
+func newConfigSchema(keys ConfigKeys, cmds ...*cobra.Command) configSchema {
+	schema := configSchema{}
+	addFlag := func(flag *pflag.Flag) {
+		if flag.Name != "config" && flag.Name != "help" {
+			schema[strings.ReplaceAll(flag.Name, "-", ".")] = flag.Value.Type()
+		}
+	}
+	for _, cmd := range cmds {
+		cmd.PersistentFlags().VisitAll(addFlag)
+		cmd.Flags().VisitAll(addFlag)
+	}
+	for key, kind := range keys {
+		schema[strings.ToLower(key)] = kind
+	}
+	return schema
+}
+
+// lookup returns the type of a value key and whether it is known.
+func (s configSchema) lookup(key string) (string, bool) {
+	for pattern, kind := range s {
+		if matchKey(pattern, key) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// isSection tells whether the key is the parent of known keys.
+func (s configSchema) isSection(key string) bool {
+	parts := strings.Split(key, ".")
+	for pattern := range s {
+		patternParts := strings.Split(pattern, ".")
+		if len(patternParts) > len(parts) && matchKey(strings.Join(patternParts[:len(parts)], "."), key) {
+			return true
+		}
+	}
+	return false
+}
+
+// siblings returns the names of the known keys or sections with the given parent.
+func (s configSchema) siblings(parent string) []string {
+	var names []string
+	depth := 0
+	if parent != "" {
+		depth = len(strings.Split(parent, "."))
+	}
+	for pattern := range s {
+		parts := strings.Split(pattern, ".")
+		if len(parts) > depth && (depth == 0 || matchKey(strings.Join(parts[:depth], "."), parent)) {
+			names = append(names, parts[depth])
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func matchKey(pattern string, key string) bool {
+	patternParts := strings.Split(pattern, ".")
+	keyParts := strings.Split(key, ".")
+	if len(patternParts) != len(keyParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if part != "*" && part != keyParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateConfig checks the content of a YAML configuration file against the flags of the commands
+// and the additional keys.
+// It reports the values of the wrong type, the flag names used instead of nested keys and the unknown keys.
+func ValidateConfig(content []byte, keys ConfigKeys, cmds ...*cobra.Command) ([]ConfigProblem, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	schema := newConfigSchema(keys, cmds...)
+	return schema.validate(root.Content[0], ""), nil
+}
+
+func (s configSchema) validate(node *yaml.Node, parent string) []ConfigProblem {
+	if node.Kind != yaml.MappingNode {
+		return []ConfigProblem{{Line: node.Line, Message: "the configuration needs to be a map of keys and values"}}
+	}
+
+	var problems []ConfigProblem
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		name := strings.ToLower(keyNode.Value)
+		key := name
+		if parent != "" {
+			key = parent + "." + name
+		}
+
+		if kind, ok := s.lookup(key); ok {
+			if !matchType(kind, value) {
+				problems = append(problems, ConfigProblem{
+					Line: value.Line, Message: fmt.Sprintf("%s needs to be %s", key, typeName(kind)),
+				})
+			}
+			continue
+		}
+		if s.isSection(key) {
+			problems = append(problems, s.validate(value, key)...)
+			continue
+		}
+
+		dotted := strings.ReplaceAll(key, "-", ".")
+		if _, ok := s.lookup(dotted); ok || s.isSection(dotted) {
+			problems = append(problems, ConfigProblem{Line: keyNode.Line, Message: fmt.Sprintf(
+				"%s needs to be written with nested keys: %s", key, nestedExample(dotted))})
+			continue
+		}
+
+		message := fmt.Sprintf("unknown key %s", key)
+		if suggestion := closestName(name, s.siblings(parent)); suggestion != "" {
+			message += fmt.Sprintf(", did you mean %s?", strings.TrimPrefix(parent+"."+suggestion, "."))
+		}
+		problems = append(problems, ConfigProblem{Line: keyNode.Line, Message: message, Unknown: true})
+	}
+	return problems
+}
+
+// matchType tells whether the YAML value can be decoded for a flag type.
+func matchType(kind string, value *yaml.Node) bool {
+	switch kind {
+	case "":
+		return true
+	case "stringSlice":
+		if value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					return false
+				}
+			}
+			return true
+		}
+		return value.Kind == yaml.ScalarNode
+	}
+	if value.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch kind {
+	case "bool":
+		return value.Tag == "!!bool"
+	case "int":
+		return value.Tag == "!!int"
+	case "float64":
+		return value.Tag == "!!int" || value.Tag == "!!float"
+	}
+	return true
+}
+
+// typeName describes the expected values of a flag type in the messages.
+func typeName(kind string) string {
+	switch kind {
+	case "bool":
+		return "true or false"
+	case "int":
+		return "an integer"
+	case "float64":
+		return "a number"
+	case "stringSlice":
+		return "a list"
+	}
+	return "a " + kind + " value"
+}
+
+// nestedExample shows how to write a dotted key in YAML, like "csv: {columns: ...}".
+func nestedExample(key string) string {
+	parts := strings.Split(key, ".")
+	return strings.Join(parts, ": {") + ": ..." + strings.Repeat("}", len(parts)-1)
+}
+
+// closestName returns the name with at most two different characters from the given one, if any.
+func closestName(name string, names []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range names {
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings.
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// checkConfigFile validates the YAML configuration file read by a tool.
+// The unknown keys are warnings since the file may be shared with other tools. The other problems are errors.
+func checkConfigFile(path string, keys ConfigKeys, cmd *cobra.Command) (warnings []ConfigProblem, err error) {
+	if ext := ConfigFileType(path); ext != "yaml" && ext != "yml" {
+		return nil, nil
+	}
+	content, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := ValidateConfig(content, keys, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []error
+	for _, problem := range problems {
+		if problem.Unknown {
+			warnings = append(warnings, problem)
+		} else {
+			allErrors = append(allErrors, problem)
+		}
+	}
+	return warnings, errors.Join(allErrors...)
+}
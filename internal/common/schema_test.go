@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newSchemaTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "tool"}
+	cmd.PersistentFlags().String("config", "", "")
+	cmd.PersistentFlags().String("email", "", "")
+	cmd.PersistentFlags().Int("column-width", 40, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().StringSlice("only", nil, "")
+	cmd.Flags().String("csv-columns-iban", "iban", "")
+	cmd.Flags().String("csv-comma", ",", "")
+	return cmd
+}
+
+func TestValidateConfig(t *testing.T) {
+	keys := ConfigKeys{"limits.*": "float64", "debtors.*.iban": "string"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []ConfigProblem
+	}{
+		{
+			name: "valid",
+			content: `email: user@example.com
+column:
+  width: 20
+dry:
+  run: true
+only: [accounts, periods]
+csv:
+  comma: ";"
+  columns:
+    iban: IBAN
+limits:
+  food: 100.5
+debtors:
+  main:
+    iban: FR76
+`,
+		},
+		{name: "empty", content: ""},
+		{
+			name:    "unknown keys",
+			content: "emial: user@example.com\ncsv:\n  columns:\n    ibna: IBAN\nother: value\n",
+			expected: []ConfigProblem{
+				{Line: 1, Message: "unknown key emial, did you mean email?", Unknown: true},
+				{Line: 4, Message: "unknown key csv.columns.ibna, did you mean csv.columns.iban?", Unknown: true},
+				{Line: 5, Message: "unknown key other", Unknown: true},
+			},
+		},
+		{
+			name:    "wrong types",
+			content: "column:\n  width: wide\ndry:\n  run: yes\nlimits:\n  food: lots\nonly:\n  a: b\ncsv: comma\n",
+			expected: []ConfigProblem{
+				{Line: 2, Message: "column.width needs to be an integer"},
+				{Line: 4, Message: "dry.run needs to be true or false"},
+				{Line: 6, Message: "limits.food needs to be a number"},
+				{Line: 8, Message: "only needs to be a list"},
+				{Line: 9, Message: "the configuration needs to be a map of keys and values"},
+			},
+		},
+		{
+			name:    "flag names",
+			content: "csv-columns:\n  iban: IBAN\ncolumn-width: 20\n",
+			expected: []ConfigProblem{
+				{Line: 1, Message: "csv-columns needs to be written with nested keys: csv: {columns: ...}"},
+				{Line: 3, Message: "column-width needs to be written with nested keys: column: {width: ...}"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		problems, err := ValidateConfig([]byte(test.content), keys, newSchemaTestCommand())
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(problems, test.expected) {
+			t.Errorf("%s: problems mismatch. Got: %v, Want: %v", test.name, problems, test.expected)
+		}
+	}
+
+	if _, err := ValidateConfig([]byte("email: [unclosed"), keys, newSchemaTestCommand()); err == nil {
+		t.Error("Expected an error for an invalid YAML file")
+	}
+}
+
+func TestCheckConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "emial: user@example.com\nloader:\n  key: value\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The typos and the keys of other tools are warnings.
+	warnings, err := checkConfigFile(path, nil, newSchemaTestCommand())
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if len(warnings) != 2 || warnings[0].Line != 1 || !strings.Contains(warnings[0].Message, "did you mean email?") ||
+		warnings[1].Line != 2 || warnings[1].Message != "unknown key loader" {
+		t.Errorf("Warnings mismatch. Got: %v", warnings)
+	}
+
+	if err := os.WriteFile(path, []byte("column:\n  width: wide\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkConfigFile(path, nil, newSchemaTestCommand()); err == nil ||
+		!strings.Contains(err.Error(), "line 2: column.width needs to be an integer") {
+		t.Errorf("Expected a type error, got: %v", err)
+	}
+
+	// Only the YAML files are checked.
+	tomlPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(tomlPath, []byte("column.width = 'wide'\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkConfigFile(tomlPath, nil, newSchemaTestCommand()); err != nil {
+		t.Errorf("Unexpected error for a TOML file: %s", err)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// IsLoopbackAddress tells whether the listen address only accepts local connections.
+// An address without host listens on all the interfaces.
+func IsLoopbackAddress(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// CheckListenAddress refuses to listen on a non loopback address without a token
+// as the server would then let anyone on the network use the happy-compta account.
+func CheckListenAddress(listen string, token string) error {
+	if token == "" && !IsLoopbackAddress(listen) {
+		return WithExitCode(ExitUsage,
+			fmt.Errorf("a token is required to listen on %s, only loopback addresses can be used without one", listen))
+	}
+	return nil
+}
+
+// RequireToken checks the bearer token of the requests before passing them to next.
+// The unauthorized function writes the response of the requests without a valid token.
+// No token is checked if the token is empty.
+func RequireToken(token string, next http.Handler, unauthorized func(w http.ResponseWriter)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			value, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(value), []byte(token)) != 1 {
+				unauthorized(w)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
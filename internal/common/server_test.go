@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckListenAddress(t *testing.T) {
+	tests := []struct {
+		listen   string
+		token    string
+		expected bool
+	}{
+		{"127.0.0.1:8080", "", true},
+		{"localhost:8080", "", true},
+		{"[::1]:8080", "", true},
+		{":8080", "", false},
+		{"0.0.0.0:8080", "", false},
+		{"192.168.1.10:8080", "", false},
+		{":8080", "s3cret", true},
+	}
+
+	for _, test := range tests {
+		err := CheckListenAddress(test.listen, test.token)
+		if (err == nil) != test.expected {
+			t.Errorf("%s: error mismatch. Got: %v, Want an error: %t", test.listen, err, !test.expected)
+		}
+		if err != nil && ExitCode(err) != ExitUsage {
+			t.Errorf("%s: exit code mismatch. Got: %d, Want: %d", test.listen, ExitCode(err), ExitUsage)
+		}
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	unauthorized := func(w http.ResponseWriter) { w.WriteHeader(http.StatusUnauthorized) }
+
+	tests := []struct {
+		name     string
+		token    string
+		header   string
+		expected int
+	}{
+		{"no token", "", "", http.StatusOK},
+		{"missing", "s3cret", "", http.StatusUnauthorized},
+		{"wrong", "s3cret", "Bearer nope", http.StatusUnauthorized},
+		{"not bearer", "s3cret", "s3cret", http.StatusUnauthorized},
+		{"valid", "s3cret", "Bearer s3cret", http.StatusOK},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		rec := httptest.NewRecorder()
+		RequireToken(test.token, next, unauthorized).ServeHTTP(rec, req)
+		if rec.Code != test.expected {
+			t.Errorf("%s: status mismatch. Got: %d, Want: %d", test.name, rec.Code, test.expected)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// sopsMetadataKey is the key of the sops metadata in the encrypted files.
+const sopsMetadataKey = "sops"
+
+// sopsCommand is the sops tool decrypting the files.
+var sopsCommand = "sops"
+
+// sopsRoot returns the root mapping of a YAML file encrypted by sops, or nil if it isn't one.
+func sopsRoot(document *yaml.Node) *yaml.Node {
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 ||
+		document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == sopsMetadataKey && root.Content[i+1].Kind == yaml.MappingNode {
+			return root
+		}
+	}
+	return nil
+}
+
+// isSOPSEncrypted returns whether the YAML content has been encrypted by sops.
+func isSOPSEncrypted(content []byte) bool {
+	var document yaml.Node
+	return yaml.Unmarshal(content, &document) == nil && sopsRoot(&document) != nil
+}
+
+// decryptSOPS decrypts a file encrypted by sops with the sops tool, which checks the MAC of the whole file.
+// The age identity file pointed by HAPPYCOMPTA_AGE_IDENTITY is passed to sops if SOPS_AGE_KEY_FILE isn't set.
+func decryptSOPS(path string) ([]byte, error) {
+	cmd := exec.Command(sopsCommand, "--decrypt", path)
+	cmd.Env = os.Environ()
+	if identity := os.Getenv(ageIdentityVariable); identity != "" && os.Getenv("SOPS_AGE_KEY_FILE") == "" {
+		cmd.Env = append(cmd.Env, "SOPS_AGE_KEY_FILE="+identity)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	content, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("the sops tool is needed to decrypt the file: %s", err)
+	}
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("sops failed: %s", message)
+		}
+		return nil, fmt.Errorf("sops failed: %s", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sopsFileForTest is the start of a file encrypted by sops.
+const sopsFileForTest = `email: ENC[AES256_GCM,data:aGVsbG8=,iv:aXY=,tag:dGFn,type:str]
+sops:
+    age:
+        - recipient: age1test
+    mac: ENC[AES256_GCM,data:bWFj,iv:aXY=,tag:dGFn,type:str]
+    version: 3.9.4
+`
+
+// fakeSOPSForTest replaces the sops tool by a script printing its arguments and age key file,
+// or failing like sops when the MAC doesn't match.
+func fakeSOPSForTest(t *testing.T) {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "sops")
+	content := `#!/bin/sh
+if grep -q tampered "$2"; then
+    echo "MAC mismatch. File has 1234, computed 5678" >&2
+    exit 1
+fi
+echo "email: treasurer@example.com"
+echo "args: $*"
+echo "keys: $SOPS_AGE_KEY_FILE"
+`
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	command := sopsCommand
+	sopsCommand = script
+	t.Cleanup(func() { sopsCommand = command })
+}
+
+func TestDecryptSOPS(t *testing.T) {
+	fakeSOPSForTest(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(sopsFileForTest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if !isSOPSEncrypted([]byte(sopsFileForTest)) {
+		t.Fatal("Expected the file to be detected as encrypted by sops")
+	}
+
+	t.Setenv("HAPPYCOMPTA_AGE_IDENTITY", "/keys/happycompta.txt")
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	decrypted, err := decryptSOPS(path)
+	if err != nil {
+		t.Fatalf("decryptSOPS failed: %v", err)
+	}
+	expected := "email: treasurer@example.com\nargs: --decrypt " + path + "\nkeys: /keys/happycompta.txt\n"
+	if string(decrypted) != expected {
+		t.Errorf("Decrypted content mismatch. Got: %q, Want: %q", decrypted, expected)
+	}
+
+	// The sops key file variable has precedence.
+	t.Setenv("SOPS_AGE_KEY_FILE", "/keys/sops.txt")
+	if decrypted, err := decryptSOPS(path); err != nil || !strings.HasSuffix(string(decrypted), "keys: /keys/sops.txt\n") {
+		t.Errorf("Expected the sops key file to be kept, got: %q, %v", decrypted, err)
+	}
+
+	tampered := filepath.Join(dir, "tampered.yaml")
+	if err := os.WriteFile(tampered, []byte(sopsFileForTest+"# tampered\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expectedErr := "sops failed: MAC mismatch. File has 1234, computed 5678"
+	if _, err := decryptSOPS(tampered); err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected the sops error, got: %v", err)
+	}
+
+	sopsCommand = "happycompta-missing-sops"
+	if _, err := decryptSOPS(path); err == nil || !strings.Contains(err.Error(), "the sops tool is needed") {
+		t.Errorf("Expected an error for the missing sops tool, got: %v", err)
+	}
+
+	if isSOPSEncrypted([]byte("email: treasurer@example.com\nsops: enabled\n")) {
+		t.Error("Expected a plain file with a sops scalar not to be detected as encrypted")
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	identity := newAgeIdentityForTest(t)
+	dir := t.TempDir()
+	identityPath := filepath.Join(dir, "keys.txt")
+	if err := os.WriteFile(identityPath, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HAPPYCOMPTA_AGE_IDENTITY", identityPath)
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	fakeSOPSForTest(t)
+
+	plain := "email: treasurer@example.com\n"
+	files := map[string][]byte{
+		"config.yaml":     []byte(plain),
+		"config.yaml.age": encryptAgeForTest(t, []byte(plain), identity.Recipient()),
+		"sops.yaml":       []byte(sopsFileForTest),
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			actual, err := ReadConfigFile(path)
+			if err != nil {
+				t.Fatalf("ReadConfigFile failed: %v", err)
+			}
+			if !strings.HasPrefix(string(actual), plain) {
+				t.Errorf("Content mismatch. Got: %s, Want prefix: %s", actual, plain)
+			}
+		})
+	}
+
+	t.Setenv("HAPPYCOMPTA_AGE_IDENTITY", filepath.Join(dir, "missing.txt"))
+	if _, err := ReadConfigFile(filepath.Join(dir, "config.yaml.age")); err == nil ||
+		!strings.Contains(err.Error(), "missing.txt") {
+		t.Errorf("Expected the identity file error to be reported, got: %v", err)
+	}
+}
+
+func TestWriteEncryptedFile(t *testing.T) {
+	workFactor := ageScryptWorkFactor
+	ageScryptWorkFactor = 10
+	t.Cleanup(func() { ageScryptWorkFactor = workFactor })
+
+	identity := newAgeIdentityForTest(t)
+	dir := t.TempDir()
+	identityPath := filepath.Join(dir, "keys.txt")
+	if err := os.WriteFile(identityPath, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HAPPYCOMPTA_AGE_IDENTITY", identityPath)
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	plain := []byte("Martin Marie:\n  iban: FR5120041010051631529138143\n")
+	path := filepath.Join(dir, "roster.yaml.age")
+	if err := WriteEncryptedFile(path, plain); err != nil {
+		t.Fatalf("WriteEncryptedFile failed: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the file to be only readable by its owner, got: %v %v", info.Mode(), err)
+	}
+	actual, err := ReadConfigFile(path)
+	if err != nil {
+		t.Fatalf("ReadConfigFile failed: %v", err)
+	}
+	if !bytes.Equal(actual, plain) {
+		t.Errorf("Content mismatch. Got: %s, Want: %s", actual, plain)
+	}
+
+	// Without identity, the passphrase the file has been read with is used again.
+	t.Setenv("HAPPYCOMPTA_AGE_IDENTITY", "")
+	passphrases[path] = "correct horse"
+	t.Cleanup(func() { delete(passphrases, path) })
+	if err := WriteEncryptedFile(path, plain); err != nil {
+		t.Fatalf("WriteEncryptedFile failed: %v", err)
+	}
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err = decryptAge(encrypted, ageKeys{passphrase: func() (string, error) { return "correct horse", nil }})
+	if err != nil {
+		t.Fatalf("decryptAge failed: %v", err)
+	}
+	if !bytes.Equal(actual, plain) {
+		t.Errorf("Content mismatch. Got: %s, Want: %s", actual, plain)
+	}
+}
+
+func TestConfigFileType(t *testing.T) {
+	tests := map[string]string{
+		"config.yaml":     "yaml",
+		"config.YML":      "yml",
+		"config.yaml.age": "yaml",
+		"config.json.AGE": "json",
+		"config":          "",
+	}
+	for path, expected := range tests {
+		if actual := ConfigFileType(path); actual != expected {
+			t.Errorf("Type of %s mismatch. Got: %s, Want: %s", path, actual, expected)
+		}
+	}
+}
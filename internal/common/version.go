@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import "fmt"
+
+// These variables are set during the build process via ldflags,
+// like -X github.com/cbosdo/happycompta-tools/internal/common.version=1.0.
+var (
+	version  = "dev"
+	revision = "HEAD"
+)
+
+// Version returns the version of the tools.
+func Version() string {
+	return version
+}
+
+// FullVersion returns the version of the tools with the revision they have been built from.
+func FullVersion() string {
+	return fmt.Sprintf("%s (%s)", version, revision)
+}
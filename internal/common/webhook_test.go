@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var body, contentType string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, contentType = string(data), r.Header.Get("Content-Type")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	if err := PostWebhook(server.URL, []byte(`{"event":"post"}`)); err != nil {
+		t.Fatalf("PostWebhook failed: %v", err)
+	}
+	if body != `{"event":"post"}` || contentType != "application/json" {
+		t.Errorf("Request mismatch. Got: %s %s", contentType, body)
+	}
+
+	status = http.StatusBadGateway
+	if err := PostWebhook(server.URL, []byte("{}")); err == nil || !strings.Contains(err.Error(), "returned status 502") {
+		t.Errorf("Expected a status error, got: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RowReader reads the rows of a CSV or spreadsheet file one by one.
+// It returns io.EOF after the last row.
+type RowReader interface {
+	Read() ([]string, error)
+}
+
+// GetRowReader opens an XLSX file if the path has the .xlsx extension and a CSV file otherwise.
+// The returned cleaner function must be called when the reader is no longer needed.
+func GetRowReader(params CSVParams, dataPath string) (RowReader, func(), error) {
+	if strings.EqualFold(filepath.Ext(dataPath), ".xlsx") {
+		return GetXLSXReader(params.Sheet, dataPath)
+	}
+
+	reader, cleaner, err := GetCSVReader(params, dataPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, cleaner, nil
+}
+
+// maxExcelSerial is the serial number of 31/12/9999, the last date of the spreadsheets.
+const maxExcelSerial = 2958465
+
+// parseExcelDate converts the serial number of a spreadsheet date cell, like 45730 for 14/03/2025.
+// The fraction is the time of the day.
+func parseExcelDate(value string) (time.Time, bool) {
+	if value == "" || strings.Trim(value, "0123456789.") != "" {
+		return time.Time{}, false
+	}
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial < 1 || serial > maxExcelSerial {
+		return time.Time{}, false
+	}
+	date, err := excelize.ExcelDateToTime(serial, false)
+	return date, err == nil
+}
+
+// xlsxReader reads the rows of a worksheet.
+type xlsxReader struct {
+	rows *excelize.Rows
+}
+
+// GetXLSXReader opens a worksheet of an XLSX file, or the first one if sheet is empty.
+// The cells are read without applying their number format to avoid the rounding
+// and scientific notation of the displayed values.
+// The returned cleaner function must be called when the reader is no longer needed.
+func GetXLSXReader(sheet string, dataPath string) (RowReader, func(), error) {
+	file, err := excelize.OpenFile(dataPath, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open XLSX file %s: %w", dataPath, err)
+	}
+
+	if sheet == "" {
+		sheet = file.GetSheetName(0)
+	}
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("failed to read sheet %s of %s: %w", sheet, dataPath, err)
+	}
+	cleaner := func() {
+		_ = rows.Close()
+		_ = file.Close()
+	}
+	return &xlsxReader{rows: rows}, cleaner, nil
+}
+
+// Read returns the values of the next row. The trailing empty cells are not returned.
+// Like in CSV files, the empty rows are skipped.
+func (r *xlsxReader) Read() ([]string, error) {
+	for r.rows.Next() {
+		row, err := r.rows.Columns()
+		if err != nil || len(row) > 0 {
+			return row, err
+		}
+	}
+	if err := r.rows.Error(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
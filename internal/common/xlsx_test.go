@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func writeXLSX(t *testing.T, path string, sheets map[string][][]any) {
+	file := excelize.NewFile()
+	defer func() { _ = file.Close() }()
+
+	first := true
+	for sheet, rows := range sheets {
+		if first {
+			if err := file.SetSheetName("Sheet1", sheet); err != nil {
+				t.Fatal(err)
+			}
+			first = false
+		} else if _, err := file.NewSheet(sheet); err != nil {
+			t.Fatal(err)
+		}
+		for i, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readAllRows(t *testing.T, reader RowReader) [][]string {
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("failed to read the rows: %s", err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestGetRowReaderXLSX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.XLSX")
+	writeXLSX(t, path, map[string][][]any{
+		"Remboursements": {
+			{"creditor", "iban", "amount"},
+			{"Jean Dupont", "FR7420041010058652109911007", 1234.5},
+			{},
+			{"Marie Martin", "FR5120041010051631529138143", 10},
+		},
+	})
+
+	reader, cleaner, err := GetRowReader(CSVParams{}, path)
+	if err != nil {
+		t.Fatalf("GetRowReader failed: %s", err)
+	}
+	defer cleaner()
+
+	expected := [][]string{
+		{"creditor", "iban", "amount"},
+		{"Jean Dupont", "FR7420041010058652109911007", "1234.5"},
+		{"Marie Martin", "FR5120041010051631529138143", "10"},
+	}
+	if rows := readAllRows(t, reader); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Rows mismatch. Got: %v, Want: %v", rows, expected)
+	}
+}
+
+func TestGetXLSXReaderSheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.xlsx")
+	writeXLSX(t, path, map[string][][]any{
+		"First":  {{"a"}},
+		"Second": {{"b"}},
+	})
+
+	reader, cleaner, err := GetXLSXReader("Second", path)
+	if err != nil {
+		t.Fatalf("GetXLSXReader failed: %s", err)
+	}
+	defer cleaner()
+
+	expected := [][]string{{"b"}}
+	if rows := readAllRows(t, reader); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Rows mismatch. Got: %v, Want: %v", rows, expected)
+	}
+
+	if _, _, err := GetXLSXReader("Missing", path); err == nil {
+		t.Error("Expected an error for a missing sheet")
+	}
+}
+
+func TestGetRowReaderCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("a;b\n1;2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, cleaner, err := GetRowReader(CSVParams{Comma: ";"}, path)
+	if err != nil {
+		t.Fatalf("GetRowReader failed: %s", err)
+	}
+	defer cleaner()
+
+	expected := [][]string{{"a", "b"}, {"1", "2"}}
+	if rows := readAllRows(t, reader); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Rows mismatch. Got: %v, Want: %v", rows, expected)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// maxRemittanceInfoLength is the maximum length of the unstructured remittance information in the SEPA files.
+const maxRemittanceInfoLength = 140
+
+// aggregateByCreditor merges the transactions to the same creditor account in the same payment into one
+// transaction summing their amounts to reduce the bank fees.
+// The merged transaction keeps the end to end ID of the first one and the information texts are concatenated.
+// The transactions with a creditor reference are never merged since their reference identifies them.
+func aggregateByCreditor(transactions []groupedTransaction) []groupedTransaction {
+	type creditorKey struct {
+		date             string
+		debtor           string
+		file             int
+		group            string
+		iban             string
+		currency         string
+		ultimateDebtor   string
+		ultimateCreditor string
+	}
+
+	result := make([]groupedTransaction, 0, len(transactions))
+	merged := map[creditorKey]int{}
+	var truncated []string
+	for _, item := range transactions {
+		transaction := item.transaction
+		if transaction.Reference != "" {
+			result = append(result, item)
+			continue
+		}
+
+		key := creditorKey{
+			date: item.date, debtor: debtorIBAN(item.debtor), file: item.file, group: item.group,
+			iban: transaction.Creditor.IBAN, currency: transaction.Currency,
+			ultimateDebtor: transaction.UltimateDebtor, ultimateCreditor: transaction.UltimateCreditor,
+		}
+		idx, found := merged[key]
+		if !found {
+			// Copy the transaction to keep the original one untouched.
+			copied := *transaction
+			item.transaction = &copied
+			merged[key] = len(result)
+			result = append(result, item)
+			continue
+		}
+
+		target := result[idx].transaction
+		target.Amount += transaction.Amount
+		if transaction.Info != "" {
+			info := strings.TrimPrefix(target.Info+", "+transaction.Info, ", ")
+			if len(info) > maxRemittanceInfoLength {
+				if !slices.Contains(truncated, target.EndToEndID) {
+					truncated = append(truncated, target.EndToEndID)
+				}
+				info = strings.TrimSpace(info[:maxRemittanceInfoLength])
+			}
+			target.Info = info
+		}
+	}
+
+	if merged := len(transactions) - len(result); merged > 0 {
+		slog.Info("transactions merged with other ones to the same creditor", "count", merged)
+	}
+	if len(truncated) > 0 {
+		slog.Warn("the information of the merged transactions has been truncated",
+			"transactions", strings.Join(truncated, ", "))
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestAggregateByCreditor(t *testing.T) {
+	jean := sepa.Party{Name: "Jean Dupont", IBAN: "FR7420041010058652109911007"}
+	marie := sepa.Party{Name: "Marie Martin", IBAN: "FR5120041010051631529138143"}
+	newItem := func(row int, date string, creditor sepa.Party, amount sepa.Amount, info string) groupedTransaction {
+		return groupedTransaction{row: row, date: date, transaction: &sepa.Transaction{
+			EndToEndID: strings.Repeat("0", row), Amount: amount, Currency: "EUR", Creditor: creditor, Info: info,
+		}}
+	}
+
+	transactions := []groupedTransaction{
+		newItem(1, "2025-06-11", jean, 1000, "Train"),
+		newItem(2, "2025-06-11", marie, 500, "Taxi"),
+		newItem(3, "2025-06-11", jean, 250, "Bus"),
+		newItem(4, "2025-06-12", jean, 100, "Metro"),
+		newItem(5, "2025-06-11", jean, 50, ""),
+	}
+	transactions[3].transaction.Reference = "RF18539007547034"
+	transactions[3].date = "2025-06-11"
+
+	actual := aggregateByCreditor(transactions)
+	type summary struct {
+		id     string
+		amount sepa.Amount
+		info   string
+	}
+	var summaries []summary
+	for _, item := range actual {
+		summaries = append(summaries, summary{item.transaction.EndToEndID, item.transaction.Amount, item.transaction.Info})
+	}
+	expected := []summary{
+		{"0", 1300, "Train, Bus"},
+		{"00", 500, "Taxi"},
+		{"0000", 100, "Metro"},
+	}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("Aggregated transactions mismatch. Got: %v, Want: %v", summaries, expected)
+	}
+	if transactions[0].transaction.Amount != 1000 {
+		t.Errorf("Original transaction modified. Got: %s, Want: 10.00", transactions[0].transaction.Amount)
+	}
+
+	// The transactions of different payments are kept apart.
+	transactions = []groupedTransaction{
+		newItem(1, "2025-06-11", jean, 1000, "Train"),
+		newItem(2, "2025-06-12", jean, 250, "Bus"),
+	}
+	if actual := aggregateByCreditor(transactions); len(actual) != 2 {
+		t.Errorf("Transactions count mismatch. Got: %d, Want: 2", len(actual))
+	}
+}
+
+func TestAggregateByCreditorTruncatesInfo(t *testing.T) {
+	creditor := sepa.Party{Name: "Jean Dupont", IBAN: "FR7420041010058652109911007"}
+	var transactions []groupedTransaction
+	for i := range 6 {
+		transactions = append(transactions, groupedTransaction{row: i + 1, transaction: &sepa.Transaction{
+			EndToEndID: "id", Amount: 100, Creditor: creditor, Info: strings.Repeat("a", 30),
+		}})
+	}
+
+	actual := aggregateByCreditor(transactions)
+	if len(actual) != 1 || len(actual[0].transaction.Info) != maxRemittanceInfoLength {
+		t.Errorf("Info length mismatch. Got: %d, Want: %d", len(actual[0].transaction.Info), maxRemittanceInfoLength)
+	}
+}
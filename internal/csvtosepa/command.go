@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"fmt"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"github.com/spf13/cobra"
+)
+
+type Config struct {
+	Email               string `mapstructure:"email"`
+	Password            string `mapstructure:"password"`
+	Output              string
+	Debtor              sepa.Party
+	Debtors             map[string]sepa.Party
+	BatchID             string
+	CSV                 CsvConfig
+	Check               bool
+	Instant             bool
+	Preview             bool
+	Yes                 bool
+	Roster              string
+	Force               bool
+	Checksum            bool
+	Limit               LimitConfig
+	Currency            string
+	ExecutionDate       string `flag:"execution-date"`
+	MaxTransactions     int    `flag:"max-transactions"`
+	DebtorProfile       string `flag:"debtor-profile"`
+	DedupIDs            bool   `flag:"dedup-ids"`
+	IDsCSV              string `flag:"ids-csv"`
+	SummaryCSV          string `flag:"summary-csv"`
+	PaymentPerFile      bool   `flag:"payment-per-file"`
+	ConfirmOverLimit    bool   `flag:"confirm-over-limit"`
+	ExpectedCount       int    `flag:"expected-count"`
+	ExpectedTotal       string `flag:"expected-total"`
+	ChargeBearer        string `flag:"charge-bearer"`
+	AggregateByCreditor bool   `flag:"aggregate-by-creditor"`
+	HistoryFile         string `flag:"history-file"`
+	HistoryDays         int    `flag:"history-days"`
+	ConfirmDuplicate    bool   `flag:"confirm-duplicate"`
+}
+
+type CsvConfig struct {
+	common.CSVParams `mapstructure:",squash"`
+	Columns          ColumnsConfig
+	Trailer          string
+}
+
+type ColumnsConfig struct {
+	Creditor         string
+	IBAN             string
+	BIC              string
+	EndToEndID       string `mapstructure:"id"`
+	Amount           string
+	Info             string
+	Date             string
+	Reference        string
+	Group            string
+	Debtor           string
+	Street           string
+	PostCode         string
+	City             string
+	Country          string
+	Currency         string
+	UltimateDebtor   string `flag:"csv-columns-ultimate-debtor"`
+	UltimateCreditor string `flag:"csv-columns-ultimate-creditor"`
+}
+
+// readConfig reads the configuration from the file, environment and flags.
+func readConfig() (Config, error) {
+	var flags Config
+	if err := common.UnmarshalConfig(&flags); err != nil {
+		return flags, common.WithExitCode(common.ExitConfig, fmt.Errorf("failed to parse configuration: %s", err))
+	}
+	return flags, nil
+}
+
+// ConfigKeys are the keys of the configuration file not matching a flag of the CSV to SEPA command.
+var ConfigKeys = common.ConfigKeys{
+	"debtor.address.*":    "string",
+	"debtors.*.name":      "string",
+	"debtors.*.iban":      "string",
+	"debtors.*.bic":       "string",
+	"debtors.*.address.*": "string",
+}
+
+// NewCommand creates the CSV to SEPA command with the given name.
+func NewCommand(name string) *cobra.Command {
+	sepaCmd := &cobra.Command{
+		Use:   name + " path/to/data...",
+		Short: "Convert CSV or XLSX files to a SEPA transfer file",
+		Long: `Convert CSV or XLSX files to a SEPA transfer file.
+The transactions of all the files are merged in one transfer initiation.
+A - path reads a CSV file from the standard input.
+Without output flag, the SEPA file is written to the standard output and all the messages go to the standard error.`,
+		Args:    common.UsageArgs(cobra.MinimumNArgs(1)),
+		Version: common.FullVersion(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags, err := readConfig()
+			if err != nil {
+				return err
+			}
+			return toPain001(flags, args...)
+		},
+	}
+	sepaCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		common.SetupCommand(sepaCmd, "CSV_SEPA", ConfigKeys)
+	}
+
+	addTransferFlags(sepaCmd)
+	sepaCmd.SetFlagErrorFunc(common.FlagError)
+	sepaCmd.Flags().String("ids-csv", "", `CSV file listing the end to end IDs generated when there is no id column.
+Defaults to the input file name with an -ids suffix.`)
+	sepaCmd.Flags().Bool("payment-per-file", false, `Put the transactions of each input file in separate payments.
+By default, the transactions of all the files are grouped together.`)
+	sepaCmd.Flags().Bool("check", false, `Only validate all the rows and report their problems.
+The SEPA file is not written and the command fails if any row is invalid.`)
+	sepaCmd.Flags().String("roster", "", `CSV, XLSX or YAML file with the creditors bank accounts.
+The rows without IBAN get the one of their creditor in the roster, ignoring the names case and accents.
+CSV and XLSX rosters have name, iban and optional bic columns, YAML ones map the names to their iban and bic.
+The YAML rosters can be encrypted, like the one managed by the roster command.`)
+	sepaCmd.Flags().String("csv-columns-creditor", "creditor", "Name of the column for the creditor name")
+	sepaCmd.Flags().String("csv-columns-iban", "iban", "Name of the column for the creditor's IBAN")
+	sepaCmd.Flags().String("csv-columns-bic", "bic", "Name of the column for the creditor's BIC")
+	sepaCmd.Flags().String("csv-columns-id", "id", `Name of the column for the end to end id.
+Without this column, the IDs are generated from the batch ID and the row number.`)
+	sepaCmd.Flags().String("csv-columns-info", "info", "Name of the column for the transaction information")
+	sepaCmd.Flags().String("csv-columns-amount", "amount", "Name of the column for the transaction amount")
+	sepaCmd.Flags().String("csv-columns-date", "", `Name of the optional column for the requested execution date.
+The dates are in one of the csv-date-layouts formats and the transactions are grouped in one payment per date.
+Rows without date use the execution-date flag value.`)
+	sepaCmd.Flags().String("csv-columns-reference", "", `Name of the optional column for the ISO 11649 creditor reference.
+The reference is sent as structured information instead of the transaction information.`)
+	sepaCmd.Flags().String("csv-columns-group", "", `Name of the optional column to group the transactions in payments.
+The transactions are grouped in one payment per group and execution date.`)
+	sepaCmd.Flags().String("csv-columns-debtor", "", `Name of the optional column for the debtor profile name.
+The rows without value use the default debtor.`)
+	sepaCmd.Flags().String("csv-columns-street", "", "Name of the optional column for the creditor street and number")
+	sepaCmd.Flags().String("csv-columns-postcode", "", "Name of the optional column for the creditor postal code")
+	sepaCmd.Flags().String("csv-columns-city", "", "Name of the optional column for the creditor town")
+	sepaCmd.Flags().String("csv-columns-country", "", `Name of the optional column for the creditor ISO 3166 country code.
+The town and country are required for the rows with an address.`)
+	sepaCmd.Flags().String("csv-columns-currency", "", `Name of the optional column for the ISO 4217 currency code.
+The rows without value use the currency flag value.`)
+	sepaCmd.Flags().String("csv-columns-ultimate-debtor", "", `Name of the optional column for the ultimate debtor name.
+It is the party on behalf of which the transfer is paid, like a section of the association.`)
+	sepaCmd.Flags().String("csv-columns-ultimate-creditor", "",
+		`Name of the optional column for the ultimate creditor name.
+It is the party for which the creditor receives the money, like the child of a reimbursed parent.`)
+
+	// CSV Structure flags
+	sepaCmd.Flags().String("csv-trailer", "", `Creditor value of the optional trailer row of the files.
+The trailer row is not a transaction: its amount is the expected total of the file.`)
+
+	sepaCmd.SetVersionTemplate("{{.Version}}\n")
+
+	sepaCmd.AddCommand(newHappyComptaCmd())
+	sepaCmd.AddCommand(newRosterCmd())
+
+	return sepaCmd
+}
+
+// addTransferFlags adds the persistent flags of the commands writing SEPA transfer files.
+func addTransferFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("config", "c", "", "Configuration file path")
+	common.AddLogFlags(cmd)
+	common.AddLanguageFlag(cmd)
+	cmd.PersistentFlags().String("email", "", "happy-compta user email address, needed to read the reimbursements")
+	cmd.PersistentFlags().String("password", "", "happy-compta user password, needed to read the reimbursements")
+	cmd.PersistentFlags().StringP("output", "o", "", `SEPA file to write to. Defaults to stdout, also used for -.
+The {{date}} and {{batchid}} placeholders are replaced by the current date and the batch ID.
+For example: sepa-{{date}}-{{batchid}}.xml. Existing files are only overwritten with the force flag.`)
+	cmd.PersistentFlags().Bool("force", false, "Overwrite the SEPA file if it already exists.")
+	cmd.PersistentFlags().Bool("checksum", false, `Write the SHA-256 checksum of the SEPA file in a .sha256 file.
+The checksum file is next to the SEPA file and helps checking the integrity of the file handed to the bank.`)
+	cmd.PersistentFlags().String("batchid", "", `Unique identifier of the transfer initiation.
+Defaults to a generated one made of the debtor name, the date and a random suffix.`)
+	cmd.PersistentFlags().String("execution-date", "", `Requested execution date of the transfers.
+The date is in one of the csv-date-layouts formats and defaults to today.
+The date can't be in the past or on a TARGET2 closing day.`)
+	cmd.PersistentFlags().Int("max-transactions", 0, `Maximum number of transactions per payment.
+The bigger payments are split. 0 means no limit.`)
+	cmd.PersistentFlags().Bool("dedup-ids", false,
+		"Add a -<n> suffix to the duplicate end to end IDs instead of failing.")
+	cmd.PersistentFlags().String("summary-csv", "", `CSV file to write the transactions count and amount per creditor.
+The same summary is always printed on the standard error.`)
+	cmd.PersistentFlags().Bool("preview", false, `Print the transfers and ask for confirmation before writing the file.
+The IBANs are masked in the printed table.`)
+	cmd.PersistentFlags().BoolP("yes", "y", false, `Do not ask for confirmations.
+It is needed for the preview when the data is read from the standard input.`)
+	cmd.PersistentFlags().Bool("instant", false, `Request SEPA instant credit transfers (SCT Inst).
+The debtor bank needs to support them. They can be executed on TARGET2 closing days.`)
+	cmd.PersistentFlags().String("limit-transaction", "", `Amount above which a transaction needs to be confirmed.
+Empty means no limit.`)
+	cmd.PersistentFlags().String("limit-batch", "", `Total amount above which the transfers need to be confirmed.
+Empty means no limit.`)
+	cmd.PersistentFlags().Bool("confirm-over-limit", false,
+		"Write the SEPA file even if amounts are over the limits, only warning about them.")
+	cmd.PersistentFlags().String("history-file", defaultHistoryPath(), `File recording the generated batches.
+The SEPA file is not written if the same transfers have already been generated recently. Empty disables the check.`)
+	cmd.PersistentFlags().Int("history-days", 30, "Number of days during which the generated batches are recorded.")
+	cmd.PersistentFlags().Bool("confirm-duplicate", false,
+		"Write the SEPA file even if the same transfers have been generated recently, only warning about it.")
+	cmd.PersistentFlags().Int("expected-count", 0, `Expected number of transactions.
+The SEPA file is not written if it doesn't match. 0 means no check.`)
+	cmd.PersistentFlags().String("expected-total", "", `Expected total amount of the transactions.
+The SEPA file is not written if it doesn't match. Empty means no check.`)
+	cmd.PersistentFlags().String("currency", "EUR", `ISO 4217 code of the amounts currency.
+The currency column overrides it for each row. Instant transfers are only in EUR.`)
+	cmd.PersistentFlags().Bool("aggregate-by-creditor", false, `Merge the transactions to the same IBAN in one payment.
+The amounts are summed and the information texts concatenated to save the bank fees per transaction.
+The end to end ID of the first transaction is kept and the transactions with a creditor reference are not merged.`)
+	cmd.PersistentFlags().String("charge-bearer", "SLEV", `Party paying the transfer fees: SLEV, DEBT, CRED or SHAR.
+SEPA transfers require SLEV, the others are only for transfers outside of the SEPA zone.`)
+	common.AddFlagCompletion(cmd, "charge-bearer", "SLEV", "DEBT", "CRED", "SHAR")
+	cmd.PersistentFlags().String("debtor-name", "", "Debtor name")
+	cmd.PersistentFlags().String("debtor-iban", "", "Debtor IBAN")
+	cmd.PersistentFlags().String("debtor-bic", "", "Debtor BIC")
+	cmd.PersistentFlags().String("debtor-profile", "", `Name of the debtor profile to use.
+It replaces the debtor-* flags.
+The profiles are defined in the debtors section of the configuration file with a name, iban and bic.`)
+	cmd.PersistentFlags().String("csv-comma", ",", "CSV field separator character.")
+	cmd.PersistentFlags().String("csv-comment", "#", "CSV comment character.")
+	cmd.PersistentFlags().String("csv-encoding", "auto", `CSV file encoding, like utf-8 or windows-1252.
+auto detects the byte order mark and falls back to windows-1252 for non UTF-8 content.`)
+	cmd.PersistentFlags().StringSlice("csv-date-layouts", nil, `Comma-separated list of the accepted date formats, as Go layouts.
+Defaults to DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY: 02/01/2006,2006-01-02,02-01-06.`)
+	cmd.PersistentFlags().String("csv-sheet", "", `Name of the worksheet to read in .xlsx files.
+Defaults to the first one. The same column names as in CSV files are used.`)
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+// debtorProfiles holds the debtor accounts the payments can be made from.
+type debtorProfiles struct {
+	defaultDebtor *sepa.Party
+	profiles      map[string]*sepa.Party
+}
+
+// newDebtorProfiles validates the configured debtors.
+// The default debtor is the selected profile if any, or the one defined by the debtor-* flags.
+func newDebtorProfiles(debtor sepa.Party, profiles map[string]sepa.Party, selected string) (*debtorProfiles, error) {
+	result := &debtorProfiles{profiles: make(map[string]*sepa.Party, len(profiles))}
+
+	for name, profile := range profiles {
+		if err := normalizeParty(&profile); err != nil {
+			return nil, fmt.Errorf("invalid debtor profile %s: %s", name, err)
+		}
+		result.profiles[strings.ToLower(name)] = &profile
+	}
+
+	if selected != "" {
+		profile, err := result.get(selected)
+		if err != nil {
+			return nil, err
+		}
+		result.defaultDebtor = profile
+		return result, nil
+	}
+
+	if err := normalizeParty(&debtor); err != nil {
+		return nil, fmt.Errorf("invalid debtor: %s", err)
+	}
+	result.defaultDebtor = &debtor
+	return result, nil
+}
+
+// get returns the debtor profile with the given name or the default one if the name is empty.
+func (d *debtorProfiles) get(name string) (*sepa.Party, error) {
+	if name == "" {
+		return d.defaultDebtor, nil
+	}
+	profile, found := d.profiles[strings.ToLower(name)]
+	if !found {
+		names := make([]string, 0, len(d.profiles))
+		for profileName := range d.profiles {
+			names = append(names, profileName)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("unknown debtor profile %s, defined ones are: %s", name, strings.Join(names, ", "))
+	}
+	return profile, nil
+}
+
+// normalizeParty removes the spaces from the account identifiers and validates the BIC.
+func normalizeParty(party *sepa.Party) error {
+	party.BIC = strings.ToUpper(strings.ReplaceAll(party.BIC, " ", ""))
+	party.IBAN = strings.ReplaceAll(party.IBAN, " ", "")
+	if party.IBAN != "" {
+		if err := validateIBAN(party.IBAN); err != nil {
+			return err
+		}
+	}
+	return validateBIC(party.BIC)
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestNewDebtorProfiles(t *testing.T) {
+	debtor := sepa.Party{Name: "Default", IBAN: "FR74 2004 1010 0586 5210 9911 007", BIC: "pmxncxv94rh"}
+	profiles := map[string]sepa.Party{
+		"ASC": {Name: "CSE ASC", IBAN: "FR51 2004 1010 0516 3152 9138 143", BIC: "DPYCNL539SF"},
+		"fon": {Name: "CSE FON", IBAN: "FR6920041010056927446332670"},
+	}
+
+	tests := []struct {
+		selected string
+		expected string
+		err      bool
+	}{
+		{"", "Default FR7420041010058652109911007 PMXNCXV94RH", false},
+		{"asc", "CSE ASC FR5120041010051631529138143 DPYCNL539SF", false},
+		{"FON", "CSE FON FR6920041010056927446332670 ", false},
+		{"AEP", "", true},
+	}
+
+	for _, test := range tests {
+		debtors, err := newDebtorProfiles(debtor, profiles, test.selected)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for profile %s", test.selected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error for profile %s: %s", test.selected, err)
+		}
+		actual := debtors.defaultDebtor.Name + " " + debtors.defaultDebtor.IBAN + " " + debtors.defaultDebtor.BIC
+		if actual != test.expected {
+			t.Errorf("Default debtor mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestDebtorProfilesGet(t *testing.T) {
+	debtors, err := newDebtorProfiles(sepa.Party{Name: "Default"}, map[string]sepa.Party{"ASC": {Name: "CSE ASC"}}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for name, expected := range map[string]string{"": "Default", "asc": "CSE ASC", "Asc": "CSE ASC"} {
+		debtor, err := debtors.get(name)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", name, err)
+			continue
+		}
+		if debtor.Name != expected {
+			t.Errorf("Debtor mismatch for %s. Got: %s, Want: %s", name, debtor.Name, expected)
+		}
+	}
+
+	if _, err := debtors.get("FON"); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+func TestNewDebtorProfilesInvalidBIC(t *testing.T) {
+	if _, err := newDebtorProfiles(sepa.Party{}, map[string]sepa.Party{"asc": {BIC: "FAKE"}}, ""); err == nil {
+		t.Error("Expected an error for an invalid profile BIC")
+	}
+}
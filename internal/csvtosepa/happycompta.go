@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"github.com/spf13/cobra"
+)
+
+func newHappyComptaCmd() *cobra.Command {
+	var happyComptaCmd = &cobra.Command{
+		Use:   "happycompta",
+		Short: "Generate the SEPA file from the happy-compta employee reimbursements",
+		Long: `Generate the SEPA file from the employee reimbursements of an accounting period in happy-compta.
+
+The reimbursements are the spend entries paid by transfer to an employee.
+happy-compta doesn't tell which of them have already been paid: use the from and to flags
+to only select the entries that have not been transferred yet.
+
+The employees bank accounts are read from a roster CSV or XLSX file with the following columns:
+  name       the employee name as "Lastname Firstname" in happy-compta, the column can also be named employee
+  iban       the employee IBAN
+  bic        the employee BIC, optional
+The roster can also be a YAML file mapping the names to their iban and bic.
+
+The end to end IDs of the transfers are the entry IDs and their information is the entry title.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags, err := readConfig()
+			if err != nil {
+				return err
+			}
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			flags.Email, flags.Password = credentials.Email, credentials.Password
+
+			opts, err := getReimbursementOptions(cmd, flags.CSV.Date)
+			if err != nil {
+				return err
+			}
+			return fromHappyCompta(flags, opts)
+		},
+	}
+	addReimbursementFlags(happyComptaCmd, "roster.csv")
+
+	return happyComptaCmd
+}
+
+// reimbursementOptions selects the employee reimbursements to transfer.
+type reimbursementOptions struct {
+	Period string
+	Roster string
+	From   time.Time
+	To     time.Time
+}
+
+func addReimbursementFlags(cmd *cobra.Command, roster string) {
+	cmd.Flags().String("period", "", `Accounting period of the reimbursements.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	cmd.Flags().String("roster", roster, "CSV, XLSX or YAML file with the employees bank accounts.")
+	cmd.Flags().String("from", "", `Only transfer the entries dated on or after this day.
+The date is in one of the csv-date-layouts formats.`)
+	cmd.Flags().String("to", "", `Only transfer the entries dated on or before this day.
+The date is in one of the csv-date-layouts formats.`)
+}
+
+func getReimbursementOptions(cmd *cobra.Command, dates common.DateParams) (opts reimbursementOptions, err error) {
+	if opts.Period, err = cmd.Flags().GetString("period"); err != nil {
+		return
+	}
+	if opts.Roster, err = cmd.Flags().GetString("roster"); err != nil {
+		return
+	}
+	if opts.From, err = getDateFlag(cmd, "from", dates); err != nil {
+		return
+	}
+	opts.To, err = getDateFlag(cmd, "to", dates)
+	return
+}
+
+func getDateFlag(cmd *cobra.Command, name string, dates common.DateParams) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	date, err := dates.Parse(value)
+	if err != nil {
+		return date, fmt.Errorf("invalid %s date: %s", name, err)
+	}
+	return date, nil
+}
+
+// fromHappyCompta writes the pain001 file transferring the employee reimbursements of a period.
+func fromHappyCompta(flags Config, opts reimbursementOptions) error {
+	roster, err := readRoster(flags.CSV.CSVParams, opts.Roster)
+	if err != nil {
+		return err
+	}
+	client, err := newHappyComptaClient(flags)
+	if err != nil {
+		return err
+	}
+	entries, employees, err := listReimbursements(client, opts)
+	if err != nil {
+		return err
+	}
+	_, err = writeReimbursements(flags, entries, employees, roster)
+	return err
+}
+
+// newHappyComptaClient returns a happy-compta client logged in with the configured credentials.
+func newHappyComptaClient(flags Config) (*lib.Client, error) {
+	client, err := lib.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Login(flags.Email, flags.Password); err != nil {
+		return nil, common.WithExitCode(common.ExitAuth, err)
+	}
+	return client, nil
+}
+
+// listReimbursements returns the employee reimbursements of the period selected by the options and the employees.
+func listReimbursements(client *lib.Client, opts reimbursementOptions) ([]lib.Entry, []lib.Employee, error) {
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return nil, nil, err
+	}
+	periodID, err := lib.FindPeriod(periods, opts.Period)
+	if err != nil {
+		return nil, nil, err
+	}
+	employees, err := client.ListEmployees()
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := lib.EntryFilter{Period: periodID, Kind: lib.KindSpend, From: opts.From, To: opts.To}
+	entries, err := client.ListEntries(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return selectReimbursements(entries, opts.From, opts.To), employees, nil
+}
+
+// writeReimbursements writes the pain001 file transferring the reimbursement entries to the employees accounts.
+// It returns the ID of the written batch.
+func writeReimbursements(
+	flags Config, entries []lib.Entry, employees []lib.Employee, roster map[string]sepa.Party,
+) (string, error) {
+	debtors, err := newDebtorProfiles(flags.Debtor, flags.Debtors, flags.DebtorProfile)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	executionDate, err := getExecutionDate(flags.ExecutionDate, flags.CSV.Date, now, flags.Instant)
+	if err != nil {
+		return "", err
+	}
+	flags.BatchID = getBatchID(flags.BatchID, debtors.defaultDebtor.Name, now)
+
+	transactions, err := newReimbursementTransactions(entries, employees, roster)
+	if err != nil {
+		return "", err
+	}
+	if len(transactions) == 0 {
+		slog.Warn("no employee reimbursement to transfer")
+	}
+	if err := checkExpectedTotals(transactions, flags.ExpectedCount, flags.ExpectedTotal); err != nil {
+		return "", err
+	}
+
+	for i := range transactions {
+		transactions[i].date = executionDate.Format(dateLayout)
+		transactions[i].debtor = debtors.defaultDebtor
+	}
+	return flags.BatchID, writeTransfers(flags, debtors.defaultDebtor, executionDate, transactions)
+}
+
+// selectReimbursements returns the spend entries paid by transfer to an employee between from and to.
+// Zero dates are not filtering.
+func selectReimbursements(entries []lib.Entry, from time.Time, to time.Time) []lib.Entry {
+	return slices.DeleteFunc(slices.Clone(entries), func(e lib.Entry) bool {
+		_, isEmployee := e.Party.(*lib.Employee)
+		return e.Kind != lib.KindSpend || !isEmployee || e.PaymentMethod != lib.PaymentMethodTransfer ||
+			(!from.IsZero() && e.Date.Before(from)) || (!to.IsZero() && e.Date.After(to))
+	})
+}
+
+// newReimbursementTransactions converts the reimbursement entries into transactions to the employees accounts.
+// All the entries are checked and their problems are returned together.
+func newReimbursementTransactions(
+	entries []lib.Entry, employees []lib.Employee, roster map[string]sepa.Party,
+) ([]groupedTransaction, error) {
+	names := make(map[string]string, len(employees))
+	for _, employee := range employees {
+		names[employee.ID] = employee.Lastname + " " + employee.Firstname
+	}
+
+	var allErrors []error
+	var transactions []groupedTransaction
+	for i, entry := range entries {
+		var entryErrors []error
+
+		name := names[entry.Party.GetID()]
+		account, found := roster[rosterKey(name)]
+		if !found {
+			entryErrors = append(entryErrors, fmt.Errorf("employee '%s' not found in the roster", name))
+		}
+
+		var sum float64
+		for _, line := range entry.Allocation {
+			sum += line.Amount
+		}
+		amount := sepa.Amount(math.Round(sum * 100))
+		if amount <= 0 {
+			entryErrors = append(entryErrors, fmt.Errorf("invalid amount: %.2f", sum))
+		}
+
+		endToEndID, err := sanitizeString(entry.ID, maxEndToEndIDLength)
+		if err != nil {
+			entryErrors = append(entryErrors, fmt.Errorf("invalid end to end ID: %s", err))
+		}
+
+		if len(entryErrors) > 0 {
+			allErrors = append(allErrors, fmt.Errorf("entry %s: %s", entry.ID, errors.Join(entryErrors...)))
+			continue
+		}
+
+		transactions = append(transactions, groupedTransaction{
+			row: i + 1,
+			transaction: &sepa.Transaction{
+				EndToEndID: endToEndID,
+				Amount:     amount,
+				Currency:   defaultCurrency,
+				Creditor:   account,
+				Purpose:    "REFU",
+				Info:       cleanString(entry.Name, 35),
+			},
+		})
+	}
+	return transactions, errors.Join(allErrors...)
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestSelectReimbursements(t *testing.T) {
+	entries := []lib.Entry{
+		{ID: "ok", Kind: lib.KindSpend, Party: &lib.Employee{ID: "e1"}, PaymentMethod: lib.PaymentMethodTransfer,
+			Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "provider", Kind: lib.KindSpend, Party: &lib.Provider{ID: "p1"}, PaymentMethod: lib.PaymentMethodTransfer,
+			Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "card", Kind: lib.KindSpend, Party: &lib.Employee{ID: "e1"}, PaymentMethod: lib.PaymentMethodCard,
+			Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "income", Kind: lib.KindTake, Party: &lib.Employee{ID: "e1"}, PaymentMethod: lib.PaymentMethodTransfer,
+			Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "old", Kind: lib.KindSpend, Party: &lib.Employee{ID: "e1"}, PaymentMethod: lib.PaymentMethodTransfer,
+			Date: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		from     time.Time
+		expected []string
+	}{
+		{time.Time{}, []string{"ok", "old"}},
+		{time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), []string{"ok"}},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, entry := range selectReimbursements(entries, test.from, time.Time{}) {
+			actual = append(actual, entry.ID)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Reimbursements from %s mismatch. Got: %v, Want: %v", test.from, actual, test.expected)
+		}
+	}
+}
+
+func TestNewReimbursementTransactions(t *testing.T) {
+	employees := []lib.Employee{
+		{ID: "e1", Lastname: "Dupont", Firstname: "Jérôme"},
+		{ID: "e2", Lastname: "Martin", Firstname: "Marie"},
+	}
+	roster := map[string]sepa.Party{
+		"dupont jerome": {Name: "Dupont Jerome", IBAN: "FR7420041010058652109911007"},
+	}
+	entries := []lib.Entry{
+		{ID: "ASC000001", Name: "Frais de déplacement & péage", Party: &lib.Employee{ID: "e1"},
+			Allocation: []lib.AllocationLine{{Amount: 12.3}, {Amount: 0.1}}},
+	}
+
+	transactions, err := newReimbursementTransactions(entries, employees, roster)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []groupedTransaction{{row: 1, transaction: &sepa.Transaction{
+		EndToEndID: "ASC000001",
+		Amount:     1240,
+		Currency:   "EUR",
+		Creditor:   sepa.Party{Name: "Dupont Jerome", IBAN: "FR7420041010058652109911007"},
+		Purpose:    "REFU",
+		Info:       "Frais de deplacement   peage",
+	}}}
+	if !reflect.DeepEqual(transactions, expected) {
+		t.Errorf("Transactions mismatch. Got: %+v, Want: %+v", *transactions[0].transaction, *expected[0].transaction)
+	}
+
+	entries = append(entries, lib.Entry{ID: "ASC000002", Party: &lib.Employee{ID: "e2"}})
+	_, err = newReimbursementTransactions(entries, employees, roster)
+	if err == nil {
+		t.Fatal("Expected an error for the missing employee")
+	}
+	for _, message := range []string{"entry ASC000002", "'Martin Marie' not found", "invalid amount"} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Error mismatch. Got: %s, Want it to contain: %s", err, message)
+		}
+	}
+}
+
+func TestCleanString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Cadeaux de Noël", "Cadeaux de Noel"},
+		{"Repas 12€ & boissons", "Repas 12    boissons"},
+		{"Un libellé beaucoup trop long pour un virement SEPA", "Un libelle beaucoup trop long pour"},
+	}
+
+	for _, test := range tests {
+		if actual := cleanString(test.input, 35); actual != test.expected {
+			t.Errorf("cleanString(%q) mismatch. Got: %q, Want: %q", test.input, actual, test.expected)
+		}
+	}
+}
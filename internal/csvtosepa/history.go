@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// historyEntry is a generated batch recorded in the history file.
+type historyEntry struct {
+	time    time.Time
+	hash    string
+	batchID string
+}
+
+// defaultHistoryPath returns the history file in the user cache directory, or an empty path if there is none.
+func defaultHistoryPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "happycompta-tools", "csv-to-sepa-history.csv")
+}
+
+// batchHash computes a hash of the transfers content.
+// The IDs and dates are ignored as they usually change when generating the same list of transfers again.
+func batchHash(transactions []groupedTransaction) string {
+	lines := make([]string, 0, len(transactions))
+	for _, item := range transactions {
+		t := item.transaction
+		lines = append(lines, strings.Join([]string{
+			debtorIBAN(item.debtor), t.Creditor.IBAN, t.Amount.String(), t.Currency, t.Info, t.Reference,
+		}, "\x1f"))
+	}
+	// The same transfers in another order would still be paid twice.
+	slices.Sort(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// readHistory reads the batches recorded in the history file.
+// A missing file is an empty history.
+func readHistory(path string) ([]historyEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the history file %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the history file %s: %s", path, err)
+	}
+	var entries []historyEntry
+	for i, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("invalid line %d of the history file %s", i+1, path)
+		}
+		timestamp, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date on line %d of the history file %s: %s", i+1, path, err)
+		}
+		entries = append(entries, historyEntry{time: timestamp, hash: record[1], batchID: record[2]})
+	}
+	return entries, nil
+}
+
+// checkHistory reports the batches with the same hash generated less than days ago.
+// The problem is only logged as a warning if confirmed, otherwise it is returned as an error.
+func checkHistory(entries []historyEntry, hash string, now time.Time, days int, confirmed bool) error {
+	for _, entry := range recentEntries(entries, now, days) {
+		if entry.hash != hash {
+			continue
+		}
+		problem := fmt.Sprintf("the same transfers have been generated on %s in batch %s",
+			entry.time.Local().Format("2006-01-02 15:04"), entry.batchID)
+		if confirmed {
+			slog.Warn(problem)
+			return nil
+		}
+		return fmt.Errorf(
+			common.Tr("%s: check they have not been paid already and confirm with the confirm-duplicate flag"),
+			problem)
+	}
+	return nil
+}
+
+// recentEntries returns the entries generated less than days ago.
+func recentEntries(entries []historyEntry, now time.Time, days int) []historyEntry {
+	limit := now.AddDate(0, 0, -days)
+	var recent []historyEntry
+	for _, entry := range entries {
+		if entry.time.After(limit) {
+			recent = append(recent, entry)
+		}
+	}
+	return recent
+}
+
+// recordBatch adds the generated batch to the history file.
+// The entries older than days are dropped to keep the file small.
+func recordBatch(path string, entries []historyEntry, entry historyEntry, days int) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the history file directory: %s", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create the history file %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	w := csv.NewWriter(file)
+	for _, item := range append(recentEntries(entries, entry.time, days), entry) {
+		if err := w.Write([]string{item.time.UTC().Format(time.RFC3339), item.hash, item.batchID}); err != nil {
+			return fmt.Errorf("failed to write the history file %s: %s", path, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write the history file %s: %s", path, err)
+	}
+	return file.Close()
+}
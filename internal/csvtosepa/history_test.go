@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestBatchHash(t *testing.T) {
+	debtor := &sepa.Party{IBAN: "FR7420041010058652109911007"}
+	newTransaction := func(id string, iban string, amount sepa.Amount) groupedTransaction {
+		return groupedTransaction{debtor: debtor, date: id, transaction: &sepa.Transaction{
+			EndToEndID: id, Amount: amount, Currency: "EUR", Info: "refund", Creditor: sepa.Party{IBAN: iban},
+		}}
+	}
+	first := newTransaction("A1", "FR5120041010051631529138143", 1000)
+	second := newTransaction("A2", "FR6920041010056927446332670", 2000)
+	reference := batchHash([]groupedTransaction{first, second})
+
+	tests := []struct {
+		name         string
+		transactions []groupedTransaction
+		same         bool
+	}{
+		{"other order", []groupedTransaction{second, first}, true},
+		{
+			"other IDs and dates",
+			[]groupedTransaction{
+				newTransaction("B1", "FR5120041010051631529138143", 1000),
+				newTransaction("B2", "FR6920041010056927446332670", 2000),
+			},
+			true,
+		},
+		{"other amount", []groupedTransaction{first, newTransaction("A2", "FR6920041010056927446332670", 2001)}, false},
+		{"missing transaction", []groupedTransaction{first}, false},
+	}
+
+	for _, test := range tests {
+		if actual := batchHash(test.transactions) == reference; actual != test.same {
+			t.Errorf("%s: same hash mismatch. Got: %v, Want: %v", test.name, actual, test.same)
+		}
+	}
+}
+
+func TestCheckHistory(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	entries := []historyEntry{
+		{time: now.AddDate(0, 0, -40), hash: "old", batchID: "B1"},
+		{time: now.AddDate(0, 0, -2), hash: "recent", batchID: "B2"},
+	}
+
+	tests := []struct {
+		name      string
+		hash      string
+		confirmed bool
+		err       string
+	}{
+		{"new batch", "new", false, ""},
+		{"old batch", "old", false, ""},
+		{"recent batch", "recent", false, "in batch B2: check they have not been paid already"},
+		{"confirmed batch", "recent", true, ""},
+	}
+
+	for _, test := range tests {
+		err := checkHistory(entries, test.hash, now, 30, test.confirmed)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", test.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error mismatch. Got: %v, Want: %s", test.name, err, test.err)
+		}
+	}
+}
+
+func TestRecordBatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "history.csv")
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	entries, err := readHistory(path)
+	if err != nil || entries != nil {
+		t.Fatalf("Missing history mismatch. Got: %v, %v", entries, err)
+	}
+
+	old := historyEntry{time: now.AddDate(0, 0, -40), hash: "old", batchID: "B1"}
+	recent := historyEntry{time: now.AddDate(0, 0, -2), hash: "recent", batchID: "B2"}
+	if err := recordBatch(path, nil, old, 60); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordBatch(path, []historyEntry{old}, recent, 60); err != nil {
+		t.Fatal(err)
+	}
+	entry := historyEntry{time: now, hash: "new", batchID: "B3"}
+	if err := recordBatch(path, []historyEntry{old, recent}, entry, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = readHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []historyEntry{recent, entry}; !reflect.DeepEqual(entries, expected) {
+		t.Errorf("History mismatch. Got: %v, Want: %v", entries, expected)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// maxEndToEndIDLength is the maximum length of the end to end IDs in the SEPA files.
+const maxEndToEndIDLength = 35
+
+// maxMessageIDNameLength is the maximum length of the debtor name part of the generated message IDs.
+const maxMessageIDNameLength = 16
+
+var nonAlphanumeric = regexp.MustCompile("[^A-Za-z0-9]+")
+
+// getBatchID returns the batch ID or generates one if it is empty.
+// Banks reject the files without message ID and the ones reusing an already sent one.
+func getBatchID(batchID string, debtorName string, now time.Time) string {
+	if batchID != "" {
+		return batchID
+	}
+	batchID = generateMessageID(debtorName, now)
+	slog.Info("no batch ID set, using a generated one", "batch", batchID)
+	return batchID
+}
+
+// generateMessageID builds a message ID from the debtor name, the date and a random suffix, like ACME-20250611-3F9A1C.
+func generateMessageID(debtorName string, now time.Time) string {
+	suffix := make([]byte, 3)
+	// The crypto random reader never fails.
+	_, _ = rand.Read(suffix)
+
+	parts := []string{now.Format("20060102"), strings.ToUpper(hex.EncodeToString(suffix))}
+	name := strings.ToUpper(nonAlphanumeric.ReplaceAllString(removeAccents(debtorName), ""))
+	if name != "" {
+		parts = append([]string{name[:min(len(name), maxMessageIDNameLength)]}, parts...)
+	}
+	return strings.Join(parts, "-")
+}
+
+// checkEndToEndIDs ensures that the end to end IDs of the transactions are set and unique.
+// With dedup, the duplicate IDs get a -<n> suffix instead of failing.
+func checkEndToEndIDs(transactions []groupedTransaction, dedup bool) error {
+	var allErrors []error
+	firstRows := map[string]string{}
+	counts := map[string]int{}
+
+	for _, item := range transactions {
+		id := item.transaction.EndToEndID
+		if id == "" {
+			allErrors = append(allErrors, fmt.Errorf("empty end to end ID on %s", item.location()))
+			continue
+		}
+
+		firstRow, found := firstRows[id]
+		if !found {
+			firstRows[id] = item.location()
+			counts[id] = 1
+			continue
+		}
+		if !dedup {
+			allErrors = append(allErrors, fmt.Errorf("end to end ID %s on %s is already used on %s",
+				id, item.location(), firstRow))
+			continue
+		}
+
+		// Look for the next free suffix, the new ID may also be in the file already.
+		for {
+			counts[id]++
+			suffix := "-" + strconv.Itoa(counts[id])
+			newID := id[:min(len(id), maxEndToEndIDLength-len(suffix))] + suffix
+			if _, used := firstRows[newID]; !used {
+				firstRows[newID] = item.location()
+				counts[newID] = 1
+				item.transaction.EndToEndID = newID
+				break
+			}
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// generateEndToEndIDs sets end to end IDs made of the batch ID and the row number, like BATCH/2025-06/007.
+// The batch ID is truncated if needed to fit the maximum ID length.
+func generateEndToEndIDs(transactions []groupedTransaction, batchID string) {
+	width := 3
+	if len(transactions) > 0 {
+		width = max(width, len(strconv.Itoa(transactions[len(transactions)-1].row)))
+	}
+	for _, item := range transactions {
+		suffix := fmt.Sprintf("%0*d", width, item.row)
+		prefix := ""
+		if batchID != "" {
+			prefix = batchID[:min(len(batchID), maxEndToEndIDLength-len(suffix)-1)] + "/"
+		}
+		item.transaction.EndToEndID = prefix + suffix
+	}
+}
+
+// getIDsCSVPath returns the path of the generated IDs CSV file for the given input file.
+// Without explicit path, the file is written next to the input one with an -ids suffix.
+// The IDs of the standard input are written to stdin-ids.csv in the current directory.
+func getIDsCSVPath(path string, csvPath string) string {
+	if path != "" {
+		return path
+	}
+	if csvPath == common.Stdin {
+		return "stdin-ids.csv"
+	}
+	ext := filepath.Ext(csvPath)
+	if ext == "" {
+		ext = ".csv"
+	}
+	return strings.TrimSuffix(csvPath, filepath.Ext(csvPath)) + "-ids" + ext
+}
+
+// writeIDsCSV writes the generated end to end ID of each row with the creditor and amount to identify it.
+func writeIDsCSV(path string, transactions []groupedTransaction) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create the IDs CSV file %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	w := csv.NewWriter(file)
+	if err := w.Write([]string{"row", "id", "creditor", "amount"}); err != nil {
+		return fmt.Errorf("failed to write the IDs CSV file %s: %s", path, err)
+	}
+	for _, item := range transactions {
+		err := w.Write([]string{
+			strconv.Itoa(item.row), item.transaction.EndToEndID, item.transaction.Creditor.Name,
+			item.transaction.Amount.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write the IDs CSV file %s: %s", path, err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write the IDs CSV file %s: %s", path, err)
+	}
+	return file.Close()
+}
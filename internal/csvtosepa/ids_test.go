@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func newIDTransactions(ids ...string) []groupedTransaction {
+	transactions := make([]groupedTransaction, 0, len(ids))
+	for i, id := range ids {
+		transactions = append(transactions, groupedTransaction{row: i + 1, transaction: &sepa.Transaction{EndToEndID: id}})
+	}
+	return transactions
+}
+
+func TestCheckEndToEndIDs(t *testing.T) {
+	long := strings.Repeat("x", maxEndToEndIDLength)
+
+	tests := []struct {
+		name     string
+		ids      []string
+		dedup    bool
+		expected []string
+		errors   []string
+	}{
+		{"unique", []string{"a", "b"}, false, []string{"a", "b"}, nil},
+		{
+			"duplicates", []string{"a", "b", "a", ""}, false, nil,
+			[]string{"end to end ID a on row 3 is already used on row 1", "empty end to end ID on row 4"},
+		},
+		{"dedup", []string{"a", "a", "a-2", "a"}, true, []string{"a", "a-2", "a-2-2", "a-3"}, nil},
+		{"dedup long", []string{long, long}, true, []string{long, long[:33] + "-2"}, nil},
+		{"dedup empty", []string{"", "a"}, true, nil, []string{"empty end to end ID on row 1"}},
+	}
+
+	for _, test := range tests {
+		transactions := newIDTransactions(test.ids...)
+		err := checkEndToEndIDs(transactions, test.dedup)
+		if test.errors != nil {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+				continue
+			}
+			if actual := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(actual, test.errors) {
+				t.Errorf("%s: errors mismatch. Got: %v, Want: %v", test.name, actual, test.errors)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+
+		actual := make([]string, 0, len(transactions))
+		for _, item := range transactions {
+			actual = append(actual, item.transaction.EndToEndID)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: IDs mismatch. Got: %v, Want: %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestCheckEndToEndIDsSources(t *testing.T) {
+	transactions := []groupedTransaction{
+		{row: 1, source: "first.csv", transaction: &sepa.Transaction{EndToEndID: "a"}},
+		{row: 1, source: "second.csv", transaction: &sepa.Transaction{EndToEndID: "a"}},
+	}
+
+	err := checkEndToEndIDs(transactions, false)
+	expected := "end to end ID a on row 1 of second.csv is already used on row 1 of first.csv"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error mismatch. Got: %v, Want: %s", err, expected)
+	}
+}
+
+func TestGenerateEndToEndIDs(t *testing.T) {
+	tests := []struct {
+		batchID  string
+		rows     int
+		expected []string
+	}{
+		{"BATCH/2025-06", 2, []string{"BATCH/2025-06/001", "BATCH/2025-06/002"}},
+		{"", 1, []string{"001"}},
+		{strings.Repeat("b", 40), 1, []string{strings.Repeat("b", 31) + "/001"}},
+	}
+
+	for _, test := range tests {
+		transactions := newIDTransactions(make([]string, test.rows)...)
+		generateEndToEndIDs(transactions, test.batchID)
+
+		actual := make([]string, 0, len(transactions))
+		for _, item := range transactions {
+			actual = append(actual, item.transaction.EndToEndID)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("IDs mismatch. Got: %v, Want: %v", actual, test.expected)
+		}
+	}
+
+	transactions := newIDTransactions(make([]string, 1000)...)
+	generateEndToEndIDs(transactions, "B")
+	if actual := transactions[0].transaction.EndToEndID; actual != "B/0001" {
+		t.Errorf("Padded ID mismatch. Got: %s, Want: B/0001", actual)
+	}
+}
+
+func TestGetIDsCSVPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		csvPath  string
+		expected string
+	}{
+		{"", "data/transfers.csv", "data/transfers-ids.csv"},
+		{"", "transfers", "transfers-ids.csv"},
+		{"ids.csv", "transfers.csv", "ids.csv"},
+		{"", "-", "stdin-ids.csv"},
+	}
+
+	for _, test := range tests {
+		if actual := getIDsCSVPath(test.path, test.csvPath); actual != test.expected {
+			t.Errorf("Path mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestGenerateMessageID(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Société Générale d'Électricité", "^SOCIETEGENERALED-20250611-[0-9A-F]{6}$"},
+		{"", "^20250611-[0-9A-F]{6}$"},
+	}
+	for _, test := range tests {
+		actual := generateMessageID(test.name, now)
+		if !regexp.MustCompile(test.expected).MatchString(actual) {
+			t.Errorf("Message ID mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+
+	if first, second := generateMessageID("Acme", now), generateMessageID("Acme", now); first == second {
+		t.Errorf("Expected different message IDs, got %s twice", first)
+	}
+	if actual := getBatchID("batch", "Acme", now); actual != "batch" {
+		t.Errorf("Batch ID mismatch. Got: %s, Want: batch", actual)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+// LimitConfig holds the amounts above which the transfers need to be confirmed.
+// Empty values mean no limit.
+type LimitConfig struct {
+	Transaction string
+	Batch       string
+}
+
+// checkLimits reports the transactions and batch total over the configured limits.
+// The problems are only logged as warnings if confirmed, otherwise they are returned as errors.
+func checkLimits(transactions []groupedTransaction, limits LimitConfig, confirmed bool) error {
+	transactionLimit, err := parseLimit(limits.Transaction)
+	if err != nil {
+		return fmt.Errorf("invalid transaction limit: %s", err)
+	}
+	batchLimit, err := parseLimit(limits.Batch)
+	if err != nil {
+		return fmt.Errorf("invalid batch limit: %s", err)
+	}
+
+	var problems []error
+	var total sepa.Amount
+	for _, item := range transactions {
+		amount := item.transaction.Amount
+		total += amount
+		if transactionLimit > 0 && amount > transactionLimit {
+			problems = append(problems, fmt.Errorf("amount %s on %s is over the %s transaction limit",
+				amount, item.location(), transactionLimit))
+		}
+	}
+	if batchLimit > 0 && total > batchLimit {
+		problems = append(problems, fmt.Errorf("total amount %s is over the %s batch limit", total, batchLimit))
+	}
+
+	if !confirmed {
+		if len(problems) > 0 {
+			problems = append(problems, errors.New(common.Tr("check the amounts and confirm them with the confirm-over-limit flag")))
+		}
+		return errors.Join(problems...)
+	}
+	for _, problem := range problems {
+		slog.Warn(problem.Error())
+	}
+	return nil
+}
+
+// parseLimit reads a limit amount, 0 meaning no limit.
+func parseLimit(value string) (sepa.Amount, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return parseAmount(value)
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestCheckLimits(t *testing.T) {
+	transactions := []groupedTransaction{
+		{row: 1, transaction: &sepa.Transaction{Amount: 12346}},
+		{row: 2, transaction: &sepa.Transaction{Amount: 1234567}},
+	}
+
+	tests := []struct {
+		name      string
+		limits    LimitConfig
+		confirmed bool
+		errors    []string
+	}{
+		{"no limit", LimitConfig{}, false, nil},
+		{"under the limits", LimitConfig{Transaction: "20000", Batch: "20000"}, false, nil},
+		{
+			"over the limits", LimitConfig{Transaction: "1000", Batch: "10000"}, false,
+			[]string{
+				"amount 12345.67 on row 2 is over the 1000.00 transaction limit",
+				"total amount 12469.13 is over the 10000.00 batch limit",
+				"check the amounts and confirm them with the confirm-over-limit flag",
+			},
+		},
+		{"confirmed", LimitConfig{Transaction: "1000", Batch: "10000"}, true, nil},
+		{"invalid limit", LimitConfig{Transaction: "1 000"}, true, []string{"invalid transaction limit: '1 000' " +
+			"is not a positive amount with at most two decimals"}},
+	}
+
+	for _, test := range tests {
+		err := checkLimits(transactions, test.limits, test.confirmed)
+		if test.errors == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", test.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		if actual := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(actual, test.errors) {
+			t.Errorf("%s: errors mismatch. Got: %v, Want: %v", test.name, actual, test.errors)
+		}
+	}
+}
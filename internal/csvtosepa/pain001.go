@@ -0,0 +1,679 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// inputName returns the name of an input file to use in the messages.
+func inputName(dataPath string) string {
+	if dataPath == common.Stdin {
+		return "standard input"
+	}
+	return dataPath
+}
+
+// toPain001 converts CSV or XLSX files to pain 001.001.03 for money transfers.
+// The transactions of all the files are merged in one transfer initiation.
+func toPain001(flags Config, dataPaths ...string) error {
+	if flags.IDsCSV != "" && len(dataPaths) > 1 {
+		return errors.New("the generated IDs file can't be set with several input files")
+	}
+	stdinCount := 0
+	for _, dataPath := range dataPaths {
+		if dataPath == common.Stdin {
+			stdinCount++
+		}
+	}
+	if stdinCount > 1 {
+		return errors.New("the standard input can only be read once")
+	}
+
+	debtors, err := newDebtorProfiles(flags.Debtor, flags.Debtors, flags.DebtorProfile)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	executionDate, err := getExecutionDate(flags.ExecutionDate, flags.CSV.Date, now, flags.Instant)
+	if err != nil {
+		return err
+	}
+	// The batch ID is needed to generate the missing end to end IDs.
+	flags.BatchID = getBatchID(flags.BatchID, debtors.defaultDebtor.Name, now)
+	flagCurrency, err := parseCurrency(cmp.Or(flags.Currency, defaultCurrency))
+	if err != nil {
+		return err
+	}
+	parser := rowParser{
+		debtors: debtors, executionDate: executionDate, now: now, instant: flags.Instant, currency: flagCurrency,
+		dates: flags.CSV.Date,
+	}
+	if flags.Roster != "" {
+		if parser.roster, err = readRoster(flags.CSV.CSVParams, flags.Roster); err != nil {
+			return err
+		}
+	}
+
+	var transactions []groupedTransaction
+	var results []rowResult
+	for i, dataPath := range dataPaths {
+		// The files only need to be told apart when there are several of them.
+		fileNumber := 0
+		if len(dataPaths) > 1 {
+			fileNumber = i + 1
+		}
+		fileTransactions, fileResults, err := readTransactions(flags, dataPath, fileNumber, parser)
+		if err != nil {
+			return err
+		}
+		transactions = append(transactions, fileTransactions...)
+		results = append(results, fileResults...)
+	}
+
+	batchErr := errors.Join(
+		checkEndToEndIDs(transactions, flags.DedupIDs),
+		checkExpectedTotals(transactions, flags.ExpectedCount, flags.ExpectedTotal),
+	)
+	if flags.Check {
+		return writeCheckReport(os.Stdout, results, batchErr)
+	}
+
+	// Report all the problems at once rather than having the user fix them one by one.
+	var allErrors []error
+	for _, result := range results {
+		if result.err != nil {
+			location := rowLocation(result.row, result.source)
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid %s: %s"), location, result.err))
+		}
+	}
+	if err := errors.Join(append(allErrors, batchErr)...); err != nil {
+		return common.WithExitCode(common.ExitValidation, err)
+	}
+
+	return writeTransfers(flags, debtors.defaultDebtor, executionDate, transactions)
+}
+
+// readTransactions parses the rows of an input file.
+// A positive file number identifies the file in the messages, generated IDs and payments
+// when several files are merged.
+func readTransactions(
+	flags Config, dataPath string, fileNumber int, parser rowParser,
+) ([]groupedTransaction, []rowResult, error) {
+	reader, cleaner, err := common.GetRowReader(flags.CSV.CSVParams, dataPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV: %s", err)
+	}
+	defer cleaner()
+
+	source := ""
+	if fileNumber > 0 {
+		source = inputName(dataPath)
+	}
+
+	var transactions []groupedTransaction
+	var results []rowResult
+	var headerLen int
+	var raggedRows []int
+	var trailer []string
+	trailerRow := 0
+	for rowIndex := 0; ; rowIndex++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf(common.Tr("error parsing %s: %s"), inputName(dataPath), err)
+		}
+
+		if len(parser.header) == 0 {
+			parser.header, err = getCSVHeader(flags.CSV.Columns, record, parser.roster != nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf(common.Tr("invalid header in %s: %s"), inputName(dataPath), err)
+			}
+			headerLen = len(record)
+			continue
+		}
+
+		if record, err = common.TrimRow(record, headerLen); err != nil {
+			results = append(results, rowResult{row: rowIndex, source: source, err: err})
+			continue
+		}
+		var padded bool
+		if record, padded = common.PadRow(record, headerLen); padded {
+			raggedRows = append(raggedRows, rowIndex)
+		}
+
+		if parser.isTrailer(record, flags.CSV.Trailer) {
+			trailer, trailerRow = record, rowIndex
+			continue
+		}
+
+		item, err := parser.parse(rowIndex, record)
+		item.source = source
+		if flags.PaymentPerFile {
+			item.file = fileNumber
+		}
+		results = append(results, rowResult{row: rowIndex, source: source, err: err})
+		if err == nil {
+			transactions = append(transactions, item)
+		}
+	}
+	valid := !slices.ContainsFunc(results, func(result rowResult) bool { return result.err != nil })
+	// The total can only match if all the rows are valid.
+	if trailer != nil && valid {
+		err := parser.checkTrailer(trailer, transactions)
+		results = append(results, rowResult{row: trailerRow, source: source, err: err})
+		valid = err == nil
+	}
+
+	if _, found := parser.header[columnID]; !found && len(parser.header) > 0 {
+		slog.Info("no end to end ID column found, generating the IDs",
+			"column", flags.CSV.Columns.EndToEndID, "file", inputName(dataPath))
+		batchID := flags.BatchID
+		if fileNumber > 0 {
+			batchID = strings.TrimPrefix(fmt.Sprintf("%s/%d", batchID, fileNumber), "/")
+		}
+		generateEndToEndIDs(transactions, batchID)
+
+		// The IDs are only worth keeping if the SEPA file is written.
+		if !flags.Check && valid {
+			idsPath := getIDsCSVPath(flags.IDsCSV, dataPath)
+			if err := writeIDsCSV(idsPath, transactions); err != nil {
+				return nil, nil, err
+			}
+			slog.Info("the generated end to end IDs have been listed", "file", idsPath)
+		}
+	}
+
+	if len(raggedRows) > 0 {
+		slog.Warn("rows missing trailing fields have been padded with empty values",
+			"file", inputName(dataPath), "rows", raggedRows)
+	}
+	return transactions, results, nil
+}
+
+// writeTransfers groups the transactions in payments and writes the pain001 file.
+func writeTransfers(
+	flags Config, debtor *sepa.Party, executionDate time.Time, transactions []groupedTransaction,
+) error {
+	flags.Output = outputPath(flags.Output, flags.BatchID, time.Now())
+	if err := checkOutput(flags.Output, flags.Force); err != nil {
+		return err
+	}
+	if flags.AggregateByCreditor {
+		transactions = aggregateByCreditor(transactions)
+	}
+	if err := checkLimits(transactions, flags.Limit, flags.ConfirmOverLimit); err != nil {
+		return common.WithExitCode(common.ExitValidation, err)
+	}
+	var history []historyEntry
+	hash := batchHash(transactions)
+	if flags.HistoryFile != "" {
+		var err error
+		if history, err = readHistory(flags.HistoryFile); err != nil {
+			return err
+		}
+		if err := checkHistory(history, hash, time.Now(), flags.HistoryDays, flags.ConfirmDuplicate); err != nil {
+			return common.WithExitCode(common.ExitValidation, err)
+		}
+	}
+	chargeBearer, err := parseChargeBearer(flags.ChargeBearer)
+	if err != nil {
+		return err
+	}
+
+	transferInit := sepa.NewTransferInitiation(flags.BatchID, debtor)
+	transferInit.SetExecutionDate(executionDate)
+	transferInit.Instant = flags.Instant
+	transferInit.ChargeBearer = chargeBearer
+
+	payments := groupPayments(transactions, flags.MaxTransactions)
+	// Without default debtor, the organization initiating the transfers is the one of the first payment.
+	if transferInit.Initiator.Name == "" && len(payments) > 0 {
+		transferInit.Initiator = payments[0].Debtor
+	}
+	if len(payments) == 0 {
+		transferInit.AddPayment(&sepa.Payment{})
+	}
+	for _, payment := range payments {
+		transferInit.AddPayment(payment)
+	}
+
+	if flags.Preview {
+		if err := writePreview(os.Stderr, &transferInit); err != nil {
+			return err
+		}
+		if !flags.Yes && !common.Confirm(os.Stdin, os.Stderr, "Write the SEPA file?") {
+			return errors.New("the SEPA file has not been written")
+		}
+	}
+
+	// Render the whole file before writing it to avoid leaving a truncated XML in a pipe on failure.
+	var content bytes.Buffer
+	if err := transferInit.Write(&content); err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(content.Bytes())
+	wr, cleaner, err := getOutputWriter(flags)
+	defer cleaner()
+	if err != nil {
+		return err
+	}
+	if _, err := content.WriteTo(wr); err != nil {
+		return fmt.Errorf("failed to write the SEPA file: %s", err)
+	}
+	if flags.Checksum {
+		if err := writeChecksum(flags.Output, checksum); err != nil {
+			return err
+		}
+	}
+	if flags.HistoryFile != "" {
+		entry := historyEntry{time: time.Now(), hash: hash, batchID: flags.BatchID}
+		if err := recordBatch(flags.HistoryFile, history, entry, flags.HistoryDays); err != nil {
+			return err
+		}
+	}
+
+	if flags.SummaryCSV != "" {
+		if err := writeSummaryCSV(flags.SummaryCSV, &transferInit); err != nil {
+			return err
+		}
+	}
+	// The summary can't go to the standard output: it may be the SEPA file.
+	return writeSummary(os.Stderr, &transferInit, flags.Output)
+}
+
+const (
+	columnCreditor  = "Creditor"
+	columnIBAN      = "IBAN"
+	columnBIC       = "BIC"
+	columnID        = "EndToEndID"
+	columnInfo      = "Info"
+	columnsAmount   = "Amount"
+	columnDate      = "Date"
+	columnReference = "Reference"
+	columnGroup     = "Group"
+	columnDebtor    = "Debtor"
+	columnStreet    = "Street"
+	columnPostCode  = "PostCode"
+	columnCity      = "City"
+	columnCountry   = "Country"
+	columnCurrency  = "Currency"
+	// The ultimate parties are the ones on behalf of which the debtor pays or the creditor receives the money.
+	columnUltimateDebtor   = "UltimateDebtor"
+	columnUltimateCreditor = "UltimateCreditor"
+)
+
+// groupedTransaction is a transaction with the values defining its payment.
+type groupedTransaction struct {
+	row    int
+	source string
+	date   string
+	debtor *sepa.Party
+	// file is the number of the input file when the files have separate payments, 0 otherwise.
+	file        int
+	group       string
+	transaction *sepa.Transaction
+}
+
+// location describes the input row of the transaction in the messages.
+func (t groupedTransaction) location() string {
+	return rowLocation(t.row, t.source)
+}
+
+// rowLocation describes an input row in the messages.
+// The source file is only needed when several files are merged.
+func rowLocation(row int, source string) string {
+	if source == "" {
+		return fmt.Sprintf(common.Tr("row %d"), row)
+	}
+	return fmt.Sprintf(common.Tr("row %d of %s"), row, source)
+}
+
+// groupPayments puts the transactions in one payment per execution date, debtor, input file and group.
+// The payments are sorted by date, debtor IBAN, file and group and split to have at most maxTransactions
+// transactions if positive.
+func groupPayments(transactions []groupedTransaction, maxTransactions int) []*sepa.Payment {
+	type paymentKey struct {
+		date   string
+		debtor *sepa.Party
+		file   int
+		group  string
+	}
+	grouped := map[paymentKey]*sepa.Payment{}
+	var keys []paymentKey
+	for _, item := range transactions {
+		key := paymentKey{date: item.date, debtor: item.debtor, file: item.file, group: item.group}
+		payment, found := grouped[key]
+		if !found {
+			payment = &sepa.Payment{ExecutionDate: item.date, Debtor: item.debtor}
+			grouped[key] = payment
+			keys = append(keys, key)
+		}
+		payment.Transactions = append(payment.Transactions, item.transaction)
+	}
+
+	slices.SortStableFunc(keys, func(a, b paymentKey) int {
+		return cmp.Or(cmp.Compare(a.date, b.date), cmp.Compare(debtorIBAN(a.debtor), debtorIBAN(b.debtor)),
+			cmp.Compare(a.file, b.file), cmp.Compare(a.group, b.group))
+	})
+
+	var payments []*sepa.Payment
+	for _, key := range keys {
+		payment := grouped[key]
+		if maxTransactions <= 0 {
+			payments = append(payments, payment)
+			continue
+		}
+		for chunk := range slices.Chunk(payment.Transactions, maxTransactions) {
+			payments = append(payments, &sepa.Payment{
+				ExecutionDate: payment.ExecutionDate, Debtor: payment.Debtor, Transactions: chunk,
+			})
+		}
+	}
+	return payments
+}
+
+func debtorIBAN(debtor *sepa.Party) string {
+	if debtor == nil {
+		return ""
+	}
+	return debtor.IBAN
+}
+
+// getCSVHeader finds the index of the configured columns in the header record.
+// With a roster, the IBAN and BIC columns are optional: the rows without IBAN get it from the roster.
+func getCSVHeader(flags ColumnsConfig, record []string, withRoster bool) (map[string]int, error) {
+	var header = make(map[string]int)
+
+	columns := []string{columnCreditor, columnInfo, columnsAmount}
+	bankColumns := []string{columnIBAN, columnBIC}
+	if !withRoster {
+		columns = append(columns, bankColumns...)
+	}
+	flagsValue := reflect.ValueOf(flags)
+	for _, column := range bankColumns {
+		if idx := slices.Index(record, flagsValue.FieldByName(column).String()); withRoster && idx >= 0 {
+			header[column] = idx
+		}
+	}
+	for _, column := range columns {
+		csvName := flagsValue.FieldByName(column).String()
+		idx := slices.Index(record, csvName)
+		if idx < 0 {
+			return header, fmt.Errorf(common.Tr("column not found in CSV file: %s"), csvName)
+		}
+		header[column] = idx
+	}
+
+	// The end to end IDs are generated if there is no column for them.
+	if idx := slices.Index(record, flags.EndToEndID); idx >= 0 {
+		header[columnID] = idx
+	}
+
+	// The optional columns are only looked for if configured.
+	for _, column := range []string{
+		columnDate, columnReference, columnGroup, columnDebtor, columnStreet, columnPostCode, columnCity, columnCountry,
+		columnCurrency, columnUltimateDebtor, columnUltimateCreditor,
+	} {
+		csvName := flagsValue.FieldByName(column).String()
+		if csvName == "" {
+			continue
+		}
+		idx := slices.Index(record, csvName)
+		if idx < 0 {
+			return header, fmt.Errorf(common.Tr("column not found in CSV file: %s"), csvName)
+		}
+		header[column] = idx
+	}
+
+	return header, nil
+}
+
+// outputFileChars matches the characters of the batch IDs to replace in the output file names.
+var outputFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// outputPath replaces the {{date}} and {{batchid}} placeholders of the output file name template.
+func outputPath(template string, batchID string, now time.Time) string {
+	return strings.NewReplacer(
+		"{{date}}", now.Format("2006-01-02"),
+		"{{batchid}}", outputFileChars.ReplaceAllString(batchID, "_"),
+	).Replace(template)
+}
+
+// checkOutput ensures that an existing SEPA file is only overwritten if forced.
+// Overwriting a file that may not have been sent to the bank yet would lose its transfers.
+func checkOutput(path string, force bool) error {
+	if path == "" || path == "-" || force {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf(common.Tr("the SEPA file %s already exists, use the force flag to overwrite it"), path)
+	}
+	return nil
+}
+
+// writeChecksum writes the SHA-256 checksum of the SEPA file next to it in the sha256sum format.
+func writeChecksum(path string, checksum [sha256.Size]byte) error {
+	if path == "" || path == "-" {
+		return errors.New("no checksum file can be written for the standard output")
+	}
+	content := fmt.Sprintf("%s  %s\n", hex.EncodeToString(checksum[:]), filepath.Base(path))
+	if err := os.WriteFile(path+".sha256", []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write the checksum file: %s", err)
+	}
+	return nil
+}
+
+// getOutputWriter opens the SEPA file to write, or the standard output if the output is empty or -.
+func getOutputWriter(flags Config) (io.Writer, func(), error) {
+	if flags.Output == "" || flags.Output == "-" {
+		return os.Stdout, func() {}, nil
+	}
+	f, err := os.Create(flags.Output)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	return f, func() { _ = f.Close() }, nil
+}
+
+// non breaking spaces and friends are hard to spot: replace them all!
+var whitespaces = regexp.MustCompile(`[\p{Zs}]+`)
+
+func sanitizeID(id string) string {
+	return whitespaces.ReplaceAllString(id, "")
+}
+
+var amountRegex = regexp.MustCompile(`^(\d+)(?:\.(\d{1,2}))?$`)
+
+// parseAmount reads a positive amount with at most two decimals without going through floats.
+func parseAmount(value string) (sepa.Amount, error) {
+	matches := amountRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return 0, fmt.Errorf(common.Tr("'%s' is not a positive amount with at most two decimals"), value)
+	}
+	units, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	cents, _ := strconv.Atoi((matches[2] + "00")[:2])
+	return sepa.Amount(units*100 + int64(cents)), nil
+}
+
+var bicRegex = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// validateBIC checks the format of a BIC: 4 letters for the bank, 2 for the country,
+// 2 letters or digits for the location and an optional 3 characters branch code.
+// An empty BIC is valid since it is optional for SEPA transfers.
+func validateBIC(bic string) error {
+	if bic != "" && !bicRegex.MatchString(bic) {
+		return fmt.Errorf(common.Tr("'%s' is not an 8 or 11 characters BIC"), bic)
+	}
+	return nil
+}
+
+var creditorReferenceRegex = regexp.MustCompile(`^RF[0-9]{2}[A-Z0-9]{1,21}$`)
+
+// parseCreditorReference normalizes and validates an ISO 11649 creditor reference like RF18 5390 0754 7034.
+func parseCreditorReference(value string) (string, error) {
+	reference := strings.ToUpper(sanitizeID(value))
+	if reference == "" {
+		return "", nil
+	}
+	if !creditorReferenceRegex.MatchString(reference) {
+		return "", fmt.Errorf(common.Tr("'%s' is not an ISO 11649 RF reference"), value)
+	}
+
+	if !hasValidCheckDigits(reference) {
+		return "", fmt.Errorf(common.Tr("'%s' has invalid check digits"), value)
+	}
+	return reference, nil
+}
+
+// defaultCurrency is the currency of the amounts when none is configured.
+const defaultCurrency = "EUR"
+
+// parseCurrency validates an ISO 4217 currency code.
+func parseCurrency(value string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if _, err := currency.ParseISO(code); err != nil {
+		return "", fmt.Errorf(common.Tr("%s is not an ISO 4217 currency code"), value)
+	}
+	return code, nil
+}
+
+// chargeBearers are the allowed charge bearer codes.
+var chargeBearers = []string{"SLEV", "DEBT", "CRED", "SHAR"}
+
+// parseChargeBearer validates the charge bearer code, defaulting to SLEV.
+func parseChargeBearer(value string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if code == "" {
+		return "SLEV", nil
+	}
+	if !slices.Contains(chargeBearers, code) {
+		return "", fmt.Errorf(
+			common.Tr("invalid charge bearer %s, expected one of %s"), value, strings.Join(chargeBearers, ", "),
+		)
+	}
+	return code, nil
+}
+
+var countryRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// parseAddress sanitizes and validates a postal address.
+// The address is optional, but needs at least the town and country when any of its fields is set.
+func parseAddress(street, postCode, city, country string) (sepa.PostalAddress, error) {
+	var allErrors []error
+	var address sepa.PostalAddress
+	var err error
+
+	if address.Street, err = sanitizeString(strings.TrimSpace(street), 70); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid street: %s"), err))
+	}
+	if address.PostCode, err = sanitizeString(strings.TrimSpace(postCode), 16); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid postal code: %s"), err))
+	}
+	if address.City, err = sanitizeString(strings.TrimSpace(city), 35); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid town: %s"), err))
+	}
+	address.Country = strings.ToUpper(strings.TrimSpace(country))
+	if address == (sepa.PostalAddress{}) {
+		return address, errors.Join(allErrors...)
+	}
+
+	if address.City == "" {
+		allErrors = append(allErrors, errors.New(common.Tr("missing town in the address")))
+	}
+	if !countryRegex.MatchString(address.Country) {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("'%s' is not a 2 letters ISO 3166 country code"), country))
+	}
+	return address, errors.Join(allErrors...)
+}
+
+var ibanRegex = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+
+// validateIBAN checks the format and check digits of an IBAN.
+func validateIBAN(iban string) error {
+	if !ibanRegex.MatchString(iban) {
+		return fmt.Errorf(common.Tr("'%s' is not an IBAN"), iban)
+	}
+	if !hasValidCheckDigits(iban) {
+		return fmt.Errorf(common.Tr("'%s' has invalid check digits"), iban)
+	}
+	return nil
+}
+
+// hasValidCheckDigits computes the ISO 7064 MOD 97-10 check used by IBANs and creditor references.
+// The check digits are valid if the value, with the first 4 characters moved at the end
+// and the letters replaced by numbers starting from A = 10, modulo 97 is 1.
+func hasValidCheckDigits(value string) bool {
+	remainder := 0
+	for _, char := range value[4:] + value[:4] {
+		if char >= 'A' && char <= 'Z' {
+			remainder = (remainder*100 + int(char-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(char-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+var invalidString = regexp.MustCompile("[^a-zA-Z0-9/?:().,'+ -]")
+
+// sanitizeString removes the accents and checks that the string only has the characters allowed in SEPA files.
+func sanitizeString(in string, maxLen int) (string, error) {
+	result := removeAccents(in)
+
+	if invalidString.MatchString(result) {
+		return result, fmt.Errorf(
+			common.Tr("string can only contain unaccented letter, digits and /-?:().,'+: '%s'"), result,
+		)
+	}
+
+	if len(result) > maxLen {
+		return result, fmt.Errorf(common.Tr("string cannot contain more than %d characters: '%s'"), maxLen, result)
+	}
+	return result, nil
+}
+
+// cleanString removes the accents, replaces the characters not allowed in SEPA files with spaces
+// and truncates the result to maxLen characters.
+func cleanString(in string, maxLen int) string {
+	result := strings.TrimSpace(invalidString.ReplaceAllString(removeAccents(in), " "))
+	// Only ASCII characters are left: bytes and characters are the same.
+	return strings.TrimSpace(result[:min(len(result), maxLen)])
+}
+
+func removeAccents(in string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, _ := transform.String(t, in)
+	return result
+}
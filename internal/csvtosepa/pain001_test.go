@@ -0,0 +1,1351 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"github.com/xuri/excelize/v2"
+)
+
+// setupIntegrationTest creates the necessary temporary files and returns their paths.
+func setupIntegrationTest(
+	t *testing.T, csvContent, outputFileName string,
+) (csvPath string, outPath string, cleanup func()) {
+	tempDir, err := os.MkdirTemp("", "sepa_test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	csvPath = filepath.Join(tempDir, "data.csv")
+	outPath = filepath.Join(tempDir, outputFileName)
+
+	if err := os.WriteFile(csvPath, []byte(csvContent), 0644); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+
+	cleanup = func() {
+		_ = os.RemoveAll(tempDir)
+	}
+
+	return csvPath, outPath, cleanup
+}
+
+// sanitizeXML removes dynamic timestamps and formatting for stable comparison.
+func sanitizeXML(xmlContent string) string {
+	// Remove CreDtTm (Creation Date/Time)
+	xmlContent = regexp.MustCompile(`<CreDtTm>.*?</CreDtTm>`).ReplaceAllString(xmlContent, `<CreDtTm>TIMESTAMP</CreDtTm>`)
+
+	// Remove ReqdExctnDt (Execution Date) which is dynamic (usually today's date)
+	xmlContent = regexp.MustCompile(`<ReqdExctnDt>.*?</ReqdExctnDt>`).ReplaceAllString(xmlContent, `<ReqdExctnDt>{{ ExecutionDate }}</ReqdExctnDt>`)
+
+	// Remove all non-essential whitespace for reliable comparison
+	xmlContent = strings.ReplaceAll(xmlContent, " ", "")
+	xmlContent = strings.ReplaceAll(xmlContent, "\n", "")
+	xmlContent = strings.ReplaceAll(xmlContent, "\r", "")
+	xmlContent = strings.ReplaceAll(xmlContent, "\t", "")
+
+	return xmlContent
+}
+
+func TestIntegration_SimpleTransfer(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,DPYCNL539SF,123.45,"payment for xxx"
+"payment yyy",Joe Tester,FR69 2004 1010 0569 2744 6332 670,KGJW GIOYXXX,12345.67,"payment for yyy"`
+
+	expectedXML := `<?xml version="1.0" encoding="utf-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 pain.001.001.03.xsd">
+    <CstmrCdtTrfInitn>
+        <GrpHdr>
+            <MsgId>batch/1</MsgId>
+            <CreDtTm>TIMESTAMP</CreDtTm>
+            <NbOfTxs>2</NbOfTxs>
+            <CtrlSum>12469.12</CtrlSum>
+            <InitgPty>
+                <Nm>Issuer</Nm>
+            </InitgPty>
+        </GrpHdr>
+        <PmtInf>
+            <PmtInfId>batch/1/1</PmtInfId>
+            <PmtMtd>TRF</PmtMtd>
+            <BtchBookg>false</BtchBookg>
+            <NbOfTxs>2</NbOfTxs>
+            <CtrlSum>12469.12</CtrlSum>
+            <ReqdExctnDt>{{ ExecutionDate }}</ReqdExctnDt>
+            <Dbtr>
+                <Nm>Issuer</Nm>
+            </Dbtr>
+            <DbtrAcct>
+                <Id>
+                    <IBAN>FR7420041010058652109911007</IBAN>
+                </Id>
+            </DbtrAcct>
+            <DbtrAgt>
+                <FinInstnId>
+                    <BIC>PMXNCXV94RH</BIC>
+                </FinInstnId>
+            </DbtrAgt>
+            <CdtTrfTxInf>
+                <PmtId>
+                    <EndToEndId>payment xxx</EndToEndId>
+                </PmtId>
+                <Amt>
+                    <InstdAmt Ccy="EUR">123.45</InstdAmt>
+                </Amt>
+                <ChrgBr>SLEV</ChrgBr>
+                <CdtrAgt>
+                    <FinInstnId>
+                        <BIC>DPYCNL539SF</BIC>
+                    </FinInstnId>
+                </CdtrAgt>
+                <Cdtr>
+                    <Nm>John Doe</Nm>
+                </Cdtr>
+                <CdtrAcct>
+                    <Id>
+                        <IBAN>FR5120041010051631529138143</IBAN>
+                    </Id>
+                </CdtrAcct>
+                <Purp>
+                    <Cd>REFU</Cd>
+                </Purp>
+                <RmtInf>
+                    <Ustrd>payment for xxx</Ustrd>
+                </RmtInf>
+            </CdtTrfTxInf>
+            <CdtTrfTxInf>
+                <PmtId>
+                    <EndToEndId>payment yyy</EndToEndId>
+                </PmtId>
+                <Amt>
+                    <InstdAmt Ccy="EUR">12345.67</InstdAmt>
+                </Amt>
+                <ChrgBr>SLEV</ChrgBr>
+                <CdtrAgt>
+                    <FinInstnId>
+                        <BIC>KGJWGIOYXXX</BIC>
+                    </FinInstnId>
+                </CdtrAgt>
+                <Cdtr>
+                    <Nm>Joe Tester</Nm>
+                </Cdtr>
+                <CdtrAcct>
+                    <Id>
+                        <IBAN>FR6920041010056927446332670</IBAN>
+                    </Id>
+                </CdtrAcct>
+                <Purp>
+                    <Cd>REFU</Cd>
+                </Purp>
+                <RmtInf>
+                    <Ustrd>payment for yyy</Ustrd>
+                </RmtInf>
+            </CdtTrfTxInf>
+            </PmtInf>
+    </CstmrCdtTrfInitn>
+</Document>`
+
+	// Parameters parsed into Config struct
+	cfg := Config{
+		BatchID: "batch/1",
+		Debtor: sepa.Party{
+			Name: "Issuer",
+			IBAN: "FR7420041010058652109911007",
+			BIC:  "PMXNCXV94RH",
+		},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor:   "creditor",
+				IBAN:       "iban",
+				BIC:        "bic",
+				EndToEndID: "id",
+				Amount:     "amount",
+				Info:       "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+
+	// Set the correct output path in the configuration
+	cfg.Output = outPath
+
+	// Execute the tool's core logic
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+
+	// Read the generated output
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	sanitizedGenerated := sanitizeXML(string(generatedData))
+	sanitizedExpected := sanitizeXML(string(expectedXML))
+
+	if sanitizedGenerated != sanitizedExpected {
+		t.Errorf("Generated XML does not match expected XML.")
+		t.Logf("--- Expected (Sanitized) ---\n%s", sanitizedExpected)
+		t.Logf("--- Got (Sanitized) ---\n%s", sanitizedGenerated)
+	}
+}
+
+// nextOpenDay returns the first day after the date on which TARGET2 is open.
+func nextOpenDay(date time.Time) time.Time {
+	date = date.AddDate(0, 0, 1)
+	for isTarget2Closed(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+func TestIntegration_ExecutionDates(t *testing.T) {
+	first := nextOpenDay(time.Now()).Format(dateLayout)
+	second := nextOpenDay(nextOpenDay(time.Now())).Format(dateLayout)
+
+	csvInput := `id,creditor,iban,bic,amount,info,date
+"payment xxx",John Doe,FR5120041010051631529138143,DPYCNL539SF,123.45,"payment for xxx",` + second + `
+"payment yyy",Joe Tester,FR6920041010056927446332670,KGJWGIOYXXX,10,"payment for yyy",` + first + `
+"payment zzz",Jane Tester,FR6920041010056927446332670,KGJWGIOYXXX,20,"payment for zzz",` + second + `
+"payment www",Jim Tester,FR6920041010056927446332670,KGJWGIOYXXX,30,"payment for www",`
+
+	cfg := Config{
+		BatchID:       "batch",
+		ExecutionDate: first,
+		Debtor:        sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor:   "creditor",
+				IBAN:       "iban",
+				BIC:        "bic",
+				EndToEndID: "id",
+				Amount:     "amount",
+				Info:       "info",
+				Date:       "date",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+	// Keep the execution dates that sanitizeXML would hide.
+	generated := regexp.MustCompile(`\s+`).ReplaceAllString(string(generatedData), "")
+
+	payments := regexp.MustCompile(
+		`<PmtInfId>(.*?)</PmtInfId>.*?<NbOfTxs>(\d+)</NbOfTxs><CtrlSum>(.*?)</CtrlSum><ReqdExctnDt>(.*?)</ReqdExctnDt>`,
+	).FindAllStringSubmatch(generated, -1)
+	var actual []string
+	for _, payment := range payments {
+		actual = append(actual, strings.Join(payment[1:], " "))
+	}
+
+	expected := []string{
+		"batch/1 2 40.00 " + first,
+		"batch/2 2 143.45 " + second,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Payments mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestGetCSVHeaderMissingDate(t *testing.T) {
+	columns := ColumnsConfig{
+		Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info", Date: "date",
+	}
+	if _, err := getCSVHeader(columns, []string{"id", "creditor", "iban", "bic", "amount", "info"}, false); err == nil {
+		t.Error("Expected an error for the missing date column")
+	}
+
+	columns.Date = ""
+	header, err := getCSVHeader(columns, []string{"id", "creditor", "iban", "bic", "amount", "info"}, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, found := header[columnDate]; found {
+		t.Error("Unexpected date column in the header")
+	}
+}
+
+func TestValidateBIC(t *testing.T) {
+	tests := []struct {
+		bic   string
+		valid bool
+	}{
+		{"", true},
+		{"DPYCNL53", true},
+		{"KGJWGIOYXXX", true},
+		{"DPYCNL539SF", true},
+		{"DPYCNL5", false},
+		{"DPYCNL539", false},
+		{"DPYCNL539SFX", false},
+		{"1PYCNL53", false},
+		{"DPYC1L53", false},
+	}
+
+	for _, test := range tests {
+		err := validateBIC(test.bic)
+		if (err == nil) != test.valid {
+			t.Errorf("BIC %s validity mismatch. Got: %v, Want: %v", test.bic, err == nil, test.valid)
+		}
+	}
+}
+
+func TestIntegration_OptionalBIC(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"
+"payment yyy",Joe Tester,FR6920041010056927446332670,kgjwgioyxxx,10,"payment for yyy"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	generated := sanitizeXML(string(generatedData))
+	agents := regexp.MustCompile(`<CdtrAgt>.*?<BIC>(.*?)</BIC>`).FindAllStringSubmatch(generated, -1)
+	if len(agents) != 1 || agents[0][1] != "KGJWGIOYXXX" {
+		t.Errorf("Creditor agents mismatch. Got: %v, Want: only KGJWGIOYXXX", agents)
+	}
+}
+
+func TestIntegration_InvalidBIC(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,FAKEBIC,123.45,"payment for xxx"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err == nil {
+		t.Error("Expected an error for the invalid BIC")
+	}
+}
+
+func TestParseCreditorReference(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{"", "", false},
+		{"RF18 5390 0754 7034", "RF18539007547034", false},
+		{"rf18000000000539007547034", "RF18000000000539007547034", false},
+		{"RF38 A7X 3B", "RF38A7X3B", false},
+		{"RF19 5390 0754 7034", "", true},
+		{"FR18 5390 0754 7034", "", true},
+		{"RF18 5390 0754 7034 0000 0000 0000", "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := parseCreditorReference(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for %s", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", test.value, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Reference mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_CreditorReference(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info,reference
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx",RF18 5390 0754 7034
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy",`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				Reference: "reference",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	generated := sanitizeXML(string(generatedData))
+	actual := regexp.MustCompile(`<RmtInf>(.*?)</RmtInf>`).FindAllStringSubmatch(generated, -1)
+	expected := []string{
+		"<Strd><CdtrRefInf><Tp><CdOrPrtry><Cd>SCOR</Cd></CdOrPrtry><Issr>ISO</Issr></Tp>" +
+			"<Ref>RF18539007547034</Ref></CdtrRefInf></Strd>",
+		"<Ustrd>paymentforyyy</Ustrd>",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Remittance information count mismatch. Got: %d, Want: %d", len(actual), len(expected))
+	}
+	for i, info := range actual {
+		if info[1] != expected[i] {
+			t.Errorf("Remittance information mismatch. Got: %s, Want: %s", info[1], expected[i])
+		}
+	}
+}
+
+func TestGroupPayments(t *testing.T) {
+	transactions := []groupedTransaction{
+		{date: "2025-04-15", group: "", transaction: &sepa.Transaction{EndToEndID: "1"}},
+		{date: "2025-04-14", group: "b", transaction: &sepa.Transaction{EndToEndID: "2"}},
+		{date: "2025-04-14", group: "a", transaction: &sepa.Transaction{EndToEndID: "3"}},
+		{date: "2025-04-14", group: "b", transaction: &sepa.Transaction{EndToEndID: "4"}},
+		{date: "2025-04-14", group: "b", transaction: &sepa.Transaction{EndToEndID: "5"}},
+		{date: "2025-04-14", group: "a", file: 1, transaction: &sepa.Transaction{EndToEndID: "6"}},
+	}
+
+	tests := []struct {
+		maxTransactions int
+		expected        []string
+	}{
+		{0, []string{"2025-04-14: 3", "2025-04-14: 2 4 5", "2025-04-14: 6", "2025-04-15: 1"}},
+		{2, []string{"2025-04-14: 3", "2025-04-14: 2 4", "2025-04-14: 5", "2025-04-14: 6", "2025-04-15: 1"}},
+	}
+
+	for _, test := range tests {
+		var actual []string
+		for _, payment := range groupPayments(transactions, test.maxTransactions) {
+			ids := make([]string, 0, len(payment.Transactions))
+			for _, transaction := range payment.Transactions {
+				ids = append(ids, transaction.EndToEndID)
+			}
+			actual = append(actual, payment.ExecutionDate+": "+strings.Join(ids, " "))
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Payments mismatch for max %d. Got: %v, Want: %v", test.maxTransactions, actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_DebtorProfiles(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info,account
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx",ASC
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy",FON
+"payment zzz",Jane Tester,FR6920041010056927446332670,,20,"payment for zzz",asc`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtors: map[string]sepa.Party{
+			"asc": {Name: "CSE ASC", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+			"fon": {Name: "CSE FON", IBAN: "FR7630006000011234567890189"},
+		},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				Debtor: "account",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+	generated := sanitizeXML(string(generatedData))
+
+	initiator := regexp.MustCompile(`<InitgPty><Nm>(.*?)</Nm>`).FindStringSubmatch(generated)
+	if len(initiator) < 2 || initiator[1] != "CSEASC" {
+		t.Errorf("Initiator mismatch. Got: %v, Want: CSEASC", initiator)
+	}
+
+	paymentRegex := regexp.MustCompile(`<NbOfTxs>(\d+)</NbOfTxs><CtrlSum>[^<]*</CtrlSum>` +
+		`<ReqdExctnDt>.*?</ReqdExctnDt><Dbtr><Nm>(.*?)</Nm></Dbtr><DbtrAcct><Id><IBAN>(.*?)</IBAN>`)
+	payments := paymentRegex.FindAllStringSubmatch(generated, -1)
+	var actual []string
+	for _, payment := range payments {
+		actual = append(actual, strings.Join(payment[1:], " "))
+	}
+	expected := []string{"2 CSEASC FR7420041010058652109911007", "1 CSEFON FR7630006000011234567890189"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Payments mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{"12.10", "12.10", false},
+		{"12.1", "12.10", false},
+		{"12", "12.00", false},
+		{" 0.05 ", "0.05", false},
+		{"12345.67", "12345.67", false},
+		{"12.345", "", true},
+		{"-12.10", "", true},
+		{"12,10", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		amount, err := parseAmount(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for '%s'", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for '%s': %s", test.value, err)
+			continue
+		}
+		if amount.String() != test.expected {
+			t.Errorf("Amount mismatch for '%s'. Got: %s, Want: %s", test.value, amount, test.expected)
+		}
+	}
+}
+
+func TestIntegration_GeneratedIDs(t *testing.T) {
+	csvInput := `creditor,iban,bic,amount,info
+John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"
+Joe Tester,FR6920041010056927446332670,,10,"payment for yyy"`
+
+	cfg := Config{
+		BatchID: "BATCH/2025-06",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	ids := regexp.MustCompile(`<EndToEndId>(.*?)</EndToEndId>`).FindAllStringSubmatch(string(generatedData), -1)
+	var actual []string
+	for _, id := range ids {
+		actual = append(actual, id[1])
+	}
+	expected := []string{"BATCH/2025-06/001", "BATCH/2025-06/002"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("IDs mismatch. Got: %v, Want: %v", actual, expected)
+	}
+
+	idsData, err := os.ReadFile(getIDsCSVPath("", csvPath))
+	if err != nil {
+		t.Fatalf("failed to read the IDs file: %v", err)
+	}
+	expectedIDs := "row,id,creditor,amount\n1,BATCH/2025-06/001,John Doe,123.45\n2,BATCH/2025-06/002,Joe Tester,10.00\n"
+	if string(idsData) != expectedIDs {
+		t.Errorf("IDs file mismatch. Got: %q, Want: %q", string(idsData), expectedIDs)
+	}
+}
+
+func TestIntegration_Check(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"
+"payment yyy",Joe Tester,FR6920041010056927446332671,,10,"payment for yyy"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Check:   true,
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err == nil {
+		t.Error("Expected an error for the invalid IBAN")
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Errorf("No SEPA file should be written in check mode, got: %v", err)
+	}
+}
+
+func TestIntegration_XLSX(t *testing.T) {
+	tempDir := t.TempDir()
+	xlsxPath := filepath.Join(tempDir, "data.xlsx")
+	outPath := filepath.Join(tempDir, "output.xml")
+
+	file := excelize.NewFile()
+	defer func() { _ = file.Close() }()
+	rows := [][]any{
+		{"id", "creditor", "iban", "bic", "amount", "info"},
+		{"payment xxx", "John Doe", "FR5120041010051631529138143", "DPYCNL539SF", 123.45, "payment for xxx"},
+		{"payment yyy", "Joe Tester", "FR6920041010056927446332670", "", 10, "payment for yyy"},
+	}
+	for i, row := range rows {
+		if err := file.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+1), &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.SaveAs(xlsxPath); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{
+		BatchID: "batch",
+		Output:  outPath,
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+	if err := toPain001(cfg, xlsxPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	amounts := regexp.MustCompile(`<InstdAmt Ccy="EUR">(.*?)</InstdAmt>`).FindAllStringSubmatch(string(generatedData), -1)
+	var actual []string
+	for _, amount := range amounts {
+		actual = append(actual, amount[1])
+	}
+	expected := []string{"123.45", "10.00"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Amounts mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestIntegration_SeveralFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	firstPath := filepath.Join(tempDir, "first.csv")
+	secondPath := filepath.Join(tempDir, "second.csv")
+	outPath := filepath.Join(tempDir, "output.xml")
+
+	first := "creditor,iban,bic,amount,info\nJohn Doe,FR5120041010051631529138143,,123.45,payment for xxx\n"
+	second := "creditor,iban,bic,amount,info\nJoe Tester,FR6920041010056927446332670,,10,payment for yyy\n"
+	if err := os.WriteFile(firstPath, []byte(first), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(secondPath, []byte(second), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		perFile  bool
+		payments int
+	}{
+		{false, 1},
+		{true, 2},
+	}
+
+	for _, test := range tests {
+		cfg := Config{
+			BatchID:        "batch",
+			Output:         outPath,
+			Force:          true,
+			PaymentPerFile: test.perFile,
+			Debtor:         sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+			CSV: CsvConfig{
+				Columns: ColumnsConfig{
+					Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				},
+			},
+		}
+		if err := toPain001(cfg, firstPath, secondPath); err != nil {
+			t.Fatalf("toPain001 failed: %v", err)
+		}
+		generatedData, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatalf("failed to read generated output: %v", err)
+		}
+		generated := string(generatedData)
+
+		ids := regexp.MustCompile(`<EndToEndId>(.*?)</EndToEndId>`).FindAllStringSubmatch(generated, -1)
+		var actual []string
+		for _, id := range ids {
+			actual = append(actual, id[1])
+		}
+		expected := []string{"batch/1/001", "batch/2/001"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("IDs mismatch. Got: %v, Want: %v", actual, expected)
+		}
+		if payments := strings.Count(generated, "<PmtInf>"); payments != test.payments {
+			t.Errorf("Payments count mismatch with per file %t. Got: %d, Want: %d", test.perFile, payments, test.payments)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "second-ids.csv")); err != nil {
+		t.Errorf("Expected an IDs file for the second file: %s", err)
+	}
+
+	cfg := Config{
+		IDsCSV: filepath.Join(tempDir, "ids.csv"),
+		CSV:    CsvConfig{Columns: ColumnsConfig{Creditor: "creditor", IBAN: "iban", BIC: "bic", Amount: "amount"}},
+	}
+	if err := toPain001(cfg, firstPath, secondPath); err == nil {
+		t.Error("Expected an error for the IDs file with several input files")
+	}
+}
+
+func TestIntegration_AllRowErrors(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,12.345,"payment for xxx"
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy"
+"payment xxx",Jöe Tester,FR6920041010056927446332671,,10,"payment for zzz"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	err := toPain001(cfg, csvPath)
+	if err == nil {
+		t.Fatal("Expected an error for the invalid rows")
+	}
+	expected := []string{
+		"invalid row 1: invalid amount: '12.345' is not a positive amount with at most two decimals",
+		"invalid row 3: invalid IBAN: 'FR6920041010056927446332671' has invalid check digits",
+	}
+	if actual := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Errors mismatch. Got: %v, Want: %v", actual, expected)
+	}
+	if _, err := os.Stat(outPath); err == nil {
+		t.Error("The SEPA file should not be written with invalid rows")
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		fields   [4]string
+		expected sepa.PostalAddress
+		err      bool
+	}{
+		{[4]string{}, sepa.PostalAddress{}, false},
+		{
+			[4]string{" 12 rue de l'Église ", "75001", "Paris", "fr"},
+			sepa.PostalAddress{Street: "12 rue de l'Eglise", PostCode: "75001", City: "Paris", Country: "FR"},
+			false,
+		},
+		{[4]string{"", "", "Berlin", "DE"}, sepa.PostalAddress{City: "Berlin", Country: "DE"}, false},
+		{[4]string{"12 rue de la Paix", "", "", "FR"}, sepa.PostalAddress{}, true},
+		{[4]string{"", "", "Paris", "France"}, sepa.PostalAddress{}, true},
+		{[4]string{"", "", "Paris", ""}, sepa.PostalAddress{}, true},
+	}
+
+	for _, test := range tests {
+		actual, err := parseAddress(test.fields[0], test.fields[1], test.fields[2], test.fields[3])
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for address %v", test.fields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for address %v: %s", test.fields, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Address mismatch. Got: %+v, Want: %+v", actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_Address(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info,street,postcode,city,country
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx",12 rue de la Paix,75002,Paris,FR
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy",,,,`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				Street: "street", PostCode: "postcode", City: "city", Country: "country",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	generated := sanitizeXML(string(generatedData))
+	addresses := regexp.MustCompile(`<PstlAdr>.*?</PstlAdr>`).FindAllString(generated, -1)
+	expected := []string{
+		"<PstlAdr><StrtNm>12ruedelaPaix</StrtNm><PstCd>75002</PstCd><TwnNm>Paris</TwnNm><Ctry>FR</Ctry></PstlAdr>",
+	}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("Addresses mismatch. Got: %v, Want: %v", addresses, expected)
+	}
+}
+
+func TestIntegration_Instant(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	for _, instant := range []bool{false, true} {
+		cfg := Config{
+			BatchID: "batch",
+			Instant: instant,
+			Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+			CSV: CsvConfig{
+				Columns: ColumnsConfig{
+					Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				},
+			},
+		}
+
+		csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+		defer cleanup()
+		cfg.Output = outPath
+
+		if err := toPain001(cfg, csvPath); err != nil {
+			t.Fatalf("toPain001 failed: %v", err)
+		}
+		generatedData, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatalf("failed to read generated output: %v", err)
+		}
+
+		generated := sanitizeXML(string(generatedData))
+		paymentType := "<PmtTpInf><SvcLvl><Cd>SEPA</Cd></SvcLvl><LclInstrm><Cd>INST</Cd></LclInstrm></PmtTpInf>"
+		if strings.Contains(generated, paymentType) != instant {
+			t.Errorf("Instant payment type mismatch. Got: %s, Want instant: %t", generated, instant)
+		}
+	}
+}
+
+func TestParseChargeBearer(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{"", "SLEV", false},
+		{"SLEV", "SLEV", false},
+		{" shar ", "SHAR", false},
+		{"DEBT", "DEBT", false},
+		{"OUR", "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := parseChargeBearer(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for %s", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", test.value, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Charge bearer mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_ChargeBearer(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	cfg := Config{
+		BatchID:      "batch",
+		ChargeBearer: "cred",
+		Debtor:       sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	if generated := sanitizeXML(string(generatedData)); !strings.Contains(generated, "<ChrgBr>CRED</ChrgBr>") {
+		t.Errorf("Charge bearer mismatch. Got: %s, Want: CRED", generated)
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{"EUR", "EUR", false},
+		{" chf ", "CHF", false},
+		{"GBP", "GBP", false},
+		{"", "", true},
+		{"EURO", "", true},
+		{"ABC", "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := parseCurrency(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for %s", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", test.value, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Currency mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_Currency(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info,currency
+"payment 1",John Doe,FR5120041010051631529138143,,123.45,"payment 1",
+"payment 2",Jane Doe,FR5120041010051631529138143,,10,"payment 2",gbp`
+
+	cfg := Config{
+		BatchID:  "batch",
+		Currency: "CHF",
+		Debtor:   sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				Currency: "currency",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	generated := sanitizeXML(string(generatedData))
+	for _, amount := range []string{`<InstdAmtCcy="CHF">123.45</InstdAmt>`, `<InstdAmtCcy="GBP">10.00</InstdAmt>`} {
+		if !strings.Contains(generated, amount) {
+			t.Errorf("Amount mismatch. Got: %s, Want: %s", generated, amount)
+		}
+	}
+
+	cfg.Instant = true
+	expected := "invalid row 1: invalid currency: instant transfers are only in EUR"
+	if err := toPain001(cfg, csvPath); err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Instant error mismatch. Got: %v, Want: %s", err, expected)
+	}
+}
+
+func TestIntegration_Stdin(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	file, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	stdin := os.Stdin
+	os.Stdin = file
+	defer func() { os.Stdin = stdin }()
+
+	if err := toPain001(cfg, "-", "-"); err == nil {
+		t.Error("Expected an error when reading the standard input twice")
+	}
+	if err := toPain001(cfg, "-"); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+	generated := sanitizeXML(string(generatedData))
+	if !strings.Contains(generated, "<EndToEndId>paymentxxx</EndToEndId>") {
+		t.Errorf("Transaction mismatch. Got: %s", generated)
+	}
+}
+
+func TestIntegration_UltimateParties(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info,on behalf of,for
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx",Section Judo,Léo Doe
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy",,`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				UltimateDebtor: "on behalf of", UltimateCreditor: "for",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+
+	generated := sanitizeXML(string(generatedData))
+	parties := regexp.MustCompile(`<Ultmt(Dbtr|Cdtr)>.*?</Ultmt(Dbtr|Cdtr)>`).FindAllString(generated, -1)
+	expected := []string{
+		"<UltmtDbtr><Nm>SectionJudo</Nm></UltmtDbtr>",
+		"<UltmtCdtr><Nm>LeoDoe</Nm></UltmtCdtr>",
+	}
+	if !reflect.DeepEqual(parties, expected) {
+		t.Errorf("Ultimate parties mismatch. Got: %v, Want: %v", parties, expected)
+	}
+}
+
+func TestIntegration_Preview(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Preview: true,
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+
+	answerPath := filepath.Join(filepath.Dir(csvPath), "answer")
+	if err := os.WriteFile(answerPath, []byte("n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	answer, err := os.Open(answerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = answer.Close() }()
+	stdin := os.Stdin
+	os.Stdin = answer
+	defer func() { os.Stdin = stdin }()
+
+	if err := toPain001(cfg, csvPath); err == nil {
+		t.Error("Expected an error when the preview is rejected")
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Errorf("The SEPA file should not be written, got: %v", err)
+	}
+
+	cfg.Yes = true
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	if _, err := os.Stat(outPath); err != nil {
+		t.Errorf("The SEPA file should be written, got: %v", err)
+	}
+}
+
+func TestIntegration_Roster(t *testing.T) {
+	csvInput := `id,creditor,amount,info
+"payment xxx",jerome DUPONT,123.45,"payment for xxx"
+"payment yyy",Marie Martin,10,"payment for yyy"
+"payment zzz",Unknown Person,10,"payment for zzz"`
+
+	cfg := Config{
+		BatchID: "batch",
+		Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+	cfg.Roster = filepath.Join(filepath.Dir(csvPath), "roster.yaml")
+	roster := `Jérôme Dupont:
+  iban: FR5120041010051631529138143
+  bic: PMXNCXV94RH
+Marie Martin:
+  iban: FR6920041010056927446332670
+`
+	if err := os.WriteFile(cfg.Roster, []byte(roster), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "invalid row 3: invalid creditor: Unknown Person not found in the roster"
+	if err := toPain001(cfg, csvPath); err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Roster error mismatch. Got: %v, Want: %s", err, expected)
+	}
+
+	if err := os.WriteFile(csvPath, []byte(strings.Join(strings.Split(csvInput, "\n")[:3], "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+	generated := sanitizeXML(string(generatedData))
+	accounts := regexp.MustCompile(`<CdtrAcct>.*?</CdtrAcct>`).FindAllString(generated, -1)
+	expectedAccounts := []string{
+		"<CdtrAcct><Id><IBAN>FR5120041010051631529138143</IBAN></Id></CdtrAcct>",
+		"<CdtrAcct><Id><IBAN>FR6920041010056927446332670</IBAN></Id></CdtrAcct>",
+	}
+	if !reflect.DeepEqual(accounts, expectedAccounts) {
+		t.Errorf("Creditor accounts mismatch. Got: %v, Want: %v", accounts, expectedAccounts)
+	}
+	if !strings.Contains(generated, "<BIC>PMXNCXV94RH</BIC></FinInstnId></CdtrAgt>") {
+		t.Errorf("Creditor BIC not found in: %s", generated)
+	}
+}
+
+func TestIntegration_DuplicateBatch(t *testing.T) {
+	csvInput := `creditor,iban,bic,amount,info
+John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	cfg := Config{
+		Debtor:      sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		HistoryDays: 30,
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{Creditor: "creditor", IBAN: "iban", BIC: "bic", Amount: "amount", Info: "info"},
+		},
+	}
+
+	csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	cfg.Output = outPath
+	cfg.HistoryFile = filepath.Join(filepath.Dir(csvPath), "history", "history.csv")
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	if err := os.Remove(outPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// The generated batch and end to end IDs differ, but the transfers are the same.
+	err := toPain001(cfg, csvPath)
+	if err == nil || !strings.Contains(err.Error(), "the same transfers have been generated on") {
+		t.Errorf("Expected a duplicate batch error, got: %v", err)
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Errorf("The SEPA file should not be written, got: %v", err)
+	}
+
+	cfg.ConfirmDuplicate = true
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	history, err := readHistory(cfg.HistoryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Errorf("History entries count mismatch. Got: %d, Want: 2", len(history))
+	}
+}
+
+func TestOutputPath(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		template string
+		batchID  string
+		expected string
+	}{
+		{"", "batch", ""},
+		{"out.xml", "batch", "out.xml"},
+		{"sepa-{{date}}-{{batchid}}.xml", "ACME-20250611-3F9A1C", "sepa-2025-06-11-ACME-20250611-3F9A1C.xml"},
+		{"out/{{batchid}}.xml", "batch 2025/06", "out/batch_2025_06.xml"},
+	}
+
+	for _, test := range tests {
+		if actual := outputPath(test.template, test.batchID, now); actual != test.expected {
+			t.Errorf("Output path mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestIntegration_OutputTemplate(t *testing.T) {
+	csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"`
+
+	cfg := Config{
+		BatchID:  "batch",
+		Checksum: true,
+		Debtor:   sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		CSV: CsvConfig{
+			Columns: ColumnsConfig{
+				Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+			},
+		},
+	}
+
+	csvPath, _, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+	defer cleanup()
+	dir := filepath.Dir(csvPath)
+	cfg.Output = filepath.Join(dir, "sepa-{{date}}-{{batchid}}.xml")
+
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+	outPath := filepath.Join(dir, fmt.Sprintf("sepa-%s-batch.xml", time.Now().Format("2006-01-02")))
+	generatedData, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read generated output: %v", err)
+	}
+	checksum, err := os.ReadFile(outPath + ".sha256")
+	if err != nil {
+		t.Fatalf("failed to read the checksum file: %v", err)
+	}
+	sum := sha256.Sum256(generatedData)
+	expected := fmt.Sprintf("%x  %s\n", sum, filepath.Base(outPath))
+	if string(checksum) != expected {
+		t.Errorf("Checksum mismatch. Got: %s, Want: %s", checksum, expected)
+	}
+
+	expectedErr := fmt.Sprintf("the SEPA file %s already exists, use the force flag to overwrite it", outPath)
+	if err := toPain001(cfg, csvPath); err == nil || err.Error() != expectedErr {
+		t.Errorf("Overwrite error mismatch. Got: %v, Want: %s", err, expectedErr)
+	}
+
+	cfg.Force = true
+	if err := toPain001(cfg, csvPath); err != nil {
+		t.Fatalf("toPain001 failed: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+// paidNotePrefix starts the line added to the comment of the reimbursements paid by a SEPA file.
+const paidNotePrefix = "Paid by SEPA transfer "
+
+// NewReimburseCommand creates the command paying the employee reimbursements with the given name.
+func NewReimburseCommand(name string) *cobra.Command {
+	reimburseCmd := &cobra.Command{
+		Use:   name,
+		Short: "Pay the employee reimbursements and mark them as paid in happy-compta",
+		Long: `Pay the employee reimbursements of an accounting period in happy-compta.
+
+The command chains the steps of the monthly reimbursements:
+  1. read the spend entries paid by transfer to an employee that have a receipt and are not paid yet,
+  2. get the employees IBAN and BIC from the roster, managed by the sepa roster commands,
+  3. write the SEPA file transferring the reimbursements,
+  4. after confirmation, mark the entries as paid by adding a line to their comment.
+
+The entries without receipt are not validated and only reported.
+The entries with a "Paid by SEPA transfer" line in their comment are already paid and skipped.
+The SEPA file flags are the same as for the sepa command.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			common.SetupCommand(cmd, "CSV_SEPA", ConfigKeys)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags, err := readConfig()
+			if err != nil {
+				return err
+			}
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			flags.Email, flags.Password = credentials.Email, credentials.Password
+
+			opts, err := getReimbursementOptions(cmd, flags.CSV.Date)
+			if err != nil {
+				return err
+			}
+			return reimburse(flags, opts)
+		},
+	}
+	addTransferFlags(reimburseCmd)
+	reimburseCmd.SetFlagErrorFunc(common.FlagError)
+	addReimbursementFlags(reimburseCmd, defaultRosterPath)
+
+	return reimburseCmd
+}
+
+// reimburse writes the pain001 file paying the validated employee reimbursements
+// and marks them as paid in happy-compta once confirmed.
+func reimburse(flags Config, opts reimbursementOptions) error {
+	roster, err := readRoster(flags.CSV.CSVParams, opts.Roster)
+	if err != nil {
+		return err
+	}
+	client, err := newHappyComptaClient(flags)
+	if err != nil {
+		return err
+	}
+	entries, employees, err := listReimbursements(client, opts)
+	if err != nil {
+		return err
+	}
+
+	entries = selectUnpaidReimbursements(entries)
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, common.Tr("No employee reimbursement to pay."))
+		return nil
+	}
+	batchID, err := writeReimbursements(flags, entries, employees, roster)
+	if err != nil {
+		return err
+	}
+
+	question := fmt.Sprintf("Mark the %d entries as paid in happy-compta?", len(entries))
+	if !flags.Yes && !common.Confirm(os.Stdin, os.Stderr, question) {
+		return errors.New("the entries have not been marked as paid")
+	}
+	return markPaid(client, entries, batchID, time.Now())
+}
+
+// selectUnpaidReimbursements returns the reimbursements with a receipt that have not been marked as paid.
+// The entries without receipt are reported.
+func selectUnpaidReimbursements(entries []lib.Entry) []lib.Entry {
+	var unpaid []lib.Entry
+	for _, entry := range entries {
+		switch {
+		case isPaid(entry):
+			slog.Debug("skipping the already paid reimbursement", "entry", entry.ID)
+		case len(entry.Receipts) == 0:
+			slog.Warn("skipping the reimbursement without receipt", "entry", entry.ID, "name", entry.Name)
+		default:
+			unpaid = append(unpaid, entry)
+		}
+	}
+	return unpaid
+}
+
+// isPaid returns whether the comment of the entry has the line added when marking it as paid.
+func isPaid(entry lib.Entry) bool {
+	for line := range strings.Lines(entry.Comment) {
+		if strings.HasPrefix(line, paidNotePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// paidNote returns the line added to the comment of the entries paid by the batch.
+func paidNote(batchID string, date time.Time) string {
+	return fmt.Sprintf("%s%s on %s", paidNotePrefix, batchID, date.Format(lib.DateLayout))
+}
+
+// markPaid adds the paid note of the batch to the comment of the entries and saves them.
+// All the entries are updated even if some fail.
+func markPaid(client lib.EntryUpdater, entries []lib.Entry, batchID string, date time.Time) error {
+	var allErrors []error
+	for _, entry := range entries {
+		if entry.Comment != "" && !strings.HasSuffix(entry.Comment, "\n") {
+			entry.Comment += "\n"
+		}
+		entry.Comment += paidNote(batchID, date)
+		if err := client.UpdateEntry(&entry); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("failed to mark entry %s as paid: %s", entry.ID, err))
+			continue
+		}
+		slog.Info("marked the reimbursement as paid", "entry", entry.ID)
+	}
+	return errors.Join(allErrors...)
+}
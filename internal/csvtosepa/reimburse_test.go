@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestSelectUnpaidReimbursements(t *testing.T) {
+	entries := []lib.Entry{
+		{ID: "ASC000001", Receipts: []string{"ticket.jpg"}},
+		{ID: "ASC000002"},
+		{ID: "ASC000003", Receipts: []string{"ticket.jpg"}, Comment: "Train\nPaid by SEPA transfer B1 on 03/03/2025"},
+		{ID: "ASC000004", Receipts: []string{"ticket.jpg"}, Comment: "Not Paid by SEPA transfer yet"},
+	}
+	var actual []string
+	for _, entry := range selectUnpaidReimbursements(entries) {
+		actual = append(actual, entry.ID)
+	}
+	if expected := []string{"ASC000001", "ASC000004"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Unpaid reimbursements mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+type fakeEntryUpdater struct {
+	updated []lib.Entry
+}
+
+func (f *fakeEntryUpdater) UpdateEntry(entry *lib.Entry) error {
+	if entry.ID == "ASC000003" {
+		return errors.New("HTTP 500")
+	}
+	f.updated = append(f.updated, *entry)
+	return nil
+}
+
+func TestMarkPaid(t *testing.T) {
+	entries := []lib.Entry{{ID: "ASC000001"}, {ID: "ASC000002", Comment: "Train tickets"}, {ID: "ASC000003"}}
+	updater := &fakeEntryUpdater{}
+	err := markPaid(updater, entries, "BATCH1", time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err == nil || !strings.Contains(err.Error(), "failed to mark entry ASC000003 as paid: HTTP 500") {
+		t.Errorf("Expected an error for ASC000003, got: %v", err)
+	}
+
+	expected := []lib.Entry{
+		{ID: "ASC000001", Comment: "Paid by SEPA transfer BATCH1 on 03/03/2025"},
+		{ID: "ASC000002", Comment: "Train tickets\nPaid by SEPA transfer BATCH1 on 03/03/2025"},
+	}
+	if !reflect.DeepEqual(updater.updated, expected) {
+		t.Errorf("Updated entries mismatch. Got: %+v, Want: %+v", updater.updated, expected)
+	}
+	if entries[1].Comment != "Train tickets" {
+		t.Errorf("Expected the entries not to be changed, got: %s", entries[1].Comment)
+	}
+	for _, entry := range updater.updated {
+		if !isPaid(entry) {
+			t.Errorf("Expected entry %s to be paid", entry.ID)
+		}
+	}
+}
+
+func TestReimburseMockServer(t *testing.T) {
+	employee := func(id string) *lib.Employee { return &lib.Employee{ID: id} }
+	reimbursement := func(id string, party lib.Party, amount float64, receipts ...string) lib.Entry {
+		return lib.Entry{
+			ID: id, Period: "42", Kind: lib.KindSpend, Date: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+			Name: "Train " + id, Budget: lib.BudgetASC, Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: amount}},
+			Party: party, PaymentMethod: lib.PaymentMethodTransfer, Account: lib.Account{ID: 1}, Receipts: receipts,
+		}
+	}
+	paid := reimbursement("ASC000003", employee("2"), 30, "old.pdf")
+	paid.Comment = "Paid by SEPA transfer OLD on 01/02/2025"
+	server := mockserver.New(mockserver.Data{
+		Email:    "treasurer@example.com",
+		Password: "secret",
+		Employees: []lib.Employee{
+			{ID: "1", Lastname: "Dupont", Firstname: "Jérôme"},
+			{ID: "2", Lastname: "Martin", Firstname: "Marie"},
+		},
+		Periods: []lib.Period{{
+			ID:     "42",
+			Status: lib.PeriodStatusCurrent,
+			Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+		Entries: []lib.Entry{
+			reimbursement("ASC000001", employee("1"), 42.5, "ticket.pdf"),
+			reimbursement("ASC000002", employee("2"), 12),
+			paid,
+		},
+	})
+	defer server.Close()
+	t.Setenv("HAPPYCOMPTA_URL", server.URL)
+
+	dir := t.TempDir()
+	roster := filepath.Join(dir, "roster.yaml")
+	content := "Dupont Jérôme:\n  iban: FR7420041010058652109911007\n" +
+		"Martin Marie:\n  iban: FR5120041010051631529138143\n"
+	if err := os.WriteFile(roster, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "reimbursements.xml")
+	flags := Config{
+		Email:    "treasurer@example.com",
+		Password: "secret",
+		Output:   output,
+		Debtor:   sepa.Party{Name: "Works council", IBAN: "FR7630006000011234567890189"},
+		BatchID:  "BATCH1",
+		Yes:      true,
+		Instant:  true,
+	}
+	if err := reimburse(flags, reimbursementOptions{Roster: roster}); err != nil {
+		t.Fatalf("reimburse failed: %v", err)
+	}
+
+	xml, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read the SEPA file: %v", err)
+	}
+	for _, expected := range []string{"<EndToEndId>ASC000001</EndToEndId>", "<NbOfTxs>1</NbOfTxs>"} {
+		if !strings.Contains(string(xml), expected) {
+			t.Errorf("SEPA file mismatch. Got:\n%s\nWant it to contain: %s", xml, expected)
+		}
+	}
+
+	today := time.Now().Format(lib.DateLayout)
+	expected := []string{"Paid by SEPA transfer BATCH1 on " + today, "", paid.Comment}
+	var comments []string
+	for _, entry := range server.Entries() {
+		comments = append(comments, entry.Comment)
+	}
+	if !reflect.DeepEqual(comments, expected) {
+		t.Errorf("Comments mismatch. Got: %q, Want: %q", comments, expected)
+	}
+
+	// The paid entries are skipped by the next run.
+	if err := reimburse(flags, reimbursementOptions{Roster: roster}); err != nil {
+		t.Fatalf("reimburse failed: %v", err)
+	}
+}
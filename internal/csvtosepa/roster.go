@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"go.yaml.in/yaml/v3"
+)
+
+// rosterAccount is the bank account of a creditor in a YAML roster.
+type rosterAccount struct {
+	IBAN string `yaml:"iban"`
+	BIC  string `yaml:"bic"`
+}
+
+// readRoster reads the creditors bank accounts indexed by their roster key.
+// YAML rosters map the names to their iban and optional bic.
+// CSV or XLSX rosters have name, iban and optional bic columns. The name column can also be named employee.
+// The YAML rosters can be encrypted with age or sops, like the one of the roster command.
+func readRoster(params common.CSVParams, path string) (map[string]sepa.Party, error) {
+	switch common.ConfigFileType(path) {
+	case "yaml", "yml":
+		return readYAMLRoster(path)
+	}
+
+	reader, cleaner, err := common.GetRowReader(params, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the roster: %s", err)
+	}
+	defer cleaner()
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the roster header: %s", err)
+	}
+	columns := map[string]int{}
+	for _, column := range []string{"name", "employee", "iban", "bic"} {
+		columns[column] = slices.Index(header, column)
+	}
+	if columns["name"] < 0 {
+		columns["name"] = columns["employee"]
+	}
+	if columns["name"] < 0 || columns["iban"] < 0 {
+		return nil, errors.New("the roster requires name and iban columns")
+	}
+
+	roster := map[string]sepa.Party{}
+	var allErrors []error
+	for rowIndex := 1; ; rowIndex++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the roster: %s", err)
+		}
+		if record, err = common.TrimRow(record, len(header)); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid roster row %d: %s", rowIndex, err))
+			continue
+		}
+		record, _ = common.PadRow(record, len(header))
+
+		bic := ""
+		if columns["bic"] >= 0 {
+			bic = record[columns["bic"]]
+		}
+		if err := addRosterAccount(roster, record[columns["name"]], record[columns["iban"]], bic); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid roster row %d: %s", rowIndex, err))
+		}
+	}
+	return roster, errors.Join(allErrors...)
+}
+
+// readYAMLRoster reads a roster mapping the creditor names to their bank account.
+func readYAMLRoster(path string) (map[string]sepa.Party, error) {
+	content, err := common.ReadConfigFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the roster: %s", err)
+	}
+	var accounts map[string]rosterAccount
+	if err := yaml.Unmarshal(content, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse the roster %s: %s", path, err)
+	}
+
+	names := make([]string, 0, len(accounts))
+	for name := range accounts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	roster := map[string]sepa.Party{}
+	var allErrors []error
+	for _, name := range names {
+		account := accounts[name]
+		if err := addRosterAccount(roster, name, account.IBAN, account.BIC); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid roster entry %s: %s", name, err))
+		}
+	}
+	return roster, errors.Join(allErrors...)
+}
+
+// addRosterAccount validates a creditor bank account and adds it to the roster.
+func addRosterAccount(roster map[string]sepa.Party, name string, iban string, bic string) error {
+	name = strings.TrimSpace(name)
+	party := sepa.Party{IBAN: iban, BIC: bic}
+	var err error
+	if party.Name, err = sanitizeString(name, 140); err != nil {
+		return err
+	}
+	if err := normalizeParty(&party); err != nil {
+		return err
+	}
+	if party.IBAN == "" {
+		return errors.New("missing IBAN")
+	}
+	roster[rosterKey(name)] = party
+	return nil
+}
+
+// rosterKey returns the key of a name in the roster: the names are compared regardless of case and accents.
+func rosterKey(name string) string {
+	return strings.ToLower(removeAccents(strings.TrimSpace(name)))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestReadRoster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roster.csv")
+	content := "employee,iban,bic\n" +
+		"Dupont Jérôme,FR74 2004 1010 0586 5210 9911 007,pmxncxv94rh\n" +
+		"Martin Marie,FR5120041010051631529138143\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	roster, err := readRoster(common.CSVParams{Comma: ",", Encoding: "utf-8"}, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]sepa.Party{
+		"dupont jerome": {Name: "Dupont Jerome", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		"martin marie":  {Name: "Martin Marie", IBAN: "FR5120041010051631529138143"},
+	}
+	if !reflect.DeepEqual(roster, expected) {
+		t.Errorf("Roster mismatch. Got: %v, Want: %v", roster, expected)
+	}
+
+	content = "name,iban\nDupont Jérôme,FR00 1234\nMartin Marie,\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = readRoster(common.CSVParams{Comma: ",", Encoding: "utf-8"}, path)
+	if err == nil || !strings.Contains(err.Error(), "row 1") || !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("Expected errors for rows 1 and 2, got: %v", err)
+	}
+}
+
+func TestReadYAMLRoster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roster.yaml")
+	content := `Dupont Jérôme:
+  iban: FR74 2004 1010 0586 5210 9911 007
+  bic: pmxncxv94rh
+Martin Marie:
+  iban: FR5120041010051631529138143
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	roster, err := readRoster(common.CSVParams{}, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]sepa.Party{
+		"dupont jerome": {Name: "Dupont Jerome", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		"martin marie":  {Name: "Martin Marie", IBAN: "FR5120041010051631529138143"},
+	}
+	if !reflect.DeepEqual(roster, expected) {
+		t.Errorf("Roster mismatch. Got: %v, Want: %v", roster, expected)
+	}
+
+	if err := os.WriteFile(path, []byte("Martin Marie:\n  bic: PMXNCXV94RH\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readRoster(common.CSVParams{}, path); err == nil || !strings.Contains(err.Error(), "Martin Marie") {
+		t.Errorf("Expected an error for Martin Marie, got: %v", err)
+	}
+}
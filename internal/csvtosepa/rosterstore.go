@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultRosterPath is the encrypted roster managed by the roster commands.
+const defaultRosterPath = "roster.yaml.age"
+
+func newRosterCmd() *cobra.Command {
+	rosterCmd := &cobra.Command{
+		Use:   "roster",
+		Short: "Manage the encrypted roster of the creditors bank accounts",
+		Long: `Manage the encrypted roster of the creditors bank accounts, like the employees IBAN and BIC.
+
+happy-compta doesn't store the employees bank accounts: the roster keeps them for the SEPA files.
+It is a YAML file mapping the names to their iban and bic, encrypted with age and usable as --roster value.
+The roster is encrypted for the age identities of the HAPPYCOMPTA_AGE_IDENTITY, SOPS_AGE_KEY
+or SOPS_AGE_KEY_FILE variables or, without any, with a passphrase prompted on the terminal.
+The names are matched regardless of their case and accents.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+	}
+	rosterCmd.PersistentFlags().String("roster", defaultRosterPath, "Encrypted roster file.")
+
+	addCmd := &cobra.Command{
+		Use:   "add name",
+		Short: "Add a creditor bank account to the roster",
+		Args:  common.UsageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateRoster(cmd, func(store *rosterStore) error {
+				if name, found := store.find(args[0]); found {
+					return fmt.Errorf("%s is already in the roster, use the update command to change it", name)
+				}
+				iban, _ := cmd.Flags().GetString("iban")
+				bic, _ := cmd.Flags().GetString("bic")
+				return store.set(args[0], iban, bic)
+			})
+		},
+	}
+	addCmd.Flags().String("iban", "", "IBAN of the creditor (REQUIRED)")
+	addCmd.Flags().String("bic", "", "BIC of the creditor bank")
+
+	updateCmd := &cobra.Command{
+		Use:   "update name",
+		Short: "Change a creditor bank account in the roster",
+		Long: `Change a creditor bank account in the roster.
+
+Changing the IBAN without --bic removes the BIC of the previous bank.`,
+		Args: common.UsageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateRoster(cmd, func(store *rosterStore) error {
+				name, found := store.find(args[0])
+				if !found {
+					return fmt.Errorf("%s is not in the roster", args[0])
+				}
+				account := store.accounts[name]
+				if cmd.Flags().Changed("iban") {
+					account.IBAN, _ = cmd.Flags().GetString("iban")
+					account.BIC = ""
+				}
+				if cmd.Flags().Changed("bic") {
+					account.BIC, _ = cmd.Flags().GetString("bic")
+				}
+				delete(store.accounts, name)
+				return store.set(args[0], account.IBAN, account.BIC)
+			})
+		},
+	}
+	updateCmd.Flags().String("iban", "", "New IBAN of the creditor")
+	updateCmd.Flags().String("bic", "", "New BIC of the creditor bank")
+
+	removeCmd := &cobra.Command{
+		Use:   "remove name",
+		Short: "Remove a creditor from the roster",
+		Args:  common.UsageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateRoster(cmd, func(store *rosterStore) error {
+				name, found := store.find(args[0])
+				if !found {
+					return fmt.Errorf("%s is not in the roster", args[0])
+				}
+				delete(store.accounts, name)
+				return nil
+			})
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the creditors of the roster with their masked IBAN",
+		Args:  common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("roster")
+			store, err := openRosterStore(path)
+			if err != nil {
+				return err
+			}
+			return store.write(os.Stdout)
+		},
+	}
+
+	rosterCmd.AddCommand(addCmd, updateCmd, removeCmd, listCmd)
+	return rosterCmd
+}
+
+// updateRoster opens the roster of the command, changes it and saves it.
+func updateRoster(cmd *cobra.Command, change func(store *rosterStore) error) error {
+	path, err := cmd.Flags().GetString("roster")
+	if err != nil {
+		return err
+	}
+	store, err := openRosterStore(path)
+	if err != nil {
+		return err
+	}
+	if err := change(store); err != nil {
+		return common.WithExitCode(common.ExitValidation, err)
+	}
+	return store.save()
+}
+
+// rosterStore holds the bank accounts of an encrypted YAML roster indexed by creditor name.
+type rosterStore struct {
+	path     string
+	accounts map[string]rosterAccount
+	// writeFile writes the content of the roster, encrypting it.
+	writeFile func(path string, content []byte) error
+}
+
+// openRosterStore reads the encrypted roster. A missing file gives an empty roster.
+func openRosterStore(path string) (*rosterStore, error) {
+	store := &rosterStore{path: path, accounts: map[string]rosterAccount{}, writeFile: common.WriteEncryptedFile}
+	content, err := common.ReadConfigFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the roster: %s", err)
+	}
+	if err := yaml.Unmarshal(content, &store.accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse the roster %s: %s", path, err)
+	}
+	if store.accounts == nil {
+		store.accounts = map[string]rosterAccount{}
+	}
+	return store, nil
+}
+
+// find returns the name of the roster matching the name regardless of its case and accents.
+func (s *rosterStore) find(name string) (string, bool) {
+	for stored := range s.accounts {
+		if rosterKey(stored) == rosterKey(name) {
+			return stored, true
+		}
+	}
+	return "", false
+}
+
+// set validates the bank account and stores it with its IBAN and BIC normalized.
+func (s *rosterStore) set(name string, iban string, bic string) error {
+	validated := map[string]sepa.Party{}
+	if err := addRosterAccount(validated, name, iban, bic); err != nil {
+		return fmt.Errorf("invalid bank account of %s: %s", name, err)
+	}
+	party := validated[rosterKey(name)]
+	s.accounts[strings.TrimSpace(name)] = rosterAccount{IBAN: party.IBAN, BIC: party.BIC}
+	return nil
+}
+
+// save encrypts the roster and writes it.
+func (s *rosterStore) save() error {
+	content, err := yaml.Marshal(s.accounts)
+	if err != nil {
+		return err
+	}
+	return s.writeFile(s.path, content)
+}
+
+// write lists the creditors sorted by name with their IBAN masked.
+func (s *rosterStore) write(w io.Writer) error {
+	names := make([]string, 0, len(s.accounts))
+	for name := range s.accounts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int { return strings.Compare(rosterKey(a), rosterKey(b)) })
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tIBAN\tBIC")
+	for _, name := range names {
+		account := s.accounts[name]
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", name, maskIBAN(account.IBAN), account.BIC)
+	}
+	return tw.Flush()
+}
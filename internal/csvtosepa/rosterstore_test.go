@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+func TestRosterStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roster.yaml")
+	store, err := openRosterStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error for a missing roster: %s", err)
+	}
+	store.writeFile = func(path string, content []byte) error { return os.WriteFile(path, content, 0o600) }
+
+	if err := store.set("Dupont Jérôme", "FR74 2004 1010 0586 5210 9911 007", "pmxncxv94rh"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := store.set("Martin Marie", "FR5120041010051631529138143", ""); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := store.set("Durand Paul", "FR00 1234", ""); err == nil || !strings.Contains(err.Error(), "Durand Paul") {
+		t.Errorf("Expected an error for the invalid IBAN of Durand Paul, got: %v", err)
+	}
+	if name, found := store.find("DUPONT jerome"); !found || name != "Dupont Jérôme" {
+		t.Errorf("Find mismatch. Got: %s %v, Want: Dupont Jérôme", name, found)
+	}
+	if err := store.save(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	reopened, err := openRosterStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]rosterAccount{
+		"Dupont Jérôme": {IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+		"Martin Marie":  {IBAN: "FR5120041010051631529138143"},
+	}
+	if !reflect.DeepEqual(reopened.accounts, expected) {
+		t.Errorf("Roster mismatch. Got: %v, Want: %v", reopened.accounts, expected)
+	}
+
+	// The saved roster is usable by the sepa command.
+	roster, err := readRoster(common.CSVParams{}, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if party := roster["martin marie"]; party.IBAN != "FR5120041010051631529138143" {
+		t.Errorf("IBAN mismatch. Got: %s, Want: FR5120041010051631529138143", party.IBAN)
+	}
+
+	var out bytes.Buffer
+	if err := reopened.write(&out); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	want := "NAME           IBAN                         BIC\n" +
+		"Dupont Jérôme  FR74*******************1007  PMXNCXV94RH\n" +
+		"Martin Marie   FR51*******************8143  \n"
+	if got := out.String(); got != want {
+		t.Errorf("List mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+// maxPartyNameLength is the maximum length of the ultimate party names allowed by the SEPA rules.
+const maxPartyNameLength = 70
+
+// rowParser converts the CSV records into transactions.
+type rowParser struct {
+	header        map[string]int
+	debtors       *debtorProfiles
+	executionDate time.Time
+	now           time.Time
+	instant       bool
+	// dates holds the accepted layouts of the date column.
+	dates common.DateParams
+	// currency is the currency of the rows without a currency value.
+	currency string
+	// roster holds the bank accounts of the creditors for the rows without IBAN.
+	roster map[string]sepa.Party
+}
+
+// parse builds the transaction of a record and returns all the problems found in it.
+func (p *rowParser) parse(rowIndex int, record []string) (groupedTransaction, error) {
+	var allErrors []error
+	var err error
+
+	transaction := sepa.Transaction{
+		Purpose: "REFU", // TODO Use an optional column for this
+	}
+
+	amountStr := strings.ReplaceAll(record[p.header[columnsAmount]], "€", "")
+	if transaction.Amount, err = parseAmount(amountStr); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid amount: %s"), err))
+	}
+	transaction.Currency = cmp.Or(p.currency, defaultCurrency)
+	if value := strings.TrimSpace(p.field(record, columnCurrency)); value != "" {
+		if transaction.Currency, err = parseCurrency(value); err != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid currency: %s"), err))
+		}
+	}
+	if p.instant && transaction.Currency != defaultCurrency {
+		allErrors = append(allErrors,
+			fmt.Errorf(common.Tr("invalid currency: instant transfers are only in %s"), defaultCurrency))
+	}
+	if transaction.Info, err = sanitizeString(record[p.header[columnInfo]], 35); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid information: %s"), err))
+	}
+	if transaction.Creditor.Name, err = sanitizeString(record[p.header[columnCreditor]], 140); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid creditor: %s"), err))
+	}
+	iban, bic := p.field(record, columnIBAN), p.field(record, columnBIC)
+	if strings.TrimSpace(iban) == "" && p.roster != nil {
+		name := record[p.header[columnCreditor]]
+		if account, found := p.roster[rosterKey(name)]; found {
+			iban, bic = account.IBAN, account.BIC
+		} else {
+			allErrors = append(allErrors,
+				fmt.Errorf(common.Tr("invalid creditor: %s not found in the roster"), strings.TrimSpace(name)))
+		}
+	}
+	transaction.Creditor.IBAN = strings.ToUpper(sanitizeID(iban))
+	if err := validateIBAN(transaction.Creditor.IBAN); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid IBAN: %s"), err))
+	}
+	transaction.Creditor.BIC = strings.ToUpper(sanitizeID(bic))
+	if err := validateBIC(transaction.Creditor.BIC); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid BIC: %s"), err))
+	}
+	if idx, found := p.header[columnID]; found {
+		if transaction.EndToEndID, err = sanitizeString(record[idx], maxEndToEndIDLength); err != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid end to end ID: %s"), err))
+		}
+	}
+	if transaction.Creditor.Address, err = parseAddress(
+		p.field(record, columnStreet), p.field(record, columnPostCode),
+		p.field(record, columnCity), p.field(record, columnCountry),
+	); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid address: %s"), err))
+	}
+	if idx, found := p.header[columnReference]; found {
+		if transaction.Reference, err = parseCreditorReference(record[idx]); err != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid creditor reference: %s"), err))
+		}
+	}
+	if transaction.UltimateDebtor, err = sanitizeString(
+		strings.TrimSpace(p.field(record, columnUltimateDebtor)), maxPartyNameLength,
+	); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid ultimate debtor: %s"), err))
+	}
+	if transaction.UltimateCreditor, err = sanitizeString(
+		strings.TrimSpace(p.field(record, columnUltimateCreditor)), maxPartyNameLength,
+	); err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid ultimate creditor: %s"), err))
+	}
+	// SEPA only allows one of the structured and unstructured remittance information.
+	if transaction.Reference != "" && transaction.Info != "" {
+		slog.Warn("the row has a creditor reference, ignoring its information text", "row", rowIndex)
+		transaction.Info = ""
+	}
+
+	date := p.executionDate
+	if idx, found := p.header[columnDate]; found && strings.TrimSpace(record[idx]) != "" {
+		if date, err = getExecutionDate(strings.TrimSpace(record[idx]), p.dates, p.now, p.instant); err != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid date: %s"), err))
+		}
+	}
+	group := ""
+	if idx, found := p.header[columnGroup]; found {
+		group = strings.TrimSpace(record[idx])
+	}
+	profile := ""
+	if idx, found := p.header[columnDebtor]; found {
+		profile = strings.TrimSpace(record[idx])
+	}
+	debtor, err := p.debtors.get(profile)
+	if err != nil {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid debtor: %s"), err))
+	}
+
+	return groupedTransaction{
+		row: rowIndex, date: date.Format(dateLayout), debtor: debtor, group: group, transaction: &transaction,
+	}, errors.Join(allErrors...)
+}
+
+// field returns the value of an optional column or an empty string if the column is not in the file.
+func (p *rowParser) field(record []string, column string) string {
+	if idx, found := p.header[column]; found {
+		return record[idx]
+	}
+	return ""
+}
+
+// rowResult is the outcome of the validation of a row.
+type rowResult struct {
+	row    int
+	source string
+	err    error
+}
+
+// writeCheckReport writes the validation result of each row and the batch-wide problems.
+// An error is returned if any problem has been found.
+func writeCheckReport(w io.Writer, results []rowResult, batchErr error) error {
+	invalid := 0
+	for _, result := range results {
+		if result.err == nil {
+			if _, err := fmt.Fprintf(w, common.Tr("%s: ok\n"), rowLocation(result.row, result.source)); err != nil {
+				return err
+			}
+			continue
+		}
+		invalid++
+		message := strings.ReplaceAll(result.err.Error(), "\n", "\n    ")
+		location := rowLocation(result.row, result.source)
+		if _, err := fmt.Fprintf(w, common.Tr("%s: invalid\n    %s\n"), location, message); err != nil {
+			return err
+		}
+	}
+	if batchErr != nil {
+		message := strings.ReplaceAll(batchErr.Error(), "\n", "\n    ")
+		if _, err := fmt.Fprintf(w, common.Tr("batch: invalid\n    %s\n"), message); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, common.Tr("%d rows checked, %d invalid\n"), len(results), invalid); err != nil {
+		return err
+	}
+	if invalid > 0 || batchErr != nil {
+		return common.WithExitCode(common.ExitValidation, errors.New(common.Tr("the CSV file has invalid data")))
+	}
+	return nil
+}
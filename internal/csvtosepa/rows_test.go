@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func newTestRowParser(t *testing.T) *rowParser {
+	debtors, err := newDebtorProfiles(sepa.Party{Name: "Issuer"}, nil, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	header := map[string]int{
+		columnID: 0, columnCreditor: 1, columnIBAN: 2, columnBIC: 3, columnsAmount: 4, columnInfo: 5,
+	}
+	now := time.Date(2025, 4, 11, 10, 0, 0, 0, time.UTC)
+	return &rowParser{header: header, debtors: debtors, executionDate: now, now: now}
+}
+
+func TestRowParserParse(t *testing.T) {
+	parser := newTestRowParser(t)
+
+	record := []string{"id1", "Jérôme Doe", "fr76 3000 6000 0112 3456 7890 189", "", "12.1", "Refund"}
+	item, err := parser.parse(1, record)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := sepa.Transaction{
+		EndToEndID: "id1",
+		Amount:     1210,
+		Currency:   "EUR",
+		Creditor:   sepa.Party{Name: "Jerome Doe", IBAN: "FR7630006000011234567890189"},
+		Purpose:    "REFU",
+		Info:       "Refund",
+	}
+	if !reflect.DeepEqual(*item.transaction, expected) {
+		t.Errorf("Transaction mismatch. Got: %v, Want: %v", *item.transaction, expected)
+	}
+	if item.row != 1 || item.date != "2025-04-11" || item.debtor.Name != "Issuer" {
+		t.Errorf("Grouping values mismatch. Got: %d %s %s", item.row, item.date, item.debtor.Name)
+	}
+}
+
+func TestRowParserParseErrors(t *testing.T) {
+	parser := newTestRowParser(t)
+
+	_, err := parser.parse(2, []string{"id_1", "John Doe", "FR7630006000011234567890188", "FAKE", "12,10", "Refund"})
+	if err == nil {
+		t.Fatal("Expected errors")
+	}
+	expected := []string{"invalid amount", "invalid IBAN", "invalid BIC", "invalid end to end ID"}
+	var actual []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		actual = append(actual, line[:strings.Index(line, ":")])
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Errors mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban  string
+		valid bool
+	}{
+		{"FR7630006000011234567890189", true},
+		{"DE89370400440532013000", true},
+		{"FR7630006000011234567890188", false},
+		{"FR76", false},
+		{"", false},
+		{"7630006000011234567890189FR", false},
+	}
+
+	for _, test := range tests {
+		if err := validateIBAN(test.iban); (err == nil) != test.valid {
+			t.Errorf("IBAN %s validity mismatch. Got: %v, Want: %v", test.iban, err == nil, test.valid)
+		}
+	}
+}
+
+func TestWriteCheckReport(t *testing.T) {
+	results := []rowResult{
+		{row: 1},
+		{row: 2, err: errors.Join(errors.New("invalid amount: bad"), errors.New("invalid BIC: bad"))},
+	}
+
+	var buf bytes.Buffer
+	err := writeCheckReport(&buf, results, errors.New("empty end to end ID on row 1"))
+	if err == nil {
+		t.Error("Expected an error for the invalid rows")
+	}
+
+	expected := `row 1: ok
+row 2: invalid
+    invalid amount: bad
+    invalid BIC: bad
+batch: invalid
+    empty end to end ID on row 1
+2 rows checked, 1 invalid
+`
+	if buf.String() != expected {
+		t.Errorf("Report mismatch. Got: %q, Want: %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := writeCheckReport(&buf, results[:1], nil); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+// creditorTotal is the sum of the transactions to a creditor account in a currency.
+type creditorTotal struct {
+	Creditor sepa.Party
+	Currency string
+	Count    int
+	Amount   sepa.Amount
+}
+
+// creditorTotals sums the transactions per creditor name, IBAN and currency, sorted by name.
+func creditorTotals(c *sepa.CustomerCreditTransferInitiation) []creditorTotal {
+	type creditorKey struct {
+		name     string
+		iban     string
+		currency string
+	}
+	totals := map[creditorKey]*creditorTotal{}
+	var keys []creditorKey
+	for _, payment := range c.Payments {
+		for _, transaction := range payment.Transactions {
+			key := creditorKey{
+				name: transaction.Creditor.Name, iban: transaction.Creditor.IBAN, currency: transaction.Currency,
+			}
+			total, found := totals[key]
+			if !found {
+				total = &creditorTotal{Creditor: transaction.Creditor, Currency: transaction.Currency}
+				totals[key] = total
+				keys = append(keys, key)
+			}
+			total.Count++
+			total.Amount += transaction.Amount
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b creditorKey) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.iban, b.iban), cmp.Compare(a.currency, b.currency))
+	})
+	result := make([]creditorTotal, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *totals[key])
+	}
+	return result
+}
+
+// currencyTotals sums the transactions per currency, sorted by currency code.
+func currencyTotals(c *sepa.CustomerCreditTransferInitiation) []creditorTotal {
+	totals := map[string]*creditorTotal{}
+	var currencies []string
+	for _, payment := range c.Payments {
+		for _, transaction := range payment.Transactions {
+			total, found := totals[transaction.Currency]
+			if !found {
+				total = &creditorTotal{Currency: transaction.Currency}
+				totals[transaction.Currency] = total
+				currencies = append(currencies, transaction.Currency)
+			}
+			total.Count++
+			total.Amount += transaction.Amount
+		}
+	}
+
+	slices.Sort(currencies)
+	result := make([]creditorTotal, 0, len(currencies))
+	for _, currency := range currencies {
+		result = append(result, *totals[currency])
+	}
+	return result
+}
+
+// writeSummary writes a human readable summary of the generated transfers.
+func writeSummary(w io.Writer, c *sepa.CustomerCreditTransferInitiation, output string) error {
+	if output == "" {
+		output = "standard output"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "SEPA file:\t%s\n", output)
+	_, _ = fmt.Fprintf(tw, "Message ID:\t%s\n", c.ID)
+	_, _ = fmt.Fprintf(tw, "Transactions:\t%d\n", c.Count())
+	label := "Total:"
+	for _, total := range currencyTotals(c) {
+		_, _ = fmt.Fprintf(tw, "%s\t%s %s\n", label, total.Amount, total.Currency)
+		label = ""
+	}
+	_, _ = fmt.Fprintln(tw, "\nCREDITOR\tIBAN\tTRANSACTIONS\tAMOUNT")
+	for _, total := range creditorTotals(c) {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s %s\n",
+			total.Creditor.Name, total.Creditor.IBAN, total.Count, total.Amount, total.Currency)
+	}
+	return tw.Flush()
+}
+
+// writePreview writes the transactions to be transferred and the totals for the user to review them.
+// The IBANs are masked since the preview may be shared.
+func writePreview(w io.Writer, c *sepa.CustomerCreditTransferInitiation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CREDITOR\tIBAN\tAMOUNT\tINFO")
+	for _, payment := range c.Payments {
+		for _, transaction := range payment.Transactions {
+			info := cmp.Or(transaction.Reference, transaction.Info)
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s %s\t%s\n", transaction.Creditor.Name,
+				maskIBAN(transaction.Creditor.IBAN), transaction.Amount, transaction.Currency, info)
+		}
+	}
+	for _, total := range currencyTotals(c) {
+		_, _ = fmt.Fprintf(tw, "Total: %d transactions\t\t%s %s\t\n", total.Count, total.Amount, total.Currency)
+	}
+	return tw.Flush()
+}
+
+// maskIBAN hides the IBAN characters except the first and last four ones.
+func maskIBAN(iban string) string {
+	if len(iban) <= 8 {
+		return iban
+	}
+	return iban[:4] + strings.Repeat("*", len(iban)-8) + iban[len(iban)-4:]
+}
+
+// writeSummaryCSV writes the per creditor totals, the overall total of each currency
+// and the message ID in a CSV file.
+func writeSummaryCSV(path string, c *sepa.CustomerCreditTransferInitiation) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create the summary file %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	w := csv.NewWriter(file)
+	rows := [][]string{{"creditor", "iban", "transactions", "amount", "currency"}}
+	for _, total := range creditorTotals(c) {
+		rows = append(rows, []string{
+			total.Creditor.Name, total.Creditor.IBAN, strconv.Itoa(total.Count), total.Amount.String(), total.Currency,
+		})
+	}
+	for _, total := range currencyTotals(c) {
+		rows = append(rows, []string{"Total", "", strconv.Itoa(total.Count), total.Amount.String(), total.Currency})
+	}
+	rows = append(rows, []string{"Message ID", c.ID, "", "", ""})
+
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write the summary file %s: %s", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func newSummaryTransfer() *sepa.CustomerCreditTransferInitiation {
+	jean := sepa.Party{Name: "Jean Dupont", IBAN: "FR7420041010058652109911007"}
+	marie := sepa.Party{Name: "Marie Martin", IBAN: "FR5120041010051631529138143"}
+	return &sepa.CustomerCreditTransferInitiation{ID: "ACME-20250611-3F9A1C", Payments: []*sepa.Payment{
+		{Transactions: []*sepa.Transaction{
+			{Creditor: marie, Amount: 1050, Currency: "EUR", Info: "Taxi"},
+			{Creditor: jean, Amount: 2000, Currency: "EUR"},
+			{Creditor: jean, Amount: 4000, Currency: "CHF"},
+		}},
+		{Transactions: []*sepa.Transaction{
+			{Creditor: jean, Amount: 125, Currency: "EUR", Reference: "RF18539007547034"},
+		}},
+	}}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSummary(&buf, newSummaryTransfer(), "out.xml"); err != nil {
+		t.Fatalf("writeSummary failed: %s", err)
+	}
+
+	expected := `SEPA file:     out.xml
+Message ID:    ACME-20250611-3F9A1C
+Transactions:  4
+Total:         40.00 CHF
+               31.75 EUR
+
+CREDITOR      IBAN                         TRANSACTIONS  AMOUNT
+Jean Dupont   FR7420041010058652109911007  1             40.00 CHF
+Jean Dupont   FR7420041010058652109911007  2             21.25 EUR
+Marie Martin  FR5120041010051631529138143  1             10.50 EUR
+`
+	if buf.String() != expected {
+		t.Errorf("Summary mismatch. Got:\n%s\nWant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteSummaryCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.csv")
+	if err := writeSummaryCSV(path, newSummaryTransfer()); err != nil {
+		t.Fatalf("writeSummaryCSV failed: %s", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `creditor,iban,transactions,amount,currency
+Jean Dupont,FR7420041010058652109911007,1,40.00,CHF
+Jean Dupont,FR7420041010058652109911007,2,21.25,EUR
+Marie Martin,FR5120041010051631529138143,1,10.50,EUR
+Total,,1,40.00,CHF
+Total,,3,31.75,EUR
+Message ID,ACME-20250611-3F9A1C,,,
+`
+	if string(content) != expected {
+		t.Errorf("Summary CSV mismatch. Got:\n%s\nWant:\n%s", content, expected)
+	}
+}
+
+func TestWritePreview(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writePreview(&buf, newSummaryTransfer()); err != nil {
+		t.Fatalf("writePreview failed: %s", err)
+	}
+
+	// The empty cells are padded: ignore the trailing spaces.
+	actual := regexp.MustCompile(`(?m) +$`).ReplaceAllString(buf.String(), "")
+	expected := `CREDITOR               IBAN                         AMOUNT     INFO
+Marie Martin           FR51*******************8143  10.50 EUR  Taxi
+Jean Dupont            FR74*******************1007  20.00 EUR
+Jean Dupont            FR74*******************1007  40.00 CHF
+Jean Dupont            FR74*******************1007  1.25 EUR   RF18539007547034
+Total: 1 transactions                               40.00 CHF
+Total: 3 transactions                               31.75 EUR
+`
+	if actual != expected {
+		t.Errorf("Preview mismatch. Got:\n%s\nWant:\n%s", actual, expected)
+	}
+}
+
+func TestMaskIBAN(t *testing.T) {
+	tests := map[string]string{
+		"FR7630006000011234567890189": "FR76*******************0189",
+		"DE89370400440532013000":      "DE89**************3000",
+		"FR76":                        "FR76",
+	}
+	for iban, expected := range tests {
+		if actual := maskIBAN(iban); actual != expected {
+			t.Errorf("Masked IBAN mismatch. Got: %s, Want: %s", actual, expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// dateLayout is the format of the dates in the flags and in the SEPA files.
+const dateLayout = "2006-01-02"
+
+// getExecutionDate parses the requested execution date in one of the accepted layouts, defaulting to today.
+// The transfers can't be executed in the past or on a TARGET2 closing day,
+// unless they are instant ones which are processed every day.
+func getExecutionDate(value string, dates common.DateParams, today time.Time, instant bool) (time.Time, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if value == "" {
+		return today, nil
+	}
+
+	date, err := dates.Parse(value)
+	if err != nil {
+		return date, fmt.Errorf("invalid execution date: %s", err)
+	}
+	if date.Before(today) {
+		return date, fmt.Errorf("execution date %s is in the past", value)
+	}
+	if !instant && isTarget2Closed(date) {
+		return date, fmt.Errorf("execution date %s is a TARGET2 closing day", value)
+	}
+	return date, nil
+}
+
+// isTarget2Closed returns whether the TARGET2 payment system is closed on that day.
+// It is closed on weekends, New Year's Day, Good Friday, Easter Monday, the 1st of May, the 25th and 26th of December.
+func isTarget2Closed(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return true
+	}
+
+	switch {
+	case date.Month() == time.January && date.Day() == 1,
+		date.Month() == time.May && date.Day() == 1,
+		date.Month() == time.December && (date.Day() == 25 || date.Day() == 26):
+		return true
+	}
+
+	easter := easterSunday(date.Year())
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return day.Equal(easter.AddDate(0, 0, -2)) || day.Equal(easter.AddDate(0, 0, 1))
+}
+
+// easterSunday computes the date of the Gregorian Easter Sunday using the anonymous algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+func TestEasterSunday(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2026, "2026-04-05"},
+		{2038, "2038-04-25"},
+	}
+
+	for _, test := range tests {
+		actual := easterSunday(test.year).Format(dateLayout)
+		if actual != test.expected {
+			t.Errorf("Easter %d mismatch. Got: %s, Want: %s", test.year, actual, test.expected)
+		}
+	}
+}
+
+func TestIsTarget2Closed(t *testing.T) {
+	tests := []struct {
+		date     string
+		expected bool
+	}{
+		{"2025-04-14", false}, // Monday
+		{"2025-04-12", true},  // Saturday
+		{"2025-04-13", true},  // Sunday
+		{"2025-01-01", true},  // New Year's Day
+		{"2025-04-18", true},  // Good Friday
+		{"2025-04-21", true},  // Easter Monday
+		{"2025-05-01", true},  // Labour Day
+		{"2025-05-08", false}, // French holiday, but TARGET2 is open
+		{"2025-12-25", true},  // Christmas
+		{"2025-12-26", true},  // Christmas holiday
+	}
+
+	for _, test := range tests {
+		date, _ := time.Parse(dateLayout, test.date)
+		if actual := isTarget2Closed(date); actual != test.expected {
+			t.Errorf("Closed %s mismatch. Got: %v, Want: %v", test.date, actual, test.expected)
+		}
+	}
+}
+
+func TestGetExecutionDate(t *testing.T) {
+	today := time.Date(2025, 4, 11, 16, 30, 0, 0, time.Local) // Friday
+
+	tests := []struct {
+		value    string
+		instant  bool
+		expected string
+		err      bool
+	}{
+		{"", false, "2025-04-11", false},
+		{"2025-04-11", false, "2025-04-11", false},
+		{"2025-04-14", false, "2025-04-14", false},
+		{"2025-04-10", false, "", true},
+		{"2025-04-12", false, "", true},
+		{"2025-04-18", false, "", true},
+		{"14/04/2025", false, "2025-04-14", false},
+		{"14.04.2025", false, "", true},
+		{"2025-04-12", true, "2025-04-12", false},
+		{"2025-04-10", true, "", true},
+	}
+
+	for _, test := range tests {
+		date, err := getExecutionDate(test.value, common.DateParams{}, today, test.instant)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected an error for %s", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", test.value, err)
+			continue
+		}
+		if actual := date.Format(dateLayout); actual != test.expected {
+			t.Errorf("Execution date mismatch for '%s'. Got: %s, Want: %s", test.value, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+package csvtosepa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+// checkExpectedTotals compares the number of transactions and their total amount to the expected ones.
+// A zero count or an empty total are not checked.
+func checkExpectedTotals(transactions []groupedTransaction, count int, total string) error {
+	var allErrors []error
+	if count > 0 && len(transactions) != count {
+		allErrors = append(allErrors, fmt.Errorf("found %d transactions instead of the expected %d",
+			len(transactions), count))
+	}
+	if total != "" {
+		expected, err := parseAmount(total)
+		if err != nil {
+			return fmt.Errorf("invalid expected total: %s", err)
+		}
+		if actual := sumTransactions(transactions); actual != expected {
+			allErrors = append(allErrors, fmt.Errorf("total amount %s doesn't match the expected %s", actual, expected))
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// isTrailer returns whether the record is the trailer row with the expected total of the file.
+// The trailer row has the label in the creditor column.
+func (p *rowParser) isTrailer(record []string, label string) bool {
+	return label != "" && strings.EqualFold(strings.TrimSpace(record[p.header[columnCreditor]]), label)
+}
+
+// checkTrailer compares the total amount of the transactions with the one of the trailer row.
+func (p *rowParser) checkTrailer(record []string, transactions []groupedTransaction) error {
+	expected, err := parseAmount(strings.ReplaceAll(record[p.header[columnsAmount]], "€", ""))
+	if err != nil {
+		return fmt.Errorf("invalid trailer total: %s", err)
+	}
+	if actual := sumTransactions(transactions); actual != expected {
+		return fmt.Errorf("total amount %s doesn't match the trailer total %s", actual, expected)
+	}
+	return nil
+}
+
+func sumTransactions(transactions []groupedTransaction) sepa.Amount {
+	var sum sepa.Amount
+	for _, item := range transactions {
+		sum += item.transaction.Amount
+	}
+	return sum
+}
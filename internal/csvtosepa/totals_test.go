@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csvtosepa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib/sepa"
+)
+
+func TestCheckExpectedTotals(t *testing.T) {
+	transactions := []groupedTransaction{
+		{row: 1, transaction: &sepa.Transaction{Amount: 12345}},
+		{row: 2, transaction: &sepa.Transaction{Amount: 1000}},
+	}
+
+	tests := []struct {
+		count    int
+		total    string
+		expected string
+	}{
+		{0, "", ""},
+		{2, "133.45", ""},
+		{3, "", "found 2 transactions instead of the expected 3"},
+		{0, "133.46", "total amount 133.45 doesn't match the expected 133.46"},
+		{0, "133,45", "invalid expected total"},
+	}
+
+	for _, test := range tests {
+		err := checkExpectedTotals(transactions, test.count, test.total)
+		if test.expected == "" {
+			if err != nil {
+				t.Errorf("Unexpected error for %d, %s: %s", test.count, test.total, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Error mismatch for %d, %s. Got: %v, Want: %s", test.count, test.total, err, test.expected)
+		}
+	}
+}
+
+func TestIntegration_Trailer(t *testing.T) {
+	tests := []struct {
+		total string
+		err   bool
+	}{
+		{"133.45", false},
+		{"123.45 €", true},
+	}
+
+	for _, test := range tests {
+		csvInput := `id,creditor,iban,bic,amount,info
+"payment xxx",John Doe,FR5120041010051631529138143,,123.45,"payment for xxx"
+"payment yyy",Joe Tester,FR6920041010056927446332670,,10,"payment for yyy"
+,Total,,,` + test.total + ","
+
+		cfg := Config{
+			BatchID: "batch",
+			Debtor:  sepa.Party{Name: "Issuer", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"},
+			CSV: CsvConfig{
+				Columns: ColumnsConfig{
+					Creditor: "creditor", IBAN: "iban", BIC: "bic", EndToEndID: "id", Amount: "amount", Info: "info",
+				},
+				Trailer: "TOTAL",
+			},
+		}
+
+		csvPath, outPath, cleanup := setupIntegrationTest(t, csvInput, "output.xml")
+		defer cleanup()
+		cfg.Output = outPath
+
+		err := toPain001(cfg, csvPath)
+		if test.err {
+			expected := "invalid row 3: total amount 133.45 doesn't match the trailer total 123.45"
+			if err == nil || err.Error() != expected {
+				t.Errorf("Error mismatch. Got: %v, Want: %s", err, expected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("toPain001 failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+// referenceTypes are the object types stored in happy-compta.
+// The other types are computed from the entries.
+var referenceTypes = map[string]bool{
+	typeEmployees: true, typeProviders: true, typePeriods: true, typeAccounts: true, typeCategories: true,
+}
+
+func newBackupCmd() *cobra.Command {
+	var backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "Save all the data and receipts in an archive",
+		Long: `Save all the data, the entries of all the accounting periods and their receipts in a tar.gz archive.
+
+The archive contains:
+  data.json                          the employees, providers, periods, accounts and categories
+  entries/<period>.json              the entries of each period
+  receipts/<period>/<entry>/<file>   the receipts of the entries
+  manifest.json                      the size and SHA-256 checksum of all the other files`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			out, err := cmd.Flags().GetString("out")
+			if err != nil {
+				return err
+			}
+			withReceipts, err := cmd.Flags().GetBool("receipts")
+			if err != nil {
+				return err
+			}
+
+			return backup(cfg, out, withReceipts)
+		},
+	}
+	backupCmd.Flags().String("out", "", "Path of the archive to create. Defaults to backup-<timestamp>.tar.gz.")
+	backupCmd.Flags().Bool("receipts", true, "Include the receipts in the archive.")
+
+	return backupCmd
+}
+
+// manifestFile describes a file of the backup archive.
+type manifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// backupManifest lists the files of the backup archive.
+type backupManifest struct {
+	Schema  int            `json:"schema"`
+	Created time.Time      `json:"created"`
+	Version string         `json:"version"`
+	Files   []manifestFile `json:"files"`
+}
+
+// backupWriter adds files to the backup archive and records them in the manifest.
+type backupWriter struct {
+	gzip     *gzip.Writer
+	tar      *tar.Writer
+	manifest backupManifest
+}
+
+func newBackupWriter(w io.Writer, now time.Time) *backupWriter {
+	gz := gzip.NewWriter(w)
+	return &backupWriter{
+		gzip:     gz,
+		tar:      tar.NewWriter(gz),
+		manifest: backupManifest{Schema: schemaVersion, Created: now, Version: common.Version(), Files: []manifestFile{}},
+	}
+}
+
+func (b *backupWriter) write(name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: b.manifest.Created,
+	}
+	if err := b.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to add %s to the backup: %s", name, err)
+	}
+	if _, err := b.tar.Write(content); err != nil {
+		return fmt.Errorf("failed to add %s to the backup: %s", name, err)
+	}
+	return nil
+}
+
+// add writes a file in the archive and records it in the manifest.
+func (b *backupWriter) add(name string, content []byte) error {
+	if err := b.write(name, content); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(content)
+	b.manifest.Files = append(b.manifest.Files, manifestFile{
+		Path: name, Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:]),
+	})
+	return nil
+}
+
+// addJSON writes the value as a JSON file in the archive.
+func (b *backupWriter) addJSON(name string, value any) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %s", name, err)
+	}
+	return b.add(name, append(content, '\n'))
+}
+
+// close writes the manifest and finishes the archive.
+func (b *backupWriter) close() error {
+	content, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the backup manifest: %s", err)
+	}
+	if err := b.write("manifest.json", append(content, '\n')); err != nil {
+		return err
+	}
+	if err := b.tar.Close(); err != nil {
+		return fmt.Errorf("failed to finish the backup archive: %s", err)
+	}
+	if err := b.gzip.Close(); err != nil {
+		return fmt.Errorf("failed to finish the backup archive: %s", err)
+	}
+	return nil
+}
+
+func backup(cfg Config, out string, withReceipts bool) error {
+	now := time.Now()
+	if out == "" {
+		out = fmt.Sprintf("backup-%s.tar.gz", now.Format(timestampFormat))
+	}
+
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	data, err := fetchDump(client, referenceTypes)
+	if err != nil {
+		return err
+	}
+	data.Metadata = newOutputMetadata(now, cfg.Organization)
+	entries, err := fetchPeriodEntries(client, data.Periods)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", out, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var downloader receiptDownloader
+	if withReceipts {
+		downloader = client
+	}
+	if err := writeBackup(file, data, entries, downloader, now); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// writeBackup writes the backup archive of the data and entries.
+// The receipts are not included if downloader is nil.
+func writeBackup(
+	w io.Writer, data dumpData, entries map[string][]lib.Entry, downloader receiptDownloader, now time.Time,
+) error {
+	archive := newBackupWriter(w, now)
+
+	if err := archive.addJSON("data.json", newDumpOutput(data)); err != nil {
+		return err
+	}
+
+	for _, period := range data.Periods {
+		periodEntries := entriesData{
+			Entries:    entries[period.ID],
+			Accounts:   data.Accounts,
+			Categories: data.Categories,
+			Employees:  data.Employees,
+			Providers:  data.Providers,
+			Metadata:   data.Metadata,
+		}
+		if err := archive.addJSON(path.Join("entries", period.ID+".json"), newEntriesOutput(periodEntries)); err != nil {
+			return err
+		}
+	}
+
+	if downloader != nil {
+		for _, period := range data.Periods {
+			err := forEachReceipt(entries[period.ID], func(entry lib.Entry, name string, link string) error {
+				var content bytes.Buffer
+				if err := downloader.DownloadReceipt(link, &content); err != nil {
+					return err
+				}
+				return archive.add(path.Join("receipts", period.ID, entry.ID, path.Base(name)), content.Bytes())
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return archive.close()
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func readBackup(t *testing.T, content []byte) ([]string, map[string][]byte) {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("invalid gzip archive: %v", err)
+	}
+	reader := tar.NewReader(gz)
+
+	var names []string
+	files := map[string][]byte{}
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid tar archive: %v", err)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", header.Name, err)
+		}
+		names = append(names, header.Name)
+		files[header.Name] = data
+	}
+	return names, files
+}
+
+func TestWriteBackup(t *testing.T) {
+	data := getMockDumpData()
+	data.Types = referenceTypes
+	entriesMock := getMockEntriesData()
+	entries := map[string][]lib.Entry{"12345": entriesMock.Entries}
+	entries["12345"][0].ReceiptLinks = []string{"https://example.com/invoice.pdf"}
+	downloader := fakeDownloader{files: map[string]string{"https://example.com/invoice.pdf": "invoice content"}}
+	now := time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := writeBackup(&buf, data, entries, downloader, now); err != nil {
+		t.Fatalf("writeBackup failed: %v", err)
+	}
+
+	names, files := readBackup(t, buf.Bytes())
+	expected := []string{
+		"data.json", "entries/12345.json", "receipts/12345/ASC000012/invoice.pdf", "manifest.json",
+	}
+	if !slices.Equal(names, expected) {
+		t.Fatalf("Files mismatch. Got: %v, Want: %v", names, expected)
+	}
+	if string(files["receipts/12345/ASC000012/invoice.pdf"]) != "invoice content" {
+		t.Errorf("Receipt content mismatch. Got: %q", files["receipts/12345/ASC000012/invoice.pdf"])
+	}
+
+	var dump dumpOutput
+	if err := json.Unmarshal(files["data.json"], &dump); err != nil {
+		t.Fatalf("invalid data.json: %v", err)
+	}
+	if len(dump.Providers) != 1 || dump.Providers[0].Name != "ACME" {
+		t.Errorf("Unexpected providers in data.json: %+v", dump.Providers)
+	}
+
+	var manifest backupManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("invalid manifest.json: %v", err)
+	}
+	if !manifest.Created.Equal(now) {
+		t.Errorf("Manifest creation date mismatch. Got: %v, Want: %v", manifest.Created, now)
+	}
+	if len(manifest.Files) != 3 {
+		t.Fatalf("Expected 3 files in the manifest, got: %+v", manifest.Files)
+	}
+	for _, file := range manifest.Files {
+		sum := sha256.Sum256(files[file.Path])
+		if file.SHA256 != hex.EncodeToString(sum[:]) || file.Size != int64(len(files[file.Path])) {
+			t.Errorf("Manifest entry mismatch for %s: %+v", file.Path, file)
+		}
+	}
+}
+
+func TestWriteBackupWithoutReceipts(t *testing.T) {
+	data := getMockDumpData()
+	entries := map[string][]lib.Entry{"12345": getMockEntriesData().Entries}
+
+	var buf bytes.Buffer
+	if err := writeBackup(&buf, data, entries, nil, time.Now()); err != nil {
+		t.Fatalf("writeBackup failed: %v", err)
+	}
+
+	names, _ := readBackup(t, buf.Bytes())
+	expected := []string{"data.json", "entries/12345.json", "manifest.json"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("Files mismatch. Got: %v, Want: %v", names, expected)
+	}
+}
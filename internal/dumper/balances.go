@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// balance holds the totals of a set of entries.
+type balance struct {
+	Income   float64
+	Spending float64
+}
+
+// Balance returns the difference between the income and the spending.
+func (b balance) Balance() float64 {
+	return b.Income - b.Spending
+}
+
+func (b *balance) add(entry lib.Entry) {
+	var amount float64
+	for _, line := range entry.Allocation {
+		amount += line.Amount
+	}
+	b.addAmount(entry.Kind, amount)
+}
+
+// addAmount adds the amount of an entry of the given kind.
+// The allocations only assign budgets to categories: no money comes in or out of the bank accounts.
+func (b *balance) addAmount(kind lib.Kind, amount float64) {
+	switch kind {
+	case lib.KindSpend:
+		b.Spending += amount
+	case lib.KindTake:
+		b.Income += amount
+	}
+}
+
+// budgetBalance is the balance of the entries of a budget.
+type budgetBalance struct {
+	Budget lib.Budget
+	balance
+}
+
+// accountBalance is the balance of the entries of a bank account.
+type accountBalance struct {
+	Account lib.Account
+	balance
+}
+
+// periodBalances holds the balances of an accounting period.
+type periodBalances struct {
+	Period   lib.Period
+	Total    balance
+	Budgets  []budgetBalance
+	Accounts []accountBalance
+}
+
+// computeBalances sums the entries of each period per budget and per account.
+// The opening balances of the accounts are not known: the balances only reflect the entries of the periods.
+func computeBalances(periods []lib.Period, accounts []lib.Account, entries map[string][]lib.Entry) []periodBalances {
+	result := make([]periodBalances, 0, len(periods))
+	for _, period := range periods {
+		result = append(result, newPeriodBalances(period, accounts, entries[period.ID]))
+	}
+	return result
+}
+
+// newPeriodBalances sums the entries of a period per budget and per account.
+func newPeriodBalances(period lib.Period, accounts []lib.Account, entries []lib.Entry) periodBalances {
+	balances := periodBalances{Period: period}
+	for _, entry := range entries {
+		balances.Total.add(entry)
+
+		budgetIdx := slices.IndexFunc(balances.Budgets, func(b budgetBalance) bool { return b.Budget == entry.Budget })
+		if budgetIdx < 0 {
+			balances.Budgets = append(balances.Budgets, budgetBalance{Budget: entry.Budget})
+			budgetIdx = len(balances.Budgets) - 1
+		}
+		balances.Budgets[budgetIdx].add(entry)
+
+		accountIdx := slices.IndexFunc(balances.Accounts, func(a accountBalance) bool {
+			return a.Account.ID == entry.Account.ID
+		})
+		if accountIdx < 0 {
+			account := entry.Account
+			if idx := slices.IndexFunc(accounts, func(a lib.Account) bool { return a.ID == account.ID }); idx >= 0 {
+				account = accounts[idx]
+			}
+			balances.Accounts = append(balances.Accounts, accountBalance{Account: account})
+			accountIdx = len(balances.Accounts) - 1
+		}
+		balances.Accounts[accountIdx].add(entry)
+	}
+
+	slices.SortFunc(balances.Budgets, func(a, b budgetBalance) int { return int(a.Budget) - int(b.Budget) })
+	slices.SortFunc(balances.Accounts, func(a, b accountBalance) int { return a.Account.ID - b.Account.ID })
+	return balances
+}
+
+// fetchPeriodEntries gets the entries of all the periods.
+func fetchPeriodEntries(client dumpClient, periods []lib.Period) (map[string][]lib.Entry, error) {
+	entries := make(map[string][]lib.Entry, len(periods))
+	for _, period := range periods {
+		periodEntries, err := client.ListEntries(lib.EntryFilter{Period: period.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the entries of period %s: %s", period.ID, err)
+		}
+		entries[period.ID] = periodEntries
+	}
+	return entries, nil
+}
+
+func writeBalancesText(w io.Writer, balances []periodBalances) {
+	fmt.Fprintf(w, "\nBalances:\n")
+	for _, b := range balances {
+		fmt.Fprintf(w, "%s: %s - %s: %s\n",
+			b.Period.ID, b.Period.Start.Format(lib.DateLayout), b.Period.End.Format(lib.DateLayout), balanceText(b.Total),
+		)
+		for _, budget := range b.Budgets {
+			fmt.Fprintf(w, "    %s: %s\n", budget.Budget, balanceText(budget.balance))
+		}
+		for _, account := range b.Accounts {
+			fmt.Fprintf(w, "    %d %s: %s\n", account.Account.ID, account.Account.Bank, balanceText(account.balance))
+		}
+	}
+}
+
+func balanceText(b balance) string {
+	return fmt.Sprintf("income %.2f, spending %.2f, balance %.2f", b.Income, b.Spending, b.Balance())
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockBalancesData() dumpData {
+	// Only the periods and accounts are retrieved to compute the balances.
+	mock := getMockDumpData()
+	data := dumpData{
+		Types:    map[string]bool{typeBalances: true},
+		Periods:  mock.Periods,
+		Accounts: mock.Accounts,
+	}
+	data.Periods = append(data.Periods, lib.Period{
+		ID:     "12000",
+		Status: lib.PeriodStatusDefinitelyClosed,
+		Start:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+	data.Accounts = append(data.Accounts, lib.Account{ID: 2, Bank: "Bank B", Budget: lib.BudgetASC})
+
+	entries := map[string][]lib.Entry{
+		"12345": {
+			{
+				Kind: lib.KindSpend, Budget: lib.BudgetASC, Account: lib.Account{ID: 2},
+				Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 120.5}, {CategoryID: 11, Amount: 30}},
+			},
+			{
+				Kind: lib.KindTake, Budget: lib.BudgetASC, Account: lib.Account{ID: 2},
+				Allocation: []lib.AllocationLine{{CategoryID: 12, Amount: 1000}},
+			},
+			// The allocations don't move money: they are left out of the balances.
+			{
+				Kind: lib.KindAllocation, Budget: lib.BudgetASC, Account: lib.Account{ID: 2},
+				Allocation: []lib.AllocationLine{{CategoryID: 14, Amount: 5000}},
+			},
+			{
+				Kind: lib.KindTake, Budget: lib.BudgetFON, Account: lib.Account{ID: 1},
+				Allocation: []lib.AllocationLine{{CategoryID: 13, Amount: 200}},
+			},
+		},
+	}
+	data.Balances = computeBalances(data.Periods, data.Accounts, entries)
+	return data
+}
+
+func TestComputeBalances(t *testing.T) {
+	data := getMockBalancesData()
+
+	expected := []periodBalances{
+		{
+			Period: data.Periods[0],
+			Total:  balance{Income: 1200, Spending: 150.5},
+			Budgets: []budgetBalance{
+				{Budget: lib.BudgetFON, balance: balance{Income: 200}},
+				{Budget: lib.BudgetASC, balance: balance{Income: 1000, Spending: 150.5}},
+			},
+			Accounts: []accountBalance{
+				{Account: data.Accounts[0], balance: balance{Income: 200}},
+				{Account: data.Accounts[1], balance: balance{Income: 1000, Spending: 150.5}},
+			},
+		},
+		{Period: data.Periods[1]},
+	}
+	if !reflect.DeepEqual(data.Balances, expected) {
+		t.Errorf("Balances mismatch. Got: %+v, Want: %+v", data.Balances, expected)
+	}
+}
+
+func TestWriteBalancesText(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, getMockBalancesData(), formatText); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `Dump happy-compta data for test purpose
+
+Balances:
+12345: 01/01/2025 - 31/12/2025: income 1200.00, spending 150.50, balance 1049.50
+    FON: income 200.00, spending 0.00, balance 200.00
+    ASC: income 1000.00, spending 150.50, balance 849.50
+    1 Bank A: income 200.00, spending 0.00, balance 200.00
+    2 Bank B: income 1000.00, spending 150.50, balance 849.50
+12000: 01/01/2024 - 31/12/2024: income 0.00, spending 0.00, balance 0.00
+`
+	if got := out.String(); got != want {
+		t.Errorf("Text output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteBalancesYAML(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, getMockBalancesData(), formatYAML); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	// The periods and accounts are not dumped as they have not been selected.
+	want := `balances:
+  - period: "12345"
+    start: "2025-01-01"
+    end: "2025-12-31"
+    income: 1200
+    spending: 150.5
+    balance: 1049.5
+    budgets:
+      - budget: FON
+        income: 200
+        spending: 0
+        balance: 200
+      - budget: ASC
+        income: 1000
+        spending: 150.5
+        balance: 849.5
+    accounts:
+      - id: 1
+        bank: Bank A
+        income: 200
+        spending: 0
+        balance: 200
+      - id: 2
+        bank: Bank B
+        income: 1000
+        spending: 150.5
+        balance: 849.5
+  - period: "12000"
+    start: "2024-01-01"
+    end: "2024-12-31"
+    income: 0
+    spending: 0
+    balance: 0
+    budgets: []
+    accounts: []
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Config holds the application parameters.
+type Config struct {
+	Email    string   `mapstructure:"email"`
+	Password string   `mapstructure:"password"`
+	Format   string   `mapstructure:"format"`
+	Only     []string `mapstructure:"only"`
+	Skip     []string `mapstructure:"skip"`
+	Output   string   `mapstructure:"output"`
+	Budget   string   `mapstructure:"budget"`
+	// Organization is the name of the organization written in the structured outputs metadata.
+	Organization string `mapstructure:"organization"`
+	// Limits are the spending limits indexed by category ID or name.
+	Limits map[string]float64 `mapstructure:"limits"`
+	// Emails are the email addresses of the employees indexed by ID or "Firstname Lastname".
+	Emails map[string]string   `mapstructure:"emails"`
+	CSV    CSVConfig           `mapstructure:"csv"`
+	Notify common.NotifyConfig `mapstructure:"notify"`
+
+	ActiveOnly      bool `flag:"active-only"`
+	IncludeArchived bool `flag:"include-archived"`
+}
+
+// CSVConfig holds the settings of the CSV files.
+type CSVConfig struct {
+	// Output configures the written CSV files, like the entries text output or the export one.
+	Output common.CSVWriterParams `mapstructure:"output"`
+}
+
+// ConfigKeys are the keys of the configuration file not matching a flag of the dumper command.
+var ConfigKeys = common.ConfigKeys{
+	"organization": "string",
+	"limits.*":     "float64",
+	"emails.*":     "string",
+}
+
+// NewCommand creates the dumper command with the given name.
+func NewCommand(name string) *cobra.Command {
+	dumperCmd := &cobra.Command{
+		Use:   name,
+		Short: "A program dumping data from happy-compta",
+		Long: `A program dumping data from happy-compta.
+
+The balances are computed from the entries of all the accounting periods, per budget and per bank account.
+The stock report counts the items bought and handed out for the categories with stock enabled.
+The spending report sums the spending entries per category. Set the spending limits in the limits map
+of the configuration file, indexed by category ID or name, to get the consumption percentages.
+Since all the entries need to be read for them, skip those when not needed to speed up the dump.
+The sites and users, with their roles and the sites they can access, help auditing the accesses
+of multi-site organizations.`,
+		Version: common.FullVersion(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			// Actually do something
+			summary, err := dump(cfg)
+			common.Notify(cfg.Notify, summary.notification())
+			return err
+		},
+	}
+	dumperCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) (err error) {
+		common.SetupCommand(dumperCmd, "DUMPER", ConfigKeys)
+
+		// Not all the commands can write to a file
+		output, _ := cmd.Flags().GetString("output")
+		terminal := output == "" && isTerminal(os.Stdout)
+		tableSettings, err = newTableOptions(viper.GetString("color"), viper.GetInt("column.width"), terminal)
+		return
+	}
+
+	dumperCmd.PersistentFlags().StringP("config", "c", "", "Configuration file path")
+	common.AddLogFlags(dumperCmd)
+	common.AddLanguageFlag(dumperCmd)
+	dumperCmd.SetFlagErrorFunc(common.FlagError)
+	dumperCmd.PersistentFlags().String("email", "", "User email address (REQUIRED)")
+	dumperCmd.PersistentFlags().String("password", "", "User password (REQUIRED)")
+
+	dumperCmd.PersistentFlags().String("format", formatText, "Output format. Can be one of text, table or yaml.")
+	dumperCmd.PersistentFlags().String("color", colorAuto, `Colorize the table format.
+Can be one of auto, always or never. auto only colorizes when writing to a terminal.`)
+	dumperCmd.PersistentFlags().String("csv-output-comma", "", `Field separator of the written CSV files.
+Defaults to a comma, or a semicolon for the export command.`)
+	dumperCmd.PersistentFlags().String("csv-output-encoding", "", "Encoding of the written CSV files. Defaults to utf-8.")
+	dumperCmd.PersistentFlags().Bool("csv-output-bom", false,
+		"Start the written UTF-8 CSV files with a byte order mark for Excel to detect their encoding.")
+	dumperCmd.PersistentFlags().Int("column-width", 40,
+		"Maximum width of the table format cells, 0 to disable the truncation.")
+	dumperCmd.Flags().StringSlice("only", nil, `Comma-separated list of the object types to dump.
+Can contain `+strings.Join(objectTypes, ", ")+`.
+All the types but `+strings.Join(optInObjectTypes, ", ")+` are dumped by default:
+these ones need to be listed as they download all the entries of all the periods
+or, for the sites and users, need an administrator account.`)
+	dumperCmd.Flags().StringSlice("skip", nil, "Comma-separated list of the object types not to dump.")
+	dumperCmd.Flags().StringP("output", "o", "", `File to write the dump to instead of the standard output.
+If the path is a directory or ends with a separator, each object type is written in a timestamped file in it.`)
+	dumperCmd.Flags().Bool("active-only", false, "Only dump the active employees.")
+	dumperCmd.Flags().Bool("include-archived", true, "Dump the archived providers.")
+	common.AddNotifyFlags(dumperCmd)
+	dumperCmd.Flags().String("budget", "", "Only dump the categories and accounts of a budget. Can be one of FON or ASC.")
+	common.AddFlagCompletion(dumperCmd, "format", formatText, formatTable, formatYAML)
+	common.AddFlagCompletion(dumperCmd, "color", colorAuto, colorAlways, colorNever)
+	common.AddFlagCompletion(dumperCmd, "only", objectTypes...)
+	common.AddFlagCompletion(dumperCmd, "skip", objectTypes...)
+	common.AddFlagCompletion(dumperCmd, "budget", lib.BudgetFON.String(), lib.BudgetASC.String())
+
+	dumperCmd.SetVersionTemplate("{{.Version}}\n")
+
+	dumperCmd.AddCommand(newEntriesCmd())
+	dumperCmd.AddCommand(newReceiptsCmd())
+	dumperCmd.AddCommand(newReconcileCmd())
+	dumperCmd.AddCommand(newBackupCmd())
+	dumperCmd.AddCommand(newWatchCmd())
+	dumperCmd.AddCommand(newExportCmd())
+	dumperCmd.AddCommand(newContactsCmd())
+	dumperCmd.AddCommand(newMissingReceiptsCmd())
+
+	return dumperCmd
+}
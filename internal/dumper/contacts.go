@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	contactsVCard  = "vcard"
+	contactsGoogle = "google"
+)
+
+// The contact groups are named like the happy-compta lists.
+const (
+	providersGroup = "Fournisseurs"
+	employeesGroup = "Salariés"
+)
+
+// googleColumns are the columns of the Google Contacts CSV import.
+var googleColumns = []string{
+	"First Name", "Last Name", "Organization Name", "E-mail 1 - Label", "E-mail 1 - Value",
+	"Phone 1 - Label", "Phone 1 - Value", "Address 1 - Label", "Address 1 - Street", "Address 1 - City",
+	"Address 1 - Postal Code", "Notes", "Labels",
+}
+
+func newContactsCmd() *cobra.Command {
+	var contactsCmd = &cobra.Command{
+		Use:   "contacts",
+		Short: "Export the providers and employees as contacts",
+		Long: `Export the providers and employees as contacts to import in a mail client.
+
+The vcard format writes a vCard 3.0 file and the google format a CSV file for the Google Contacts import.
+The providers are grouped in the Fournisseurs category or label and the employees in the Salariés one.
+The employees only have their names as happy-compta doesn't give their other details.
+The vCard UIDs are derived from the happy-compta IDs for the mail clients to update the existing contacts.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			format, err := cmd.Flags().GetString("contacts-format")
+			if err != nil {
+				return err
+			}
+			if format != contactsVCard && format != contactsGoogle {
+				return common.WithExitCode(common.ExitUsage, fmt.Errorf("invalid contacts format: %s", format))
+			}
+			out, err := cmd.Flags().GetString("out")
+			if err != nil {
+				return err
+			}
+			if cfg.ActiveOnly, err = cmd.Flags().GetBool("active-only"); err != nil {
+				return err
+			}
+			if cfg.IncludeArchived, err = cmd.Flags().GetBool("include-archived"); err != nil {
+				return err
+			}
+
+			return contacts(cfg, format, out)
+		},
+	}
+	contactsCmd.Flags().String("contacts-format", contactsVCard, "Format of the contacts. Can be one of vcard or google.")
+	contactsCmd.Flags().String("out", "", "File to write the contacts to instead of the standard output.")
+	contactsCmd.Flags().Bool("active-only", false, "Only export the active employees.")
+	contactsCmd.Flags().Bool("include-archived", false, "Export the archived providers.")
+	common.AddFlagCompletion(contactsCmd, "contacts-format", contactsVCard, contactsGoogle)
+
+	return contactsCmd
+}
+
+func contacts(cfg Config, format string, out string) error {
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	var data dumpData
+	if data.Employees, err = client.ListEmployees(); err != nil {
+		return err
+	}
+	if data.Providers, err = client.ListProviders(); err != nil {
+		return err
+	}
+	filter := dumpFilter{ActiveOnly: cfg.ActiveOnly, IncludeArchived: cfg.IncludeArchived}
+	data = filter.apply(data)
+
+	write := func(w io.Writer) error {
+		if format == contactsGoogle {
+			return writeGoogleContacts(w, data.Providers, data.Employees, cfg.Organization, cfg.CSV.Output)
+		}
+		return writeVCards(w, data.Providers, data.Employees, cfg.Organization)
+	}
+	if out == "" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", out, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := write(file); err != nil {
+		return fmt.Errorf("failed to write %s: %s", out, err)
+	}
+	return file.Close()
+}
+
+// writeVCards writes a vCard 3.0 card per provider and employee.
+// The employees get the organization name, if set.
+func writeVCards(w io.Writer, providers []lib.Provider, employees []lib.Employee, organization string) error {
+	for _, provider := range providers {
+		lines := []string{
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"UID:happycompta-provider-" + escapeVCard(provider.ID),
+			"FN:" + escapeVCard(provider.Name),
+			"N:;;;;",
+			"ORG:" + escapeVCard(provider.Name),
+		}
+		if provider.Email != "" {
+			lines = append(lines, "EMAIL;TYPE=INTERNET,WORK:"+escapeVCard(provider.Email))
+		}
+		if provider.Phone != "" {
+			lines = append(lines, "TEL;TYPE=WORK,VOICE:"+escapeVCard(provider.Phone))
+		}
+		if provider.Address != "" || provider.City != "" || provider.ZipCode != "" {
+			lines = append(lines, fmt.Sprintf("ADR;TYPE=WORK:;;%s;%s;;%s;",
+				escapeVCard(provider.Address), escapeVCard(provider.City), escapeVCard(provider.ZipCode)))
+		}
+		if provider.Comment != "" {
+			lines = append(lines, "NOTE:"+escapeVCard(provider.Comment))
+		}
+		lines = append(lines, "CATEGORIES:"+providersGroup, "END:VCARD")
+		if err := writeVCardLines(w, lines); err != nil {
+			return err
+		}
+	}
+
+	for _, employee := range employees {
+		lines := []string{
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"UID:happycompta-employee-" + escapeVCard(employee.ID),
+			"FN:" + escapeVCard(employee.Firstname+" "+employee.Lastname),
+			"N:" + escapeVCard(employee.Lastname) + ";" + escapeVCard(employee.Firstname) + ";;;",
+		}
+		if organization != "" {
+			lines = append(lines, "ORG:"+escapeVCard(organization))
+		}
+		lines = append(lines, "CATEGORIES:"+employeesGroup, "END:VCARD")
+		if err := writeVCardLines(w, lines); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeVCard escapes the special characters of a vCard text value.
+func escapeVCard(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`,
+	).Replace(value)
+}
+
+// writeVCardLines writes the lines with CRLF endings, folding them at 75 bytes as required by the vCard format.
+// The lines are never split in the middle of a UTF-8 character.
+func writeVCardLines(w io.Writer, lines []string) error {
+	var builder strings.Builder
+	for _, line := range lines {
+		limit := 75
+		for len(line) > limit {
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			builder.WriteString(line[:cut] + "\r\n ")
+			line = line[cut:]
+			// The continuation lines start with a space.
+			limit = 74
+		}
+		builder.WriteString(line + "\r\n")
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// writeGoogleContacts writes the contacts in the CSV format of the Google Contacts import.
+func writeGoogleContacts(
+	w io.Writer,
+	providers []lib.Provider,
+	employees []lib.Employee,
+	organization string,
+	params common.CSVWriterParams,
+) error {
+	writer, err := common.NewCSVWriter(w, params, googleColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range providers {
+		var emailLabel, phoneLabel, addressLabel string
+		if provider.Email != "" {
+			emailLabel = "Work"
+		}
+		if provider.Phone != "" {
+			phoneLabel = "Work"
+		}
+		if provider.Address != "" || provider.City != "" || provider.ZipCode != "" {
+			addressLabel = "Work"
+		}
+		err := writer.Write([]string{
+			"", "", provider.Name, emailLabel, provider.Email, phoneLabel, provider.Phone,
+			addressLabel, provider.Address, provider.City, provider.ZipCode, provider.Comment, providersGroup,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, employee := range employees {
+		err := writer.Write([]string{
+			employee.Firstname, employee.Lastname, organization, "", "", "", "", "", "", "", "", "", employeesGroup,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
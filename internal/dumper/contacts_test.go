@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+var contactsProviders = []lib.Provider{
+	{
+		ID: "p1", Name: "Boutique; Cadeaux", Address: "1 rue des Lilas", ZipCode: "75001", City: "Paris",
+		Phone: "01 23 45 67 89", Email: "contact@boutique.fr", Comment: "Livraison\nle mardi",
+	},
+	{ID: "p2", Name: "Traiteur"},
+}
+
+var contactsEmployees = []lib.Employee{{ID: "e1", Lastname: "Dupont", Firstname: "Jean", Active: true}}
+
+func TestWriteVCards(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeVCards(&buf, contactsProviders, contactsEmployees, "CSE Exemple"); err != nil {
+		t.Fatalf("writeVCards failed: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"UID:happycompta-provider-p1",
+		`FN:Boutique\; Cadeaux`,
+		"N:;;;;",
+		`ORG:Boutique\; Cadeaux`,
+		"EMAIL;TYPE=INTERNET,WORK:contact@boutique.fr",
+		"TEL;TYPE=WORK,VOICE:01 23 45 67 89",
+		"ADR;TYPE=WORK:;;1 rue des Lilas;Paris;;75001;",
+		`NOTE:Livraison\nle mardi`,
+		"CATEGORIES:Fournisseurs",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"UID:happycompta-provider-p2",
+		"FN:Traiteur",
+		"N:;;;;",
+		"ORG:Traiteur",
+		"CATEGORIES:Fournisseurs",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"UID:happycompta-employee-e1",
+		"FN:Jean Dupont",
+		"N:Dupont;Jean;;;",
+		"ORG:CSE Exemple",
+		"CATEGORIES:Salariés",
+		"END:VCARD",
+		"",
+	}, "\r\n")
+	if buf.String() != expected {
+		t.Errorf("vCards mismatch. Got:\n%s\nWant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteVCardLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{"short", "FN:Traiteur", "FN:Traiteur\r\n"},
+		{
+			"folded",
+			"NOTE:" + strings.Repeat("a", 80),
+			"NOTE:" + strings.Repeat("a", 70) + "\r\n " + strings.Repeat("a", 10) + "\r\n",
+		},
+		{
+			"multibyte",
+			"NOTE:" + strings.Repeat("a", 69) + "éé",
+			"NOTE:" + strings.Repeat("a", 69) + "\r\n éé\r\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeVCardLines(&buf, []string{tc.line}); err != nil {
+				t.Fatalf("writeVCardLines failed: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("Folded line mismatch. Got: %q, Want: %q", buf.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestWriteGoogleContacts(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeGoogleContacts(&buf, contactsProviders, contactsEmployees, "CSE Exemple", common.CSVWriterParams{})
+	if err != nil {
+		t.Fatalf("writeGoogleContacts failed: %v", err)
+	}
+
+	expected := "First Name,Last Name,Organization Name,E-mail 1 - Label,E-mail 1 - Value," +
+		"Phone 1 - Label,Phone 1 - Value,Address 1 - Label,Address 1 - Street,Address 1 - City," +
+		"Address 1 - Postal Code,Notes,Labels\n" +
+		",,Boutique; Cadeaux,Work,contact@boutique.fr,Work,01 23 45 67 89,Work,1 rue des Lilas,Paris,75001," +
+		"\"Livraison\nle mardi\",Fournisseurs\n" +
+		",,Traiteur,,,,,,,,,,Fournisseurs\n" +
+		"Jean,Dupont,CSE Exemple,,,,,,,,,,Salariés\n"
+	if buf.String() != expected {
+		t.Errorf("Google contacts mismatch. Got:\n%s\nWant:\n%s", buf.String(), expected)
+	}
+}
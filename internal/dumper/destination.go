@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// timestampFormat is the layout of the timestamp in the generated file names.
+const timestampFormat = "20060102-150405"
+
+// isOutputDir returns whether the output path is a directory.
+// Paths ending with a separator are directories even if they don't exist yet.
+func isOutputDir(path string) bool {
+	if path == "" {
+		return false
+	}
+	if strings.HasSuffix(path, string(os.PathSeparator)) || strings.HasSuffix(path, "/") {
+		return true
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// formatExtension returns the file extension for the output format.
+// textExt is the extension of the text format as it differs depending on the command.
+func formatExtension(format string, textExt string) string {
+	switch format {
+	case formatYAML:
+		return "yaml"
+	case formatTable:
+		return "txt"
+	}
+	return textExt
+}
+
+// outputFileName returns a timestamped file name to keep the previous outputs in a directory.
+func outputFileName(name string, ext string, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", name, now.Format(timestampFormat), ext)
+}
+
+// createOutput opens the file to write to.
+// The standard output is used when the path is empty and a timestamped file is created in directories.
+func createOutput(path string, name string, ext string, now time.Time) (io.WriteCloser, error) {
+	if path == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	if isOutputDir(path) {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create the output directory %s: %s", path, err)
+		}
+		path = filepath.Join(path, outputFileName(name, ext, now))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the output file %s: %s", path, err)
+	}
+	return file, nil
+}
+
+// writeOutput writes to the output path using the write function.
+func writeOutput(path string, name string, ext string, now time.Time, write func(io.Writer) error) error {
+	w, err := createOutput(path, name, ext, now)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = w.Close() }()
+
+	if err := write(w); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsOutputDir(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"", false},
+		{dir, true},
+		{filepath.Join(dir, "dump.yaml"), false},
+		{filepath.Join(dir, "backups") + string(os.PathSeparator), true},
+	}
+
+	for _, tt := range tests {
+		if got := isOutputDir(tt.path); got != tt.want {
+			t.Errorf("isOutputDir(%q) mismatch. Got: %t, Want: %t", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestWriteOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.txt")
+	now := time.Date(2025, 3, 31, 23, 0, 5, 0, time.UTC)
+
+	if err := writeOutput(path, "dump", "txt", now, func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+		return err
+	}); err != nil {
+		t.Fatalf("writeOutput failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the output: %v", err)
+	}
+	if string(content) != "content" {
+		t.Errorf("Content mismatch. Got: %q, Want: %q", content, "content")
+	}
+}
+
+func TestWriteDumpDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+	data := getMockDumpData()
+	data.Types = map[string]bool{typeProviders: true, typeAccounts: true}
+	now := time.Date(2025, 3, 31, 23, 0, 5, 0, time.UTC)
+
+	if err := writeDumpDir(dir, data, formatYAML, now); err != nil {
+		t.Fatalf("writeDumpDir failed: %v", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read the output directory: %v", err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	want := []string{"accounts-20250331-230005.yaml", "providers-20250331-230005.yaml"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("Files mismatch. Got: %v, Want: %v", names, want)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, want[1]))
+	if err != nil {
+		t.Fatalf("failed to read the providers file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "providers:\n") || strings.Contains(string(content), "accounts:") {
+		t.Errorf("Unexpected providers file content: %q", content)
+	}
+}
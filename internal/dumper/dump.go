@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// Object types that can be dumped
+const (
+	typeEmployees  = "employees"
+	typeProviders  = "providers"
+	typePeriods    = "periods"
+	typeAccounts   = "accounts"
+	typeCategories = "categories"
+	typeBalances   = "balances"
+	typeStock      = "stock"
+	typeSpending   = "spending"
+	typeSites      = "sites"
+	typeUsers      = "users"
+)
+
+var objectTypes = []string{
+	typeEmployees, typeProviders, typePeriods, typeAccounts, typeCategories, typeBalances, typeStock, typeSpending,
+	typeSites, typeUsers,
+}
+
+// optInObjectTypes are the object types only dumped when listed in only.
+// The balances, stock and spending are computed from all the entries of all the periods
+// and downloading the whole history takes a long time.
+// The sites and users pages are only accessible to the administrators.
+var optInObjectTypes = []string{typeBalances, typeStock, typeSpending, typeSites, typeUsers}
+
+// selectObjectTypes returns the object types to dump.
+// Only the types in only are selected if it is not empty and the types in skip are never selected.
+// Without only, the opt-in types are not selected.
+func selectObjectTypes(only []string, skip []string) (map[string]bool, error) {
+	for _, name := range append(slices.Clone(only), skip...) {
+		if !slices.Contains(objectTypes, name) {
+			return nil, fmt.Errorf("unknown object type %s, accepted values are %s", name, strings.Join(objectTypes, ", "))
+		}
+	}
+
+	types := map[string]bool{}
+	for _, name := range objectTypes {
+		selected := slices.Contains(only, name) || (len(only) == 0 && !slices.Contains(optInObjectTypes, name))
+		if selected && !slices.Contains(skip, name) {
+			types[name] = true
+		}
+	}
+	return types, nil
+}
+
+// dumpData holds the data retrieved from happy-compta.
+type dumpData struct {
+	// Types are the object types that have been retrieved.
+	Types      map[string]bool
+	Employees  []lib.Employee
+	Providers  []lib.Provider
+	Periods    []lib.Period
+	Accounts   []lib.Account
+	Categories []lib.Category
+	Balances   []periodBalances
+	Stock      []stockLevel
+	Spending   []periodSpending
+	Sites      []lib.Site
+	Users      []lib.User
+	// Metadata describes the dump in the structured formats.
+	Metadata *outputMetadata
+}
+
+func dump(cfg Config) (summary dumpSummary, err error) {
+	summary = dumpSummary{Output: cfg.Output, Format: cfg.Format, Started: time.Now()}
+	defer func() {
+		summary.Finished = time.Now()
+		if err != nil {
+			summary.Error = err.Error()
+		}
+	}()
+
+	types, err := selectObjectTypes(cfg.Only, cfg.Skip)
+	if err != nil {
+		return summary, err
+	}
+	filter, err := newDumpFilter(cfg.ActiveOnly, cfg.IncludeArchived, cfg.Budget)
+	if err != nil {
+		return summary, err
+	}
+
+	client, err := lib.NewClient()
+	if err != nil {
+		return summary, err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return summary, common.WithExitCode(common.ExitAuth, err)
+	}
+
+	data, err := fetchDump(client, types)
+	if err != nil {
+		return summary, err
+	}
+	data = filter.apply(data)
+	setSpendingLimits(data.Spending, cfg.Limits)
+	summary.Counts = data.counts()
+
+	now := time.Now()
+	data.Metadata = newOutputMetadata(now, cfg.Organization)
+	if isOutputDir(cfg.Output) {
+		return summary, writeDumpDir(cfg.Output, data, cfg.Format, now)
+	}
+	return summary, writeOutput(cfg.Output, "dump", formatExtension(cfg.Format, "txt"), now, func(w io.Writer) error {
+		return writeDump(w, data, cfg.Format)
+	})
+}
+
+// writeDumpDir writes each object type in its own file in the dir folder.
+func writeDumpDir(dir string, data dumpData, format string, now time.Time) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create the output directory %s: %s", dir, err)
+	}
+
+	for _, name := range objectTypes {
+		if !data.Types[name] {
+			continue
+		}
+		typeData := data
+		typeData.Types = map[string]bool{name: true}
+		if err := writeOutput(dir, name, formatExtension(format, "txt"), now, func(w io.Writer) error {
+			return writeDump(w, typeData, format)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpClient is the part of the happy-compta client used to fetch the dumped data.
+type dumpClient interface {
+	ListEmployees() ([]lib.Employee, error)
+	ListProviders() ([]lib.Provider, error)
+	ListPeriods() ([]lib.Period, error)
+	ListAccounts() ([]lib.Account, error)
+	ListCategories() ([]lib.Category, error)
+	ListSites() ([]lib.Site, error)
+	ListUsers() ([]lib.User, error)
+	ListEntries(filter lib.EntryFilter) ([]lib.Entry, error)
+}
+
+// fetchDump gets the data of the selected object types from happy-compta.
+// The lists are independent and fetched concurrently.
+func fetchDump(client dumpClient, types map[string]bool) (data dumpData, err error) {
+	data.Types = types
+
+	// The balances, stock and spending are computed from the entries of all periods.
+	// The balances need the accounts names and the stock and spending the categories.
+	needEntries := types[typeBalances] || types[typeStock] || types[typeSpending]
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+	fetch := func(needed bool, list func() error) {
+		if !needed {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := list(); err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	fetch(types[typeEmployees], func() (err error) {
+		data.Employees, err = client.ListEmployees()
+		return
+	})
+	fetch(types[typeProviders], func() (err error) {
+		data.Providers, err = client.ListProviders()
+		return
+	})
+	fetch(types[typePeriods] || needEntries, func() (err error) {
+		data.Periods, err = client.ListPeriods()
+		return
+	})
+	fetch(types[typeAccounts] || types[typeBalances], func() (err error) {
+		data.Accounts, err = client.ListAccounts()
+		return
+	})
+	fetch(types[typeCategories] || types[typeStock] || types[typeSpending], func() (err error) {
+		data.Categories, err = client.ListCategories()
+		return
+	})
+	fetch(types[typeSites], func() (err error) {
+		data.Sites, err = client.ListSites()
+		return
+	})
+	fetch(types[typeUsers], func() (err error) {
+		data.Users, err = client.ListUsers()
+		return
+	})
+	wg.Wait()
+
+	if err = errors.Join(errs...); err != nil {
+		return
+	}
+
+	if needEntries {
+		var entries map[string][]lib.Entry
+		if entries, err = fetchPeriodEntries(client, data.Periods); err != nil {
+			return
+		}
+		if types[typeBalances] {
+			data.Balances = computeBalances(data.Periods, data.Accounts, entries)
+		}
+		if types[typeStock] {
+			data.Stock = computeStock(data.Categories, entries)
+		}
+		if types[typeSpending] {
+			data.Spending = computeSpending(data.Periods, data.Categories, entries)
+		}
+	}
+	return
+}
+
+// writeDump writes the data in the requested format.
+func writeDump(w io.Writer, data dumpData, format string) error {
+	switch format {
+	case "", formatText:
+		return writeText(w, data)
+	case formatTable:
+		return writeTables(w, newDumpTables(data), tableSettings)
+	case formatYAML:
+		return writeYAML(w, newDumpOutput(data))
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+// writeText writes the data in a human readable form.
+func writeText(w io.Writer, data dumpData) error {
+	fmt.Fprintf(w, "Dump happy-compta data for test purpose\n")
+
+	if data.Types[typeEmployees] {
+		writeEmployeesText(w, data.Employees)
+	}
+	if data.Types[typeProviders] {
+		writeProvidersText(w, data.Providers)
+	}
+	if data.Types[typePeriods] {
+		writePeriodsText(w, data.Periods)
+	}
+	if data.Types[typeAccounts] {
+		writeAccountsText(w, data.Accounts)
+	}
+	if data.Types[typeCategories] {
+		writeCategoriesText(w, data.Categories)
+	}
+	if data.Types[typeBalances] {
+		writeBalancesText(w, data.Balances)
+	}
+	if data.Types[typeStock] {
+		writeStockText(w, data.Stock)
+	}
+	if data.Types[typeSpending] {
+		writeSpendingText(w, data.Spending)
+	}
+	if data.Types[typeSites] {
+		writeSitesText(w, data.Sites)
+	}
+	if data.Types[typeUsers] {
+		writeUsersText(w, data.Users)
+	}
+	return nil
+}
+
+func writeEmployeesText(w io.Writer, employees []lib.Employee) {
+	fmt.Fprintf(w, "\nEmployees (%d):\n", len(employees))
+	for _, emp := range employees {
+		active := "inactive"
+		if emp.Active {
+			active = "active"
+		}
+
+		fmt.Fprintf(w, "%s: %s,%s (%s)\n", emp.ID, emp.Lastname, emp.Firstname, active)
+	}
+}
+
+func writeProvidersText(w io.Writer, providers []lib.Provider) {
+	fmt.Fprintf(w, "\nProviders (%d):\n", len(providers))
+	for _, p := range providers {
+		archived := ""
+		if p.Archived {
+			archived = " (Archived)"
+		}
+		fmt.Fprintf(w,
+			"%s: %s%s\n    %s - %s %s\n    %s\n    %s\n    %s\n",
+			p.ID, p.Name, archived,
+			p.Address, p.ZipCode, p.City,
+			p.Phone,
+			p.Email,
+			p.Comment,
+		)
+	}
+}
+
+func writePeriodsText(w io.Writer, periods []lib.Period) {
+	fmt.Fprintf(w, "\nPeriods:\n")
+	for _, p := range periods {
+		fmt.Fprintf(w, "%s: %s - %s (%d)\n", p.ID, p.Start.Format(lib.DateLayout), p.End.Format(lib.DateLayout), p.Status)
+	}
+}
+
+func writeAccountsText(w io.Writer, accounts []lib.Account) {
+	fmt.Fprintf(w, "\nAccounts:\n")
+	for _, account := range accounts {
+		fmt.Fprintf(w, "%d: %s (%d - %s)\n", account.ID, account.Bank, account.Budget, account.Abbrev)
+	}
+}
+
+func writeCategoriesText(w io.Writer, categories []lib.Category) {
+	fmt.Fprintf(w, "\nCategories (%d)\n", len(categories))
+	for _, category := range categories {
+		fmt.Fprintf(w,
+			"%d: %s (%s), parent: %d, section: %d\n",
+			category.ID,
+			category.Name,
+			category.Kind,
+			category.ParentID,
+			category.Budget,
+		)
+	}
+}
+
+func writeSitesText(w io.Writer, sites []lib.Site) {
+	fmt.Fprintf(w, "\nSites (%d):\n", len(sites))
+	for _, site := range sites {
+		fmt.Fprintf(w, "%s: %s\n    %s - %s %s\n", site.ID, site.Name, site.Address, site.ZipCode, site.City)
+	}
+}
+
+func writeUsersText(w io.Writer, users []lib.User) {
+	fmt.Fprintf(w, "\nUsers (%d):\n", len(users))
+	for _, user := range users {
+		active := "inactive"
+		if user.Active {
+			active = "active"
+		}
+		sites := "all sites"
+		if len(user.Sites) > 0 {
+			sites = strings.Join(user.Sites, ", ")
+		}
+		fmt.Fprintf(w, "%s: %s,%s <%s> %s on %s (%s)\n",
+			user.ID, user.Lastname, user.Firstname, user.Email, user.Role, sites, active)
+	}
+}
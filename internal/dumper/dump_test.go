@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestSelectObjectTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    map[string]bool
+		wantErr bool
+	}{
+		{
+			name: "all but the opt-in types by default",
+			want: map[string]bool{
+				typeEmployees: true, typeProviders: true, typePeriods: true, typeAccounts: true, typeCategories: true,
+			},
+		},
+		{
+			name: "opt-in types",
+			only: []string{typeBalances, typeStock, typeSpending, typeSites, typeUsers},
+			want: map[string]bool{
+				typeBalances: true, typeStock: true, typeSpending: true, typeSites: true, typeUsers: true,
+			},
+		},
+		{
+			name: "only",
+			only: []string{typeProviders, typeAccounts},
+			want: map[string]bool{typeProviders: true, typeAccounts: true},
+		},
+		{
+			name: "skip",
+			skip: []string{typeEmployees, typeCategories},
+			want: map[string]bool{typeProviders: true, typePeriods: true, typeAccounts: true},
+		},
+		{
+			name: "only and skip",
+			only: []string{typeProviders, typeAccounts},
+			skip: []string{typeAccounts},
+			want: map[string]bool{typeProviders: true},
+		},
+		{name: "unknown type", only: []string{"invoices"}, wantErr: true},
+		{name: "unknown skipped type", skip: []string{"invoices"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectObjectTypes(tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectObjectTypes error mismatch. Got: %v, Want error: %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Types mismatch. Got: %v, Want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTextSelectedTypes(t *testing.T) {
+	data := getMockDumpData()
+	data.Types = map[string]bool{typeProviders: true}
+
+	var out bytes.Buffer
+	if err := writeDump(&out, data, formatText); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "Providers (1):\np1: ACME (Archived)") {
+		t.Errorf("Expected the providers in the output, got: %q", got)
+	}
+	for _, section := range []string{"Employees", "Periods", "Accounts", "Categories"} {
+		if strings.Contains(got, section) {
+			t.Errorf("Unexpected %s section in the output: %q", section, got)
+		}
+	}
+}
+
+// fakeDumpClient returns the mock data once all the expected calls are running concurrently.
+type fakeDumpClient struct {
+	data    dumpData
+	started sync.WaitGroup
+	failing bool
+}
+
+func (c *fakeDumpClient) wait() error {
+	c.started.Done()
+	done := make(chan struct{})
+	go func() {
+		c.started.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		return errors.New("the lists are not fetched concurrently")
+	}
+	if c.failing {
+		return errors.New("failed to fetch")
+	}
+	return nil
+}
+
+func (c *fakeDumpClient) ListEmployees() ([]lib.Employee, error) {
+	return c.data.Employees, c.wait()
+}
+
+func (c *fakeDumpClient) ListProviders() ([]lib.Provider, error) {
+	return c.data.Providers, c.wait()
+}
+
+func (c *fakeDumpClient) ListPeriods() ([]lib.Period, error) {
+	return c.data.Periods, c.wait()
+}
+
+func (c *fakeDumpClient) ListAccounts() ([]lib.Account, error) {
+	return c.data.Accounts, c.wait()
+}
+
+func (c *fakeDumpClient) ListCategories() ([]lib.Category, error) {
+	return c.data.Categories, c.wait()
+}
+
+func (c *fakeDumpClient) ListSites() ([]lib.Site, error) {
+	return c.data.Sites, c.wait()
+}
+
+func (c *fakeDumpClient) ListUsers() ([]lib.User, error) {
+	return c.data.Users, c.wait()
+}
+
+func (c *fakeDumpClient) ListEntries(filter lib.EntryFilter) ([]lib.Entry, error) {
+	return nil, nil
+}
+
+func TestFetchDump(t *testing.T) {
+	expected := getMockDumpData()
+	client := &fakeDumpClient{data: expected}
+	client.started.Add(5)
+
+	data, err := fetchDump(client, expected.Types)
+	if err != nil {
+		t.Fatalf("fetchDump failed: %v", err)
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Data mismatch. Got: %+v, Want: %+v", data, expected)
+	}
+}
+
+func TestFetchDumpSitesUsers(t *testing.T) {
+	expected := getMockSitesUsersData()
+	client := &fakeDumpClient{data: expected}
+	client.started.Add(2)
+
+	data, err := fetchDump(client, expected.Types)
+	if err != nil {
+		t.Fatalf("fetchDump failed: %v", err)
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Data mismatch. Got: %+v, Want: %+v", data, expected)
+	}
+}
+
+func TestWriteTextSitesUsers(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, getMockSitesUsersData(), formatText); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `Dump happy-compta data for test purpose
+
+Sites (2):
+s1: Head office
+    1 rue de la Paix - 75002 Paris
+s2: Factory
+     - 69007 Lyon
+
+Users (2):
+u1: Doe,Jane <jane@example.com> Administrateur on all sites (active)
+u2: Smith,John <john@example.com> Lecteur on Head office, Factory (inactive)
+`
+	if got := out.String(); got != want {
+		t.Errorf("Text output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestFetchDumpError(t *testing.T) {
+	client := &fakeDumpClient{data: getMockDumpData(), failing: true}
+	client.started.Add(2)
+
+	_, err := fetchDump(client, map[string]bool{typeEmployees: true, typeProviders: true})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if count := strings.Count(err.Error(), "failed to fetch"); count != 2 {
+		t.Errorf("Expected both errors to be reported, got: %v", err)
+	}
+}
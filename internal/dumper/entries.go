@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+func newEntriesCmd() *cobra.Command {
+	var entriesCmd = &cobra.Command{
+		Use:   "entries",
+		Short: "List entries details",
+		Long: `List the entries of an accounting period with their allocations, party, payment method and receipts.
+The text format is a CSV table with one line per entry.`,
+		Args: common.UsageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			period, err := cmd.Flags().GetString("period")
+			if err != nil {
+				return err
+			}
+			// The period used to be passed as argument
+			if len(args) > 0 {
+				period = args[0]
+			}
+			if cfg.Output, err = cmd.Flags().GetString("output"); err != nil {
+				return err
+			}
+
+			from, err := getDateFlag(cmd, "from")
+			if err != nil {
+				return err
+			}
+			to, err := getDateFlag(cmd, "to")
+			if err != nil {
+				return err
+			}
+
+			// Actually do something
+			return entries(cfg, period, from, to)
+		},
+	}
+	entriesCmd.Flags().String("period", "", `Accounting period of the entries to list.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	entriesCmd.Flags().StringP("output", "o", "", `File to write the entries to instead of the standard output.
+If the path is a directory or ends with a separator, the entries are written in a timestamped file in it.`)
+	entriesCmd.Flags().String("from", "", "Only list the entries dated on or after this day, like DD/MM/YYYY or YYYY-MM-DD.")
+	entriesCmd.Flags().String("to", "", "Only list the entries dated on or before this day, like DD/MM/YYYY or YYYY-MM-DD.")
+
+	return entriesCmd
+}
+
+func getDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseDateFlag(name, value)
+}
+
+// entriesData holds the entries and the data needed to describe them.
+type entriesData struct {
+	Entries    []lib.Entry
+	Accounts   []lib.Account
+	Categories []lib.Category
+	Employees  []lib.Employee
+	Providers  []lib.Provider
+	Metadata   *outputMetadata
+}
+
+func entries(cfg Config, period string, from time.Time, to time.Time) error {
+	data, err := fetchEntriesData(cfg, period)
+	if err != nil {
+		return err
+	}
+	data.Entries = filterEntriesByDate(data.Entries, from, to)
+
+	now := time.Now()
+	data.Metadata = newOutputMetadata(now, cfg.Organization)
+	return writeOutput(cfg.Output, "entries", formatExtension(cfg.Format, "csv"), now, func(w io.Writer) error {
+		return writeEntries(w, data, cfg.Format, cfg.CSV.Output)
+	})
+}
+
+// fetchEntriesData logs in and gets the entries of the period with the data needed to describe them.
+func fetchEntriesData(cfg Config, period string) (data entriesData, err error) {
+	client, err := lib.NewClient()
+	if err != nil {
+		return
+	}
+	if err = client.Login(cfg.Email, cfg.Password); err != nil {
+		err = common.WithExitCode(common.ExitAuth, err)
+		return
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return
+	}
+	periodID, err := lib.FindPeriod(periods, period)
+	if err != nil {
+		return
+	}
+
+	if data.Accounts, err = client.ListAccounts(); err != nil {
+		return
+	}
+	if data.Categories, err = client.ListCategories(); err != nil {
+		return
+	}
+	if data.Employees, err = client.ListEmployees(); err != nil {
+		return
+	}
+	if data.Providers, err = client.ListProviders(); err != nil {
+		return
+	}
+	data.Entries, err = client.ListEntries(lib.EntryFilter{Period: periodID})
+	return
+}
+
+// writeEntries writes the entries in the requested format.
+func writeEntries(w io.Writer, data entriesData, format string, csvParams common.CSVWriterParams) error {
+	switch format {
+	case "", formatText:
+		return writeEntriesCSV(w, data, csvParams)
+	case formatTable:
+		return writeTable(w, newEntriesTable(data), tableSettings)
+	case formatYAML:
+		return writeYAML(w, newEntriesOutput(data))
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+func writeEntriesCSV(w io.Writer, data entriesData, params common.CSVWriterParams) error {
+	t := newEntriesTable(data)
+
+	writer, err := common.NewCSVWriter(w, params, t.Header)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// newEntriesTable converts the entries into a table with one row per entry.
+func newEntriesTable(data entriesData) table {
+	output := newEntriesOutput(data)
+
+	t := table{
+		Title: "Entries",
+		Header: []string{
+			"Entry ID", "Date", "Kind", "Title", "Amount", "Budget", "Categories", "Party", "Payment", "Account",
+			"Comment", "Receipts",
+		},
+	}
+	for _, entry := range output.Entries {
+		amount := 0.0
+		allocations := make([]string, 0, len(entry.Allocations))
+		for _, allocation := range entry.Allocations {
+			amount += allocation.Amount
+			line := fmt.Sprintf("%s: %.2f", allocation.Category, allocation.Amount)
+			if allocation.Stock != 0 {
+				line += fmt.Sprintf(" (x%d)", allocation.Stock)
+			}
+			allocations = append(allocations, line)
+		}
+		party := ""
+		if entry.Party != nil {
+			party = entry.Party.Name
+		}
+
+		t.Rows = append(t.Rows, []string{
+			entry.ID,
+			entry.Date,
+			entry.Kind,
+			entry.Name,
+			fmt.Sprintf("%.2f", amount),
+			entry.Budget,
+			strings.Join(allocations, "; "),
+			party,
+			entry.PaymentMethod,
+			entry.Account,
+			entry.Comment,
+			strings.Join(entry.Receipts, " "),
+		})
+	}
+	return t
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockEntriesData() entriesData {
+	data := getMockDumpData()
+	return entriesData{
+		Accounts:   data.Accounts,
+		Categories: data.Categories,
+		Employees:  data.Employees,
+		Providers:  data.Providers,
+		Entries: []lib.Entry{
+			{
+				ID:            "ASC000012",
+				Period:        "12345",
+				Kind:          lib.KindSpend,
+				Date:          time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+				Name:          "Christmas gifts",
+				Budget:        lib.BudgetASC,
+				Allocation:    []lib.AllocationLine{{CategoryID: 10, Amount: 120.5}, {CategoryID: 11, Amount: 30, Stock: 3}},
+				Party:         &lib.Provider{ID: "p1"},
+				PaymentMethod: lib.PaymentMethodCard,
+				Account:       lib.Account{ID: 1},
+				Comment:       "December",
+				Receipts:      []string{"invoice.pdf", "ticket.jpg"},
+			},
+			{
+				ID:            "FON000001",
+				Period:        "12345",
+				Kind:          lib.KindTake,
+				Date:          time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				Name:          "Subsidy",
+				Budget:        lib.BudgetFON,
+				Allocation:    []lib.AllocationLine{{CategoryID: 10, Amount: 1000}},
+				Party:         &lib.Employee{ID: "e1"},
+				PaymentMethod: lib.PaymentMethodTransfer,
+				Account:       lib.Account{ID: 1},
+			},
+		},
+	}
+}
+
+func TestWriteEntriesCSV(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeEntries(&out, getMockEntriesData(), formatText, common.CSVWriterParams{}); err != nil {
+		t.Fatalf("writeEntries failed: %v", err)
+	}
+
+	want := "Entry ID,Date,Kind,Title,Amount,Budget,Categories,Party,Payment,Account,Comment,Receipts\n" +
+		"ASC000012,2025-03-14,depenses,Christmas gifts,150.50,ASC,Gifts: 120.50; Vouchers: 30.00 (x3),ACME,card," +
+		"Bank A,December,invoice.pdf ticket.jpg\n" +
+		"FON000001,2025-01-02,recettes,Subsidy,1000.00,FON,Gifts: 1000.00,Doe Jane,transfer,Bank A,,\n"
+	if got := out.String(); got != want {
+		t.Errorf("CSV output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteEntriesYAML(t *testing.T) {
+	data := getMockEntriesData()
+	data.Entries = data.Entries[:1]
+
+	var out bytes.Buffer
+	if err := writeEntries(&out, data, formatYAML, common.CSVWriterParams{}); err != nil {
+		t.Fatalf("writeEntries failed: %v", err)
+	}
+
+	want := `entries:
+  - id: ASC000012
+    date: "2025-03-14"
+    kind: depenses
+    name: Christmas gifts
+    period: "12345"
+    budget: ASC
+    allocations:
+      - category_id: 10
+        category: Gifts
+        amount: 120.5
+      - category_id: 11
+        category: Vouchers
+        amount: 30
+        stock: 3
+    party:
+      type: provider
+      id: p1
+      name: ACME
+    payment_method: card
+    account: Bank A
+    comment: December
+    receipts:
+      - invoice.pdf
+      - ticket.jpg
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestEntriesMockServer(t *testing.T) {
+	data := getMockEntriesData()
+	server := mockserver.New(mockserver.Data{
+		Email:      "treasurer@example.com",
+		Password:   "secret",
+		Accounts:   data.Accounts,
+		Categories: data.Categories,
+		Employees:  data.Employees,
+		Providers:  data.Providers,
+		Periods: []lib.Period{{
+			ID:     "12345",
+			Status: lib.PeriodStatusCurrent,
+			Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+		Entries: data.Entries,
+	})
+	defer server.Close()
+	t.Setenv("HAPPYCOMPTA_URL", server.URL)
+
+	output := filepath.Join(t.TempDir(), "entries.csv")
+	cfg := Config{Email: "treasurer@example.com", Password: "secret", Output: output}
+	if err := entries(cfg, "", time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("entries failed: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read the output: %v", err)
+	}
+	for _, entry := range data.Entries {
+		if !strings.Contains(string(content), entry.Name) {
+			t.Errorf("Output mismatch. Got: %s, Want the %s entry", content, entry.Name)
+		}
+	}
+}
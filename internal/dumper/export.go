@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+// exportColumns are the columns of the loader happy-compta profile, plus the loader default period column.
+var exportColumns = []string{
+	"Date", "Libellé", "Montant", "Stock", "Catégorie", "Remarques", "Mode de paiement", "Budget", "Salarié",
+	"Fournisseur", "Type", "Banque", "period",
+}
+
+// exportComma is the CSV separator of the loader happy-compta profile.
+const exportComma = ';'
+
+func newExportCmd() *cobra.Command {
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the entries of a period for the loader",
+		Long: `Export the entries of an accounting period in a folder to load them in another organization.
+
+The folder contains:
+  entries.csv              the entries in the layout of the loader happy-compta profile
+  receipts/<row>/<file>    the receipts of the entries, in folders named after their CSV row number
+
+Load them in the other organization with:
+  happycompta-loader --profile happy-compta --receipts <folder>/receipts <folder>/entries.csv
+
+The loader only takes one category per row: the entries with several allocation lines
+are exported as one row per line and their receipts are attached to the first one.
+The periods, categories, employees, providers and accounts need to exist in the other organization.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			period, err := cmd.Flags().GetString("period")
+			if err != nil {
+				return err
+			}
+			out, err := cmd.Flags().GetString("out")
+			if err != nil {
+				return err
+			}
+
+			return export(cfg, period, out)
+		},
+	}
+	exportCmd.Flags().String("period", "", `Accounting period of the entries to export.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	exportCmd.Flags().String("out", "export", "Folder to write the CSV file and the receipts to.")
+
+	return exportCmd
+}
+
+func export(cfg Config, period string, out string) error {
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return err
+	}
+	periodID, err := lib.FindPeriod(periods, period)
+	if err != nil {
+		return err
+	}
+
+	var data entriesData
+	if data.Accounts, err = client.ListAccounts(); err != nil {
+		return err
+	}
+	if data.Categories, err = client.ListCategories(); err != nil {
+		return err
+	}
+	if data.Employees, err = client.ListEmployees(); err != nil {
+		return err
+	}
+	if data.Providers, err = client.ListProviders(); err != nil {
+		return err
+	}
+	if data.Entries, err = client.ListEntries(lib.EntryFilter{Period: periodID}); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %s", out, err)
+	}
+
+	rows, entryRows := newExportRows(data, periods)
+
+	csvPath := filepath.Join(out, "entries.csv")
+	file, err := os.Create(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", csvPath, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := writeExportCSV(file, rows, cfg.CSV.Output); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return writeExportReceipts(filepath.Join(out, "receipts"), data.Entries, entryRows, client)
+}
+
+// newExportRows converts the entries into loader CSV rows with one row per allocation line.
+// The returned map gives the number of the first row of each entry, starting from 1 like the loader does.
+func newExportRows(data entriesData, periods []lib.Period) (rows [][]string, entryRows map[string]int) {
+	categories := make(map[int]string, len(data.Categories))
+	for _, category := range data.Categories {
+		categories[category.ID] = category.Name
+	}
+	accounts := make(map[int]string, len(data.Accounts))
+	for _, account := range data.Accounts {
+		accounts[account.ID] = account.Bank
+	}
+	employees := make(map[string]string, len(data.Employees))
+	for _, employee := range data.Employees {
+		employees[employee.ID] = employee.Lastname + " " + employee.Firstname
+	}
+	providers := make(map[string]string, len(data.Providers))
+	for _, provider := range data.Providers {
+		providers[provider.ID] = provider.Name
+	}
+	// The loader identifies the periods by their dates as the IDs differ between organizations.
+	periodNames := make(map[string]string, len(periods))
+	for _, period := range periods {
+		periodNames[period.ID] = period.Start.Format(lib.DateLayout) + "-" + period.End.Format(lib.DateLayout)
+	}
+
+	entryRows = make(map[string]int, len(data.Entries))
+	for _, entry := range data.Entries {
+		entryRows[entry.ID] = len(rows) + 1
+
+		var employee, provider string
+		switch party := entry.Party.(type) {
+		case *lib.Employee:
+			employee = employees[party.ID]
+		case *lib.Provider:
+			provider = providers[party.ID]
+		}
+
+		lines := entry.Allocation
+		if len(lines) == 0 {
+			// Still export the entry so that the loader reports it.
+			lines = []lib.AllocationLine{{}}
+		}
+		for _, line := range lines {
+			stock := ""
+			if line.Stock != 0 {
+				stock = strconv.Itoa(line.Stock)
+			}
+			rows = append(rows, []string{
+				entry.Date.Format(lib.DateLayout),
+				entry.Name,
+				fmt.Sprintf("%.2f", line.Amount),
+				stock,
+				categories[line.CategoryID],
+				entry.Comment,
+				entry.PaymentMethod.String(),
+				entry.Budget.String(),
+				employee,
+				provider,
+				kindString(entry.Kind),
+				accounts[entry.Account.ID],
+				periodNames[entry.Period],
+			})
+		}
+	}
+	return
+}
+
+// writeExportCSV writes the rows in the loader happy-compta profile CSV format.
+// The loader profile separator is used unless another one is configured.
+func writeExportCSV(w io.Writer, rows [][]string, params common.CSVWriterParams) error {
+	if params.Comma == "" {
+		params.Comma = string(exportComma)
+	}
+	writer, err := common.NewCSVWriter(w, params, exportColumns)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// writeExportReceipts downloads the receipts in folders named after the first CSV row of their entry.
+func writeExportReceipts(
+	dir string, entries []lib.Entry, entryRows map[string]int, downloader receiptDownloader,
+) error {
+	return forEachReceipt(entries, func(entry lib.Entry, name string, link string) error {
+		folder := filepath.Join(dir, strconv.Itoa(entryRows[entry.ID]))
+		if err := os.MkdirAll(folder, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %s", folder, err)
+		}
+
+		receiptPath := filepath.Join(folder, path.Base(name))
+		file, err := os.Create(receiptPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %s", receiptPath, err)
+		}
+		defer func() { _ = file.Close() }()
+
+		if err := downloader.DownloadReceipt(link, file); err != nil {
+			return err
+		}
+		return file.Close()
+	})
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestNewExportRows(t *testing.T) {
+	data := entriesData{
+		Accounts:   []lib.Account{{ID: 1, Bank: "Crédit Mutuel", Budget: lib.BudgetASC}},
+		Categories: []lib.Category{{ID: 10, Name: "Cadeaux"}, {ID: 11, Name: "Chèques vacances", Stock: true}},
+		Employees:  []lib.Employee{{ID: "e1", Lastname: "Dupont", Firstname: "Jean"}},
+		Providers:  []lib.Provider{{ID: "p1", Name: "Boutique"}},
+		Entries: []lib.Entry{
+			{
+				ID:            "ASC000001",
+				Period:        "42",
+				Kind:          lib.KindSpend,
+				Date:          time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+				Name:          "Cadeaux de Noël",
+				Budget:        lib.BudgetASC,
+				Allocation:    []lib.AllocationLine{{CategoryID: 10, Amount: 100}, {CategoryID: 11, Amount: 50, Stock: 5}},
+				Party:         &lib.Provider{ID: "p1"},
+				PaymentMethod: lib.PaymentMethodCard,
+				Account:       lib.Account{ID: 1},
+				Comment:       "Commande",
+			},
+			{
+				ID:            "ASC000002",
+				Period:        "42",
+				Kind:          lib.KindAllocation,
+				Date:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				Name:          "Chèques",
+				Budget:        lib.BudgetASC,
+				Allocation:    []lib.AllocationLine{{CategoryID: 11, Stock: 2}},
+				Party:         &lib.Employee{ID: "e1"},
+				PaymentMethod: lib.PaymentMethodCheckAllocation,
+				Account:       lib.Account{ID: 1},
+			},
+		},
+	}
+	periods := []lib.Period{
+		{
+			ID:    "42",
+			Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	rows, entryRows := newExportRows(data, periods)
+
+	expectedRows := [][]string{
+		{
+			"14/03/2025", "Cadeaux de Noël", "100.00", "", "Cadeaux", "Commande", "card", "ASC", "", "Boutique",
+			"depenses", "Crédit Mutuel", "01/01/2025-31/12/2025",
+		},
+		{
+			"14/03/2025", "Cadeaux de Noël", "50.00", "5", "Chèques vacances", "Commande", "card", "ASC", "",
+			"Boutique", "depenses", "Crédit Mutuel", "01/01/2025-31/12/2025",
+		},
+		{
+			"01/04/2025", "Chèques", "0.00", "2", "Chèques vacances", "", "check allocation", "ASC", "Dupont Jean",
+			"", "attributions", "Crédit Mutuel", "01/01/2025-31/12/2025",
+		},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Rows mismatch. Got: %v, Want: %v", rows, expectedRows)
+	}
+
+	expectedEntryRows := map[string]int{"ASC000001": 1, "ASC000002": 3}
+	if !reflect.DeepEqual(entryRows, expectedEntryRows) {
+		t.Errorf("Entry rows mismatch. Got: %v, Want: %v", entryRows, expectedEntryRows)
+	}
+}
+
+func TestWriteExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeExportCSV(&buf, [][]string{{"01/04/2025", "Chèques; vacances"}}, common.CSVWriterParams{}); err != nil {
+		t.Fatalf("writeExportCSV failed: %v", err)
+	}
+
+	expected := "Date;Libellé;Montant;Stock;Catégorie;Remarques;Mode de paiement;Budget;Salarié;Fournisseur;" +
+		"Type;Banque;period\n01/04/2025;\"Chèques; vacances\";;;;;;;;;;;\n"
+	if buf.String() != expected {
+		t.Errorf("CSV mismatch. Got: %q, Want: %q", buf.String(), expected)
+	}
+}
+
+func TestWriteExportReceipts(t *testing.T) {
+	dir := t.TempDir()
+	entries := []lib.Entry{
+		{
+			ID:           "ASC000001",
+			Receipts:     []string{"invoice.pdf"},
+			ReceiptLinks: []string{"https://example.com/invoice.pdf"},
+		},
+		{
+			ID:           "ASC000002",
+			Receipts:     []string{"ticket.jpg", "lost.pdf"},
+			ReceiptLinks: []string{"https://example.com/ticket.jpg"},
+		},
+	}
+	downloader := fakeDownloader{files: map[string]string{
+		"https://example.com/invoice.pdf": "invoice content",
+		"https://example.com/ticket.jpg":  "ticket content",
+	}}
+
+	err := writeExportReceipts(dir, entries, map[string]int{"ASC000001": 1, "ASC000002": 3}, downloader)
+	if err != nil {
+		t.Fatalf("writeExportReceipts failed: %v", err)
+	}
+
+	actual := map[string]string{}
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		actual[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read the receipts: %v", err)
+	}
+
+	expected := map[string]string{
+		"1/invoice.pdf": "invoice content",
+		"3/ticket.jpg":  "ticket content",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Receipts mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
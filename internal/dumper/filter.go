@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// dumpFilter restricts the dumped objects.
+type dumpFilter struct {
+	// ActiveOnly excludes the inactive employees.
+	ActiveOnly bool
+	// IncludeArchived keeps the archived providers.
+	IncludeArchived bool
+	// Budget restricts the categories and accounts to a budget if defined.
+	Budget lib.Budget
+}
+
+// newDumpFilter creates the filter from the configuration values.
+func newDumpFilter(activeOnly bool, includeArchived bool, budget string) (dumpFilter, error) {
+	filter := dumpFilter{ActiveOnly: activeOnly, IncludeArchived: includeArchived}
+	if budget != "" {
+		filter.Budget = lib.NewBudgetFromString(budget)
+		if filter.Budget == lib.BudgetUndefined {
+			return filter, fmt.Errorf("invalid budget %s, accepted values are FON or ASC", budget)
+		}
+	}
+	return filter, nil
+}
+
+// apply removes the objects not matching the filter from the data.
+func (f dumpFilter) apply(data dumpData) dumpData {
+	if f.ActiveOnly {
+		data.Employees = slices.DeleteFunc(slices.Clone(data.Employees), func(e lib.Employee) bool {
+			return !e.Active
+		})
+	}
+	if !f.IncludeArchived {
+		data.Providers = slices.DeleteFunc(slices.Clone(data.Providers), func(p lib.Provider) bool {
+			return p.Archived
+		})
+	}
+	if f.Budget != lib.BudgetUndefined {
+		data.Categories = slices.DeleteFunc(slices.Clone(data.Categories), func(c lib.Category) bool {
+			return c.Budget != f.Budget
+		})
+		data.Accounts = slices.DeleteFunc(slices.Clone(data.Accounts), func(a lib.Account) bool {
+			return a.Budget != f.Budget
+		})
+	}
+	return data
+}
+
+// filterEntriesByDate keeps the entries between from and to included.
+// Zero dates are not limiting the range.
+func filterEntriesByDate(entries []lib.Entry, from time.Time, to time.Time) []lib.Entry {
+	return slices.DeleteFunc(slices.Clone(entries), func(e lib.Entry) bool {
+		return (!from.IsZero() && e.Date.Before(from)) || (!to.IsZero() && e.Date.After(to))
+	})
+}
+
+// parseDateFlag parses a date value in one of the default date layouts. Empty values give a zero date.
+func parseDateFlag(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := common.ParseDate(value, nil)
+	if err != nil {
+		return date, fmt.Errorf("invalid %s date: %s", name, err)
+	}
+	return date, nil
+}
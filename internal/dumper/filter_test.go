@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestNewDumpFilter(t *testing.T) {
+	filter, err := newDumpFilter(true, false, "fon")
+	if err != nil {
+		t.Fatalf("newDumpFilter failed: %v", err)
+	}
+	expected := dumpFilter{ActiveOnly: true, Budget: lib.BudgetFON}
+	if filter != expected {
+		t.Errorf("Filter mismatch. Got: %+v, Want: %+v", filter, expected)
+	}
+
+	if _, err := newDumpFilter(false, true, "CSE"); err == nil {
+		t.Error("Expected an error for an invalid budget")
+	}
+}
+
+func TestDumpFilterApply(t *testing.T) {
+	data := dumpData{
+		Employees: []lib.Employee{{ID: "e1", Active: true}, {ID: "e2"}},
+		Providers: []lib.Provider{{ID: "p1", Archived: true}, {ID: "p2"}},
+		Accounts:  []lib.Account{{ID: 1, Budget: lib.BudgetFON}, {ID: 2, Budget: lib.BudgetASC}},
+		Categories: []lib.Category{
+			{ID: 10, Budget: lib.BudgetASC}, {ID: 11, Budget: lib.BudgetFON}, {ID: 12, Budget: lib.BudgetASC},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter dumpFilter
+		want   dumpData
+	}{
+		{
+			name:   "no filter",
+			filter: dumpFilter{IncludeArchived: true},
+			want:   data,
+		},
+		{
+			name:   "active employees and no archived provider",
+			filter: dumpFilter{ActiveOnly: true},
+			want: dumpData{
+				Employees:  data.Employees[:1],
+				Providers:  data.Providers[1:],
+				Accounts:   data.Accounts,
+				Categories: data.Categories,
+			},
+		},
+		{
+			name:   "budget",
+			filter: dumpFilter{IncludeArchived: true, Budget: lib.BudgetASC},
+			want: dumpData{
+				Employees:  data.Employees,
+				Providers:  data.Providers,
+				Accounts:   data.Accounts[1:],
+				Categories: []lib.Category{data.Categories[0], data.Categories[2]},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.apply(data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filtered data mismatch. Got: %+v, Want: %+v", got, tt.want)
+			}
+		})
+	}
+
+	// The original data must not be modified
+	if len(data.Employees) != 2 || data.Employees[1].ID != "e2" {
+		t.Errorf("The filter modified the original data: %+v", data.Employees)
+	}
+}
+
+func TestFilterEntriesByDate(t *testing.T) {
+	entries := []lib.Entry{
+		{ID: "1", Date: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "3", Date: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{ID: "4", Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{name: "no range", want: []string{"1", "2", "3", "4"}},
+		{name: "from", from: "2025-02-28", want: []string{"3", "4"}},
+		{name: "to", to: "2025-02-01", want: []string{"1", "2"}},
+		{name: "range", from: "2025-02-01", to: "2025-02-28", want: []string{"2", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, err := parseDateFlag("from", tt.from)
+			if err != nil {
+				t.Fatalf("parseDateFlag failed: %v", err)
+			}
+			to, err := parseDateFlag("to", tt.to)
+			if err != nil {
+				t.Fatalf("parseDateFlag failed: %v", err)
+			}
+
+			got := []string{}
+			for _, entry := range filterEntriesByDate(entries, from, to) {
+				got = append(got, entry.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Entries mismatch. Got: %v, Want: %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseDateFlag("from", "01.02.2025"); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
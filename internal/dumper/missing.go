@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+func newMissingReceiptsCmd() *cobra.Command {
+	var missingCmd = &cobra.Command{
+		Use:   "missing-receipts",
+		Short: "List the entries without receipt",
+		Long: `List the entries of an accounting period without any receipt attached.
+
+With --by-employee, the entries are grouped by employee with a mailto link opening a reminder
+asking for the missing receipts. happy-compta doesn't know the employees email addresses:
+set them in the emails map of the configuration file, indexed by employee ID or "Firstname Lastname".
+The entries of the providers and those without party are listed in a last group without reminder.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			period, err := cmd.Flags().GetString("period")
+			if err != nil {
+				return err
+			}
+			if cfg.Output, err = cmd.Flags().GetString("output"); err != nil {
+				return err
+			}
+			byEmployee, err := cmd.Flags().GetBool("by-employee")
+			if err != nil {
+				return err
+			}
+			return missingReceipts(cfg, period, byEmployee)
+		},
+	}
+	missingCmd.Flags().String("period", "", `Accounting period of the entries to check.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	missingCmd.Flags().StringP("output", "o", "", `File to write the list to instead of the standard output.
+If the path is a directory or ends with a separator, the list is written in a timestamped file in it.`)
+	missingCmd.Flags().Bool("by-employee", false, "Group the entries by employee with a reminder to send them.")
+
+	return missingCmd
+}
+
+// missingReceiptsGroup holds the entries without receipt of an employee.
+type missingReceiptsGroup struct {
+	// Employee is nil for the group of the entries without employee.
+	Employee *lib.Employee
+	// Email is the configured address of the employee, if any.
+	Email   string
+	Entries []lib.Entry
+}
+
+func missingReceipts(cfg Config, period string, byEmployee bool) error {
+	data, err := fetchEntriesData(cfg, period)
+	if err != nil {
+		return err
+	}
+	data.Entries = findMissingReceipts(data.Entries)
+
+	var groups []missingReceiptsGroup
+	if byEmployee {
+		groups = groupByEmployee(data.Entries, data.Employees, cfg.Emails)
+	}
+
+	now := time.Now()
+	data.Metadata = newOutputMetadata(now, cfg.Organization)
+	return writeOutput(cfg.Output, "missing-receipts", formatExtension(cfg.Format, "txt"), now, func(w io.Writer) error {
+		return writeMissingReceipts(w, data, groups, cfg.Format)
+	})
+}
+
+// findMissingReceipts returns the entries without receipt sorted by date.
+func findMissingReceipts(entries []lib.Entry) []lib.Entry {
+	missing := slices.DeleteFunc(slices.Clone(entries), func(e lib.Entry) bool { return len(e.Receipts) > 0 })
+	slices.SortStableFunc(missing, func(a, b lib.Entry) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+	return missing
+}
+
+// groupByEmployee groups the entries per employee sorted by name, the entries without employee last.
+// The emails are indexed by employee ID or case-insensitive "Firstname Lastname".
+func groupByEmployee(entries []lib.Entry, employees []lib.Employee, emails map[string]string) []missingReceiptsGroup {
+	normalized := make(map[string]string, len(emails))
+	for key, email := range emails {
+		normalized[strings.ToLower(key)] = email
+	}
+
+	var groups []missingReceiptsGroup
+	var others []lib.Entry
+	for _, entry := range entries {
+		party, isEmployee := entry.Party.(*lib.Employee)
+		if !isEmployee {
+			others = append(others, entry)
+			continue
+		}
+		idx := slices.IndexFunc(groups, func(g missingReceiptsGroup) bool { return g.Employee.ID == party.ID })
+		if idx < 0 {
+			employee := *party
+			if i := slices.IndexFunc(employees, func(e lib.Employee) bool { return e.ID == party.ID }); i >= 0 {
+				employee = employees[i]
+			}
+			email, found := normalized[strings.ToLower(employee.ID)]
+			if !found {
+				email = normalized[strings.ToLower(employee.Firstname+" "+employee.Lastname)]
+			}
+			groups = append(groups, missingReceiptsGroup{Employee: &employee, Email: email})
+			idx = len(groups) - 1
+		}
+		groups[idx].Entries = append(groups[idx].Entries, entry)
+	}
+
+	slices.SortFunc(groups, func(a, b missingReceiptsGroup) int {
+		return cmp.Or(strings.Compare(a.Employee.Lastname, b.Employee.Lastname),
+			strings.Compare(a.Employee.Firstname, b.Employee.Firstname))
+	})
+	if len(others) > 0 {
+		groups = append(groups, missingReceiptsGroup{Entries: others})
+	}
+	return groups
+}
+
+// Name returns the name of the employee of the group or a label for the entries without employee.
+func (g missingReceiptsGroup) Name() string {
+	if g.Employee == nil {
+		return "Without employee"
+	}
+	return g.Employee.Lastname + " " + g.Employee.Firstname
+}
+
+// Reminder returns the subject and body of the email asking the employee for the missing receipts.
+func (g missingReceiptsGroup) Reminder() (string, string) {
+	var body strings.Builder
+	fmt.Fprintf(&body, common.Tr("Hello %s,"), g.Employee.Firstname)
+	body.WriteString("\n\n" + common.Tr("The receipts of the following entries are missing:") + "\n")
+	for _, entry := range g.Entries {
+		fmt.Fprintf(&body, "- %s %s: %s (%.2f)\n", entry.ID, entry.Date.Format(lib.DateLayout), entry.Name,
+			math.Abs(entryAmount(entry)))
+	}
+	body.WriteString("\n" + common.Tr("Could you send them to me?") + "\n\n" + common.Tr("Thank you.") + "\n")
+	return common.Tr("Missing receipts"), body.String()
+}
+
+// MailtoLink returns the link opening the reminder in the mail client, empty for the entries without employee.
+func (g missingReceiptsGroup) MailtoLink() string {
+	if g.Employee == nil {
+		return ""
+	}
+	subject, body := g.Reminder()
+	// Mail clients don't decode the + of the query escaping as spaces.
+	escape := func(value string) string { return strings.ReplaceAll(url.QueryEscape(value), "+", "%20") }
+	return fmt.Sprintf("mailto:%s?subject=%s&body=%s", url.PathEscape(g.Email), escape(subject), escape(body))
+}
+
+// missingReceiptsOutput is the structure of the entries without receipt in the structured formats.
+type missingReceiptsOutput struct {
+	Metadata  *outputMetadata              `yaml:"metadata,omitempty"`
+	Entries   []missingEntryOutput         `yaml:"entries,omitempty"`
+	Employees []missingReceiptsGroupOutput `yaml:"employees,omitempty"`
+}
+
+type missingEntryOutput struct {
+	ID     string  `yaml:"id"`
+	Date   string  `yaml:"date"`
+	Name   string  `yaml:"name"`
+	Amount float64 `yaml:"amount"`
+	Party  string  `yaml:"party,omitempty"`
+}
+
+type missingReceiptsGroupOutput struct {
+	ID      string               `yaml:"id,omitempty"`
+	Name    string               `yaml:"name"`
+	Email   string               `yaml:"email,omitempty"`
+	Subject string               `yaml:"subject,omitempty"`
+	Body    string               `yaml:"body,omitempty"`
+	Mailto  string               `yaml:"mailto,omitempty"`
+	Entries []missingEntryOutput `yaml:"entries"`
+}
+
+// writeMissingReceipts writes the entries without receipt, grouped if there are groups, in the requested format.
+func writeMissingReceipts(w io.Writer, data entriesData, groups []missingReceiptsGroup, format string) error {
+	parties := map[string]string{}
+	for _, entry := range newEntriesOutput(data).Entries {
+		if entry.Party != nil {
+			parties[entry.ID] = entry.Party.Name
+		}
+	}
+	newEntryOutput := func(entry lib.Entry) missingEntryOutput {
+		return missingEntryOutput{
+			ID: entry.ID, Date: entry.Date.Format(dateFormat), Name: entry.Name, Amount: math.Abs(entryAmount(entry)),
+			Party: parties[entry.ID],
+		}
+	}
+
+	switch format {
+	case "", formatText:
+		return writeMissingReceiptsText(w, data.Entries, groups, newEntryOutput)
+	case formatTable:
+		return writeMissingReceiptsTables(w, data.Entries, groups, newEntryOutput)
+	case formatYAML:
+		output := missingReceiptsOutput{Metadata: data.Metadata}
+		if groups == nil {
+			output.Entries = make([]missingEntryOutput, 0, len(data.Entries))
+			for _, entry := range data.Entries {
+				output.Entries = append(output.Entries, newEntryOutput(entry))
+			}
+		}
+		for _, group := range groups {
+			groupOutput := missingReceiptsGroupOutput{Name: group.Name(), Email: group.Email, Mailto: group.MailtoLink()}
+			if group.Employee != nil {
+				groupOutput.ID = group.Employee.ID
+				groupOutput.Subject, groupOutput.Body = group.Reminder()
+			}
+			for _, entry := range group.Entries {
+				groupOutput.Entries = append(groupOutput.Entries, newEntryOutput(entry))
+			}
+			output.Employees = append(output.Employees, groupOutput)
+		}
+		return writeYAML(w, output)
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+func writeMissingReceiptsText(w io.Writer, entries []lib.Entry, groups []missingReceiptsGroup,
+	newEntryOutput func(lib.Entry) missingEntryOutput) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	writeEntry := func(prefix string, entry lib.Entry) {
+		e := newEntryOutput(entry)
+		fmt.Fprintf(tw, "%s%s\t%s\t%.2f\t%s\t%s\n",
+			prefix, e.ID, entry.Date.Format(lib.DateLayout), e.Amount, e.Name, e.Party)
+	}
+
+	if groups == nil {
+		fmt.Fprintf(tw, "Entries without receipt (%d):\n", len(entries))
+		for _, entry := range entries {
+			writeEntry("", entry)
+		}
+		return tw.Flush()
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		title := group.Name()
+		if group.Email != "" {
+			title += " <" + group.Email + ">"
+		}
+		fmt.Fprintf(tw, "%s (%d):\n", title, len(group.Entries))
+		for _, entry := range group.Entries {
+			writeEntry("    ", entry)
+		}
+		if link := group.MailtoLink(); link != "" {
+			fmt.Fprintf(tw, "    %s\n", link)
+		}
+	}
+	return tw.Flush()
+}
+
+func writeMissingReceiptsTables(w io.Writer, entries []lib.Entry, groups []missingReceiptsGroup,
+	newEntryOutput func(lib.Entry) missingEntryOutput) error {
+	newTable := func(title string, entries []lib.Entry) table {
+		t := table{Title: title, Header: []string{"ID", "DATE", "AMOUNT", "NAME", "PARTY"}}
+		for _, entry := range entries {
+			e := newEntryOutput(entry)
+			t.Rows = append(t.Rows, []string{
+				e.ID, entry.Date.Format(lib.DateLayout), fmt.Sprintf("%.2f", e.Amount), e.Name, e.Party,
+			})
+		}
+		return t
+	}
+
+	if groups == nil {
+		return writeTable(w, newTable("Entries without receipt", entries), tableSettings)
+	}
+	for i, group := range groups {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := writeTable(w, newTable(group.Name(), group.Entries), tableSettings); err != nil {
+			return err
+		}
+		if link := group.MailtoLink(); link != "" {
+			if _, err := fmt.Fprintln(w, link); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
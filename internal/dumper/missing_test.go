@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockMissingReceiptsData() entriesData {
+	return entriesData{
+		Employees: []lib.Employee{
+			{ID: "1", Lastname: "Doe", Firstname: "Jane"},
+			{ID: "2", Lastname: "Martin", Firstname: "Paul"},
+		},
+		Providers: []lib.Provider{{ID: "p1", Name: "ACME"}},
+		Entries: []lib.Entry{
+			{
+				ID: "ASC000003", Kind: lib.KindSpend, Date: day(20), Name: "Train tickets",
+				Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 42.5}}, Party: &lib.Employee{ID: "2"},
+			},
+			{
+				ID: "ASC000002", Kind: lib.KindSpend, Date: day(10), Name: "Gifts",
+				Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 120}}, Party: &lib.Provider{ID: "p1"},
+			},
+			{
+				ID: "ASC000001", Kind: lib.KindSpend, Date: day(5), Name: "Lunch",
+				Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 18}}, Party: &lib.Employee{ID: "1"},
+				Receipts: []string{"ticket.jpg"},
+			},
+			{
+				ID: "ASC000004", Kind: lib.KindSpend, Date: day(3), Name: "Taxi",
+				Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 25}}, Party: &lib.Employee{ID: "1"},
+			},
+		},
+	}
+}
+
+func entryIDs(entries []lib.Entry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestFindMissingReceipts(t *testing.T) {
+	missing := findMissingReceipts(getMockMissingReceiptsData().Entries)
+	expected := []string{"ASC000004", "ASC000002", "ASC000003"}
+	if actual := entryIDs(missing); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Missing receipts mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestGroupByEmployee(t *testing.T) {
+	data := getMockMissingReceiptsData()
+	emails := map[string]string{"1": "jane@example.com", "paul MARTIN": "paul@example.com"}
+	groups := groupByEmployee(findMissingReceipts(data.Entries), data.Employees, emails)
+
+	type groupSummary struct {
+		Name    string
+		Email   string
+		Entries []string
+	}
+	var actual []groupSummary
+	for _, group := range groups {
+		actual = append(actual, groupSummary{group.Name(), group.Email, entryIDs(group.Entries)})
+	}
+	expected := []groupSummary{
+		{"Doe Jane", "jane@example.com", []string{"ASC000004"}},
+		{"Martin Paul", "paul@example.com", []string{"ASC000003"}},
+		{"Without employee", "", []string{"ASC000002"}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Groups mismatch. Got: %+v, Want: %+v", actual, expected)
+	}
+	if link := groups[2].MailtoLink(); link != "" {
+		t.Errorf("Expected no reminder for the entries without employee, got: %s", link)
+	}
+}
+
+func TestMailtoLink(t *testing.T) {
+	common.SetLanguage(common.LanguageFrench)
+	defer common.SetLanguage("")
+
+	group := missingReceiptsGroup{
+		Employee: &lib.Employee{ID: "1", Lastname: "Doe", Firstname: "Jane"},
+		Email:    "jane@example.com",
+		Entries:  getMockMissingReceiptsData().Entries[3:],
+	}
+	link := group.MailtoLink()
+	if strings.Contains(link, "+") {
+		t.Errorf("Expected the spaces to be escaped as %%20, got: %s", link)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("Invalid mailto link %s: %v", link, err)
+	}
+	if parsed.Scheme != "mailto" || parsed.Opaque != "jane@example.com" {
+		t.Errorf("Recipient mismatch. Got: %s, Want: mailto:jane@example.com", link)
+	}
+	query := parsed.Query()
+	if subject := query.Get("subject"); subject != "Justificatifs manquants" {
+		t.Errorf("Subject mismatch. Got: %s, Want: Justificatifs manquants", subject)
+	}
+	expectedBody := `Bonjour Jane,
+
+Les justificatifs des écritures suivantes sont manquants :
+- ASC000004 03/03/2025: Taxi (25.00)
+
+Pourriez-vous me les envoyer ?
+
+Merci.
+`
+	if body := query.Get("body"); body != expectedBody {
+		t.Errorf("Body mismatch. Got:\n%s\nWant:\n%s", body, expectedBody)
+	}
+}
+
+func TestWriteMissingReceiptsText(t *testing.T) {
+	data := getMockMissingReceiptsData()
+	data.Entries = findMissingReceipts(data.Entries)
+
+	var out bytes.Buffer
+	if err := writeMissingReceipts(&out, data, nil, formatText); err != nil {
+		t.Fatalf("writeMissingReceipts failed: %v", err)
+	}
+	want := `Entries without receipt (3):
+ASC000004  03/03/2025  25.00   Taxi           Doe Jane
+ASC000002  10/03/2025  120.00  Gifts          ACME
+ASC000003  20/03/2025  42.50   Train tickets  Martin Paul
+`
+	if got := out.String(); got != want {
+		t.Errorf("Text output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+
+	out.Reset()
+	groups := groupByEmployee(data.Entries, data.Employees, map[string]string{"1": "jane@example.com"})
+	if err := writeMissingReceipts(&out, data, groups, formatText); err != nil {
+		t.Fatalf("writeMissingReceipts failed: %v", err)
+	}
+	got := out.String()
+	for _, expected := range []string{
+		"Doe Jane <jane@example.com> (1):\n    ASC000004  03/03/2025  25.00",
+		"\n    mailto:jane@example.com?subject=Missing%20receipts&body=Hello%20Jane%2C",
+		"\nMartin Paul (1):\n",
+		"\n    mailto:?subject=",
+		"\nWithout employee (1):\n    ASC000002",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("Grouped text output mismatch. Got:\n%s\nWant it to contain: %s", got, expected)
+		}
+	}
+}
+
+func TestWriteMissingReceiptsYAML(t *testing.T) {
+	data := getMockMissingReceiptsData()
+	data.Entries = findMissingReceipts(data.Entries)[:1]
+	groups := groupByEmployee(data.Entries, data.Employees, nil)
+
+	var out bytes.Buffer
+	if err := writeMissingReceipts(&out, data, groups, formatYAML); err != nil {
+		t.Fatalf("writeMissingReceipts failed: %v", err)
+	}
+	want := `employees:
+  - id: "1"
+    name: Doe Jane
+    subject: Missing receipts
+    body: |
+      Hello Jane,
+
+      The receipts of the following entries are missing:
+      - ASC000004 03/03/2025: Taxi (25.00)
+
+      Could you send them to me?
+
+      Thank you.
+    mailto: mailto:?subject=Missing%20receipts&body=Hello%20Jane%2C%0A%0AThe%20receipts%20of%20the%20following%20entries%20are%20missing%3A%0A-%20ASC000004%2003%2F03%2F2025%3A%20Taxi%20%2825.00%29%0A%0ACould%20you%20send%20them%20to%20me%3F%0A%0AThank%20you.%0A
+    entries:
+      - id: ASC000004
+        date: "2025-03-03"
+        name: Taxi
+        amount: 25
+        party: Doe Jane
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
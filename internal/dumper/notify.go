@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// dumpSummary describes the outcome of a dump for the notifications.
+type dumpSummary struct {
+	Output   string    `json:"output,omitempty"`
+	Format   string    `json:"format"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished,omitzero"`
+	// Counts are the numbers of dumped items indexed by object type.
+	Counts map[string]int `json:"counts,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// counts returns the number of items of the retrieved object types.
+func (d dumpData) counts() map[string]int {
+	all := map[string]int{
+		typeEmployees:  len(d.Employees),
+		typeProviders:  len(d.Providers),
+		typePeriods:    len(d.Periods),
+		typeAccounts:   len(d.Accounts),
+		typeCategories: len(d.Categories),
+		typeBalances:   len(d.Balances),
+		typeStock:      len(d.Stock),
+		typeSpending:   len(d.Spending),
+		typeSites:      len(d.Sites),
+		typeUsers:      len(d.Users),
+	}
+	counts := map[string]int{}
+	for name, count := range all {
+		if d.Types[name] {
+			counts[name] = count
+		}
+	}
+	return counts
+}
+
+// notification returns the notification describing the outcome of the dump.
+func (s dumpSummary) notification() common.Notification {
+	n := common.Notification{Tool: "dumper", Status: common.NotifySuccess, Summary: s}
+	if s.Error != "" {
+		n.Status = common.NotifyFailure
+		n.Text = "Dump failed: " + s.Error
+		return n
+	}
+
+	var parts []string
+	for _, name := range objectTypes {
+		if count, found := s.Counts[name]; found {
+			parts = append(parts, fmt.Sprintf("%d %s", count, name))
+		}
+	}
+	n.Text = "Dump done: " + strings.Join(parts, ", ")
+	if s.Output != "" {
+		n.Text += " written to " + s.Output
+	}
+	return n
+}
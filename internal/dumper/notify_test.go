@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestDumpDataCounts(t *testing.T) {
+	data := dumpData{
+		Types:     map[string]bool{typeEmployees: true, typeAccounts: true},
+		Employees: []lib.Employee{{ID: "1"}, {ID: "2"}},
+		Providers: []lib.Provider{{ID: "3"}},
+	}
+	expected := map[string]int{typeEmployees: 2, typeAccounts: 0}
+	if actual := data.counts(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Counts mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestDumpSummaryNotification(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  dumpSummary
+		status   string
+		expected string
+	}{
+		{
+			"stdout",
+			dumpSummary{Counts: map[string]int{typeProviders: 4, typeEmployees: 2}},
+			common.NotifySuccess,
+			"Dump done: 2 employees, 4 providers",
+		},
+		{
+			"file",
+			dumpSummary{Output: "mirror.yaml", Counts: map[string]int{typeAccounts: 1}},
+			common.NotifySuccess,
+			"Dump done: 1 accounts written to mirror.yaml",
+		},
+		{"error", dumpSummary{Error: "login failed"}, common.NotifyFailure, "Dump failed: login failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.summary.notification()
+			if n.Tool != "dumper" || n.Status != tt.status {
+				t.Errorf("Notification mismatch. Got: %+v, Want status: %s", n, tt.status)
+			}
+			if n.Text != tt.expected {
+				t.Errorf("Text mismatch. Got: %s, Want: %s", n.Text, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats
+const (
+	formatText  = "text"
+	formatTable = "table"
+	formatYAML  = "yaml"
+)
+
+// dateFormat is the layout of the dates in the structured outputs.
+const dateFormat = "2006-01-02"
+
+// schemaVersion is the version of the structured outputs format.
+// Increase it when changing or removing fields so that the consumers can detect it.
+const schemaVersion = 1
+
+// outputMetadata describes how a structured output has been generated.
+type outputMetadata struct {
+	Schema       int    `yaml:"schema" json:"schema"`
+	Generator    string `yaml:"generator" json:"generator"`
+	Version      string `yaml:"version" json:"version"`
+	Generated    string `yaml:"generated" json:"generated"`
+	Organization string `yaml:"organization,omitempty" json:"organization,omitempty"`
+}
+
+// newOutputMetadata creates the metadata of an output generated at the given time.
+func newOutputMetadata(now time.Time, organization string) *outputMetadata {
+	return &outputMetadata{
+		Schema:       schemaVersion,
+		Generator:    "dumper",
+		Version:      common.Version(),
+		Generated:    now.Format(time.RFC3339),
+		Organization: organization,
+	}
+}
+
+// dumpOutput is the structure of the dump in the structured formats.
+// It is also used for the JSON data of the backups.
+// The object types that have not been dumped are omitted.
+type dumpOutput struct {
+	Metadata   *outputMetadata  `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Employees  []employeeOutput `yaml:"employees,omitempty" json:"employees,omitempty"`
+	Providers  []providerOutput `yaml:"providers,omitempty" json:"providers,omitempty"`
+	Periods    []periodOutput   `yaml:"periods,omitempty" json:"periods,omitempty"`
+	Accounts   []accountOutput  `yaml:"accounts,omitempty" json:"accounts,omitempty"`
+	Categories []categoryOutput `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Balances   []balancesOutput `yaml:"balances,omitempty" json:"balances,omitempty"`
+	Stock      []stockOutput    `yaml:"stock,omitempty" json:"stock,omitempty"`
+	Spending   []spendingOutput `yaml:"spending,omitempty" json:"spending,omitempty"`
+	Sites      []siteOutput     `yaml:"sites,omitempty" json:"sites,omitempty"`
+	Users      []userOutput     `yaml:"users,omitempty" json:"users,omitempty"`
+}
+
+type employeeOutput struct {
+	ID        string `yaml:"id" json:"id"`
+	Lastname  string `yaml:"lastname" json:"lastname"`
+	Firstname string `yaml:"firstname" json:"firstname"`
+	Active    bool   `yaml:"active" json:"active"`
+}
+
+type providerOutput struct {
+	ID       string `yaml:"id" json:"id"`
+	Name     string `yaml:"name" json:"name"`
+	Address  string `yaml:"address,omitempty" json:"address,omitempty"`
+	ZipCode  string `yaml:"zip_code,omitempty" json:"zip_code,omitempty"`
+	City     string `yaml:"city,omitempty" json:"city,omitempty"`
+	Phone    string `yaml:"phone,omitempty" json:"phone,omitempty"`
+	Email    string `yaml:"email,omitempty" json:"email,omitempty"`
+	Comment  string `yaml:"comment,omitempty" json:"comment,omitempty"`
+	Archived bool   `yaml:"archived" json:"archived"`
+}
+
+type siteOutput struct {
+	ID      string `yaml:"id" json:"id"`
+	Name    string `yaml:"name" json:"name"`
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+	ZipCode string `yaml:"zip_code,omitempty" json:"zip_code,omitempty"`
+	City    string `yaml:"city,omitempty" json:"city,omitempty"`
+}
+
+type userOutput struct {
+	ID        string   `yaml:"id" json:"id"`
+	Lastname  string   `yaml:"lastname" json:"lastname"`
+	Firstname string   `yaml:"firstname" json:"firstname"`
+	Email     string   `yaml:"email" json:"email"`
+	Role      string   `yaml:"role" json:"role"`
+	Sites     []string `yaml:"sites,omitempty" json:"sites,omitempty"`
+	Active    bool     `yaml:"active" json:"active"`
+}
+
+type periodOutput struct {
+	ID     string `yaml:"id" json:"id"`
+	Start  string `yaml:"start" json:"start"`
+	End    string `yaml:"end" json:"end"`
+	Status string `yaml:"status" json:"status"`
+}
+
+type accountOutput struct {
+	ID     int    `yaml:"id" json:"id"`
+	Bank   string `yaml:"bank" json:"bank"`
+	Budget string `yaml:"budget" json:"budget"`
+	Abbrev string `yaml:"abbreviation" json:"abbreviation"`
+}
+
+type categoryOutput struct {
+	ID       int    `yaml:"id" json:"id"`
+	ParentID int    `yaml:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Name     string `yaml:"name" json:"name"`
+	Kind     string `yaml:"kind" json:"kind"`
+	Budget   string `yaml:"budget" json:"budget"`
+	Stock    bool   `yaml:"stock" json:"stock"`
+}
+
+type balanceOutput struct {
+	Income   float64 `yaml:"income" json:"income"`
+	Spending float64 `yaml:"spending" json:"spending"`
+	Balance  float64 `yaml:"balance" json:"balance"`
+}
+
+type budgetBalanceOutput struct {
+	Budget        string `yaml:"budget" json:"budget"`
+	balanceOutput `yaml:",inline"`
+}
+
+type accountBalanceOutput struct {
+	ID            int    `yaml:"id" json:"id"`
+	Bank          string `yaml:"bank" json:"bank"`
+	balanceOutput `yaml:",inline"`
+}
+
+type balancesOutput struct {
+	Period        string `yaml:"period" json:"period"`
+	Start         string `yaml:"start" json:"start"`
+	End           string `yaml:"end" json:"end"`
+	balanceOutput `yaml:",inline"`
+	Budgets       []budgetBalanceOutput  `yaml:"budgets" json:"budgets"`
+	Accounts      []accountBalanceOutput `yaml:"accounts" json:"accounts"`
+}
+
+type stockOutput struct {
+	CategoryID int    `yaml:"category_id" json:"category_id"`
+	Category   string `yaml:"category" json:"category"`
+	Allocated  int    `yaml:"allocated" json:"allocated"`
+	Spent      int    `yaml:"spent" json:"spent"`
+	Remaining  int    `yaml:"remaining" json:"remaining"`
+}
+
+type spendingOutput struct {
+	Period     string                   `yaml:"period" json:"period"`
+	Start      string                   `yaml:"start" json:"start"`
+	End        string                   `yaml:"end" json:"end"`
+	Categories []categorySpendingOutput `yaml:"categories" json:"categories"`
+}
+
+type categorySpendingOutput struct {
+	ID          int     `yaml:"id" json:"id"`
+	Name        string  `yaml:"name" json:"name"`
+	Spent       float64 `yaml:"spent" json:"spent"`
+	Limit       float64 `yaml:"limit,omitempty" json:"limit,omitempty"`
+	Consumption float64 `yaml:"consumption,omitempty" json:"consumption,omitempty"`
+}
+
+func newBalanceOutput(b balance) balanceOutput {
+	return balanceOutput{Income: b.Income, Spending: b.Spending, Balance: b.Balance()}
+}
+
+// newDumpOutput converts the happy-compta data into the structured output.
+func newDumpOutput(data dumpData) dumpOutput {
+	output := dumpOutput{
+		Metadata:   data.Metadata,
+		Employees:  make([]employeeOutput, 0, len(data.Employees)),
+		Providers:  make([]providerOutput, 0, len(data.Providers)),
+		Periods:    make([]periodOutput, 0, len(data.Periods)),
+		Accounts:   make([]accountOutput, 0, len(data.Accounts)),
+		Categories: make([]categoryOutput, 0, len(data.Categories)),
+		Balances:   make([]balancesOutput, 0, len(data.Balances)),
+		Stock:      make([]stockOutput, 0, len(data.Stock)),
+		Spending:   make([]spendingOutput, 0, len(data.Spending)),
+		Sites:      make([]siteOutput, 0, len(data.Sites)),
+		Users:      make([]userOutput, 0, len(data.Users)),
+	}
+
+	if data.Types[typeEmployees] {
+		for _, e := range data.Employees {
+			output.Employees = append(output.Employees, employeeOutput{
+				ID: e.ID, Lastname: e.Lastname, Firstname: e.Firstname, Active: e.Active,
+			})
+		}
+	}
+	if data.Types[typeProviders] {
+		for _, p := range data.Providers {
+			output.Providers = append(output.Providers, providerOutput{
+				ID: p.ID, Name: p.Name, Address: p.Address, ZipCode: p.ZipCode, City: p.City,
+				Phone: p.Phone, Email: p.Email, Comment: p.Comment, Archived: p.Archived,
+			})
+		}
+	}
+	for _, s := range data.Sites {
+		output.Sites = append(output.Sites, siteOutput{
+			ID: s.ID, Name: s.Name, Address: s.Address, ZipCode: s.ZipCode, City: s.City,
+		})
+	}
+	for _, u := range data.Users {
+		output.Users = append(output.Users, userOutput{
+			ID: u.ID, Lastname: u.Lastname, Firstname: u.Firstname, Email: u.Email, Role: u.Role, Sites: u.Sites,
+			Active: u.Active,
+		})
+	}
+	// The periods and accounts may have been retrieved only to compute the balances.
+	if data.Types[typePeriods] {
+		for _, p := range data.Periods {
+			output.Periods = append(output.Periods, periodOutput{
+				ID: p.ID, Start: p.Start.Format(dateFormat), End: p.End.Format(dateFormat), Status: p.Status.String(),
+			})
+		}
+	}
+	if data.Types[typeAccounts] {
+		for _, a := range data.Accounts {
+			output.Accounts = append(output.Accounts, accountOutput{
+				ID: a.ID, Bank: a.Bank, Budget: a.Budget.String(), Abbrev: a.Abbrev,
+			})
+		}
+	}
+	if data.Types[typeCategories] {
+		for _, c := range data.Categories {
+			output.Categories = append(output.Categories, categoryOutput{
+				ID: c.ID, ParentID: c.ParentID, Name: c.Name, Kind: kindString(c.Kind),
+				Budget: c.Budget.String(), Stock: bool(c.Stock),
+			})
+		}
+	}
+	for _, b := range data.Balances {
+		balances := balancesOutput{
+			Period:        b.Period.ID,
+			Start:         b.Period.Start.Format(dateFormat),
+			End:           b.Period.End.Format(dateFormat),
+			balanceOutput: newBalanceOutput(b.Total),
+			Budgets:       make([]budgetBalanceOutput, 0, len(b.Budgets)),
+			Accounts:      make([]accountBalanceOutput, 0, len(b.Accounts)),
+		}
+		for _, budget := range b.Budgets {
+			balances.Budgets = append(balances.Budgets, budgetBalanceOutput{
+				Budget: budget.Budget.String(), balanceOutput: newBalanceOutput(budget.balance),
+			})
+		}
+		for _, account := range b.Accounts {
+			balances.Accounts = append(balances.Accounts, accountBalanceOutput{
+				ID: account.Account.ID, Bank: account.Account.Bank, balanceOutput: newBalanceOutput(account.balance),
+			})
+		}
+		output.Balances = append(output.Balances, balances)
+	}
+	for _, level := range data.Stock {
+		output.Stock = append(output.Stock, stockOutput{
+			CategoryID: level.Category.ID, Category: level.Category.Name,
+			Allocated: level.Allocated, Spent: level.Spent, Remaining: level.Remaining(),
+		})
+	}
+	for _, s := range data.Spending {
+		spending := spendingOutput{
+			Period:     s.Period.ID,
+			Start:      s.Period.Start.Format(dateFormat),
+			End:        s.Period.End.Format(dateFormat),
+			Categories: make([]categorySpendingOutput, 0, len(s.Categories)),
+		}
+		for _, category := range s.Categories {
+			spending.Categories = append(spending.Categories, categorySpendingOutput{
+				ID: category.Category.ID, Name: category.Category.Name, Spent: category.Spent,
+				Limit: category.Limit, Consumption: math.Round(category.Consumption()*10) / 10,
+			})
+		}
+		output.Spending = append(output.Spending, spending)
+	}
+	return output
+}
+
+// entriesOutput is the structure of the entries in the structured formats.
+type entriesOutput struct {
+	Metadata *outputMetadata `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Entries  []entryOutput   `yaml:"entries" json:"entries"`
+}
+
+type entryOutput struct {
+	ID            string             `yaml:"id" json:"id"`
+	Date          string             `yaml:"date" json:"date"`
+	Kind          string             `yaml:"kind" json:"kind"`
+	Name          string             `yaml:"name" json:"name"`
+	Period        string             `yaml:"period" json:"period"`
+	Budget        string             `yaml:"budget" json:"budget"`
+	Allocations   []allocationOutput `yaml:"allocations" json:"allocations"`
+	Party         *partyOutput       `yaml:"party,omitempty" json:"party,omitempty"`
+	PaymentMethod string             `yaml:"payment_method" json:"payment_method"`
+	Account       string             `yaml:"account" json:"account"`
+	Comment       string             `yaml:"comment,omitempty" json:"comment,omitempty"`
+	Receipts      []string           `yaml:"receipts,omitempty" json:"receipts,omitempty"`
+}
+
+type allocationOutput struct {
+	CategoryID int     `yaml:"category_id" json:"category_id"`
+	Category   string  `yaml:"category" json:"category"`
+	Amount     float64 `yaml:"amount" json:"amount"`
+	Stock      int     `yaml:"stock,omitempty" json:"stock,omitempty"`
+}
+
+type partyOutput struct {
+	Type string `yaml:"type" json:"type"`
+	ID   string `yaml:"id" json:"id"`
+	Name string `yaml:"name" json:"name"`
+}
+
+// newEntriesOutput converts the entries into the structured output.
+// The entries only reference the categories, accounts and parties by ID: their names are looked up.
+func newEntriesOutput(data entriesData) entriesOutput {
+	categories := make(map[int]string, len(data.Categories))
+	for _, category := range data.Categories {
+		categories[category.ID] = category.Name
+	}
+	accounts := make(map[int]string, len(data.Accounts))
+	for _, account := range data.Accounts {
+		accounts[account.ID] = account.Bank
+	}
+	employees := make(map[string]string, len(data.Employees))
+	for _, employee := range data.Employees {
+		employees[employee.ID] = employee.Lastname + " " + employee.Firstname
+	}
+	providers := make(map[string]string, len(data.Providers))
+	for _, provider := range data.Providers {
+		providers[provider.ID] = provider.Name
+	}
+
+	output := entriesOutput{Metadata: data.Metadata, Entries: make([]entryOutput, 0, len(data.Entries))}
+	for _, e := range data.Entries {
+		entry := entryOutput{
+			ID:            e.ID,
+			Date:          e.Date.Format(dateFormat),
+			Kind:          kindString(e.Kind),
+			Name:          e.Name,
+			Period:        e.Period,
+			Budget:        e.Budget.String(),
+			Allocations:   make([]allocationOutput, 0, len(e.Allocation)),
+			PaymentMethod: e.PaymentMethod.String(),
+			Account:       accounts[e.Account.ID],
+			Comment:       e.Comment,
+			Receipts:      e.Receipts,
+		}
+		for _, line := range e.Allocation {
+			entry.Allocations = append(entry.Allocations, allocationOutput{
+				CategoryID: line.CategoryID,
+				Category:   categories[line.CategoryID],
+				Amount:     line.Amount,
+				Stock:      line.Stock,
+			})
+		}
+		switch party := e.Party.(type) {
+		case *lib.Employee:
+			entry.Party = &partyOutput{Type: "employee", ID: party.ID, Name: employees[party.ID]}
+		case *lib.Provider:
+			entry.Party = &partyOutput{Type: "provider", ID: party.ID, Name: providers[party.ID]}
+		}
+		output.Entries = append(output.Entries, entry)
+	}
+	return output
+}
+
+// kindString returns the happy-compta name of the kind or an empty string if undefined.
+func kindString(kind lib.Kind) string {
+	if kind == lib.KindUndefined {
+		return ""
+	}
+	return kind.String()
+}
+
+// writeYAML writes the value as a YAML document.
+func writeYAML(w io.Writer, value any) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write YAML output: %s", err)
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockDumpData() dumpData {
+	return dumpData{
+		Types: map[string]bool{
+			typeEmployees: true, typeProviders: true, typePeriods: true, typeAccounts: true, typeCategories: true,
+		},
+		Employees: []lib.Employee{{ID: "e1", Lastname: "Doe", Firstname: "Jane", Active: true}},
+		Providers: []lib.Provider{{ID: "p1", Name: "ACME", City: "Paris", Archived: true}},
+		Periods: []lib.Period{{
+			ID:     "12345",
+			Status: lib.PeriodStatusCurrent,
+			Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+		Accounts: []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON, Abbrev: "BA"}},
+		Categories: []lib.Category{
+			{ID: 10, Name: "Gifts", Kind: lib.KindSpend, Budget: lib.BudgetASC},
+			{ID: 11, ParentID: 10, Name: "Vouchers", Kind: lib.KindAllocation, Budget: lib.BudgetASC, Stock: true},
+		},
+	}
+}
+
+// getMockSitesUsersData returns a dump of the sites and users only.
+func getMockSitesUsersData() dumpData {
+	return dumpData{
+		Types: map[string]bool{typeSites: true, typeUsers: true},
+		Sites: []lib.Site{
+			{ID: "s1", Name: "Head office", Address: "1 rue de la Paix", ZipCode: "75002", City: "Paris"},
+			{ID: "s2", Name: "Factory", ZipCode: "69007", City: "Lyon"},
+		},
+		Users: []lib.User{
+			{ID: "u1", Lastname: "Doe", Firstname: "Jane", Email: "jane@example.com", Role: "Administrateur", Active: true},
+			{
+				ID: "u2", Lastname: "Smith", Firstname: "John", Email: "john@example.com", Role: "Lecteur",
+				Sites: []string{"Head office", "Factory"},
+			},
+		},
+	}
+}
+
+func TestWriteDumpYAML(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, getMockDumpData(), formatYAML); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `employees:
+  - id: e1
+    lastname: Doe
+    firstname: Jane
+    active: true
+providers:
+  - id: p1
+    name: ACME
+    city: Paris
+    archived: true
+periods:
+  - id: "12345"
+    start: "2025-01-01"
+    end: "2025-12-31"
+    status: current
+accounts:
+  - id: 1
+    bank: Bank A
+    budget: FON
+    abbreviation: BA
+categories:
+  - id: 10
+    name: Gifts
+    kind: depenses
+    budget: ASC
+    stock: false
+  - id: 11
+    parent_id: 10
+    name: Vouchers
+    kind: attributions
+    budget: ASC
+    stock: true
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteDumpUnknownFormat(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, dumpData{}, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestWriteDumpYAMLSelectedTypes(t *testing.T) {
+	data := getMockDumpData()
+	data.Types = map[string]bool{typeAccounts: true}
+	data.Employees = nil
+	data.Providers = nil
+	data.Periods = nil
+	data.Categories = nil
+
+	var out bytes.Buffer
+	if err := writeDump(&out, data, formatYAML); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `accounts:
+  - id: 1
+    bank: Bank A
+    budget: FON
+    abbreviation: BA
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteDumpYAMLSitesUsers(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeDump(&out, getMockSitesUsersData(), formatYAML); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `sites:
+  - id: s1
+    name: Head office
+    address: 1 rue de la Paix
+    zip_code: "75002"
+    city: Paris
+  - id: s2
+    name: Factory
+    zip_code: "69007"
+    city: Lyon
+users:
+  - id: u1
+    lastname: Doe
+    firstname: Jane
+    email: jane@example.com
+    role: Administrateur
+    active: true
+  - id: u2
+    lastname: Smith
+    firstname: John
+    email: john@example.com
+    role: Lecteur
+    sites:
+      - Head office
+      - Factory
+    active: false
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteDumpMetadata(t *testing.T) {
+	data := dumpData{Types: map[string]bool{typeAccounts: true}, Accounts: getMockDumpData().Accounts}
+	data.Metadata = newOutputMetadata(time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC), "CSE ACME")
+
+	var out bytes.Buffer
+	if err := writeDump(&out, data, formatYAML); err != nil {
+		t.Fatalf("writeDump failed: %v", err)
+	}
+
+	want := `metadata:
+  schema: 1
+  generator: dumper
+  version: dev
+  generated: "2025-06-30T22:00:00Z"
+  organization: CSE ACME
+accounts:
+  - id: 1
+    bank: Bank A
+    budget: FON
+    abbreviation: BA
+`
+	if got := out.String(); got != want {
+		t.Errorf("YAML output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
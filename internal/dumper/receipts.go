@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+func newReceiptsCmd() *cobra.Command {
+	var receiptsCmd = &cobra.Command{
+		Use:   "receipts",
+		Short: "Download the receipts of a period",
+		Long: `Download the receipts of all the entries of an accounting period in a zip archive.
+The receipts are stored in a folder per entry named after the entry number.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			period, err := cmd.Flags().GetString("period")
+			if err != nil {
+				return err
+			}
+			out, err := cmd.Flags().GetString("out")
+			if err != nil {
+				return err
+			}
+
+			return receipts(cfg, period, out)
+		},
+	}
+	receiptsCmd.Flags().String("period", "", `Accounting period of the entries.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	receiptsCmd.Flags().String("out", "receipts.zip", "Path of the zip archive to create.")
+
+	return receiptsCmd
+}
+
+// receiptDownloader fetches the content of a receipt.
+type receiptDownloader interface {
+	DownloadReceipt(link string, w io.Writer) error
+}
+
+func receipts(cfg Config, period string, out string) error {
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return err
+	}
+	periodID, err := lib.FindPeriod(periods, period)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ListEntries(lib.EntryFilter{Period: periodID})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", out, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := writeReceiptsArchive(file, entries, client); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// writeReceiptsArchive writes a zip archive with the receipts of the entries.
+// Each entry gets a folder named after its number containing its receipts.
+func writeReceiptsArchive(w io.Writer, entries []lib.Entry, downloader receiptDownloader) error {
+	archive := zip.NewWriter(w)
+
+	err := forEachReceipt(entries, func(entry lib.Entry, name string, link string) error {
+		file, err := archive.Create(path.Join(entry.ID, path.Base(name)))
+		if err != nil {
+			return fmt.Errorf("failed to add receipt %s of entry %s: %s", name, entry.ID, err)
+		}
+		return downloader.DownloadReceipt(link, file)
+	})
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// forEachReceipt calls fn for each receipt of the entries with a known link.
+func forEachReceipt(entries []lib.Entry, fn func(entry lib.Entry, name string, link string) error) error {
+	for _, entry := range entries {
+		for i, name := range entry.Receipts {
+			link := ""
+			if i < len(entry.ReceiptLinks) {
+				link = entry.ReceiptLinks[i]
+			}
+			if link == "" {
+				slog.Warn("no link found for the receipt, skipping it", "receipt", name, "entry", entry.ID)
+				continue
+			}
+			if err := fn(entry, name, link); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
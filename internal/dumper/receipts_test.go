@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+type fakeDownloader struct {
+	files map[string]string
+}
+
+func (d fakeDownloader) DownloadReceipt(link string, w io.Writer) error {
+	content, found := d.files[link]
+	if !found {
+		return fmt.Errorf("receipt %s not found", link)
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+func TestWriteReceiptsArchive(t *testing.T) {
+	entries := []lib.Entry{
+		{
+			ID:           "ASC000012",
+			Receipts:     []string{"invoice.pdf", "ticket.jpg"},
+			ReceiptLinks: []string{"https://example.com/invoice.pdf", "https://example.com/ticket.jpg"},
+		},
+		{
+			ID:           "FON000001",
+			Receipts:     []string{"lost.pdf"},
+			ReceiptLinks: []string{""},
+		},
+		{ID: "FON000002"},
+	}
+	downloader := fakeDownloader{files: map[string]string{
+		"https://example.com/invoice.pdf": "invoice content",
+		"https://example.com/ticket.jpg":  "ticket content",
+	}}
+
+	var buf bytes.Buffer
+	if err := writeReceiptsArchive(&buf, entries, downloader); err != nil {
+		t.Fatalf("writeReceiptsArchive failed: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip archive: %v", err)
+	}
+	actual := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		_ = reader.Close()
+		actual[file.Name] = string(content)
+	}
+
+	expected := map[string]string{
+		"ASC000012/invoice.pdf": "invoice content",
+		"ASC000012/ticket.jpg":  "ticket content",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Archive content mismatch. Got: %v, Want: %v", actual, expected)
+	}
+}
+
+func TestWriteReceiptsArchiveDownloadError(t *testing.T) {
+	entries := []lib.Entry{
+		{ID: "ASC000012", Receipts: []string{"invoice.pdf"}, ReceiptLinks: []string{"https://example.com/invoice.pdf"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeReceiptsArchive(&buf, entries, fakeDownloader{}); err == nil {
+		t.Error("Expected an error when a receipt download fails")
+	}
+}
@@ -0,0 +1,528 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// statementHelp describes the bank statement files and how their lines match the entries.
+const statementHelp = `The statement is a CSV file, an ISO 20022 camt.053 XML file with a .xml extension
+or an OFX file with a .ofx or .qfx extension. The column, date and CSV flags only apply to the CSV files.
+
+A line matches an entry with the same amount and a date close enough. The spending entries
+match negative amounts and the income ones positive amounts. When several entries match,
+the one with its ID or the most words of its name in the line label is preferred, then the closest one.`
+
+// statementColumns holds the names of the bank statement columns.
+type statementColumns struct {
+	Date   string
+	Amount string
+	Label  string
+}
+
+// reconcileOptions holds the parameters of the reconcile command.
+type reconcileOptions struct {
+	Account    string
+	Period     string
+	Columns    statementColumns
+	DateLayout string
+	Tolerance  int
+	CSV        common.CSVParams
+}
+
+func newReconcileCmd() *cobra.Command {
+	var reconcileCmd = &cobra.Command{
+		Use:   "reconcile statement.csv",
+		Short: "Match a bank statement against the entries",
+		Long: `Match the lines of a bank statement file against the entries of an account.
+
+` + statementHelp + `
+
+The statement lines and entries without match are written to the exceptions report.
+With --mark, the matches are printed and, after confirmation, a "Reconciled with the bank statement line" line
+is added to the comment of their entries. The entries already having it are not changed.`,
+		Args: common.UsageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			opts, err := getReconcileOptions(cmd)
+			if err != nil {
+				return common.WithExitCode(common.ExitUsage, err)
+			}
+			// The output setting of the dump command doesn't apply to the exceptions report.
+			if cfg.Output, err = cmd.Flags().GetString("output"); err != nil {
+				return err
+			}
+			mark, err := cmd.Flags().GetBool("mark")
+			if err != nil {
+				return err
+			}
+			yes, err := cmd.Flags().GetBool("yes")
+			if err != nil {
+				return err
+			}
+			return reconcileStatement(cfg, args[0], opts, mark, yes)
+		},
+	}
+	reconcileCmd.Flags().String("account", "",
+		"Bank account of the statement: its ID, bank name or abbreviation (REQUIRED)")
+	reconcileCmd.Flags().String("period", "", `Accounting period of the entries.
+Either the ID of the period or a year matching the start of the period. Defaults to the current one.`)
+	addStatementFlags(reconcileCmd)
+	reconcileCmd.Flags().StringP("output", "o", "", `File to write the exceptions report to instead of the standard output.
+If the path is a directory or ends with a separator, the report is written in a timestamped file in it.`)
+	reconcileCmd.Flags().Bool("mark", false, "Mark the matching entries as reconciled in happy-compta.")
+	reconcileCmd.Flags().BoolP("yes", "y", false, "Mark the matching entries without asking for confirmation.")
+
+	return reconcileCmd
+}
+
+// addStatementFlags adds the flags describing the bank statement CSV files.
+func addStatementFlags(cmd *cobra.Command) {
+	cmd.Flags().String("date-column", "date", "Name of the statement column containing the date.")
+	cmd.Flags().String("amount-column", "amount", "Name of the statement column containing the signed amount.")
+	cmd.Flags().String("label-column", "label", "Name of the statement column containing the line label.")
+	cmd.Flags().String("date-format", "", `Go layout of the statement dates.
+Defaults to DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY: 02/01/2006, 2006-01-02 or 02-01-06.`)
+	cmd.Flags().Int("date-tolerance", 3, "Maximum number of days between a line and its matching entry.")
+	cmd.Flags().String("csv-comma", "", "Field separator of the statement. Defaults to a comma.")
+	cmd.Flags().String("csv-encoding", "", "Encoding of the statement. Guessed by default.")
+}
+
+func getReconcileOptions(cmd *cobra.Command) (opts reconcileOptions, err error) {
+	if opts, err = getStatementOptions(cmd); err != nil {
+		return
+	}
+	if opts.Account, err = cmd.Flags().GetString("account"); err != nil {
+		return
+	}
+	if opts.Period, err = cmd.Flags().GetString("period"); err != nil {
+		return
+	}
+	if opts.Account == "" {
+		err = errors.New("account parameter is required")
+	}
+	return
+}
+
+// getStatementOptions reads the flags added by addStatementFlags.
+func getStatementOptions(cmd *cobra.Command) (opts reconcileOptions, err error) {
+	flags := cmd.Flags()
+	for _, flag := range []struct {
+		name  string
+		value *string
+	}{
+		{"date-column", &opts.Columns.Date},
+		{"amount-column", &opts.Columns.Amount},
+		{"label-column", &opts.Columns.Label},
+		{"date-format", &opts.DateLayout},
+		{"csv-comma", &opts.CSV.Comma},
+		{"csv-encoding", &opts.CSV.Encoding},
+	} {
+		if *flag.value, err = flags.GetString(flag.name); err != nil {
+			return
+		}
+	}
+	opts.Tolerance, err = flags.GetInt("date-tolerance")
+	return
+}
+
+// statementLine is a line of the bank statement.
+type statementLine struct {
+	Row    int
+	Date   time.Time
+	Amount float64
+	Label  string
+}
+
+// statementMatch is a statement line and its matching entry.
+type statementMatch struct {
+	Line  statementLine
+	Entry lib.Entry
+}
+
+// reconciliation is the result of matching the statement lines with the entries.
+type reconciliation struct {
+	Metadata         *outputMetadata
+	Account          lib.Account
+	Matched          int
+	Matches          []statementMatch
+	UnmatchedLines   []statementLine
+	UnmatchedEntries []lib.Entry
+}
+
+// reconcileStatement matches the statement lines with the entries of the account and writes the exceptions report.
+// With mark, the matching entries are marked as reconciled once confirmed.
+func reconcileStatement(cfg Config, statementPath string, opts reconcileOptions, mark bool, yes bool) error {
+	lines, err := loadStatement(statementPath, opts)
+	if err != nil {
+		return err
+	}
+
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	accounts, err := client.ListAccounts()
+	if err != nil {
+		return err
+	}
+	account, err := findAccount(accounts, opts.Account)
+	if err != nil {
+		return err
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return err
+	}
+	periodID, err := lib.FindPeriod(periods, opts.Period)
+	if err != nil {
+		return err
+	}
+	entries, err := client.ListEntries(lib.EntryFilter{Period: periodID})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	result := reconcile(lines, accountEntries(entries, account, lines, opts.Tolerance), opts.Tolerance)
+	result.Account = account
+	result.Metadata = newOutputMetadata(now, cfg.Organization)
+
+	var markErr error
+	if matches := unmarkedMatches(result.Matches); mark && len(matches) > 0 {
+		if err := writeMatches(os.Stderr, matches); err != nil {
+			return err
+		}
+		question := fmt.Sprintf("Mark the %d entries as reconciled in happy-compta?", len(matches))
+		if yes || common.Confirm(os.Stdin, os.Stderr, question) {
+			markErr = markReconciled(client, matches)
+		} else {
+			markErr = errors.New("the entries have not been marked as reconciled")
+		}
+	}
+
+	writeErr := writeOutput(cfg.Output, "exceptions", formatExtension(cfg.Format, "txt"), now,
+		func(w io.Writer) error { return writeReconciliation(w, result, cfg.Format) })
+	return errors.Join(markErr, writeErr)
+}
+
+// loadStatement reads the lines of the bank statement file in the format of its extension.
+func loadStatement(path string, opts reconcileOptions) ([]statementLine, error) {
+	if format := statementFormat(path); format != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = file.Close() }()
+
+		if format == statementOFX {
+			return readOFX(file)
+		}
+		return readCAMT053(file)
+	}
+
+	reader, cleaner, err := common.GetCSVReader(opts.CSV, path)
+	if err != nil {
+		return nil, err
+	}
+	defer cleaner()
+
+	dates := common.DateParams{}
+	if opts.DateLayout != "" {
+		dates.Layouts = []string{opts.DateLayout}
+	}
+	return readStatement(reader, opts.Columns, dates)
+}
+
+// readStatement reads the lines of the bank statement, parsing the dates in the given layouts.
+func readStatement(reader *csv.Reader, columns statementColumns, dates common.DateParams) ([]statementLine, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the statement header: %s", err)
+	}
+	findColumn := func(name string) int {
+		return slices.IndexFunc(header, func(column string) bool {
+			return strings.EqualFold(strings.TrimSpace(column), name)
+		})
+	}
+
+	dateIdx := findColumn(columns.Date)
+	amountIdx := findColumn(columns.Amount)
+	labelIdx := findColumn(columns.Label)
+	if dateIdx < 0 || amountIdx < 0 {
+		return nil, fmt.Errorf("the statement needs %s and %s columns", columns.Date, columns.Amount)
+	}
+
+	var lines []statementLine
+	var errs []error
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the statement: %s", err)
+		}
+		if fields, err = common.TrimRow(fields, len(header)); err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %s", row, err))
+			continue
+		}
+		fields, _ = common.PadRow(fields, len(header))
+
+		line := statementLine{Row: row}
+		if labelIdx >= 0 {
+			line.Label = fields[labelIdx]
+		}
+		if line.Date, err = dates.Parse(fields[dateIdx]); err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %s", row, err))
+			continue
+		}
+		if line.Amount, err = common.ParseAmount(fields[amountIdx]); err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %s", row, err))
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, errors.Join(errs...)
+}
+
+// findAccount returns the account matching the value: its ID, bank name or abbreviation.
+func findAccount(accounts []lib.Account, value string) (lib.Account, error) {
+	for _, account := range accounts {
+		if strconv.Itoa(account.ID) == value || strings.EqualFold(account.Abbrev, value) ||
+			strings.EqualFold(account.Bank, value) {
+			return account, nil
+		}
+	}
+	return lib.Account{}, fmt.Errorf("no account matching %s", value)
+}
+
+// entryAmount returns the total of the entry, negative for the spending.
+func entryAmount(entry lib.Entry) float64 {
+	var amount float64
+	for _, line := range entry.Allocation {
+		amount += line.Amount
+	}
+	if entry.Kind == lib.KindSpend {
+		return -amount
+	}
+	return amount
+}
+
+// accountEntries returns the entries of the account dated around the statement lines.
+func accountEntries(entries []lib.Entry, account lib.Account, lines []statementLine, tolerance int) []lib.Entry {
+	var from, to time.Time
+	for _, line := range lines {
+		if from.IsZero() || line.Date.Before(from) {
+			from = line.Date
+		}
+		if to.IsZero() || line.Date.After(to) {
+			to = line.Date
+		}
+	}
+	margin := time.Duration(tolerance) * 24 * time.Hour
+
+	var result []lib.Entry
+	for _, entry := range entries {
+		if entry.Account.ID != account.ID {
+			continue
+		}
+		if !from.IsZero() && (entry.Date.Before(from.Add(-margin)) || entry.Date.After(to.Add(margin))) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// reconcile matches each statement line with an unmatched entry of the same amount dated within the tolerance.
+// The entry whose label score is the highest is preferred, then the one with the closest date.
+func reconcile(lines []statementLine, entries []lib.Entry, tolerance int) reconciliation {
+	var result reconciliation
+	matched := make([]bool, len(entries))
+
+	for _, line := range lines {
+		best := -1
+		bestDays, bestScore := 0, 0
+		for i, entry := range entries {
+			if matched[i] || toCents(entryAmount(entry)) != toCents(line.Amount) {
+				continue
+			}
+			days := int(math.Abs(entry.Date.Sub(line.Date).Hours() / 24))
+			if days > tolerance {
+				continue
+			}
+			score := labelScore(line.Label, entry)
+			if best < 0 || score > bestScore || (score == bestScore && days < bestDays) {
+				best = i
+				bestDays, bestScore = days, score
+			}
+		}
+
+		if best < 0 {
+			result.UnmatchedLines = append(result.UnmatchedLines, line)
+			continue
+		}
+		matched[best] = true
+		result.Matched++
+		result.Matches = append(result.Matches, statementMatch{Line: line, Entry: entries[best]})
+	}
+
+	for i, entry := range entries {
+		if !matched[i] {
+			result.UnmatchedEntries = append(result.UnmatchedEntries, entry)
+		}
+	}
+	return result
+}
+
+// labelScore rates how much the statement line label refers to the entry.
+// The ID of the entry, like in the end to end ID of the transfers, weighs more than the words of its name.
+func labelScore(label string, entry lib.Entry) int {
+	words := labelWords(label)
+	score := 0
+	if entry.ID != "" && slices.Contains(words, strings.ToLower(entry.ID)) {
+		score += 10
+	}
+	for _, word := range labelWords(entry.Name) {
+		if len(word) >= 3 && slices.Contains(words, word) {
+			score++
+		}
+	}
+	return score
+}
+
+// labelWords returns the lower case words of the text without their accents.
+func labelWords(text string) []string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	text, _, _ = transform.String(t, strings.ToLower(text))
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// reconciliationOutput is the structure of the reconciliation in the structured formats.
+type reconciliationOutput struct {
+	Metadata         *outputMetadata        `yaml:"metadata,omitempty"`
+	Account          string                 `yaml:"account"`
+	Matched          int                    `yaml:"matched"`
+	UnmatchedLines   []unmatchedLineOutput  `yaml:"unmatched_lines"`
+	UnmatchedEntries []unmatchedEntryOutput `yaml:"unmatched_entries"`
+}
+
+type unmatchedLineOutput struct {
+	Row    int     `yaml:"row"`
+	Date   string  `yaml:"date"`
+	Amount float64 `yaml:"amount"`
+	Label  string  `yaml:"label,omitempty"`
+}
+
+type unmatchedEntryOutput struct {
+	ID     string  `yaml:"id"`
+	Date   string  `yaml:"date"`
+	Amount float64 `yaml:"amount"`
+	Name   string  `yaml:"name"`
+}
+
+// writeReconciliation writes the reconciliation result in the requested format.
+func writeReconciliation(w io.Writer, result reconciliation, format string) error {
+	switch format {
+	case "", formatText:
+		return writeReconciliationText(w, result)
+	case formatTable:
+		return writeReconciliationTables(w, result)
+	case formatYAML:
+		output := reconciliationOutput{
+			Metadata:         result.Metadata,
+			Account:          result.Account.Bank,
+			Matched:          result.Matched,
+			UnmatchedLines:   make([]unmatchedLineOutput, 0, len(result.UnmatchedLines)),
+			UnmatchedEntries: make([]unmatchedEntryOutput, 0, len(result.UnmatchedEntries)),
+		}
+		for _, line := range result.UnmatchedLines {
+			output.UnmatchedLines = append(output.UnmatchedLines, unmatchedLineOutput{
+				Row: line.Row, Date: line.Date.Format(dateFormat), Amount: line.Amount, Label: line.Label,
+			})
+		}
+		for _, entry := range result.UnmatchedEntries {
+			output.UnmatchedEntries = append(output.UnmatchedEntries, unmatchedEntryOutput{
+				ID: entry.ID, Date: entry.Date.Format(dateFormat), Amount: entryAmount(entry), Name: entry.Name,
+			})
+		}
+		return writeYAML(w, output)
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+func writeReconciliationText(w io.Writer, result reconciliation) error {
+	fmt.Fprintf(w, "%s: %d matched lines\n", result.Account.Bank, result.Matched)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nUnmatched statement lines (%d):\n", len(result.UnmatchedLines))
+	for _, line := range result.UnmatchedLines {
+		fmt.Fprintf(tw, "row %d\t%s\t%.2f\t%s\n", line.Row, line.Date.Format(lib.DateLayout), line.Amount, line.Label)
+	}
+	fmt.Fprintf(tw, "\nUnmatched entries (%d):\n", len(result.UnmatchedEntries))
+	for _, entry := range result.UnmatchedEntries {
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%s\n", entry.ID, entry.Date.Format(lib.DateLayout), entryAmount(entry), entry.Name)
+	}
+	return tw.Flush()
+}
+
+func writeReconciliationTables(w io.Writer, result reconciliation) error {
+	fmt.Fprintf(w, "%s: %d matched lines\n\n", result.Account.Bank, result.Matched)
+
+	lines := table{Title: "Unmatched statement lines", Header: []string{"ROW", "DATE", "AMOUNT", "LABEL"}}
+	for _, line := range result.UnmatchedLines {
+		lines.Rows = append(lines.Rows, []string{
+			strconv.Itoa(line.Row), line.Date.Format(lib.DateLayout), fmt.Sprintf("%.2f", line.Amount), line.Label,
+		})
+	}
+	entries := table{Title: "Unmatched entries", Header: []string{"ID", "DATE", "AMOUNT", "NAME"}}
+	for _, entry := range result.UnmatchedEntries {
+		entries.Rows = append(entries.Rows, []string{
+			entry.ID, entry.Date.Format(lib.DateLayout), fmt.Sprintf("%.2f", entryAmount(entry)), entry.Name,
+		})
+	}
+	return writeTables(w, []table{lines, entries}, tableSettings)
+}
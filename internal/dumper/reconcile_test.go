@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReadStatement(t *testing.T) {
+	content := `Date;Label;Amount
+03/03/2025;CARD ACME;-120,50
+05/03/2025;TRANSFER COMPANY;1 000,00
+`
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = ';'
+
+	lines, err := readStatement(reader, statementColumns{Date: "date", Amount: "amount", Label: "label"}, common.DateParams{Layouts: []string{lib.DateLayout}})
+	if err != nil {
+		t.Fatalf("readStatement failed: %v", err)
+	}
+	expected := []statementLine{
+		{Row: 2, Date: day(3), Amount: -120.5, Label: "CARD ACME"},
+		{Row: 3, Date: day(5), Amount: 1000, Label: "TRANSFER COMPANY"},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines mismatch. Got: %+v, Want: %+v", lines, expected)
+	}
+}
+
+func TestReadStatementErrors(t *testing.T) {
+	columns := statementColumns{Date: "date", Amount: "amount", Label: "label"}
+
+	reader := csv.NewReader(strings.NewReader("when,amount\n03/03/2025,12\n"))
+	if _, err := readStatement(reader, columns, common.DateParams{Layouts: []string{lib.DateLayout}}); err == nil {
+		t.Error("Expected an error for a missing date column")
+	}
+
+	reader = csv.NewReader(strings.NewReader("date,amount\n2025-03-03,12\n03/03/2025,abc\n04/03/2025,12\n"))
+	lines, err := readStatement(reader, columns, common.DateParams{Layouts: []string{lib.DateLayout}})
+	if err == nil || !strings.Contains(err.Error(), "row 2") || !strings.Contains(err.Error(), "row 3") {
+		t.Errorf("Expected errors for rows 2 and 3, got: %v", err)
+	}
+	if len(lines) != 1 || lines[0].Row != 4 {
+		t.Errorf("Expected only row 4 to be read, got: %+v", lines)
+	}
+
+	reader = csv.NewReader(strings.NewReader("date,amount\n03/03/2025,12,\n04/03/2025,12,shifted\n"))
+	reader.FieldsPerRecord = -1
+	lines, err = readStatement(reader, columns, common.DateParams{Layouts: []string{lib.DateLayout}})
+	if err == nil || !strings.Contains(err.Error(), "row 3: 3 fields, more than the 2 columns of the header") {
+		t.Errorf("Expected an error for the long row 3, got: %v", err)
+	}
+	if len(lines) != 1 || lines[0].Row != 2 {
+		t.Errorf("Expected only row 2 to be read, got: %+v", lines)
+	}
+}
+
+func TestFindAccount(t *testing.T) {
+	accounts := []lib.Account{{ID: 1, Bank: "First National Bank", Abbrev: "FNB"}, {ID: 2, Bank: "Other"}}
+
+	for _, value := range []string{"FNB", "fnb", "first national bank", "1"} {
+		account, err := findAccount(accounts, value)
+		if err != nil || account.ID != 1 {
+			t.Errorf("findAccount(%s) mismatch. Got: %+v, %v, Want: account 1", value, account, err)
+		}
+	}
+	if _, err := findAccount(accounts, "XYZ"); err == nil {
+		t.Error("Expected an error for an unknown account")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	account := lib.Account{ID: 1, Bank: "Bank A"}
+	lines := []statementLine{
+		{Row: 2, Date: day(3), Amount: -120.5, Label: "CARD ACME"},
+		{Row: 3, Date: day(5), Amount: 1000, Label: "TRANSFER COMPANY"},
+		{Row: 4, Date: day(10), Amount: -15, Label: "BANK FEES"},
+		{Row: 5, Date: day(12), Amount: -120.5, Label: "CARD ACME"},
+	}
+	spend := func(id string, d int, amount float64, accountID int) lib.Entry {
+		return lib.Entry{
+			ID: id, Kind: lib.KindSpend, Date: day(d), Account: lib.Account{ID: accountID},
+			Allocation: []lib.AllocationLine{{Amount: amount}},
+		}
+	}
+	entries := []lib.Entry{
+		spend("ASC000001", 1, 120.5, 1),
+		{
+			ID: "FON000001", Kind: lib.KindAllocation, Date: day(4), Account: lib.Account{ID: 1},
+			Allocation: []lib.AllocationLine{{Amount: 600}, {Amount: 400}},
+		},
+		spend("ASC000002", 11, 120.5, 1),
+		spend("ASC000003", 20, 50, 1),
+		spend("ASC000004", 12, 15, 2),
+		spend("ASC000005", 25, 15, 1),
+	}
+
+	filtered := accountEntries(entries, account, lines, 3)
+	var ids []string
+	for _, entry := range filtered {
+		ids = append(ids, entry.ID)
+	}
+	wantIDs := []string{"ASC000001", "FON000001", "ASC000002"}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Fatalf("Account entries mismatch. Got: %v, Want: %v", ids, wantIDs)
+	}
+
+	result := reconcile(lines, filtered, 3)
+	if result.Matched != 3 {
+		t.Errorf("Matched mismatch. Got: %d, Want: 3", result.Matched)
+	}
+	if len(result.UnmatchedLines) != 1 || result.UnmatchedLines[0].Row != 4 {
+		t.Errorf("Unmatched lines mismatch. Got: %+v", result.UnmatchedLines)
+	}
+	if len(result.UnmatchedEntries) != 0 {
+		t.Errorf("Unmatched entries mismatch. Got: %+v", result.UnmatchedEntries)
+	}
+}
+
+func TestReconcileLabels(t *testing.T) {
+	spend := func(id string, d int, name string) lib.Entry {
+		return lib.Entry{
+			ID: id, Kind: lib.KindSpend, Date: day(d), Name: name, Allocation: []lib.AllocationLine{{Amount: 42}},
+		}
+	}
+	entries := []lib.Entry{
+		spend("ASC000001", 3, "Train tickets"),
+		spend("ASC000002", 5, "Cadeaux de Noël"),
+		spend("ASC000003", 6, "Train tickets"),
+	}
+	lines := []statementLine{
+		{Row: 1, Date: day(3), Amount: -42, Label: "CB CADEAUX NOEL"},
+		{Row: 2, Date: day(3), Amount: -42, Label: "SEPA TRANSFER ASC000003 TRAIN"},
+		{Row: 3, Date: day(3), Amount: -42, Label: "CARD"},
+	}
+	result := reconcile(lines, entries, 3)
+
+	var actual []string
+	for _, match := range result.Matches {
+		actual = append(actual, fmt.Sprintf("%d:%s", match.Line.Row, match.Entry.ID))
+	}
+	if expected := []string{"1:ASC000002", "2:ASC000003", "3:ASC000001"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Matches mismatch. Got: %v, Want: %v", actual, expected)
+	}
+	if result.Matched != 3 {
+		t.Errorf("Matched mismatch. Got: %d, Want: 3", result.Matched)
+	}
+}
+
+func TestWriteReconciliationText(t *testing.T) {
+	result := reconciliation{
+		Account:        lib.Account{ID: 1, Bank: "Bank A"},
+		Matched:        3,
+		UnmatchedLines: []statementLine{{Row: 4, Date: day(10), Amount: -15, Label: "BANK FEES"}},
+		UnmatchedEntries: []lib.Entry{{
+			ID: "ASC000003", Kind: lib.KindSpend, Date: day(20), Name: "Gifts",
+			Allocation: []lib.AllocationLine{{Amount: 50}},
+		}},
+	}
+
+	var out bytes.Buffer
+	if err := writeReconciliation(&out, result, formatText); err != nil {
+		t.Fatalf("writeReconciliation failed: %v", err)
+	}
+
+	want := `Bank A: 3 matched lines
+
+Unmatched statement lines (1):
+row 4  10/03/2025  -15.00  BANK FEES
+
+Unmatched entries (1):
+ASC000003  20/03/2025  -50.00  Gifts
+`
+	if got := out.String(); got != want {
+		t.Errorf("Text output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
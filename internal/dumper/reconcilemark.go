@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// reconciledNotePrefix starts the line added to the comment of the entries matching a bank statement line.
+const reconciledNotePrefix = "Reconciled with the bank statement line "
+
+// isReconciled returns whether the comment of the entry has the line added when marking it as reconciled.
+func isReconciled(entry lib.Entry) bool {
+	for line := range strings.Lines(entry.Comment) {
+		if strings.HasPrefix(line, reconciledNotePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarkedMatches returns the matches with an entry not marked as reconciled yet.
+func unmarkedMatches(matches []statementMatch) []statementMatch {
+	var result []statementMatch
+	for _, match := range matches {
+		if !isReconciled(match.Entry) {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+// reconciledNote returns the line added to the comment of the entry matching the statement line.
+func reconciledNote(line statementLine) string {
+	note := fmt.Sprintf("%sof %s", reconciledNotePrefix, line.Date.Format(lib.DateLayout))
+	if line.Label != "" {
+		note += ": " + line.Label
+	}
+	return note
+}
+
+// writeMatches lists the matching statement lines and entries.
+func writeMatches(w io.Writer, matches []statementMatch) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ROW\tDATE\tAMOUNT\tLABEL\tENTRY\tDATE\tNAME")
+	for _, match := range matches {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%.2f\t%s\t%s\t%s\t%s\n", match.Line.Row,
+			match.Line.Date.Format(lib.DateLayout), match.Line.Amount, match.Line.Label,
+			match.Entry.ID, match.Entry.Date.Format(lib.DateLayout), match.Entry.Name)
+	}
+	return tw.Flush()
+}
+
+// markReconciled adds the reconciled note of their statement line to the comment of the entries and saves them.
+// All the entries are updated even if some fail.
+func markReconciled(client lib.EntryUpdater, matches []statementMatch) error {
+	var allErrors []error
+	for _, match := range matches {
+		entry := match.Entry
+		if entry.Comment != "" && !strings.HasSuffix(entry.Comment, "\n") {
+			entry.Comment += "\n"
+		}
+		entry.Comment += reconciledNote(match.Line)
+		if err := client.UpdateEntry(&entry); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("failed to mark entry %s as reconciled: %s", entry.ID, err))
+			continue
+		}
+		slog.Info("marked the entry as reconciled", "entry", entry.ID)
+	}
+	return errors.Join(allErrors...)
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+type fakeEntryUpdater struct {
+	updated []lib.Entry
+}
+
+func (f *fakeEntryUpdater) UpdateEntry(entry *lib.Entry) error {
+	if entry.ID == "ASC000003" {
+		return errors.New("HTTP 500")
+	}
+	f.updated = append(f.updated, *entry)
+	return nil
+}
+
+func TestMarkReconciled(t *testing.T) {
+	line := statementLine{Row: 2, Date: day(3), Amount: -42, Label: "CARD ACME"}
+	matches := []statementMatch{
+		{Line: line, Entry: lib.Entry{ID: "ASC000001"}},
+		{Line: statementLine{Row: 3, Date: day(4)}, Entry: lib.Entry{ID: "ASC000002", Comment: "Gifts"}},
+		{Line: line, Entry: lib.Entry{ID: "ASC000003"}},
+	}
+	updater := &fakeEntryUpdater{}
+	err := markReconciled(updater, matches)
+	if err == nil || !strings.Contains(err.Error(), "failed to mark entry ASC000003 as reconciled: HTTP 500") {
+		t.Errorf("Expected an error for ASC000003, got: %v", err)
+	}
+
+	expected := []lib.Entry{
+		{ID: "ASC000001", Comment: "Reconciled with the bank statement line of 03/03/2025: CARD ACME"},
+		{ID: "ASC000002", Comment: "Gifts\nReconciled with the bank statement line of 04/03/2025"},
+	}
+	if !reflect.DeepEqual(updater.updated, expected) {
+		t.Errorf("Updated entries mismatch. Got: %+v, Want: %+v", updater.updated, expected)
+	}
+	if matches := unmarkedMatches([]statementMatch{{Entry: expected[1]}, {Entry: matches[2].Entry}}); len(matches) != 1 ||
+		matches[0].Entry.ID != "ASC000003" {
+		t.Errorf("Unmarked matches mismatch. Got: %+v", matches)
+	}
+}
+
+func TestReconcileStatementMockServer(t *testing.T) {
+	spend := func(id string, d int, amount float64, comment string) lib.Entry {
+		return lib.Entry{
+			ID: id, Period: "12345", Kind: lib.KindSpend, Date: day(d), Name: "Spend " + id, Budget: lib.BudgetASC,
+			Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: amount}}, Account: lib.Account{ID: 1},
+			PaymentMethod: lib.PaymentMethodCard, Comment: comment, CheckNumber: "1234567",
+		}
+	}
+	reconciled := "Reconciled with the bank statement line of 01/03/2025"
+	server := mockserver.New(mockserver.Data{
+		Email:    "treasurer@example.com",
+		Password: "secret",
+		Accounts: []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetASC, Abbrev: "BA"}},
+		Periods: []lib.Period{{
+			ID:     "12345",
+			Status: lib.PeriodStatusCurrent,
+			Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+		Entries: []lib.Entry{
+			spend("ASC000001", 3, 120.5, ""),
+			spend("ASC000002", 1, 15, reconciled),
+			spend("ASC000003", 4, 60, ""),
+		},
+	})
+	defer server.Close()
+	t.Setenv("HAPPYCOMPTA_URL", server.URL)
+
+	dir := t.TempDir()
+	statement := filepath.Join(dir, "statement.ofx")
+	content := "<OFX>\n" +
+		"<STMTTRN><DTPOSTED>20250304<TRNAMT>-120.50<NAME>CARD ACME</STMTTRN>\n" +
+		"<STMTTRN><DTPOSTED>20250301<TRNAMT>-15.00<NAME>BANK FEES</STMTTRN>\n" +
+		"<STMTTRN><DTPOSTED>20250305<TRNAMT>-9.99<NAME>SUBSCRIPTION</STMTTRN>\n" +
+		"</OFX>\n"
+	if err := os.WriteFile(statement, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "exceptions.txt")
+	cfg := Config{Email: "treasurer@example.com", Password: "secret", Format: formatText, Output: output}
+	opts := reconcileOptions{Account: "BA", Tolerance: 3}
+	comments := func() []string {
+		var result []string
+		for _, entry := range server.Entries() {
+			result = append(result, entry.Comment)
+			if entry.CheckNumber != "1234567" {
+				t.Errorf("Expected the check number of %s to be kept, got: %s", entry.ID, entry.CheckNumber)
+			}
+		}
+		return result
+	}
+
+	// Without --mark, only the exceptions report is written.
+	if err := reconcileStatement(cfg, statement, opts, false, false); err != nil {
+		t.Fatalf("reconcileStatement failed: %v", err)
+	}
+	if got, expected := comments(), []string{"", reconciled, ""}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Comments mismatch without marking. Got: %q, Want: %q", got, expected)
+	}
+
+	if err := reconcileStatement(cfg, statement, opts, true, true); err != nil {
+		t.Fatalf("reconcileStatement failed: %v", err)
+	}
+	expected := []string{"Reconciled with the bank statement line of 04/03/2025: CARD ACME", reconciled, ""}
+	if got := comments(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Comments mismatch. Got: %q, Want: %q", got, expected)
+	}
+
+	report, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read the exceptions report: %v", err)
+	}
+	for _, expected := range []string{
+		"Bank A: 2 matched lines",
+		"Unmatched statement lines (1):\nrow 3",
+		"Unmatched entries (1):\nASC000003",
+	} {
+		if !strings.Contains(string(report), expected) {
+			t.Errorf("Exceptions report mismatch. Got:\n%s\nWant it to contain: %s", report, expected)
+		}
+	}
+}
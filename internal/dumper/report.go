@@ -0,0 +1,455 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+// Formats of the closing report
+const (
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+)
+
+// monthLayout is the layout of the month of the closing report.
+const monthLayout = "2006-01"
+
+// NewReportCommand creates the closing report command with the given name.
+func NewReportCommand(name string) *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   name,
+		Short: "Write the closing report of a month",
+		Long: `Write the closing report of a month for the board meetings.
+
+The report lists the income and spending of the month per budget, bank account and category,
+the entries without receipt and, when the bank statement of an account is given,
+the entries not matching any of its lines like with the dump reconcile command.
+Without statement, the entries are not reconciled.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			common.SetupCommand(cmd, "DUMPER", ConfigKeys)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			// The format and output settings of the dump command don't apply to the report.
+			var err error
+			if cfg.Format, err = cmd.Flags().GetString("format"); err != nil {
+				return err
+			}
+			if cfg.Output, err = cmd.Flags().GetString("output"); err != nil {
+				return err
+			}
+			opts, err := getReportOptions(cmd)
+			if err != nil {
+				return common.WithExitCode(common.ExitUsage, err)
+			}
+			return report(cfg, opts)
+		},
+	}
+
+	reportCmd.PersistentFlags().StringP("config", "c", "", "Configuration file path")
+	common.AddLogFlags(reportCmd)
+	common.AddLanguageFlag(reportCmd)
+	reportCmd.SetFlagErrorFunc(common.FlagError)
+	reportCmd.PersistentFlags().String("email", "", "User email address (REQUIRED)")
+	reportCmd.PersistentFlags().String("password", "", "User password (REQUIRED)")
+
+	reportCmd.Flags().String("month", "", "Month of the report, like 2025-03. Defaults to the previous month.")
+	reportCmd.Flags().String("format", formatMarkdown, "Format of the report. Can be one of markdown or html.")
+	reportCmd.Flags().StringP("output", "o", "", `File to write the report to instead of the standard output.
+If the path is a directory or ends with a separator, the report is written in a timestamped file in it.`)
+	reportCmd.Flags().String("statement", "",
+		"Bank statement CSV, camt.053 or OFX file of the month to reconcile the entries of an account.")
+	reportCmd.Flags().String("account", "",
+		"Bank account of the statement: its ID, bank name or abbreviation. Required with a statement.")
+	addStatementFlags(reportCmd)
+	common.AddFlagCompletion(reportCmd, "format", formatMarkdown, formatHTML)
+
+	return reportCmd
+}
+
+// reportOptions holds the parameters of the report command.
+type reportOptions struct {
+	Month     time.Time
+	Statement string
+	Reconcile reconcileOptions
+}
+
+func getReportOptions(cmd *cobra.Command) (opts reportOptions, err error) {
+	flags := cmd.Flags()
+	month, err := flags.GetString("month")
+	if err != nil {
+		return
+	}
+	if opts.Month, err = parseMonth(month, time.Now()); err != nil {
+		return
+	}
+	if opts.Statement, err = flags.GetString("statement"); err != nil || opts.Statement == "" {
+		return
+	}
+	if opts.Reconcile, err = getStatementOptions(cmd); err != nil {
+		return
+	}
+	if opts.Reconcile.Account, err = flags.GetString("account"); err != nil {
+		return
+	}
+	if opts.Reconcile.Account == "" {
+		err = errors.New("the account of the statement is required")
+	}
+	return
+}
+
+// parseMonth returns the first day of the month value, or of the month before now if empty.
+func parseMonth(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return month, fmt.Errorf("invalid month %s, expected a value like 2025-03", value)
+	}
+	return month, nil
+}
+
+// categoryTotal holds the totals of the allocation lines of a category.
+type categoryTotal struct {
+	Category lib.Category
+	balance
+}
+
+// closingReport holds the figures of a month.
+type closingReport struct {
+	Metadata *outputMetadata
+	// Balances are the totals per budget and account. Its period covers the month.
+	Balances        periodBalances
+	Entries         []lib.Entry
+	Categories      []categoryTotal
+	MissingReceipts []lib.Entry
+	// Reconciliation is the result of matching the bank statement, nil without statement.
+	Reconciliation *reconciliation
+}
+
+func report(cfg Config, opts reportOptions) error {
+	if cfg.Format != formatMarkdown && cfg.Format != formatHTML {
+		return common.WithExitCode(common.ExitUsage, fmt.Errorf("unsupported report format: %s", cfg.Format))
+	}
+
+	var lines []statementLine
+	if opts.Statement != "" {
+		var err error
+		if lines, err = loadStatement(opts.Statement, opts.Reconcile); err != nil {
+			return err
+		}
+	}
+
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+
+	accounts, err := client.ListAccounts()
+	if err != nil {
+		return err
+	}
+	categories, err := client.ListCategories()
+	if err != nil {
+		return err
+	}
+	entries, err := fetchMonthEntries(client, opts.Month)
+	if err != nil {
+		return err
+	}
+
+	result := computeClosingReport(opts.Month, entries, accounts, categories)
+	if opts.Statement != "" {
+		account, err := findAccount(accounts, opts.Reconcile.Account)
+		if err != nil {
+			return err
+		}
+		reconciled := reconcile(lines, accountEntries(result.Entries, account, nil, 0), opts.Reconcile.Tolerance)
+		reconciled.Account = account
+		result.Reconciliation = &reconciled
+	}
+
+	now := time.Now()
+	result.Metadata = newOutputMetadata(now, cfg.Organization)
+	ext := "md"
+	if cfg.Format == formatHTML {
+		ext = "html"
+	}
+	return writeOutput(cfg.Output, "report-"+opts.Month.Format(monthLayout), ext, now, func(w io.Writer) error {
+		return writeReport(w, result, cfg.Format)
+	})
+}
+
+// fetchMonthEntries gets the entries of the accounting periods overlapping the month.
+func fetchMonthEntries(client dumpClient, month time.Time) ([]lib.Entry, error) {
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return nil, err
+	}
+	end := month.AddDate(0, 1, -1)
+	periods = slices.DeleteFunc(periods, func(p lib.Period) bool {
+		return p.Start.After(end) || p.End.Before(month)
+	})
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("no accounting period covers %s", month.Format(monthLayout))
+	}
+
+	periodEntries, err := fetchPeriodEntries(client, periods)
+	if err != nil {
+		return nil, err
+	}
+	var entries []lib.Entry
+	for _, period := range periods {
+		entries = append(entries, periodEntries[period.ID]...)
+	}
+	return entries, nil
+}
+
+// computeClosingReport sums the entries dated in the month.
+func computeClosingReport(month time.Time, entries []lib.Entry, accounts []lib.Account,
+	categories []lib.Category) closingReport {
+	end := month.AddDate(0, 1, -1)
+	entries = filterEntriesByDate(entries, month, end)
+	slices.SortStableFunc(entries, func(a, b lib.Entry) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+
+	period := lib.Period{ID: month.Format(monthLayout), Start: month, End: end}
+	result := closingReport{Balances: newPeriodBalances(period, accounts, entries), Entries: entries}
+
+	indexes := map[int]int{}
+	for _, entry := range entries {
+		if len(entry.Receipts) == 0 {
+			result.MissingReceipts = append(result.MissingReceipts, entry)
+		}
+		for _, line := range entry.Allocation {
+			idx, found := indexes[line.CategoryID]
+			if !found {
+				category := lib.Category{
+					ID: line.CategoryID, Name: strconv.Itoa(line.CategoryID), Kind: entry.Kind, Budget: entry.Budget,
+				}
+				if i := slices.IndexFunc(categories, func(c lib.Category) bool { return c.ID == line.CategoryID }); i >= 0 {
+					category = categories[i]
+				}
+				idx = len(result.Categories)
+				indexes[line.CategoryID] = idx
+				result.Categories = append(result.Categories, categoryTotal{Category: category})
+			}
+			result.Categories[idx].addAmount(entry.Kind, line.Amount)
+		}
+	}
+	slices.SortFunc(result.Categories, func(a, b categoryTotal) int {
+		return cmp.Or(int(a.Category.Budget)-int(b.Category.Budget), strings.Compare(a.Category.Name, b.Category.Name))
+	})
+	return result
+}
+
+// reportSection is a titled part of the closing report: a table or a note when there is nothing to list.
+type reportSection struct {
+	table
+	Note string
+}
+
+// reportSections returns the parts of the closing report in the order they are written.
+func reportSections(r closingReport) []reportSection {
+	amount := func(value float64) string { return fmt.Sprintf("%.2f", value) }
+	balanceRow := func(name string, b balance) []string {
+		return []string{name, amount(b.Income), amount(b.Spending), amount(b.Balance())}
+	}
+	entryRow := func(entry lib.Entry) []string {
+		return []string{entry.ID, entry.Date.Format(lib.DateLayout), entry.Name, amount(entryAmount(entry))}
+	}
+
+	unreconciled := "Not checked"
+	if r.Reconciliation != nil {
+		unreconciled = strconv.Itoa(len(r.Reconciliation.UnmatchedEntries))
+	}
+	summary := reportSection{table: table{Title: "Summary", Header: []string{"", "VALUE"}, Rows: [][]string{
+		{"Entries", strconv.Itoa(len(r.Entries))},
+		{"Income", amount(r.Balances.Total.Income)},
+		{"Spending", amount(r.Balances.Total.Spending)},
+		{"Balance", amount(r.Balances.Total.Balance())},
+		{"Missing receipts", strconv.Itoa(len(r.MissingReceipts))},
+		{"Unreconciled entries", unreconciled},
+	}}}
+
+	balanceHeader := []string{"", "INCOME", "SPENDING", "BALANCE"}
+	budgets := reportSection{table: table{Title: "Budgets", Header: append([]string{"BUDGET"}, balanceHeader[1:]...)}}
+	for _, budget := range r.Balances.Budgets {
+		budgets.Rows = append(budgets.Rows, balanceRow(budget.Budget.String(), budget.balance))
+	}
+	accounts := reportSection{table: table{Title: "Accounts", Header: append([]string{"ACCOUNT"}, balanceHeader[1:]...)}}
+	for _, account := range r.Balances.Accounts {
+		accounts.Rows = append(accounts.Rows, balanceRow(account.Account.Bank, account.balance))
+	}
+	categories := reportSection{table: table{
+		Title: "Categories", Header: []string{"CATEGORY", "BUDGET", "INCOME", "SPENDING"},
+	}}
+	for _, category := range r.Categories {
+		categories.Rows = append(categories.Rows, []string{
+			category.Category.Name, category.Category.Budget.String(), amount(category.Income), amount(category.Spending),
+		})
+	}
+
+	entryHeader := []string{"ID", "DATE", "NAME", "AMOUNT"}
+	missing := reportSection{table: table{Title: "Missing receipts", Header: entryHeader}}
+	for _, entry := range r.MissingReceipts {
+		missing.Rows = append(missing.Rows, entryRow(entry))
+	}
+	reconciled := reportSection{table: table{Title: "Unreconciled entries", Header: entryHeader}}
+	if r.Reconciliation == nil {
+		reconciled.Note = "No bank statement was given: the entries have not been reconciled."
+	} else {
+		reconciled.Title = fmt.Sprintf("Unreconciled entries of %s", r.Reconciliation.Account.Bank)
+		for _, entry := range r.Reconciliation.UnmatchedEntries {
+			reconciled.Rows = append(reconciled.Rows, entryRow(entry))
+		}
+		reconciled.Note = fmt.Sprintf("%d entries matched the statement, %d statement lines have no entry.",
+			r.Reconciliation.Matched, len(r.Reconciliation.UnmatchedLines))
+	}
+
+	return []reportSection{summary, budgets, accounts, categories, missing, reconciled}
+}
+
+// reportTitle returns the title of the closing report, with the name of the organization if known.
+func reportTitle(r closingReport) string {
+	title := "Closing report " + r.Balances.Period.ID
+	if r.Metadata != nil && r.Metadata.Organization != "" {
+		title = r.Metadata.Organization + " - " + title
+	}
+	return title
+}
+
+// writeReport writes the closing report in the requested format.
+func writeReport(w io.Writer, r closingReport, format string) error {
+	switch format {
+	case "", formatMarkdown:
+		return writeReportMarkdown(w, r)
+	case formatHTML:
+		return writeReportHTML(w, r)
+	}
+	return fmt.Errorf("unsupported report format: %s", format)
+}
+
+func writeReportMarkdown(w io.Writer, r closingReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", markdownEscape(reportTitle(r)))
+	if r.Metadata != nil {
+		fmt.Fprintf(&b, "\nGenerated on %s by %s %s.\n", r.Metadata.Generated, r.Metadata.Generator, r.Metadata.Version)
+	}
+	for _, section := range reportSections(r) {
+		fmt.Fprintf(&b, "\n## %s\n", markdownEscape(section.Title))
+		if len(section.Rows) > 0 {
+			b.WriteString("\n" + markdownRow(section.Header))
+			separators := make([]string, len(section.Header))
+			for i := range separators {
+				separators[i] = "---"
+			}
+			b.WriteString(markdownRow(separators))
+			for _, row := range section.Rows {
+				b.WriteString(markdownRow(row))
+			}
+		} else if section.Note == "" {
+			b.WriteString("\nNone.\n")
+		}
+		if section.Note != "" {
+			fmt.Fprintf(&b, "\n%s\n", markdownEscape(section.Note))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		escaped = append(escaped, markdownEscape(cell))
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+// markdownReplacer escapes the characters that could change the rendering of the text.
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;", ">", "&gt;", "\n", " ",
+)
+
+func markdownEscape(value string) string {
+	return markdownReplacer.Replace(value)
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- with .Metadata}}
+<p>Generated on {{.Generated}} by {{.Generator}} {{.Version}}.</p>
+{{- end}}
+{{- range .Sections}}
+<h2>{{.Title}}</h2>
+{{- if .Rows}}
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- else if not .Note}}
+<p>None.</p>
+{{- end}}
+{{- with .Note}}
+<p>{{.}}</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+func writeReportHTML(w io.Writer, r closingReport) error {
+	return reportHTMLTemplate.Execute(w, struct {
+		Title    string
+		Metadata *outputMetadata
+		Sections []reportSection
+	}{reportTitle(r), r.Metadata, reportSections(r)})
+}
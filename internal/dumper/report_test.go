@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockReportData() ([]lib.Entry, []lib.Account, []lib.Category) {
+	accounts := []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON, Abbrev: "BA"}}
+	categories := []lib.Category{
+		{ID: 10, Name: "Gifts", Kind: lib.KindSpend, Budget: lib.BudgetASC},
+		{ID: 11, ParentID: 10, Name: "Vouchers", Kind: lib.KindSpend, Budget: lib.BudgetASC, Stock: true},
+	}
+	entries := []lib.Entry{
+		{
+			ID:         "ASC000012",
+			Period:     "12345",
+			Kind:       lib.KindSpend,
+			Date:       day(14),
+			Name:       "Christmas gifts",
+			Budget:     lib.BudgetASC,
+			Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 120.5}, {CategoryID: 11, Amount: 30, Stock: 3}},
+			Account:    lib.Account{ID: 1},
+			Receipts:   []string{"invoice.pdf"},
+		},
+		{
+			ID:         "FON000003",
+			Period:     "12345",
+			Kind:       lib.KindTake,
+			Date:       day(2),
+			Name:       "Subsidy | <city>",
+			Budget:     lib.BudgetFON,
+			Allocation: []lib.AllocationLine{{CategoryID: 12, Amount: 1000}},
+			Account:    lib.Account{ID: 1},
+		},
+		{
+			ID:         "FON000002",
+			Period:     "12345",
+			Kind:       lib.KindTake,
+			Date:       time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			Name:       "Previous month",
+			Budget:     lib.BudgetFON,
+			Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 50}},
+			Account:    lib.Account{ID: 1},
+		},
+	}
+	return entries, accounts, categories
+}
+
+func TestParseMonth(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		value    string
+		expected time.Time
+		err      bool
+	}{
+		{"", time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025-03", day(1), false},
+		{"03/2025", time.Time{}, true},
+	}
+	for _, test := range tests {
+		actual, err := parseMonth(test.value, now)
+		if (err != nil) != test.err {
+			t.Errorf("Unexpected error for %q: %v", test.value, err)
+		}
+		if !test.err && !actual.Equal(test.expected) {
+			t.Errorf("Month of %q mismatch. Got: %s, Want: %s", test.value, actual, test.expected)
+		}
+	}
+}
+
+func TestComputeClosingReport(t *testing.T) {
+	entries, accounts, categories := getMockReportData()
+	report := computeClosingReport(day(1), entries, accounts, categories)
+
+	ids := []string{}
+	for _, entry := range report.Entries {
+		ids = append(ids, entry.ID)
+	}
+	if expected := []string{"FON000003", "ASC000012"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Entries mismatch. Got: %v, Want: %v", ids, expected)
+	}
+	if expected := (balance{Income: 1000, Spending: 150.5}); report.Balances.Total != expected {
+		t.Errorf("Total mismatch. Got: %+v, Want: %+v", report.Balances.Total, expected)
+	}
+	if len(report.MissingReceipts) != 1 || report.MissingReceipts[0].ID != "FON000003" {
+		t.Errorf("Missing receipts mismatch. Got: %+v", report.MissingReceipts)
+	}
+
+	expected := []categoryTotal{
+		{
+			Category: lib.Category{ID: 12, Name: "12", Kind: lib.KindTake, Budget: lib.BudgetFON},
+			balance:  balance{Income: 1000},
+		},
+		{Category: categories[0], balance: balance{Spending: 120.5}},
+		{Category: categories[1], balance: balance{Spending: 30}},
+	}
+	if !reflect.DeepEqual(report.Categories, expected) {
+		t.Errorf("Categories mismatch. Got: %+v, Want: %+v", report.Categories, expected)
+	}
+}
+
+func TestWriteReportMarkdown(t *testing.T) {
+	entries, accounts, categories := getMockReportData()
+	report := computeClosingReport(day(1), entries, accounts, categories)
+
+	var out bytes.Buffer
+	if err := writeReport(&out, report, formatMarkdown); err != nil {
+		t.Fatalf("writeReport failed: %v", err)
+	}
+	want := `# Closing report 2025-03
+
+## Summary
+
+|  | VALUE |
+| --- | --- |
+| Entries | 2 |
+| Income | 1000.00 |
+| Spending | 150.50 |
+| Balance | 849.50 |
+| Missing receipts | 1 |
+| Unreconciled entries | Not checked |
+
+## Budgets
+
+| BUDGET | INCOME | SPENDING | BALANCE |
+| --- | --- | --- | --- |
+| FON | 1000.00 | 0.00 | 1000.00 |
+| ASC | 0.00 | 150.50 | -150.50 |
+
+## Accounts
+
+| ACCOUNT | INCOME | SPENDING | BALANCE |
+| --- | --- | --- | --- |
+| Bank A | 1000.00 | 150.50 | 849.50 |
+
+## Categories
+
+| CATEGORY | BUDGET | INCOME | SPENDING |
+| --- | --- | --- | --- |
+| 12 | FON | 1000.00 | 0.00 |
+| Gifts | ASC | 0.00 | 120.50 |
+| Vouchers | ASC | 0.00 | 30.00 |
+
+## Missing receipts
+
+| ID | DATE | NAME | AMOUNT |
+| --- | --- | --- | --- |
+| FON000003 | 02/03/2025 | Subsidy \| &lt;city&gt; | 1000.00 |
+
+## Unreconciled entries
+
+No bank statement was given: the entries have not been reconciled.
+`
+	if got := out.String(); got != want {
+		t.Errorf("Markdown output mismatch. Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteReportHTML(t *testing.T) {
+	entries, accounts, categories := getMockReportData()
+	report := computeClosingReport(day(1), entries, accounts, categories)
+	report.Metadata = &outputMetadata{Generator: "dumper", Version: "1.0", Generated: "2025-04-01T10:00:00Z",
+		Organization: "Works council"}
+	report.Reconciliation = &reconciliation{Account: accounts[0], Matched: 1}
+
+	var out bytes.Buffer
+	if err := writeReport(&out, report, formatHTML); err != nil {
+		t.Fatalf("writeReport failed: %v", err)
+	}
+	got := out.String()
+	for _, expected := range []string{
+		"<title>Works council - Closing report 2025-03</title>",
+		"<p>Generated on 2025-04-01T10:00:00Z by dumper 1.0.</p>",
+		"<tr><td>Unreconciled entries</td><td>0</td></tr>",
+		"<td>Subsidy | &lt;city&gt;</td>",
+		"<h2>Unreconciled entries of Bank A</h2>\n<p>1 entries matched the statement, 0 statement lines have no entry.</p>",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("HTML output mismatch. Got:\n%s\nWant it to contain: %s", got, expected)
+		}
+	}
+}
+
+func TestReportMockServer(t *testing.T) {
+	entries, accounts, categories := getMockReportData()
+	server := mockserver.New(mockserver.Data{
+		Email:      "treasurer@example.com",
+		Password:   "secret",
+		Accounts:   accounts,
+		Categories: categories,
+		Periods: []lib.Period{
+			{
+				ID:     "12344",
+				Status: lib.PeriodStatusDefinitelyClosed,
+				Start:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:     "12345",
+				Status: lib.PeriodStatusCurrent,
+				Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Entries: entries,
+	})
+	defer server.Close()
+	t.Setenv("HAPPYCOMPTA_URL", server.URL)
+
+	dir := t.TempDir()
+	statement := filepath.Join(dir, "statement.csv")
+	if err := os.WriteFile(statement, []byte("date,amount,label\n15/03/2025,-150.50,CARD ACME\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	opts := reportOptions{Month: day(1), Statement: statement, Reconcile: reconcileOptions{
+		Account:   "BA",
+		Columns:   statementColumns{Date: "date", Amount: "amount", Label: "label"},
+		Tolerance: 3,
+	}}
+	output := filepath.Join(dir, "report.md")
+	cfg := Config{Email: "treasurer@example.com", Password: "secret", Format: formatMarkdown, Output: output}
+	if err := report(cfg, opts); err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read the output: %v", err)
+	}
+	for _, expected := range []string{
+		"| Unreconciled entries | 1 |",
+		"## Unreconciled entries of Bank A\n\n| ID | DATE | NAME | AMOUNT |\n| --- | --- | --- | --- |\n" +
+			"| FON000003 | 02/03/2025 | Subsidy \\| &lt;city&gt; | 1000.00 |\n\n" +
+			"1 entries matched the statement, 0 statement lines have no entry.\n",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Report mismatch. Got:\n%s\nWant it to contain: %s", content, expected)
+		}
+	}
+
+	opts.Month = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	if err := report(cfg, opts); err == nil || !strings.Contains(err.Error(), "no accounting period covers 2023-05") {
+		t.Errorf("Expected an error for a month without period, got: %v", err)
+	}
+}
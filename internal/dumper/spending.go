@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// categorySpending is the amount spent on a category during a period.
+type categorySpending struct {
+	Category lib.Category
+	Spent    float64
+	// Limit is the spending limit of the category, 0 if none is configured.
+	Limit float64
+}
+
+// Consumption returns the percentage of the limit that has been spent, 0 if there is no limit.
+func (s categorySpending) Consumption() float64 {
+	if s.Limit == 0 {
+		return 0
+	}
+	return s.Spent / s.Limit * 100
+}
+
+// periodSpending holds the spending of the categories during an accounting period.
+type periodSpending struct {
+	Period     lib.Period
+	Categories []categorySpending
+}
+
+// computeSpending sums the spending entries of each period per category.
+// All the spending categories are listed, even without entries.
+func computeSpending(periods []lib.Period, categories []lib.Category, entries map[string][]lib.Entry) []periodSpending {
+	result := make([]periodSpending, 0, len(periods))
+	for _, period := range periods {
+		spending := periodSpending{Period: period, Categories: []categorySpending{}}
+		indexes := map[int]int{}
+		for _, category := range categories {
+			if category.Kind == lib.KindSpend {
+				indexes[category.ID] = len(spending.Categories)
+				spending.Categories = append(spending.Categories, categorySpending{Category: category})
+			}
+		}
+
+		for _, entry := range entries[period.ID] {
+			if entry.Kind != lib.KindSpend {
+				continue
+			}
+			for _, line := range entry.Allocation {
+				if idx, found := indexes[line.CategoryID]; found {
+					spending.Categories[idx].Spent += line.Amount
+				}
+			}
+		}
+		result = append(result, spending)
+	}
+	return result
+}
+
+// setSpendingLimits sets the configured limits on the categories spending.
+// The limits are indexed by category ID or case-insensitive name.
+func setSpendingLimits(spending []periodSpending, limits map[string]float64) {
+	normalized := make(map[string]float64, len(limits))
+	for key, limit := range limits {
+		normalized[strings.ToLower(key)] = limit
+	}
+
+	for i := range spending {
+		for j := range spending[i].Categories {
+			category := &spending[i].Categories[j]
+			if limit, found := normalized[strconv.Itoa(category.Category.ID)]; found {
+				category.Limit = limit
+			} else if limit, found := normalized[strings.ToLower(category.Category.Name)]; found {
+				category.Limit = limit
+			}
+		}
+	}
+}
+
+func writeSpendingText(w io.Writer, spending []periodSpending) {
+	fmt.Fprintf(w, "\nSpending:\n")
+	for _, s := range spending {
+		fmt.Fprintf(w, "%s: %s - %s\n",
+			s.Period.ID, s.Period.Start.Format(lib.DateLayout), s.Period.End.Format(lib.DateLayout),
+		)
+		for _, category := range s.Categories {
+			fmt.Fprintf(w, "    %d %s: %s\n", category.Category.ID, category.Category.Name, spendingText(category))
+		}
+	}
+}
+
+func spendingText(s categorySpending) string {
+	if s.Limit == 0 {
+		return fmt.Sprintf("spent %.2f", s.Spent)
+	}
+	return fmt.Sprintf("spent %.2f of %.2f (%.1f%%)", s.Spent, s.Limit, s.Consumption())
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockSpendingData() dumpData {
+	mock := getMockDumpData()
+	categories := []lib.Category{
+		{ID: 10, Name: "Gifts", Kind: lib.KindSpend},
+		{ID: 11, Name: "Travels", Kind: lib.KindSpend},
+		{ID: 12, Name: "Subsidy", Kind: lib.KindAllocation},
+	}
+	entries := map[string][]lib.Entry{
+		"12345": {
+			{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{{CategoryID: 10, Amount: 120.5}}},
+			{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{
+				{CategoryID: 10, Amount: 29.5}, {CategoryID: 11, Amount: 300},
+			}},
+			{Kind: lib.KindAllocation, Allocation: []lib.AllocationLine{{CategoryID: 12, Amount: 5000}}},
+		},
+	}
+
+	data := dumpData{
+		Types:    map[string]bool{typeSpending: true},
+		Spending: computeSpending(mock.Periods, categories, entries),
+	}
+	setSpendingLimits(data.Spending, map[string]float64{"gifts": 200, "99": 10})
+	return data
+}
+
+func TestComputeSpending(t *testing.T) {
+	data := getMockSpendingData()
+
+	expected := []periodSpending{
+		{
+			Period: getMockDumpData().Periods[0],
+			Categories: []categorySpending{
+				{Category: lib.Category{ID: 10, Name: "Gifts", Kind: lib.KindSpend}, Spent: 150, Limit: 200},
+				{Category: lib.Category{ID: 11, Name: "Travels", Kind: lib.KindSpend}, Spent: 300},
+			},
+		},
+	}
+	if !reflect.DeepEqual(data.Spending, expected) {
+		t.Errorf("Spending mismatch. Got: %+v, Want: %+v", data.Spending, expected)
+	}
+	if consumption := data.Spending[0].Categories[0].Consumption(); consumption != 75 {
+		t.Errorf("Consumption mismatch. Got: %f, Want: 75", consumption)
+	}
+}
+
+func TestSetSpendingLimitsByID(t *testing.T) {
+	spending := []periodSpending{{Categories: []categorySpending{{Category: lib.Category{ID: 10, Name: "Gifts"}}}}}
+	setSpendingLimits(spending, map[string]float64{"10": 500, "gifts": 200})
+	if limit := spending[0].Categories[0].Limit; limit != 500 {
+		t.Errorf("Limit mismatch. Got: %f, Want: 500", limit)
+	}
+}
+
+func TestWriteSpending(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: formatText,
+			want: `Dump happy-compta data for test purpose
+
+Spending:
+12345: 01/01/2025 - 31/12/2025
+    10 Gifts: spent 150.00 of 200.00 (75.0%)
+    11 Travels: spent 300.00
+`,
+		},
+		{
+			format: formatYAML,
+			want: `spending:
+  - period: "12345"
+    start: "2025-01-01"
+    end: "2025-12-31"
+    categories:
+      - id: 10
+        name: Gifts
+        spent: 150
+        limit: 200
+        consumption: 75
+      - id: 11
+        name: Travels
+        spent: 300
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeDump(&out, getMockSpendingData(), tt.format); err != nil {
+				t.Fatalf("writeDump failed: %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("Output mismatch.\nGot:\n%s\nWant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// The bank statement formats other than CSV, detected from the file extension.
+const (
+	statementCAMT053 = "camt.053"
+	statementOFX     = "ofx"
+)
+
+// statementFormat returns the format of the bank statement file from its extension, or an empty string for CSV.
+func statementFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return statementCAMT053
+	case ".ofx", ".qfx":
+		return statementOFX
+	}
+	return ""
+}
+
+// camtDocument is the part of an ISO 20022 camt.053 bank to customer statement used to reconcile the entries.
+// The XML namespace depends on the version of the message and is ignored.
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount      string   `xml:"Amt"`
+	CreditDebit string   `xml:"CdtDbtInd"`
+	Status      camtCode `xml:"Sts"`
+	BookingDate camtDate `xml:"BookgDt"`
+	ValueDate   camtDate `xml:"ValDt"`
+	Info        string   `xml:"AddtlNtryInf"`
+	Remittance  []string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+}
+
+// camtCode is a code written directly in the element, like in the first versions, or in a Cd child element.
+type camtCode struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+func (c camtCode) String() string {
+	if c.Code != "" {
+		return strings.TrimSpace(c.Code)
+	}
+	return strings.TrimSpace(c.Value)
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// Time returns the day of the date, ignoring the time of the date time values.
+func (d camtDate) Time() (time.Time, error) {
+	value := strings.TrimSpace(d.Date)
+	if value == "" {
+		value, _, _ = strings.Cut(strings.TrimSpace(d.DateTime), "T")
+	}
+	return time.Parse(dateFormat, value)
+}
+
+// readCAMT053 reads the booked entries of a camt.053 statement.
+// The rows of the lines are the positions of the entries in the file.
+func readCAMT053(r io.Reader) ([]statementLine, error) {
+	var document camtDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse the camt.053 statement: %s", err)
+	}
+
+	var lines []statementLine
+	var errs []error
+	row := 0
+	for _, statement := range document.Statements {
+		for _, entry := range statement.Entries {
+			row++
+			if status := entry.Status.String(); status != "" && status != "BOOK" {
+				continue
+			}
+			line, err := newCAMTLine(row, entry)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("entry %d: %s", row, err))
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, errors.Join(errs...)
+}
+
+func newCAMTLine(row int, entry camtEntry) (line statementLine, err error) {
+	line = statementLine{Row: row, Label: strings.Join(entry.Remittance, " ")}
+	if line.Label == "" {
+		line.Label = entry.Info
+	}
+	line.Label = strings.Join(strings.Fields(line.Label), " ")
+
+	if line.Date, err = entry.BookingDate.Time(); err != nil {
+		if line.Date, err = entry.ValueDate.Time(); err != nil {
+			return line, fmt.Errorf("invalid booking date: %s", err)
+		}
+	}
+	if line.Amount, err = strconv.ParseFloat(strings.TrimSpace(entry.Amount), 64); err != nil {
+		return line, fmt.Errorf("invalid amount: %s", err)
+	}
+	switch strings.TrimSpace(entry.CreditDebit) {
+	case "DBIT":
+		line.Amount = -line.Amount
+	case "CRDT":
+	default:
+		return line, fmt.Errorf("invalid credit debit indicator: %s", entry.CreditDebit)
+	}
+	return line, nil
+}
+
+// readOFX reads the transactions of an OFX statement.
+// The OFX 1 files are SGML without closing tags for the values: the value of a tag is the text up to the next tag.
+// This also reads the OFX 2 XML files.
+// The rows of the lines are the positions of the transactions in the file.
+func readOFX(r io.Reader) ([]statementLine, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OFX statement: %s", err)
+	}
+
+	var lines []statementLine
+	var errs []error
+	var line *statementLine
+	var lineErrs []error
+	var name, memo string
+	row := 0
+	text := string(content)
+	for {
+		start := strings.IndexByte(text, '<')
+		end := strings.IndexByte(text[max(start, 0):], '>')
+		if start < 0 || end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[start+1 : start+end]))
+		text = text[start+end+1:]
+		value := text
+		if next := strings.IndexByte(text, '<'); next >= 0 {
+			value = text[:next]
+		}
+		value = html.UnescapeString(strings.TrimSpace(value))
+
+		switch {
+		case tag == "STMTTRN":
+			row++
+			line = &statementLine{Row: row}
+			lineErrs = nil
+			name, memo = "", ""
+		case line == nil:
+			continue
+		case tag == "/STMTTRN":
+			line.Label = strings.Join(strings.Fields(name+" "+memo), " ")
+			if line.Date.IsZero() {
+				lineErrs = append(lineErrs, errors.New("missing DTPOSTED date"))
+			}
+			if len(lineErrs) > 0 {
+				errs = append(errs, fmt.Errorf("transaction %d: %s", row, errors.Join(lineErrs...)))
+			} else {
+				lines = append(lines, *line)
+			}
+			line = nil
+		case tag == "DTPOSTED":
+			// The dates are like 20250303120000.000[+1:CET]: only the day is used.
+			if len(value) < 8 {
+				lineErrs = append(lineErrs, fmt.Errorf("invalid date: %s", value))
+			} else if line.Date, err = time.Parse("20060102", value[:8]); err != nil {
+				lineErrs = append(lineErrs, fmt.Errorf("invalid date: %s", err))
+			}
+		case tag == "TRNAMT":
+			if line.Amount, err = common.ParseAmount(value); err != nil {
+				lineErrs = append(lineErrs, fmt.Errorf("invalid amount: %s", err))
+			}
+		case tag == "NAME":
+			name = value
+		case tag == "MEMO":
+			memo = value
+		}
+	}
+	return lines, errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatementFormat(t *testing.T) {
+	tests := map[string]string{
+		"statement.csv": "",
+		"statement.XML": statementCAMT053,
+		"statement.ofx": statementOFX,
+		"statement.qfx": statementOFX,
+	}
+	for path, expected := range tests {
+		if actual := statementFormat(path); actual != expected {
+			t.Errorf("Format of %s mismatch. Got: %q, Want: %q", path, actual, expected)
+		}
+	}
+}
+
+func TestReadCAMT053(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">120.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2025-03-03</Dt></BookgDt>
+        <NtryDtls><TxDtls><RmtInf><Ustrd>CARD ACME</Ustrd><Ustrd>ASC000012</Ustrd></RmtInf></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">1000</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><DtTm>2025-03-05T10:12:00+01:00</DtTm></BookgDt>
+        <AddtlNtryInf>TRANSFER
+          COMPANY</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">15</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2025-03-10</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">abc</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <ValDt><Dt>2025-03-11</Dt></ValDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+	lines, err := readCAMT053(strings.NewReader(content))
+	if err == nil || !strings.Contains(err.Error(), "entry 4: invalid amount") {
+		t.Errorf("Expected an error for entry 4, got: %v", err)
+	}
+	expected := []statementLine{
+		{Row: 1, Date: day(3), Amount: -120.5, Label: "CARD ACME ASC000012"},
+		{Row: 2, Date: day(5), Amount: 1000, Label: "TRANSFER COMPANY"},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines mismatch. Got: %+v, Want: %+v", lines, expected)
+	}
+
+	if _, err := readCAMT053(strings.NewReader("date,amount\n")); err == nil {
+		t.Error("Expected an error for a file that isn't XML")
+	}
+}
+
+func TestReadOFX(t *testing.T) {
+	content := `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250303120000.000[+1:CET]
+<TRNAMT>-120,50
+<FITID>1
+<NAME>CARD ACME
+<MEMO>Gifts &amp; co
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250305
+<TRNAMT>1000.00
+<FITID>2
+<NAME>TRANSFER COMPANY
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>2025
+<TRNAMT>-15
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+	lines, err := readOFX(strings.NewReader(content))
+	if err == nil || !strings.Contains(err.Error(), "transaction 3: invalid date: 2025") {
+		t.Errorf("Expected an error for transaction 3, got: %v", err)
+	}
+	expected := []statementLine{
+		{Row: 1, Date: day(3), Amount: -120.5, Label: "CARD ACME Gifts & co"},
+		{Row: 2, Date: day(5), Amount: 1000, Label: "TRANSFER COMPANY"},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines mismatch. Got: %+v, Want: %+v", lines, expected)
+	}
+
+	// The OFX 2 files are XML with closing tags.
+	content = `<?xml version="1.0"?><OFX><STMTTRN><DTPOSTED>20250303</DTPOSTED><TRNAMT>-12.00</TRNAMT>` +
+		`<NAME>BAKERY</NAME></STMTTRN></OFX>`
+	lines, err = readOFX(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("readOFX failed: %v", err)
+	}
+	expected = []statementLine{{Row: 1, Date: day(3), Amount: -12, Label: "BAKERY"}}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines mismatch. Got: %+v, Want: %+v", lines, expected)
+	}
+}
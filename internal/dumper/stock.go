@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// stockLevel holds the number of items of a category with stock.
+type stockLevel struct {
+	Category lib.Category
+	// Allocated is the number of items bought in the spending entries.
+	Allocated int
+	// Spent is the number of items handed out in the income entries.
+	Spent int
+}
+
+// Remaining returns the number of items still in stock.
+func (s stockLevel) Remaining() int {
+	return s.Allocated - s.Spent
+}
+
+// computeStock counts the items of the categories with stock in the entries of all periods.
+func computeStock(categories []lib.Category, entries map[string][]lib.Entry) []stockLevel {
+	levels := []stockLevel{}
+	indexes := map[int]int{}
+	for _, category := range categories {
+		if category.Stock {
+			indexes[category.ID] = len(levels)
+			levels = append(levels, stockLevel{Category: category})
+		}
+	}
+
+	for _, periodEntries := range entries {
+		for _, entry := range periodEntries {
+			for _, line := range entry.Allocation {
+				idx, found := indexes[line.CategoryID]
+				if !found {
+					continue
+				}
+				switch entry.Kind {
+				case lib.KindSpend:
+					levels[idx].Allocated += line.Stock
+				case lib.KindTake, lib.KindAllocation:
+					levels[idx].Spent += line.Stock
+				}
+			}
+		}
+	}
+	return levels
+}
+
+func writeStockText(w io.Writer, levels []stockLevel) {
+	fmt.Fprintf(w, "\nStock (%d):\n", len(levels))
+	for _, level := range levels {
+		fmt.Fprintf(w, "%d: %s: allocated %d, spent %d, remaining %d\n",
+			level.Category.ID, level.Category.Name, level.Allocated, level.Spent, level.Remaining(),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockStockData() dumpData {
+	categories := []lib.Category{
+		{ID: 10, Name: "Gifts", Kind: lib.KindSpend},
+		{ID: 11, Name: "Cinema tickets", Kind: lib.KindSpend, Stock: true},
+		{ID: 12, Name: "Vouchers", Kind: lib.KindSpend, Stock: true},
+	}
+	entries := map[string][]lib.Entry{
+		"12000": {
+			{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{{CategoryID: 11, Amount: 500, Stock: 50}}},
+			{Kind: lib.KindTake, Allocation: []lib.AllocationLine{{CategoryID: 11, Amount: 100, Stock: 20}}},
+		},
+		"12345": {
+			{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{
+				{CategoryID: 10, Amount: 30, Stock: 3}, {CategoryID: 12, Amount: 200, Stock: 10},
+			}},
+			{Kind: lib.KindTake, Allocation: []lib.AllocationLine{{CategoryID: 11, Amount: 50, Stock: 10}}},
+		},
+	}
+	return dumpData{
+		Types: map[string]bool{typeStock: true},
+		Stock: computeStock(categories, entries),
+	}
+}
+
+func TestComputeStock(t *testing.T) {
+	data := getMockStockData()
+
+	expected := []stockLevel{
+		{
+			Category:  lib.Category{ID: 11, Name: "Cinema tickets", Kind: lib.KindSpend, Stock: true},
+			Allocated: 50,
+			Spent:     30,
+		},
+		{
+			Category:  lib.Category{ID: 12, Name: "Vouchers", Kind: lib.KindSpend, Stock: true},
+			Allocated: 10,
+		},
+	}
+	if !reflect.DeepEqual(data.Stock, expected) {
+		t.Errorf("Stock mismatch. Got: %+v, Want: %+v", data.Stock, expected)
+	}
+}
+
+func TestWriteStock(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: formatText,
+			want: `Dump happy-compta data for test purpose
+
+Stock (2):
+11: Cinema tickets: allocated 50, spent 30, remaining 20
+12: Vouchers: allocated 10, spent 0, remaining 10
+`,
+		},
+		{
+			format: formatYAML,
+			want: `stock:
+  - category_id: 11
+    category: Cinema tickets
+    allocated: 50
+    spent: 30
+    remaining: 20
+  - category_id: 12
+    category: Vouchers
+    allocated: 10
+    spent: 0
+    remaining: 10
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeDump(&out, getMockStockData(), tt.format); err != nil {
+				t.Fatalf("writeDump failed: %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("Output mismatch.\nGot:\n%s\nWant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Color modes of the table format
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ANSI escape sequences used to highlight the tables
+const (
+	ansiBold  = "\033[1m"
+	ansiCyan  = "\033[36m"
+	ansiReset = "\033[0m"
+)
+
+// tableOptions holds the rendering settings of the table format.
+type tableOptions struct {
+	// Color highlights the titles and headers.
+	Color bool
+	// MaxWidth is the maximum number of characters of a cell. 0 disables the truncation.
+	MaxWidth int
+}
+
+// tableSettings are the rendering settings of the tables, set from the command line flags.
+var tableSettings = tableOptions{MaxWidth: 40}
+
+// newTableOptions creates the table settings from the color mode and column width.
+// The auto color mode only colorizes the tables written to a terminal.
+func newTableOptions(color string, maxWidth int, terminal bool) (tableOptions, error) {
+	opts := tableOptions{MaxWidth: maxWidth}
+	switch color {
+	case colorAlways:
+		opts.Color = true
+	case "", colorAuto:
+		opts.Color = terminal && os.Getenv("NO_COLOR") == ""
+	case colorNever:
+	default:
+		return opts, fmt.Errorf("invalid color mode %s, accepted values are auto, always or never", color)
+	}
+	if maxWidth < 0 {
+		return opts, fmt.Errorf("invalid column width %d", maxWidth)
+	}
+	return opts, nil
+}
+
+// isTerminal returns whether the file is a terminal.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// table is a titled set of rows to render aligned.
+type table struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+// writeTables renders the tables separated by empty lines.
+func writeTables(w io.Writer, tables []table, opts tableOptions) error {
+	for i, t := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := writeTable(w, t, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTable renders a table with its columns aligned and its cells truncated to the maximum width.
+func writeTable(w io.Writer, t table, opts tableOptions) error {
+	header := truncateCells(t.Header, opts.MaxWidth)
+	rows := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		rows = append(rows, truncateCells(row, opts.MaxWidth))
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	if t.Title != "" {
+		title := fmt.Sprintf("%s (%d)", t.Title, len(t.Rows))
+		if opts.Color {
+			title = ansiBold + ansiCyan + title + ansiReset
+		}
+		if _, err := fmt.Fprintln(w, title); err != nil {
+			return err
+		}
+	}
+
+	headerLine := formatTableLine(header, widths)
+	if opts.Color {
+		headerLine = ansiBold + headerLine + ansiReset
+	}
+	if _, err := fmt.Fprintln(w, headerLine); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(w, formatTableLine(row, widths)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTableLine(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		b.WriteString(cell)
+		if i < len(widths)-1 {
+			b.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(cell)+2))
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// truncateCells shortens the cells longer than maxWidth, ending them with an ellipsis.
+func truncateCells(cells []string, maxWidth int) []string {
+	result := make([]string, len(cells))
+	for i, cell := range cells {
+		// Keep the tables on one line per row
+		cell = strings.Join(strings.Fields(cell), " ")
+		if runes := []rune(cell); maxWidth > 0 && len(runes) > maxWidth {
+			cell = string(runes[:max(maxWidth-1, 0)]) + "…"
+		}
+		result[i] = cell
+	}
+	return result
+}
+
+// newDumpTables converts the dumped data into tables.
+func newDumpTables(data dumpData) []table {
+	output := newDumpOutput(data)
+	var tables []table
+
+	if data.Types[typeEmployees] {
+		t := table{Title: "Employees", Header: []string{"ID", "LASTNAME", "FIRSTNAME", "ACTIVE"}}
+		for _, e := range output.Employees {
+			t.Rows = append(t.Rows, []string{e.ID, e.Lastname, e.Firstname, strconv.FormatBool(e.Active)})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeProviders] {
+		t := table{
+			Title:  "Providers",
+			Header: []string{"ID", "NAME", "ADDRESS", "ZIP CODE", "CITY", "PHONE", "EMAIL", "COMMENT", "ARCHIVED"},
+		}
+		for _, p := range output.Providers {
+			t.Rows = append(t.Rows, []string{
+				p.ID, p.Name, p.Address, p.ZipCode, p.City, p.Phone, p.Email, p.Comment, strconv.FormatBool(p.Archived),
+			})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typePeriods] {
+		t := table{Title: "Periods", Header: []string{"ID", "START", "END", "STATUS"}}
+		for _, p := range output.Periods {
+			t.Rows = append(t.Rows, []string{p.ID, p.Start, p.End, p.Status})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeAccounts] {
+		t := table{Title: "Accounts", Header: []string{"ID", "BANK", "BUDGET", "ABBREVIATION"}}
+		for _, a := range output.Accounts {
+			t.Rows = append(t.Rows, []string{strconv.Itoa(a.ID), a.Bank, a.Budget, a.Abbrev})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeCategories] {
+		t := table{Title: "Categories", Header: []string{"ID", "PARENT", "NAME", "KIND", "BUDGET", "STOCK"}}
+		for _, c := range output.Categories {
+			parent := ""
+			if c.ParentID != 0 {
+				parent = strconv.Itoa(c.ParentID)
+			}
+			t.Rows = append(t.Rows, []string{
+				strconv.Itoa(c.ID), parent, c.Name, c.Kind, c.Budget, strconv.FormatBool(c.Stock),
+			})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeBalances] {
+		t := table{Title: "Balances", Header: []string{"PERIOD", "SCOPE", "INCOME", "SPENDING", "BALANCE"}}
+		for _, b := range output.Balances {
+			t.Rows = append(t.Rows, balanceRow(b.Period, "total", b.balanceOutput))
+			for _, budget := range b.Budgets {
+				t.Rows = append(t.Rows, balanceRow(b.Period, budget.Budget, budget.balanceOutput))
+			}
+			for _, account := range b.Accounts {
+				t.Rows = append(t.Rows, balanceRow(b.Period, account.Bank, account.balanceOutput))
+			}
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeStock] {
+		t := table{Title: "Stock", Header: []string{"ID", "CATEGORY", "ALLOCATED", "SPENT", "REMAINING"}}
+		for _, s := range output.Stock {
+			t.Rows = append(t.Rows, []string{
+				strconv.Itoa(s.CategoryID), s.Category,
+				strconv.Itoa(s.Allocated), strconv.Itoa(s.Spent), strconv.Itoa(s.Remaining),
+			})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeSpending] {
+		t := table{Title: "Spending", Header: []string{"PERIOD", "ID", "CATEGORY", "SPENT", "LIMIT", "CONSUMPTION"}}
+		for _, s := range output.Spending {
+			for _, c := range s.Categories {
+				limit, consumption := "", ""
+				if c.Limit != 0 {
+					limit = fmt.Sprintf("%.2f", c.Limit)
+					consumption = fmt.Sprintf("%.1f%%", c.Consumption)
+				}
+				t.Rows = append(t.Rows, []string{
+					s.Period, strconv.Itoa(c.ID), c.Name, fmt.Sprintf("%.2f", c.Spent), limit, consumption,
+				})
+			}
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeSites] {
+		t := table{Title: "Sites", Header: []string{"ID", "NAME", "ADDRESS", "ZIP CODE", "CITY"}}
+		for _, s := range output.Sites {
+			t.Rows = append(t.Rows, []string{s.ID, s.Name, s.Address, s.ZipCode, s.City})
+		}
+		tables = append(tables, t)
+	}
+	if data.Types[typeUsers] {
+		t := table{
+			Title:  "Users",
+			Header: []string{"ID", "LASTNAME", "FIRSTNAME", "EMAIL", "ROLE", "SITES", "ACTIVE"},
+		}
+		for _, u := range output.Users {
+			t.Rows = append(t.Rows, []string{
+				u.ID, u.Lastname, u.Firstname, u.Email, u.Role, strings.Join(u.Sites, ", "), strconv.FormatBool(u.Active),
+			})
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+func balanceRow(period string, scope string, b balanceOutput) []string {
+	return []string{
+		period, scope,
+		fmt.Sprintf("%.2f", b.Income), fmt.Sprintf("%.2f", b.Spending), fmt.Sprintf("%.2f", b.Balance),
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+func TestNewTableOptions(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	tests := []struct {
+		color    string
+		terminal bool
+		want     tableOptions
+		wantErr  bool
+	}{
+		{color: colorAuto, terminal: true, want: tableOptions{Color: true, MaxWidth: 20}},
+		{color: colorAuto, terminal: false, want: tableOptions{MaxWidth: 20}},
+		{color: colorAlways, terminal: false, want: tableOptions{Color: true, MaxWidth: 20}},
+		{color: colorNever, terminal: true, want: tableOptions{MaxWidth: 20}},
+		{color: "rainbow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := newTableOptions(tt.color, 20, tt.terminal)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("newTableOptions(%s) error mismatch. Got: %v, Want error: %t", tt.color, err, tt.wantErr)
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("newTableOptions(%s, %t) mismatch. Got: %+v, Want: %+v", tt.color, tt.terminal, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateCells(t *testing.T) {
+	got := truncateCells([]string{"short", "Société Générale Paris", "two\nlines"}, 10)
+	want := []string{"short", "Société G…", "two lines"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Cells mismatch. Got: %q, Want: %q", got, want)
+	}
+
+	if got := truncateCells([]string{"Société Générale Paris"}, 0); got[0] != "Société Générale Paris" {
+		t.Errorf("Unexpected truncation without maximum width: %q", got[0])
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	data := getMockDumpData()
+	data.Types = map[string]bool{typeProviders: true, typeAccounts: true}
+
+	var out bytes.Buffer
+	if err := writeTables(&out, newDumpTables(data), tableOptions{MaxWidth: 8}); err != nil {
+		t.Fatalf("writeTables failed: %v", err)
+	}
+
+	want := `Providers (1)
+ID  NAME  ADDRESS  ZIP CODE  CITY   PHONE  EMAIL  COMMENT  ARCHIVED
+p1  ACME                     Paris                         true
+
+Accounts (1)
+ID  BANK    BUDGET  ABBREVI…
+1   Bank A  FON     BA
+`
+	if got := out.String(); got != want {
+		t.Errorf("Table output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteSitesUsersTable(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeTables(&out, newDumpTables(getMockSitesUsersData()), tableOptions{}); err != nil {
+		t.Fatalf("writeTables failed: %v", err)
+	}
+
+	want := `Sites (2)
+ID  NAME         ADDRESS           ZIP CODE  CITY
+s1  Head office  1 rue de la Paix  75002     Paris
+s2  Factory                        69007     Lyon
+
+Users (2)
+ID  LASTNAME  FIRSTNAME  EMAIL             ROLE            SITES                 ACTIVE
+u1  Doe       Jane       jane@example.com  Administrateur                        true
+u2  Smith     John       john@example.com  Lecteur         Head office, Factory  false
+`
+	if got := out.String(); got != want {
+		t.Errorf("Table output mismatch.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestWriteTableColor(t *testing.T) {
+	var out bytes.Buffer
+	tbl := table{Title: "Stock", Header: []string{"ID", "NAME"}, Rows: [][]string{{"11", "Tickets"}}}
+	if err := writeTable(&out, tbl, tableOptions{Color: true}); err != nil {
+		t.Fatalf("writeTable failed: %v", err)
+	}
+
+	want := "\033[1m\033[36mStock (1)\033[0m\n\033[1mID  NAME\033[0m\n11  Tickets\n"
+	if got := out.String(); got != want {
+		t.Errorf("Table output mismatch. Got: %q, Want: %q", got, want)
+	}
+}
+
+func TestWriteEntriesTable(t *testing.T) {
+	data := getMockEntriesData()
+	data.Entries = data.Entries[:1]
+
+	var out bytes.Buffer
+	if err := writeEntries(&out, data, formatTable, common.CSVWriterParams{}); err != nil {
+		t.Fatalf("writeEntries failed: %v", err)
+	}
+
+	tbl := newEntriesTable(data)
+	if len(tbl.Rows) != 1 || tbl.Rows[0][0] != "ASC000012" {
+		t.Fatalf("Unexpected entries table rows: %v", tbl.Rows)
+	}
+	if !bytes.HasPrefix(out.Bytes(), []byte("Entries (1)\nEntry ID   Date")) {
+		t.Errorf("Unexpected entries table output: %q", out.String())
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+// Change actions
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// objectChange is a difference between two dumps.
+type objectChange struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// watchPayload is the JSON document posted to the webhook.
+type watchPayload struct {
+	Checked time.Time      `json:"checked"`
+	Changes []objectChange `json:"changes"`
+}
+
+func newWatchCmd() *cobra.Command {
+	var watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Report the changes since the previous run",
+		Long: `Dump the employees, providers, periods, accounts and categories and report the changes since the previous run.
+
+The data of the previous run are stored in the state file. The first run only creates it.
+Without interval, the command runs once and can be scheduled with cron.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg Config
+
+			if err := common.UnmarshalConfig(&cfg); err != nil {
+				return common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+			}
+
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+			cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+			flags := cmd.Flags()
+			interval, err := flags.GetDuration("interval")
+			if err != nil {
+				return err
+			}
+			statePath, err := flags.GetString("state")
+			if err != nil {
+				return err
+			}
+			webhook, err := flags.GetString("webhook")
+			if err != nil {
+				return err
+			}
+
+			for {
+				if err := watchOnce(cfg, statePath, webhook); err != nil {
+					if interval == 0 {
+						return err
+					}
+					slog.Error("failed to check the changes", "error", err)
+				}
+				if interval == 0 {
+					return nil
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+	watchCmd.Flags().Duration("interval", 0, "Time between two checks, like 1h or 30m. Runs only once by default.")
+	watchCmd.Flags().String("state", "dumper-state.json", "Path of the file storing the data of the previous run.")
+	watchCmd.Flags().String("webhook", "", "URL to post the changes to as JSON.")
+
+	return watchCmd
+}
+
+func watchOnce(cfg Config, statePath string, webhook string) error {
+	// Log in at each check as the session may have expired
+	client, err := lib.NewClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Login(cfg.Email, cfg.Password); err != nil {
+		return common.WithExitCode(common.ExitAuth, err)
+	}
+	return checkChanges(client, os.Stdout, statePath, webhook, time.Now())
+}
+
+// checkChanges compares the current data with the state file, reports the differences and updates the state.
+func checkChanges(client dumpClient, w io.Writer, statePath string, webhook string, now time.Time) error {
+	data, err := fetchDump(client, referenceTypes)
+	if err != nil {
+		return err
+	}
+	current := newDumpOutput(data)
+
+	previous, err := loadState(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(w, "%s: initial state saved\n", now.Format(time.DateTime))
+		return saveState(statePath, current)
+	}
+	if err != nil {
+		return err
+	}
+
+	changes := diffDumps(previous, current)
+	writeChanges(w, changes, now)
+	if webhook != "" && len(changes) > 0 {
+		if err := postChanges(webhook, watchPayload{Checked: now, Changes: changes}); err != nil {
+			return err
+		}
+	}
+	return saveState(statePath, current)
+}
+
+func loadState(path string) (state dumpOutput, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(content, &state); err != nil {
+		err = fmt.Errorf("invalid state file %s: %s", path, err)
+	}
+	return
+}
+
+func saveState(path string, state dumpOutput) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the state: %s", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write the state file %s: %s", path, err)
+	}
+	return nil
+}
+
+// diffDumps lists the objects added, removed or changed between the two dumps.
+func diffDumps(previous dumpOutput, current dumpOutput) []objectChange {
+	var changes []objectChange
+	changes = append(changes, diffObjects(typeEmployees, previous.Employees, current.Employees,
+		func(e employeeOutput) (string, string) { return e.ID, e.Lastname + " " + e.Firstname })...)
+	changes = append(changes, diffObjects(typeProviders, previous.Providers, current.Providers,
+		func(p providerOutput) (string, string) { return p.ID, p.Name })...)
+	changes = append(changes, diffObjects(typePeriods, previous.Periods, current.Periods,
+		func(p periodOutput) (string, string) { return p.ID, p.Start + " - " + p.End })...)
+	changes = append(changes, diffObjects(typeAccounts, previous.Accounts, current.Accounts,
+		func(a accountOutput) (string, string) { return strconv.Itoa(a.ID), a.Bank })...)
+	changes = append(changes, diffObjects(typeCategories, previous.Categories, current.Categories,
+		func(c categoryOutput) (string, string) { return strconv.Itoa(c.ID), c.Name })...)
+	return changes
+}
+
+// diffObjects compares two lists of objects identified by the key function.
+// The key function returns the ID and the name of the object.
+func diffObjects[T any](objectType string, previous []T, current []T, key func(T) (string, string)) []objectChange {
+	var changes []objectChange
+
+	previousByID := make(map[string]T, len(previous))
+	for _, object := range previous {
+		id, _ := key(object)
+		previousByID[id] = object
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, object := range current {
+		id, name := key(object)
+		seen[id] = true
+		old, found := previousByID[id]
+		if !found {
+			changes = append(changes, objectChange{Type: objectType, ID: id, Name: name, Action: changeAdded})
+		} else if !reflect.DeepEqual(old, object) {
+			changes = append(changes, objectChange{Type: objectType, ID: id, Name: name, Action: changeChanged})
+		}
+	}
+
+	for _, object := range previous {
+		if id, name := key(object); !seen[id] {
+			changes = append(changes, objectChange{Type: objectType, ID: id, Name: name, Action: changeRemoved})
+		}
+	}
+	return changes
+}
+
+func writeChanges(w io.Writer, changes []objectChange, now time.Time) {
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "%s: no change\n", now.Format(time.DateTime))
+		return
+	}
+	fmt.Fprintf(w, "%s: %d changes\n", now.Format(time.DateTime), len(changes))
+	for _, change := range changes {
+		fmt.Fprintf(w, "    %s %s %s: %s\n", change.Action, change.Type, change.ID, change.Name)
+	}
+}
+
+func postChanges(url string, payload watchPayload) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize the changes: %s", err)
+	}
+
+	return common.PostWebhook(url, content)
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dumper
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newFakeDumpClient(data dumpData) *fakeDumpClient {
+	client := &fakeDumpClient{data: data}
+	client.started.Add(len(referenceTypes))
+	return client
+}
+
+func TestDiffObjects(t *testing.T) {
+	previous := []providerOutput{{ID: "p1", Name: "ACME"}, {ID: "p2", Name: "Old"}, {ID: "p3", Name: "Same"}}
+	current := []providerOutput{{ID: "p1", Name: "ACME", City: "Paris"}, {ID: "p3", Name: "Same"}, {ID: "p4", Name: "New"}}
+
+	changes := diffObjects(typeProviders, previous, current, func(p providerOutput) (string, string) {
+		return p.ID, p.Name
+	})
+	expected := []objectChange{
+		{Type: typeProviders, ID: "p1", Name: "ACME", Action: changeChanged},
+		{Type: typeProviders, ID: "p4", Name: "New", Action: changeAdded},
+		{Type: typeProviders, ID: "p2", Name: "Old", Action: changeRemoved},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Changes mismatch. Got: %+v, Want: %+v", changes, expected)
+	}
+}
+
+func TestCheckChanges(t *testing.T) {
+	var payloads []watchPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload watchPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("invalid webhook payload: %v", err)
+		}
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	now := time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC)
+	data := getMockDumpData()
+
+	// First run: only the state is saved
+	var out bytes.Buffer
+	if err := checkChanges(newFakeDumpClient(data), &out, statePath, server.URL, now); err != nil {
+		t.Fatalf("checkChanges failed: %v", err)
+	}
+	if out.String() != "2025-06-30 22:00:00: initial state saved\n" {
+		t.Errorf("Unexpected first run output: %q", out.String())
+	}
+
+	// Second run without change
+	out.Reset()
+	if err := checkChanges(newFakeDumpClient(data), &out, statePath, server.URL, now); err != nil {
+		t.Fatalf("checkChanges failed: %v", err)
+	}
+	if out.String() != "2025-06-30 22:00:00: no change\n" {
+		t.Errorf("Unexpected output without change: %q", out.String())
+	}
+	if len(payloads) != 0 {
+		t.Errorf("Unexpected webhook call without change: %+v", payloads)
+	}
+
+	// Third run with a new employee
+	data.Employees = append(data.Employees, lib.Employee{ID: "e2", Lastname: "Smith", Firstname: "John"})
+	out.Reset()
+	if err := checkChanges(newFakeDumpClient(data), &out, statePath, server.URL, now); err != nil {
+		t.Fatalf("checkChanges failed: %v", err)
+	}
+	want := "2025-06-30 22:00:00: 1 changes\n    added employees e2: Smith John\n"
+	if out.String() != want {
+		t.Errorf("Output mismatch. Got: %q, Want: %q", out.String(), want)
+	}
+	expected := []objectChange{{Type: typeEmployees, ID: "e2", Name: "Smith John", Action: changeAdded}}
+	if len(payloads) != 1 || !reflect.DeepEqual(payloads[0].Changes, expected) {
+		t.Errorf("Webhook payloads mismatch. Got: %+v, Want changes: %+v", payloads, expected)
+	}
+}
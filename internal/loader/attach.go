@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var attachReceiptsCmd = &cobra.Command{
+	Use:   "attach-receipts path/to/receipts",
+	Short: "Attach receipts to the entries already in happy-compta",
+	Long: `Attach the receipts of a folder to the entries already in happy-compta, without creating any entry.
+
+Each folder or file of the receipts folder is matched against the entries of the accounting period:
+- by entry number, like 12, FON12 or FON000012 - Taxi,
+- by employee name, like Doe John, if only one entry of the employee has no receipt,
+- by date and amount, like 2025-03-14 42.50.
+The files of a matched folder are all attached to the entry. The files already attached are skipped.`,
+	Args: common.UsageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period, err := cmd.Flags().GetString("period")
+		if err != nil {
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		credentials := common.ReadCredentials()
+		if err := credentials.Validate(); err != nil {
+			return err
+		}
+		client, err := login(credentials.Email, credentials.Password, viper.GetString("cache.dir"))
+		if err != nil {
+			return err
+		}
+		return attachReceipts(client, args[0], period, dryRun, os.Stdout)
+	},
+}
+
+func init() {
+	attachReceiptsCmd.Flags().String("period", "", `Accounting period of the entries, as an ID or the year of its start.
+Defaults to the current one.`)
+	attachReceiptsCmd.Flags().Bool("dry-run", false, "Print the receipts to attach without uploading them.")
+}
+
+// receiptSource is a folder or file of the receipts folder with the files to attach.
+type receiptSource struct {
+	// name is the folder name or the file name without extension, used to find the entry.
+	name  string
+	files []string
+}
+
+// listReceiptSources lists the folders and files of the receipts folder.
+func listReceiptSources(dir string) ([]receiptSource, error) {
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the receipts folder %s: %s", dir, err)
+	}
+
+	var sources []receiptSource
+	for _, item := range items {
+		path := filepath.Join(dir, item.Name())
+		if item.IsDir() {
+			files, err := checkAndGetFiles(path)
+			if err != nil {
+				return nil, err
+			}
+			if len(files) > 0 {
+				sources = append(sources, receiptSource{name: item.Name(), files: files})
+			}
+			continue
+		}
+		if err := checkReceiptSize(path); err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(item.Name(), filepath.Ext(item.Name()))
+		sources = append(sources, receiptSource{name: name, files: []string{path}})
+	}
+	return sources, nil
+}
+
+var entryNumberRegex = regexp.MustCompile(`^([A-Za-z]*)0*([0-9]+)$`)
+
+// matchReceiptSource returns the index of the entry matching the source name.
+// The employees map the IDs to the employees as the listed entries only have the employee ID set.
+func matchReceiptSource(
+	source receiptSource,
+	entries []lib.Entry,
+	employees map[string]lib.Employee,
+	dates common.DateParams,
+) (int, error) {
+	key, _, _ := strings.Cut(source.name, receiptFolderSeparator)
+	key = strings.TrimSpace(key)
+
+	// Entry number, with or without the budget prefix.
+	if matches := entryNumberRegex.FindStringSubmatch(key); matches != nil {
+		var candidates []int
+		for i, entry := range entries {
+			prefix := strings.TrimRight(entry.ID, "0123456789")
+			number := strings.TrimLeft(entry.ID[len(prefix):], "0")
+			if number == matches[2] && (matches[1] == "" || strings.EqualFold(prefix, matches[1])) {
+				candidates = append(candidates, i)
+			}
+		}
+		return uniqueMatch(source, candidates)
+	}
+
+	// Employee name, for the only entry of the employee without receipt.
+	name := stripDiacritics(strings.ToLower(strings.Join(strings.Fields(source.name), " ")))
+	var candidates []int
+	found := false
+	for i, entry := range entries {
+		party, ok := entry.Party.(*lib.Employee)
+		if !ok {
+			continue
+		}
+		employee := employees[party.ID]
+		lnFn := stripDiacritics(strings.ToLower(employee.Lastname + " " + employee.Firstname))
+		fnLn := stripDiacritics(strings.ToLower(employee.Firstname + " " + employee.Lastname))
+		if name != lnFn && name != fnLn {
+			continue
+		}
+		found = true
+		if len(entry.Receipts) == 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	if found {
+		return uniqueMatch(source, candidates)
+	}
+
+	// Date and amount, preferring the entries without receipt.
+	fields := strings.Fields(strings.ReplaceAll(source.name, receiptFolderSeparator, " "))
+	if len(fields) != 2 {
+		return -1, fmt.Errorf("no entry matching %s", source.name)
+	}
+	date, err := dates.Parse(fields[0])
+	if err != nil {
+		return -1, fmt.Errorf("no entry matching %s", source.name)
+	}
+	amount, err := common.ParseAmount(fields[1])
+	if err != nil {
+		return -1, fmt.Errorf("no entry matching %s", source.name)
+	}
+	var withoutReceipts []int
+	for i, entry := range entries {
+		total := 0.0
+		for _, line := range entry.Allocation {
+			total += line.Amount
+		}
+		if entry.Date.Format(lib.DateLayout) == date.Format(lib.DateLayout) &&
+			math.Abs(math.Abs(total)-math.Abs(amount)) < balanceTolerance {
+			candidates = append(candidates, i)
+			if len(entry.Receipts) == 0 {
+				withoutReceipts = append(withoutReceipts, i)
+			}
+		}
+	}
+	if len(candidates) > 1 && len(withoutReceipts) > 0 {
+		candidates = withoutReceipts
+	}
+	return uniqueMatch(source, candidates)
+}
+
+// uniqueMatch returns the only candidate entry or an error if there is none or several ones.
+func uniqueMatch(source receiptSource, candidates []int) (int, error) {
+	switch len(candidates) {
+	case 0:
+		return -1, fmt.Errorf("no entry matching %s", source.name)
+	case 1:
+		return candidates[0], nil
+	}
+	return -1, fmt.Errorf("%d entries match %s", len(candidates), source.name)
+}
+
+// planAttachments returns the new receipt files to attach indexed by entry.
+// The files already attached to the entries are skipped.
+func planAttachments(
+	sources []receiptSource,
+	entries []lib.Entry,
+	employees map[string]lib.Employee,
+	dates common.DateParams,
+) (map[int][]string, error) {
+	var allErrors []error
+	plan := map[int][]string{}
+	for _, source := range sources {
+		index, err := matchReceiptSource(source, entries, employees, dates)
+		if err != nil {
+			allErrors = append(allErrors, err)
+			continue
+		}
+		for _, file := range source.files {
+			if !slices.Contains(entries[index].Receipts, filepath.Base(file)) {
+				plan[index] = append(plan[index], file)
+			}
+		}
+	}
+
+	for index, files := range plan {
+		if len(files) == 0 {
+			delete(plan, index)
+			continue
+		}
+		if count := len(entries[index].Receipts) + len(files); count > 3 {
+			allErrors = append(allErrors, fmt.Errorf(
+				"entry %s would have %d receipts, but maximum is 3 per entry", entries[index].ID, count,
+			))
+			delete(plan, index)
+		}
+	}
+	return plan, errors.Join(allErrors...)
+}
+
+// attachClient is the part of the happy-compta client needed to attach receipts.
+type attachClient interface {
+	ListPeriods() ([]lib.Period, error)
+	ListEmployees() ([]lib.Employee, error)
+	ListEntries(filter lib.EntryFilter) ([]lib.Entry, error)
+	AttachReceipts(entry *lib.Entry, receipts []string) error
+}
+
+// attachReceipts uploads the receipts of the folder to the matching entries of the period.
+// The sources that can't be matched are reported but don't prevent attaching the other ones.
+func attachReceipts(client attachClient, dir string, period string, dryRun bool, w io.Writer) error {
+	sources, err := listReceiptSources(dir)
+	if err != nil {
+		return err
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return err
+	}
+	periodID, err := lib.FindPeriod(periods, period)
+	if err != nil {
+		return err
+	}
+	employeesList, err := client.ListEmployees()
+	if err != nil {
+		return err
+	}
+	employees := make(map[string]lib.Employee, len(employeesList))
+	for _, employee := range employeesList {
+		employees[employee.ID] = employee
+	}
+	entries, err := client.ListEntries(lib.EntryFilter{Period: periodID})
+	if err != nil {
+		return err
+	}
+
+	plan, matchErr := planAttachments(sources, entries, employees, common.DateParams{})
+	if matchErr != nil {
+		slog.Warn("some receipts can't be attached", "error", matchErr)
+	}
+	indexes := slices.Sorted(maps.Keys(plan))
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ENTRY\tDATE\tNAME\tRECEIPTS"); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		entry := entries[index]
+		names := make([]string, len(plan[index]))
+		for i, file := range plan[index] {
+			names[i] = filepath.Base(file)
+		}
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			entry.ID, entry.Date.Format(lib.DateLayout), entry.Name, strings.Join(names, ", "))
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if dryRun {
+		_, err := fmt.Fprintf(w, "Dry run: receipts would be attached to %d entries\n", len(indexes))
+		return err
+	}
+
+	var failures []error
+	for _, index := range indexes {
+		if err := client.AttachReceipts(&entries[index], plan[index]); err != nil {
+			failures = append(failures, fmt.Errorf("failed to attach the receipts of entry %s: %s", entries[index].ID, err))
+		}
+	}
+	slog.Info("receipts attached", "entries", len(indexes)-len(failures), "total", len(indexes))
+	if len(failures) > 0 {
+		return common.WithExitCode(common.ExitRemote, errors.Join(failures...))
+	}
+	return nil
+}
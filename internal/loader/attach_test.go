@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getMockRemoteEntries() []lib.Entry {
+	return []lib.Entry{
+		{
+			ID: "FON000012", Period: "12345", Kind: lib.KindSpend, Name: "Taxi", Budget: lib.BudgetFON,
+			Date:       time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			Allocation: []lib.AllocationLine{{CategoryID: 100, Amount: 42.5}},
+			Party:      &lib.Employee{ID: "100001"}, PaymentMethod: lib.PaymentMethodCard, Account: lib.Account{ID: 1},
+		},
+		{
+			ID: "ASC000012", Period: "12345", Kind: lib.KindSpend, Name: "Gifts", Budget: lib.BudgetASC,
+			Date:       time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			Allocation: []lib.AllocationLine{{CategoryID: 200, Amount: 100}},
+			Party:      &lib.Employee{ID: "100001"}, PaymentMethod: lib.PaymentMethodCard, Account: lib.Account{ID: 1},
+			Receipts: []string{"gifts.pdf"},
+		},
+		{
+			ID: "FON000013", Period: "12345", Kind: lib.KindSpend, Name: "Paper", Budget: lib.BudgetFON,
+			Date:       time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC),
+			Allocation: []lib.AllocationLine{{CategoryID: 100, Amount: 12}},
+			Party:      &lib.Provider{ID: "p1"}, PaymentMethod: lib.PaymentMethodCard, Account: lib.Account{ID: 1},
+		},
+	}
+}
+
+func TestMatchReceiptSource(t *testing.T) {
+	entries := getMockRemoteEntries()
+	employees := map[string]lib.Employee{"100001": {ID: "100001", Lastname: "Doe", Firstname: "Jérôme"}}
+
+	tests := []struct {
+		name     string
+		expected int
+		errMsg   string
+	}{
+		{name: "FON12", expected: 0},
+		{name: "asc000012 - Gifts", expected: 1},
+		{name: "13", expected: 2},
+		{name: "12", errMsg: "2 entries match 12"},
+		{name: "14", errMsg: "no entry matching 14"},
+		{name: "Doe Jerome", expected: 0},
+		{name: "jérôme  doe", expected: 0},
+		{name: "2025-03-20 12,00", expected: 2},
+		{name: "2025-03-14 - 42.50", expected: 0},
+		{name: "2025-03-14 100.00", expected: 1},
+		{name: "2025-03-14 7.00", errMsg: "no entry matching"},
+		{name: "Unknown", errMsg: "no entry matching Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := matchReceiptSource(receiptSource{name: tt.name}, entries, employees, common.DateParams{})
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected an error containing '%s', got: %d, %v", tt.errMsg, index, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchReceiptSource failed: %v", err)
+			}
+			if index != tt.expected {
+				t.Errorf("Entry mismatch. Got: %d, Want: %d", index, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanAttachments(t *testing.T) {
+	entries := getMockRemoteEntries()
+	sources := []receiptSource{
+		{name: "FON12", files: []string{"r/FON12/taxi.pdf"}},
+		{name: "ASC12", files: []string{"r/ASC12/gifts.pdf", "r/ASC12/a.pdf", "r/ASC12/b.pdf"}},
+		{name: "FON13", files: []string{"r/FON13/paper.pdf"}},
+		{name: "2025-03-20 12.00", files: []string{"r/2025-03-20 12.00.pdf"}},
+	}
+
+	plan, err := planAttachments(sources, entries, nil, common.DateParams{})
+
+	// The already attached gifts.pdf is skipped: the entry gets 3 receipts.
+	if err != nil {
+		t.Fatalf("planAttachments failed: %v", err)
+	}
+	expected := map[int][]string{
+		0: {"r/FON12/taxi.pdf"},
+		1: {"r/ASC12/a.pdf", "r/ASC12/b.pdf"},
+		2: {"r/FON13/paper.pdf", "r/2025-03-20 12.00.pdf"},
+	}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("Plan mismatch. Got: %v, Want: %v", plan, expected)
+	}
+
+	sources = append(sources, receiptSource{name: "ASC000012 - more", files: []string{"r/more.pdf"}})
+	if _, err := planAttachments(sources, entries, nil, common.DateParams{}); err == nil ||
+		!strings.Contains(err.Error(), "entry ASC000012 would have 4 receipts, but maximum is 3 per entry") {
+		t.Errorf("Expected a too many receipts error, got: %v", err)
+	}
+}
+
+func TestAttachReceipts(t *testing.T) {
+	server := mockserver.New(mockserver.Data{
+		Email:     "treasurer@example.com",
+		Password:  "secret",
+		Accounts:  []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}},
+		Employees: []lib.Employee{{ID: "100001", Lastname: "Doe", Firstname: "Jérôme", Active: true}},
+		Periods:   getMockPeriods(),
+		Entries:   getMockRemoteEntries(),
+		Receipts:  map[string]string{"gifts.pdf": "gifts"},
+	})
+	defer server.Close()
+	client, err := lib.NewClientWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	if err := client.Login("treasurer@example.com", "secret"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "Doe Jérôme"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeReceipt(t, filepath.Join(dir, "Doe Jérôme"), "taxi.pdf", "taxi")
+	writeReceipt(t, dir, "2025-03-20 12.00.pdf", "paper")
+	writeReceipt(t, dir, "unknown.pdf", "unknown")
+
+	var out bytes.Buffer
+	if err := attachReceipts(client, dir, "12345", true, &out); err != nil {
+		t.Fatalf("attachReceipts dry run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Dry run: receipts would be attached to 2 entries") {
+		t.Errorf("Unexpected dry run output:\n%s", out.String())
+	}
+	if receipts := server.Entries()[0].Receipts; len(receipts) != 0 {
+		t.Errorf("Expected no receipt uploaded on dry run, got: %v", receipts)
+	}
+
+	out.Reset()
+	if err := attachReceipts(client, dir, "12345", false, &out); err != nil {
+		t.Fatalf("attachReceipts failed: %v", err)
+	}
+	entries := server.Entries()
+	if !reflect.DeepEqual(entries[0].Receipts, []string{"taxi.pdf"}) {
+		t.Errorf("Taxi receipts mismatch. Got: %v", entries[0].Receipts)
+	}
+	if !reflect.DeepEqual(entries[1].Receipts, []string{"gifts.pdf"}) {
+		t.Errorf("Gifts receipts mismatch. Got: %v", entries[1].Receipts)
+	}
+	if !reflect.DeepEqual(entries[2].Receipts, []string{"2025-03-20 12.00.pdf"}) {
+		t.Errorf("Paper receipts mismatch. Got: %v", entries[2].Receipts)
+	}
+	if content, found := server.Receipt("taxi.pdf"); !found || content != "taxi" {
+		t.Errorf("Receipt content mismatch. Got: %s, Want: taxi", content)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// balanceTolerance absorbs the floating point rounding errors.
+const balanceTolerance = 0.005
+
+// balancePoint holds the running balance of a CSV row and the signed amount of its entry.
+type balancePoint struct {
+	row     int
+	delta   float64
+	balance float64
+}
+
+// newBalancePoint computes the balance change of an entry: incomes increase it and spendings decrease it.
+func newBalancePoint(rowIndex int, entry lib.Entry, balance float64) balancePoint {
+	delta := 0.0
+	for _, line := range entry.Allocation {
+		delta += math.Abs(line.Amount)
+	}
+	if entry.Kind != lib.KindTake {
+		delta = -delta
+	}
+	return balancePoint{row: rowIndex, delta: delta, balance: balance}
+}
+
+// balanceMismatches lists the errors of consecutive points not consistent with the amounts.
+// If reversed is true, the rows are expected to be sorted from the newest to the oldest.
+func balanceMismatches(points []balancePoint, reversed bool) []error {
+	var mismatches []error
+	for i := 1; i < len(points); i++ {
+		previous, current := points[i-1], points[i]
+		expected := previous.balance + current.delta
+		actual := current.balance
+		if reversed {
+			expected = current.balance + previous.delta
+			actual = previous.balance
+		}
+		if math.Abs(expected-actual) > balanceTolerance {
+			mismatches = append(mismatches, fmt.Errorf(
+				"balance mismatch between rows %d and %d: expected %.2f, got %.2f",
+				previous.row, current.row, expected, actual,
+			))
+		}
+	}
+	return mismatches
+}
+
+// checkBalances verifies that the running balances are consistent with the amounts.
+// Both chronological and reverse chronological orders are accepted.
+func checkBalances(points []balancePoint) error {
+	forward := balanceMismatches(points, false)
+	if len(forward) == 0 {
+		return nil
+	}
+
+	return balancesError(forward, balanceMismatches(points, true))
+}
+
+// balancesError returns the error of the chronological and reverse chronological mismatches,
+// or nil if one of the orders has none.
+func balancesError(forward []error, backward []error) error {
+	if len(forward) == 0 || len(backward) == 0 {
+		return nil
+	}
+
+	// Report the order with the least errors as it is likely to be the one of the file.
+	mismatches := forward
+	if len(backward) < len(forward) {
+		mismatches = backward
+	}
+	return fmt.Errorf(
+		"the balance column is inconsistent with the amounts, the export may be truncated or reordered:\n%w",
+		errors.Join(mismatches...),
+	)
+}
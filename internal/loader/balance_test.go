@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestNewBalancePoint(t *testing.T) {
+	spend := lib.Entry{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{{Amount: -12.5}}}
+	if point := newBalancePoint(1, spend, 100); point.delta != -12.5 {
+		t.Errorf("Spending delta mismatch. Got: %.2f", point.delta)
+	}
+
+	take := lib.Entry{Kind: lib.KindTake, Allocation: []lib.AllocationLine{{Amount: 10}, {Amount: 5}}}
+	if point := newBalancePoint(2, take, 100); point.delta != 15 {
+		t.Errorf("Income delta mismatch. Got: %.2f", point.delta)
+	}
+}
+
+func TestCheckBalances(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []balancePoint
+		wantErr string
+	}{
+		{
+			name:   "No balance",
+			points: nil,
+		},
+		{
+			name: "Chronological order",
+			points: []balancePoint{
+				{row: 1, delta: -10, balance: 90},
+				{row: 2, delta: 20.10, balance: 110.10},
+				{row: 3, delta: -0.10, balance: 110},
+			},
+		},
+		{
+			name: "Reverse chronological order",
+			points: []balancePoint{
+				{row: 1, delta: -0.10, balance: 110},
+				{row: 2, delta: 20.10, balance: 110.10},
+				{row: 3, delta: -10, balance: 90},
+			},
+		},
+		{
+			name: "Missing row",
+			points: []balancePoint{
+				{row: 1, delta: -10, balance: 90},
+				{row: 2, delta: -20, balance: 70},
+				{row: 3, delta: -5, balance: 60},
+				{row: 4, delta: -5, balance: 55},
+			},
+			wantErr: "balance mismatch between rows 2 and 3: expected 65.00, got 60.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBalances(tt.points)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkBalances() failed unexpectedly: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseCSV_BalanceMismatch(t *testing.T) {
+	accounts := []lib.Account{
+		{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+	}
+
+	// The row between 02/01 and 04/01 has been lost
+	csvData := `DATE,NAME,DEBIT,CREDIT,BALANCE
+01/01/2025,Paper,12.00,,88.00
+02/01/2025,Grant,,100.00,188.00
+04/01/2025,Pens,8.00,,160.00
+`
+	r := csv.NewReader(strings.NewReader(csvData))
+	columnsCfg := CSVColumns{Date: "DATE", Name: "NAME", Debit: "DEBIT", Credit: "CREDIT", Balance: "BALANCE"}
+
+	_, err := parseCSV(r, columnsCfg, getBaseDefaults(), common.DateParams{}, accounts,
+		getMockCategories(), nil, nil, getMockPeriods(), nil)
+	if err == nil || !strings.Contains(err.Error(), "balance mismatch between rows 2 and 3") {
+		t.Errorf("Expected a balance mismatch error, got: %v", err)
+	}
+}
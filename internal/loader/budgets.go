@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// BudgetsConfig holds the spending limits of the categories.
+type BudgetsConfig struct {
+	// Limits are the maximum spendings of an accounting period indexed by category name.
+	Limits map[string]float64 `mapstructure:"limits"`
+	// Block fails the import when a category goes over its limit instead of only warning.
+	Block bool `mapstructure:"block"`
+}
+
+// categoryBudget is the spending envelope of a category for an accounting period.
+type categoryBudget struct {
+	category lib.Category
+	period   string
+	limit    float64
+	// spent is the amount of the entries already in happy-compta.
+	spent float64
+	// imported is the amount of the entries to add.
+	imported float64
+}
+
+// remaining returns the amount left in the envelope after the import.
+func (b categoryBudget) remaining() float64 {
+	return b.limit - b.spent - b.imported
+}
+
+// entriesLister lists the spending entries of an accounting period.
+type entriesLister func(period string) ([]lib.Entry, error)
+
+// computeBudgets returns the envelopes of the limited categories used by the spending entries.
+// The amounts already spent are read from the entries returned by the lister, or ignored if it is nil.
+func computeBudgets(
+	limits map[string]float64,
+	categories []lib.Category,
+	entries []lib.Entry,
+	lister entriesLister,
+) ([]categoryBudget, error) {
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	// The limits keys are lower case when read from the configuration file.
+	normalizedLimits := map[string]float64{}
+	for name, limit := range limits {
+		normalizedLimits[stripDiacritics(strings.ToLower(name))] = limit
+	}
+	limitedCategories := map[int]lib.Category{}
+	for _, category := range categories {
+		if _, found := normalizedLimits[stripDiacritics(strings.ToLower(category.Name))]; found {
+			limitedCategories[category.ID] = category
+		}
+	}
+
+	var budgets []*categoryBudget
+	budgetsByKey := map[string]*categoryBudget{}
+	addAmounts := func(entry lib.Entry, create bool, add func(*categoryBudget, float64)) {
+		if entry.Kind != lib.KindSpend {
+			return
+		}
+		for _, line := range entry.Allocation {
+			category, found := limitedCategories[line.CategoryID]
+			if !found {
+				continue
+			}
+			key := fmt.Sprintf("%s|%d", entry.Period, category.ID)
+			budget, found := budgetsByKey[key]
+			if !found {
+				if !create {
+					continue
+				}
+				budget = &categoryBudget{
+					category: category,
+					period:   entry.Period,
+					limit:    normalizedLimits[stripDiacritics(strings.ToLower(category.Name))],
+				}
+				budgetsByKey[key] = budget
+				budgets = append(budgets, budget)
+			}
+			add(budget, math.Abs(line.Amount))
+		}
+	}
+
+	var periods []string
+	for _, entry := range entries {
+		addAmounts(entry, true, func(b *categoryBudget, amount float64) { b.imported += amount })
+		if len(budgets) > 0 && !slices.Contains(periods, entry.Period) {
+			periods = append(periods, entry.Period)
+		}
+	}
+
+	if lister == nil && len(budgets) > 0 {
+		slog.Warn("the amounts already spent are unknown without connecting to happy-compta")
+	} else if lister != nil {
+		for _, period := range periods {
+			existing, err := lister(period)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the entries of the %s period: %s", period, err)
+			}
+			for _, entry := range existing {
+				// The listed entries may not have the period set.
+				entry.Period = period
+				addAmounts(entry, false, func(b *categoryBudget, amount float64) { b.spent += amount })
+			}
+		}
+	}
+
+	result := make([]categoryBudget, len(budgets))
+	for i, budget := range budgets {
+		result[i] = *budget
+	}
+	return result, nil
+}
+
+// checkBudgets returns an error for each category going over its limit.
+func checkBudgets(budgets []categoryBudget) error {
+	var overruns []error
+	for _, budget := range budgets {
+		if budget.remaining() < -balanceTolerance {
+			overruns = append(overruns, fmt.Errorf(
+				"the %s category goes over its %.2f limit by %.2f: %.2f already spent and %.2f imported",
+				budget.category.Name, budget.limit, -budget.remaining(), budget.spent, budget.imported,
+			))
+		}
+	}
+	return errors.Join(overruns...)
+}
+
+// printBudgets writes the envelopes of the limited categories with their remaining amounts.
+func printBudgets(w io.Writer, budgets []categoryBudget) error {
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "\nCATEGORY\tBUDGET\tPERIOD\tLIMIT\tSPENT\tIMPORTED\tREMAINING"); err != nil {
+		return err
+	}
+	for _, budget := range budgets {
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			budget.category.Name, budget.category.Budget, budget.period,
+			budget.limit, budget.spent, budget.imported, budget.remaining(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newBudgetEntry(period string, kind lib.Kind, categoryID int, amount float64) lib.Entry {
+	return lib.Entry{Period: period, Kind: kind, Allocation: []lib.AllocationLine{{CategoryID: categoryID, Amount: amount}}}
+}
+
+func TestComputeBudgets(t *testing.T) {
+	categories := []lib.Category{
+		{ID: 1, Name: "Fournitures", Budget: lib.BudgetFON},
+		{ID: 2, Name: "Sorties", Budget: lib.BudgetASC},
+		{ID: 3, Name: "Cadeaux", Budget: lib.BudgetASC},
+	}
+	limits := map[string]float64{"fournitures": 100, "sorties": 500, "unknown": 10}
+	entries := []lib.Entry{
+		newBudgetEntry("P1", lib.KindSpend, 1, 30),
+		newBudgetEntry("P1", lib.KindSpend, 1, 20),
+		newBudgetEntry("P1", lib.KindTake, 1, 1000),
+		newBudgetEntry("P1", lib.KindSpend, 3, 1000),
+	}
+	existing := map[string][]lib.Entry{
+		"P1": {newBudgetEntry("P1", lib.KindSpend, 1, 60), newBudgetEntry("P1", lib.KindSpend, 2, 400)},
+	}
+	lister := func(period string) ([]lib.Entry, error) { return existing[period], nil }
+
+	budgets, err := computeBudgets(limits, categories, entries, lister)
+	if err != nil {
+		t.Fatalf("computeBudgets failed: %v", err)
+	}
+	// Only the imported categories are listed.
+	if len(budgets) != 1 {
+		t.Fatalf("Budgets count mismatch. Got: %d, Want: 1", len(budgets))
+	}
+	budget := budgets[0]
+	if budget.category.ID != 1 || budget.limit != 100 || budget.spent != 60 || budget.imported != 50 {
+		t.Errorf("Budget mismatch. Got: %+v", budget)
+	}
+	if budget.remaining() != -10 {
+		t.Errorf("Remaining mismatch. Got: %.2f, Want: -10.00", budget.remaining())
+	}
+
+	err = checkBudgets(budgets)
+	expected := "the Fournitures category goes over its 100.00 limit by 10.00: 60.00 already spent and 50.00 imported"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Unexpected budgets error. Got: %v, Want: %s", err, expected)
+	}
+
+	var buf bytes.Buffer
+	if err := printBudgets(&buf, budgets); err != nil {
+		t.Fatalf("printBudgets failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "REMAINING") || !strings.Contains(buf.String(), "-10.00") {
+		t.Errorf("Unexpected budgets summary:\n%s", buf.String())
+	}
+}
+
+func TestComputeBudgets_Offline(t *testing.T) {
+	categories := []lib.Category{{ID: 1, Name: "Fournitures"}}
+	entries := []lib.Entry{newBudgetEntry("P1", lib.KindSpend, 1, 30)}
+
+	budgets, err := computeBudgets(map[string]float64{"Fournitures": 100}, categories, entries, nil)
+	if err != nil {
+		t.Fatalf("computeBudgets failed: %v", err)
+	}
+	if len(budgets) != 1 || budgets[0].remaining() != 70 {
+		t.Errorf("Unexpected budgets: %+v", budgets)
+	}
+	if err := checkBudgets(budgets); err != nil {
+		t.Errorf("Unexpected budgets error: %v", err)
+	}
+}
+
+func TestComputeBudgets_ListError(t *testing.T) {
+	categories := []lib.Category{{ID: 1, Name: "Fournitures"}}
+	entries := []lib.Entry{newBudgetEntry("P1", lib.KindSpend, 1, 30)}
+	lister := func(string) ([]lib.Entry, error) { return nil, errors.New("boom") }
+
+	if _, err := computeBudgets(map[string]float64{"fournitures": 100}, categories, entries, lister); err == nil {
+		t.Error("Expected an error when the entries can't be listed")
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// expenseClaim groups the rows of the expense claim of an employee.
+type expenseClaim struct {
+	employee *lib.Employee
+	// rows are the indexes of the claim rows in the rows slice.
+	rows     []int
+	total    float64
+	declared string
+	// conflict is the error of a row declaring another total than the previous ones.
+	conflict error
+}
+
+// name returns the full name of the claim employee.
+func (c expenseClaim) name() string {
+	return strings.TrimSpace(c.employee.Firstname + " " + c.employee.Lastname)
+}
+
+// applyExpenseClaims groups the rows per employee and checks their amounts against the declared claim totals.
+// The errors are also set on the rows of the invalid claims.
+// The entries of the valid claims get a comment summarizing their claim.
+func applyExpenseClaims(colMap columnMap, rows []csvRow) ([]expenseClaim, error) {
+	if colMap.Total < 0 {
+		return nil, errors.New("the expense claims need a total column with the declared amount of each claim")
+	}
+
+	var allErrors []error
+	var claims []*expenseClaim
+	claimsByEmployee := map[string]*expenseClaim{}
+
+	for i := range rows {
+		row := &rows[i]
+		employee, ok := row.entry.Party.(*lib.Employee)
+		if !ok {
+			row.err = fmt.Errorf("row %d of the expense claims has no employee", row.index)
+			allErrors = append(allErrors, row.err)
+			continue
+		}
+
+		claim, found := claimsByEmployee[employee.ID]
+		if !found {
+			claim = &expenseClaim{employee: employee}
+			claimsByEmployee[employee.ID] = claim
+			claims = append(claims, claim)
+		}
+		claim.rows = append(claim.rows, i)
+		for _, line := range row.entry.Allocation {
+			claim.total += line.Amount
+		}
+
+		// The declared total can be repeated on all the rows of the claim or only set on one of them.
+		declared := getField(row.fields, colMap.Total)
+		if declared == "" {
+			continue
+		}
+		if claim.declared != "" && claim.declared != declared {
+			claim.conflict = fmt.Errorf("row %d declares a %s total for the claim of %s, but %s has already been declared",
+				row.index, declared, claim.name(), claim.declared)
+			continue
+		}
+		claim.declared = declared
+	}
+
+	var result []expenseClaim
+	for _, claim := range claims {
+		if err := claim.check(); err != nil {
+			for _, i := range claim.rows {
+				rows[i].err = errors.Join(rows[i].err, err)
+			}
+			allErrors = append(allErrors, err)
+			continue
+		}
+
+		summary := fmt.Sprintf("Expense claim of %s: %d lines, total %.2f €", claim.name(), len(claim.rows), claim.total)
+		for _, i := range claim.rows {
+			if rows[i].entry.Comment != "" {
+				rows[i].entry.Comment += "\n"
+			}
+			rows[i].entry.Comment += summary
+		}
+		slog.Info("expense claim", "employee", claim.name(), "lines", len(claim.rows), "total", claim.total)
+		result = append(result, *claim)
+	}
+	return result, errors.Join(allErrors...)
+}
+
+// check compares the total of the claim rows with the declared one.
+func (c expenseClaim) check() error {
+	if c.conflict != nil {
+		return c.conflict
+	}
+	if c.declared == "" {
+		return fmt.Errorf("no total declared for the expense claim of %s", c.name())
+	}
+	declared, err := common.ParseAmount(c.declared)
+	if err != nil {
+		return fmt.Errorf("invalid total declared for the expense claim of %s: %s", c.name(), err)
+	}
+	if math.Abs(declared-c.total) >= 0.005 {
+		return fmt.Errorf("the expense claim of %s totals %.2f, but %.2f is declared", c.name(), c.total, declared)
+	}
+	return nil
+}
+
+// shareClaimReceipts attaches the receipts of each claim to all its entries.
+// The claim receipts are the first ones found on its entries, usually from the folder named after the employee.
+func shareClaimReceipts(claims []expenseClaim, entries []lib.Entry) {
+	for _, claim := range claims {
+		var receipts []string
+		for _, i := range claim.rows {
+			if len(entries[i].Receipts) > 0 {
+				receipts = entries[i].Receipts
+				break
+			}
+		}
+		if len(receipts) == 0 {
+			slog.Warn("no receipt for the expense claim", "employee", claim.name())
+			continue
+		}
+		for _, i := range claim.rows {
+			entries[i].Receipts = receipts
+		}
+	}
+}
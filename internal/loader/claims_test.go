@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newClaimRow(index int, employee *lib.Employee, amount float64, total string) csvRow {
+	row := csvRow{
+		index:  index,
+		fields: []string{"", total},
+		entry: lib.Entry{
+			Comment:    "Taxi",
+			Allocation: []lib.AllocationLine{{CategoryID: 1, Amount: amount}},
+		},
+	}
+	if employee != nil {
+		row.entry.Party = employee
+	}
+	return row
+}
+
+func TestApplyExpenseClaims(t *testing.T) {
+	john := &lib.Employee{ID: "E1", Lastname: "Doe", Firstname: "John"}
+	alice := &lib.Employee{ID: "E2", Lastname: "Smith", Firstname: "Alice"}
+	colMap := buildColumnMap([]string{"name", "total"}, CSVColumns{Name: "name", Total: "total"})
+
+	tests := []struct {
+		name    string
+		rows    []csvRow
+		claims  int
+		errMsgs []string
+	}{
+		{
+			name: "Valid",
+			rows: []csvRow{
+				newClaimRow(1, john, 12.5, "32.50"),
+				newClaimRow(2, alice, 8, "8"),
+				newClaimRow(3, john, 20, ""),
+			},
+			claims: 2,
+		},
+		{
+			name:    "TotalMismatch",
+			rows:    []csvRow{newClaimRow(1, john, 12.5, "30"), newClaimRow(2, john, 20, "30")},
+			errMsgs: []string{"the expense claim of John Doe totals 32.50, but 30.00 is declared"},
+		},
+		{
+			name:    "NoTotal",
+			rows:    []csvRow{newClaimRow(1, john, 12.5, "")},
+			errMsgs: []string{"no total declared for the expense claim of John Doe"},
+		},
+		{
+			name:    "ConflictingTotals",
+			rows:    []csvRow{newClaimRow(1, john, 12.5, "32.50"), newClaimRow(2, john, 20, "33")},
+			errMsgs: []string{"row 2 declares a 33 total for the claim of John Doe, but 32.50 has already been declared"},
+		},
+		{
+			name:    "NoEmployee",
+			rows:    []csvRow{newClaimRow(1, nil, 12.5, "12.50"), newClaimRow(2, alice, 8, "8")},
+			claims:  1,
+			errMsgs: []string{"row 1 of the expense claims has no employee"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := applyExpenseClaims(colMap, tt.rows)
+			if len(claims) != tt.claims {
+				t.Errorf("Claims count mismatch. Got: %d, Want: %d", len(claims), tt.claims)
+			}
+			if len(tt.errMsgs) == 0 && err != nil {
+				t.Fatalf("applyExpenseClaims failed: %v", err)
+			}
+			for _, message := range tt.errMsgs {
+				if err == nil || !strings.Contains(err.Error(), message) {
+					t.Errorf("Expected an error containing '%s', got: %v", message, err)
+				}
+			}
+			// The errors are reported on the rows for the errors CSV file.
+			if len(tt.errMsgs) > 0 && tt.rows[0].err == nil {
+				t.Errorf("Expected an error on the first row")
+			}
+		})
+	}
+}
+
+func TestApplyExpenseClaims_Comments(t *testing.T) {
+	john := &lib.Employee{ID: "E1", Lastname: "Doe", Firstname: "John"}
+	colMap := buildColumnMap([]string{"name", "total"}, CSVColumns{Name: "name", Total: "total"})
+	rows := []csvRow{newClaimRow(1, john, 12.5, "32.50"), newClaimRow(2, john, 20, "")}
+
+	if _, err := applyExpenseClaims(colMap, rows); err != nil {
+		t.Fatalf("applyExpenseClaims failed: %v", err)
+	}
+	expected := "Taxi\nExpense claim of John Doe: 2 lines, total 32.50 €"
+	for _, row := range rows {
+		if row.entry.Comment != expected {
+			t.Errorf("Comment mismatch. Got: %s, Want: %s", row.entry.Comment, expected)
+		}
+	}
+}
+
+func TestApplyExpenseClaims_NoTotalColumn(t *testing.T) {
+	colMap := buildColumnMap([]string{"name"}, CSVColumns{Name: "name", Total: "total"})
+	if _, err := applyExpenseClaims(colMap, nil); err == nil {
+		t.Error("Expected an error without total column")
+	}
+}
+
+func TestShareClaimReceipts(t *testing.T) {
+	claims := []expenseClaim{
+		{employee: &lib.Employee{Firstname: "John"}, rows: []int{0, 2}},
+		{employee: &lib.Employee{Firstname: "Alice"}, rows: []int{1}},
+	}
+	entries := []lib.Entry{{}, {}, {Receipts: []string{"john/taxi.pdf"}}}
+
+	shareClaimReceipts(claims, entries)
+
+	expected := [][]string{{"john/taxi.pdf"}, nil, {"john/taxi.pdf"}}
+	for i, entry := range entries {
+		if !reflect.DeepEqual(entry.Receipts, expected[i]) {
+			t.Errorf("Entry %d receipts mismatch. Got: %v, Want: %v", i, entry.Receipts, expected[i])
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var load func(Config) error = loadImpl
+
+// ConfigKeys are the keys of the configuration file not matching a flag of the loader command.
+var ConfigKeys = common.ConfigKeys{
+	"serve.listen":     "string",
+	"serve.token":      "string",
+	"guests":           "stringSlice",
+	"budgets.limits.*": "float64",
+
+	"templates.*.name":     "string",
+	"templates.*.kind":     "string",
+	"templates.*.budget":   "string",
+	"templates.*.bank":     "string",
+	"templates.*.category": "string",
+	"templates.*.payment":  "string",
+	"templates.*.employee": "string",
+	"templates.*.provider": "string",
+	"templates.*.comment":  "string",
+
+	"splits.*.match":   "string",
+	"splits.*.budget":  "string",
+	"splits.*.lines.*": "float64",
+}
+
+// NewCommand creates the loader command with the given name.
+func NewCommand(name string) *cobra.Command {
+	loaderCmd := &cobra.Command{
+		Use:   name + " path/to/file.csv",
+		Short: "A program loading entries from a CSV file as entries into happy-compta",
+		Long: `A program loading entries from a CSV file as entries into happy-compta.
+
+Files with a .xlsx extension are read as spreadsheets with the same columns as the CSV files.
+Files with a .yaml, .yml or .json extension are read as manifests listing the entries,
+with their allocation lines and receipts.`,
+		Args:    common.UsageArgs(cobra.ExactArgs(1)),
+		Version: common.FullVersion(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := readConfig()
+			if err != nil {
+				return err
+			}
+			cfg.CSVPath = args[0]
+
+			// No connection is needed when validating offline
+			if cfg.ReferenceSnapshot == "" {
+				credentials := common.ReadCredentials()
+				if err := credentials.Validate(); err != nil {
+					return err
+				}
+				cfg.Email, cfg.Password = credentials.Email, credentials.Password
+			}
+
+			// Actually do something
+			return load(cfg)
+		},
+	}
+	loaderCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		common.SetupCommand(loaderCmd, "LOADER", ConfigKeys)
+	}
+
+	loaderCmd.PersistentFlags().StringP("config", "c", "", "Configuration file path")
+	common.AddLogFlags(loaderCmd)
+	common.AddLanguageFlag(loaderCmd)
+	loaderCmd.SetFlagErrorFunc(common.FlagError)
+	loaderCmd.PersistentFlags().String("email", "", "User email address (REQUIRED)")
+	loaderCmd.PersistentFlags().String("password", "", "User password (REQUIRED)")
+	loaderCmd.PersistentFlags().String("cache-dir", "", `Folder caching the happy-compta pages between the runs.
+The cached pages are only downloaded again if they changed. Empty disables the cache.`)
+
+	loaderCmd.Flags().String("receipts", "receipts", "Folder containing the receipts")
+	loaderCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmations")
+	loaderCmd.Flags().Bool("review", false, `Review the parsed entries in an interactive terminal UI before uploading them.
+The category, employee, provider and account bank can be fixed and rows can be excluded.`)
+	loaderCmd.Flags().Bool("dry-run", false, "Parse and validate the CSV file and print the entries without adding them.")
+	loaderCmd.Flags().String("reference-snapshot", "", `Reference data file written by the snapshot command.
+When set, the CSV file is validated against this file without connecting to happy-compta.
+This requires --dry-run.`)
+	loaderCmd.Flags().String("profile", "", `Preset of CSV settings for a known file layout.
+Can be one of `+strings.Join(getProfileNames(), ", ")+`.
+happy-compta reads the CSV and XLSX files exported from happy-compta operations list.
+membership reads the lists of membership fees with Date, Nom, Montant, Paiement and Chèque columns.`)
+
+	loaderCmd.Flags().String("errors-csv", "", `Path of the copy of the CSV file with an additional import_error column written on failures.
+If some entries have been added, only the failed rows are written so the file can be fixed and imported again.
+Defaults to the CSV file path with an -errors suffix.`)
+	loaderCmd.Flags().Bool("expense-claims", false, `Import the rows as employee expense claims.
+The rows are grouped per employee and each claim amount is checked against the declared one in the total column.
+The receipts of a claim are attached to all its entries and a comment summarizes the claim.`)
+	loaderCmd.Flags().String("deposit-slip", "", `Path of the file to write the deposit slip of the received checks to.
+The slip lists the check number, drawer and amount of the entries paid by check, per bank account.`)
+	loaderCmd.Flags().Bool("suggest-categories", false, `Suggest the category of the rows without one from the past entries with a similar name.
+The entries of the two latest accounting periods are fetched to learn from.
+The suggestions are listed by --dry-run and need to be accepted in the --review interface.`)
+	loaderCmd.Flags().Bool("stream", false, `Validate, match the receipts of and add the rows one at a time, for large imports.
+The memory use doesn't grow with the file size and the invalid rows are reported as soon as they are read.
+The valid rows are added even if other rows are invalid.
+Only the failed rows are written to the errors CSV.
+This can't be used with manifests, --review, --expense-claims, --suggest-categories or --budgets-block.`)
+	loaderCmd.Flags().Bool("budgets-block", false, `Fail the import when a category would go over its limit.
+The limits are set in the budgets.limits map of the configuration file. Without this flag, only a warning is logged.`)
+	loaderCmd.Flags().String("state", "", `Path of the file storing the hashes of the receipts uploaded by the previous runs.
+A warning is logged when a receipt has already been uploaded. Empty disables the tracking.`)
+	loaderCmd.Flags().String("report", "", "Path of the JSON report of the import to write.")
+	loaderCmd.Flags().String("hook-pre", "", `Shell command or webhook URL to run before the import starts.
+The import is aborted if the hook fails.
+Commands get the JSON summary on standard input and in LOADER_HOOK_SUMMARY,
+the report path in LOADER_HOOK_REPORT and the event in LOADER_HOOK_EVENT.
+Webhooks get the same JSON document posted.`)
+	loaderCmd.Flags().String("hook-post", "", `Shell command or webhook URL to run after the import finished.
+The hook gets the same data as the pre-import one.`)
+	common.AddNotifyFlags(loaderCmd)
+
+	// Default Value flags
+	loaderCmd.Flags().String("name", "", "Default value for name column.")
+	loaderCmd.Flags().String("budget", "", "Default value for budget column.")
+	loaderCmd.Flags().String("bank", "", "Default value for bank column.")
+	loaderCmd.Flags().String("category", "", "Default value for category column.")
+	loaderCmd.Flags().String("payment", "", `Default value for payment column.
+Can be one of `+strings.Join(getPaymentMethodStrings(), ", "))
+	loaderCmd.Flags().String("kind", "", `Default value for kind column.
+Can be one of `+strings.Join(getKindStrings(), ", "))
+	loaderCmd.Flags().String("period", "", "Accounting period to add the entries to. Defaults to the current one.")
+
+	loaderCmd.Flags().String("rates-source", "", `Source of the exchange rates when not defined in the CSV file.
+Can be ecb to use the European Central Bank reference rates.`)
+	common.AddFlagCompletion(loaderCmd, "profile", getProfileNames()...)
+	common.AddFlagCompletion(loaderCmd, "budget", lib.BudgetASC.String(), lib.BudgetFON.String())
+	common.AddFlagCompletion(loaderCmd, "payment", getPaymentMethodStrings()...)
+	common.AddFlagCompletion(loaderCmd, "kind", getKindStrings()...)
+	common.AddFlagCompletion(loaderCmd, "rates-source", "ecb")
+
+	// Throttling flags
+	loaderCmd.Flags().Int("pause-every", 0, "Pause the import after this number of entries.")
+	loaderCmd.Flags().Int("pause-seconds", 60, "Number of seconds to pause the import for.")
+	loaderCmd.Flags().String("schedule", "", `Daily time window during which the entries are uploaded, like 22:00-06:00.
+The import waits for the window to open before uploading the next entry.`)
+
+	// CSV Structure flags
+	loaderCmd.Flags().String("csv-comma", "", "CSV field separator character.")
+	loaderCmd.Flags().String("csv-comment", "", "CSV comment character.")
+	loaderCmd.Flags().String("csv-encoding", "auto", `CSV file encoding, like utf-8 or windows-1252.
+auto detects the byte order mark and falls back to windows-1252 for non UTF-8 content.`)
+	loaderCmd.Flags().String("csv-sheet", "", `Name of the worksheet to read in .xlsx files.
+Defaults to the first one. The same column names as in CSV files are used.`)
+	loaderCmd.Flags().StringSlice("csv-date-layouts", nil, `Comma-separated list of the accepted date formats, as Go layouts.
+Defaults to DD/MM/YYYY, YYYY-MM-DD and DD-MM-YY: 02/01/2006,2006-01-02,02-01-06.`)
+
+	// CSV Column mapping flags
+	loaderCmd.Flags().Bool("guess-columns", false, `Guess the CSV columns mapping from the header names.
+The guessed mapping is printed for confirmation.`)
+	loaderCmd.Flags().String("csv-columns-name", "name", "CSV column name for transaction name.")
+	loaderCmd.Flags().String("csv-columns-date", "date", "CSV column name for date.")
+	loaderCmd.Flags().String("csv-columns-amount", "amount", "CSV column name for amount.")
+	loaderCmd.Flags().String("csv-columns-debit", "debit", `CSV column name for the debit amount.
+The entry is a spending when this column is filled and no amount is set.`)
+	loaderCmd.Flags().String("csv-columns-credit", "credit", `CSV column name for the credit amount.
+The entry is an income when this column is filled and no amount is set.`)
+	loaderCmd.Flags().String("csv-columns-balance", "balance", `CSV column name for the running balance.
+When present, the balances are checked against the amounts before uploading anything.`)
+	loaderCmd.Flags().String("csv-columns-currency", "currency",
+		`CSV column name for the ISO 4217 currency code of the amount.
+Amounts in other currencies than EUR are converted to euros.`)
+	loaderCmd.Flags().String("csv-columns-rate", "rate", `CSV column name for the exchange rate.
+The rate is the amount in the row currency for one euro.`)
+	loaderCmd.Flags().String("csv-columns-stock", "amount", `CSV column name for the stock.
+This is usually needed for check allocations and orders.`)
+	loaderCmd.Flags().String("csv-columns-category", "category", "CSV column name for category.")
+	loaderCmd.Flags().String("csv-columns-comment", "comment", "CSV column name for comment.")
+	loaderCmd.Flags().String("csv-columns-payment", "payment", "CSV column name for payment type.")
+	loaderCmd.Flags().String("csv-columns-budget", "budget", "CSV column name for budget ID.")
+	loaderCmd.Flags().String("csv-columns-employee", "employee", "CSV column name for employee.")
+	loaderCmd.Flags().String("csv-columns-provider", "provider", "CSV column name for provider.")
+	loaderCmd.Flags().String("csv-columns-period", "period", "CSV column name for the period.")
+	loaderCmd.Flags().String("csv-columns-template", "template", `CSV column name for the entry template.
+The templates are defined in the templates map of the configuration file.`)
+	loaderCmd.Flags().String("csv-columns-total", "total", `CSV column name for the declared total of the expense claims.
+The value can be set on all the rows of the claim or only on one of them.`)
+	loaderCmd.Flags().String("csv-columns-check", "check", "CSV column name for the check number.")
+	loaderCmd.Flags().String("csv-columns-bank", "account", `CSV column name for the name of the bank holding the account.
+This is used in conjunction with the budget to identify the target account.`)
+
+	loaderCmd.AddCommand(snapshotCmd)
+	loaderCmd.AddCommand(serveCmd)
+	loaderCmd.AddCommand(scaffoldReceiptsCmd)
+	loaderCmd.AddCommand(attachReceiptsCmd)
+
+	loaderCmd.SetVersionTemplate("{{.Version}}\n")
+	return loaderCmd
+}
+
+// readConfig builds the configuration from the flags, environment and configuration file.
+func readConfig() (cfg Config, err error) {
+	if err = applyProfile(viper.GetViper(), viper.GetString("profile")); err != nil {
+		return
+	}
+
+	if err = common.UnmarshalConfig(&cfg); err != nil {
+		err = common.WithExitCode(common.ExitConfig, fmt.Errorf("error unmarshaling the configuration: %s", err))
+		return
+	}
+	if cfg.Defaults.Splits, err = compileSplitRules(cfg.Defaults.Splits); err != nil {
+		err = common.WithExitCode(common.ExitConfig, err)
+	}
+	return
+}
+
+func getPaymentMethodStrings() []string {
+	return []string{
+		lib.PaymentMethodCheckReceived.String(),
+		lib.PaymentMethodCash.String(),
+		lib.PaymentMethodCard.String(),
+		lib.PaymentMethodTransfer.String(),
+		lib.PaymentMethodDirectDebit.String(),
+		lib.PaymentMethodCheckEmitted.String(),
+		lib.PaymentMethodCheckAllocation.String(),
+	}
+}
+
+func getKindStrings() []string {
+	return []string{
+		lib.KindSpend.String(),
+		lib.KindTake.String(),
+		lib.KindAllocation.String(),
+	}
+}
@@ -3,7 +3,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package loader
 
 import (
 	"fmt"
@@ -26,6 +26,14 @@ type CSVColumns struct {
 	Kind     string `mapstructure:"kind"`
 	Period   string `mapstructure:"period"`
 	Bank     string `mapstructure:"bank"`
+	Debit    string `mapstructure:"debit"`
+	Credit   string `mapstructure:"credit"`
+	Balance  string `mapstructure:"balance"`
+	Currency string `mapstructure:"currency"`
+	Rate     string `mapstructure:"rate"`
+	Template string `mapstructure:"template"`
+	Total    string `mapstructure:"total"`
+	Check    string `mapstructure:"check"`
 }
 
 // CSVConfig provides a logical grouping for all CSV-related settings.
@@ -61,20 +69,47 @@ func (c *CSVConfig) GetCommentRune() (rune, error) {
 
 // Defaults holds the default values for optional columns.
 type Defaults struct {
+	Name     string `mapstructure:"name"`
 	Budget   string `mapstructure:"budget"`
 	Bank     string `mapstructure:"bank"`
 	Category string `mapstructure:"category"`
 	Payment  string `mapstructure:"payment"`
 	Kind     string `mapstructure:"kind"`
 	Period   string `mapstructure:"period"`
+	// Templates are the entry templates indexed by their lower case name.
+	Templates map[string]EntryTemplate `mapstructure:"templates"`
+	// Splits are the rules spreading the amount of the matching rows on several categories.
+	Splits map[string]SplitRule `mapstructure:"splits"`
+	// Guests are the people accepted in the employee column when not matching an employee,
+	// like the members paying their fee. They are in the <Lastname> <Firstname> format.
+	Guests []string `mapstructure:"guests"`
 }
 
 // Config holds the application parameters.
 type Config struct {
-	Email    string    `mapstructure:"email"`
-	Password string    `mapstructure:"password"`
-	Receipts string    `mapstructure:"receipts"`
-	CSV      CSVConfig `mapstructure:"csv"`
-	CSVPath  string
-	Defaults Defaults `mapstructure:",squash"`
+	Email             string    `mapstructure:"email"`
+	Password          string    `mapstructure:"password"`
+	Receipts          string    `mapstructure:"receipts"`
+	CSV               CSVConfig `mapstructure:"csv"`
+	CSVPath           string
+	Defaults          Defaults            `mapstructure:",squash"`
+	Pause             PauseConfig         `mapstructure:"pause"`
+	Schedule          string              `mapstructure:"schedule"`
+	Rates             RatesConfig         `mapstructure:"rates"`
+	Yes               bool                `mapstructure:"yes"`
+	Hooks             HooksConfig         `mapstructure:"hook"`
+	Report            string              `mapstructure:"report"`
+	Notify            common.NotifyConfig `mapstructure:"notify"`
+	State             string              `mapstructure:"state"`
+	Review            bool                `mapstructure:"review"`
+	Budgets           BudgetsConfig       `mapstructure:"budgets"`
+	GuessColumns      bool                `flag:"guess-columns"`
+	DryRun            bool                `flag:"dry-run"`
+	ReferenceSnapshot string              `flag:"reference-snapshot"`
+	ErrorsCSV         string              `flag:"errors-csv"`
+	CacheDir          string              `flag:"cache-dir"`
+	ExpenseClaims     bool                `flag:"expense-claims"`
+	DepositSlip       string              `flag:"deposit-slip"`
+	SuggestCategories bool                `flag:"suggest-categories"`
+	Stream            bool                `mapstructure:"stream"`
 }
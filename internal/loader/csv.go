@@ -0,0 +1,747 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// parseCSV builds entries out of the CSV reader..
+// Only the data from the CSV file are loaded, so no receipt will be attached by this function.
+func parseCSV(
+	r common.RowReader,
+	columnsCfg CSVColumns,
+	defaults Defaults,
+	dates common.DateParams,
+	accounts []lib.Account,
+	categories []lib.Category,
+	employees []lib.Employee,
+	providers []lib.Provider,
+	periods []lib.Period,
+	rates rateProvider,
+) (entries []lib.Entry, err error) {
+	parser, rows, err := readRows(
+		r, columnsCfg, defaults, dates, accounts, categories, employees, providers, periods, rates,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectEntries(parser, rows)
+}
+
+// collectEntries returns the entries of the valid rows and the errors of all the rows.
+// The running balances are also checked against the amounts.
+func collectEntries(parser *rowParser, rows []csvRow) (entries []lib.Entry, err error) {
+	var allErrors []error
+
+	for _, row := range rows {
+		if row.err != nil {
+			allErrors = append(allErrors, row.err)
+			continue
+		}
+		entries = append(entries, row.entry)
+	}
+
+	if err := checkRowBalances(parser, rows); err != nil {
+		allErrors = append(allErrors, err)
+	}
+
+	err = errors.Join(allErrors...)
+	return
+}
+
+// checkRowBalances checks the running balances of the valid rows against their amounts.
+func checkRowBalances(parser *rowParser, rows []csvRow) error {
+	var allErrors []error
+	var balances []balancePoint
+
+	for _, row := range rows {
+		balanceStr := getField(row.fields, parser.colMap.Balance)
+		if row.err != nil || balanceStr == "" {
+			continue
+		}
+		balance, err := common.ParseAmount(balanceStr)
+		if err != nil {
+			allErrors = append(allErrors,
+				fmt.Errorf(common.Tr("failed to parse balance on row %d: %s"), row.index, err))
+			continue
+		}
+		balances = append(balances, newBalancePoint(row.index, row.entry, balance))
+	}
+
+	if err := checkBalances(balances); err != nil {
+		allErrors = append(allErrors, err)
+	}
+	return errors.Join(allErrors...)
+}
+
+// csvRow holds a CSV row and the entry built from it.
+type csvRow struct {
+	index  int
+	fields []string
+	entry  lib.Entry
+	err    error
+}
+
+// rowParser converts CSV rows into entries using the happy-compta reference data.
+type rowParser struct {
+	header     []string
+	colMap     columnMap
+	defaults   Defaults
+	dates      common.DateParams
+	accounts   []lib.Account
+	categories map[string]lib.Category
+	employees  map[string]lib.Employee
+	providers  map[string]lib.Provider
+	periods    map[string]lib.Period
+	rates      rateProvider
+	// allocations are the additional allocation lines of the manifest entries, by row index.
+	allocations map[int][]manifestLine
+	// receipts are the receipt paths of the manifest entries, by row index.
+	receipts map[int][]string
+}
+
+// parse builds the entry of a row.
+func (p *rowParser) parse(rowIndex int, fields []string) (lib.Entry, error) {
+	// The split rows get the first category of the rule for the entry to be valid before splitting it.
+	defaults := p.defaults
+	var splitCategories []lib.Category
+	var splitPercents []float64
+	if ruleName, found := p.matchSplitRule(rowIndex, fields); found {
+		var err error
+		splitCategories, splitPercents, err = p.splitCategories(ruleName, p.rowBudget(fields))
+		if err != nil {
+			return lib.Entry{}, fmt.Errorf(common.Tr("failed to process entry on row %d: %s"), rowIndex, err)
+		}
+		defaults.Category = splitCategories[0].Name
+	}
+
+	entry, err := createEntryFromRow(
+		fields, p.colMap, defaults, p.dates, rowIndex, p.accounts, p.categories, p.employees, p.providers, p.periods,
+	)
+	if err != nil {
+		return entry, fmt.Errorf(common.Tr("failed to process entry on row %d: %s"), rowIndex, err)
+	}
+	if len(splitCategories) > 0 {
+		amounts := splitAmount(entry.Allocation[0].Amount, splitPercents)
+		entry.Allocation = make([]lib.AllocationLine, len(splitCategories))
+		for i, category := range splitCategories {
+			entry.Allocation[i] = lib.AllocationLine{CategoryID: category.ID, Amount: amounts[i]}
+		}
+	}
+	if err := p.addManifestLines(rowIndex, &entry); err != nil {
+		return lib.Entry{}, fmt.Errorf(common.Tr("failed to process entry on row %d: %s"), rowIndex, err)
+	}
+
+	currency := getField(fields, p.colMap.Currency)
+	if err := convertCurrency(&entry, currency, getField(fields, p.colMap.Rate), p.rates); err != nil {
+		return entry, fmt.Errorf(common.Tr("failed to convert amount on row %d: %s"), rowIndex, err)
+	}
+	return entry, nil
+}
+
+// readRows reads all the rows of the CSV reader and builds their entries.
+// The errors of the individual rows are stored in the returned rows.
+func readRows(
+	r common.RowReader,
+	columnsCfg CSVColumns,
+	defaults Defaults,
+	dates common.DateParams,
+	accounts []lib.Account,
+	categories []lib.Category,
+	employees []lib.Employee,
+	providers []lib.Provider,
+	periods []lib.Period,
+	rates rateProvider,
+) (parser *rowParser, rows []csvRow, err error) {
+	parser, err = newRowParser(r, columnsCfg, defaults, dates, accounts, categories, employees, providers, periods, rates)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parser, slices.Collect(parser.rows(r)), nil
+}
+
+// newRowParser reads the header of the CSV reader and creates the parser of its rows.
+func newRowParser(
+	r common.RowReader,
+	columnsCfg CSVColumns,
+	defaults Defaults,
+	dates common.DateParams,
+	accounts []lib.Account,
+	categories []lib.Category,
+	employees []lib.Employee,
+	providers []lib.Provider,
+	periods []lib.Period,
+	rates rateProvider,
+) (*rowParser, error) {
+	// Read the header and build the column map
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New(common.Tr("CSV file is empty"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(common.Tr("failed to read CSV header: %s"), err)
+	}
+
+	colMap := buildColumnMap(header, columnsCfg)
+	slog.Debug("CSV header read", "columns", colMap)
+
+	// Create maps for more efficient lookup later
+	return &rowParser{
+		header:     header,
+		colMap:     colMap,
+		defaults:   defaults,
+		dates:      dates,
+		accounts:   accounts,
+		categories: createCategoriesMap(categories),
+		employees:  createEmployeesMap(employees),
+		providers:  createProvidersMap(providers),
+		periods:    createPeriodsMap(periods),
+		rates:      rates,
+	}, nil
+}
+
+// rows reads the remaining rows of the CSV reader one at a time and builds their entries.
+// The errors of the individual rows are stored in the rows.
+func (p *rowParser) rows(r common.RowReader) iter.Seq[csvRow] {
+	return func(yield func(csvRow) bool) {
+		var raggedRows []int
+		defer func() {
+			if len(raggedRows) > 0 {
+				slog.Warn("rows missing trailing fields have been padded with empty values", "rows", raggedRows)
+			}
+		}()
+
+		// Load each row as an entry
+		for rowIndex := 1; ; rowIndex++ {
+			fields, err := r.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				readErr := fmt.Errorf(common.Tr("failed to read row %d: %s"), rowIndex, err)
+				if !yield(csvRow{index: rowIndex, err: readErr}) {
+					return
+				}
+				continue
+			}
+
+			if fields, err = common.TrimRow(fields, len(p.header)); err != nil {
+				if !yield(csvRow{index: rowIndex, err: fmt.Errorf(common.Tr("invalid row %d: %s"), rowIndex, err)}) {
+					return
+				}
+				continue
+			}
+			var padded bool
+			if fields, padded = common.PadRow(fields, len(p.header)); padded {
+				raggedRows = append(raggedRows, rowIndex)
+			}
+
+			row := csvRow{index: rowIndex, fields: fields}
+			row.entry, row.err = p.parse(rowIndex, fields)
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+func createCategoriesMap(slice []lib.Category) map[string]lib.Category {
+	categories := map[string]lib.Category{}
+	for _, category := range slice {
+		categories[fmt.Sprintf("%s|%s", &category.Budget, category.Name)] = category
+	}
+
+	return categories
+}
+
+func stripDiacritics(in string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, _ := transform.String(t, in)
+	return result
+}
+
+// Maps Lastname Firstname to employees.
+func createEmployeesMap(slice []lib.Employee) map[string]lib.Employee {
+	employees := map[string]lib.Employee{}
+	for _, employee := range slice {
+		fullName := strings.ToLower(fmt.Sprintf("%s %s", employee.Lastname, employee.Firstname))
+		employees[stripDiacritics(fullName)] = employee
+	}
+	return employees
+}
+
+// Maps the names to the providers.
+func createProvidersMap(slice []lib.Provider) map[string]lib.Provider {
+	providers := map[string]lib.Provider{}
+	for _, provider := range slice {
+		providers[strings.ToLower(provider.Name)] = provider
+	}
+	return providers
+}
+
+// Maps <Start>-<End> dates to the period.
+// Also map the empty string to the corresponding period since there can only be one.
+func createPeriodsMap(slice []lib.Period) map[string]lib.Period {
+	periods := map[string]lib.Period{}
+	for _, period := range slice {
+		periods[fmt.Sprintf("%s-%s", period.Start.Format(lib.DateLayout), period.End.Format(lib.DateLayout))] = period
+		if period.Status == lib.PeriodStatusCurrent {
+			periods[""] = period
+		}
+	}
+	return periods
+}
+
+// Map column names from config to their index in the CSV file
+type columnMap struct {
+	Name     int
+	Date     int
+	Amount   int
+	Stock    int
+	Category int
+	Comment  int
+	Payment  int
+	Budget   int
+	Employee int
+	Provider int
+	Kind     int
+	Period   int
+	Bank     int
+	Debit    int
+	Credit   int
+	Balance  int
+	Currency int
+	Rate     int
+	Template int
+	Total    int
+	Check    int
+}
+
+// buildColumnMap reads the header and maps the configured column names (e.g., cfg.Columns.Name)
+// to their corresponding zero-based index in the CSV file.
+func buildColumnMap(header []string, columns CSVColumns) columnMap {
+	result := columnMap{
+		Name:     -1,
+		Date:     -1,
+		Amount:   -1,
+		Stock:    -1,
+		Category: -1,
+		Comment:  -1,
+		Payment:  -1,
+		Budget:   -1,
+		Employee: -1,
+		Provider: -1,
+		Kind:     -1,
+		Period:   -1,
+		Bank:     -1,
+		Debit:    -1,
+		Credit:   -1,
+		Balance:  -1,
+		Currency: -1,
+		Rate:     -1,
+		Template: -1,
+		Total:    -1,
+		Check:    -1,
+	}
+
+	// Several fields may share the same column, like the amount and stock ones.
+	colMap := map[string][]*int{}
+	for _, field := range []struct {
+		name   string
+		idxPtr *int
+	}{
+		{columns.Name, &result.Name},
+		{columns.Date, &result.Date},
+		{columns.Amount, &result.Amount},
+		{columns.Stock, &result.Stock},
+		{columns.Category, &result.Category},
+		{columns.Comment, &result.Comment},
+		{columns.Payment, &result.Payment},
+		{columns.Budget, &result.Budget},
+		{columns.Employee, &result.Employee},
+		{columns.Provider, &result.Provider},
+		{columns.Kind, &result.Kind},
+		{columns.Period, &result.Period},
+		{columns.Bank, &result.Bank},
+		{columns.Debit, &result.Debit},
+		{columns.Credit, &result.Credit},
+		{columns.Balance, &result.Balance},
+		{columns.Currency, &result.Currency},
+		{columns.Rate, &result.Rate},
+		{columns.Template, &result.Template},
+		{columns.Total, &result.Total},
+		{columns.Check, &result.Check},
+	} {
+		colMap[field.name] = append(colMap[field.name], field.idxPtr)
+	}
+
+	for i, headerName := range header {
+		if headerName == "" {
+			continue
+		}
+		for _, idxPtr := range colMap[headerName] {
+			*idxPtr = i
+		}
+	}
+	return result
+}
+
+// getField safely retrieves a field value from the row slice.
+func getField(row []string, colIndex int) string {
+	if colIndex >= 0 && colIndex < len(row) {
+		return strings.TrimSpace(row[colIndex])
+	}
+	return ""
+}
+
+// getOptionalField retrieves a field value from the row slice, falling back to a default if the field is empty.
+func getOptionalField(row []string, colIndex int, defaultValue string) string {
+	value := getField(row, colIndex)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// createEntryFromRow processes a single CSV row and maps it to a lib.Entry.
+func createEntryFromRow(
+	row []string,
+	colMap columnMap,
+	defaults Defaults,
+	dates common.DateParams,
+	rowIndex int,
+	accounts []lib.Account,
+	categories map[string]lib.Category,
+	employees map[string]lib.Employee,
+	providers map[string]lib.Provider,
+	periods map[string]lib.Period,
+) (entry lib.Entry, err error) {
+	var allErrors []error // Initialize a slice to collect errors
+
+	// Template: its values replace the defaults for the row.
+	defaults, tmpl, templateErr := defaults.withTemplate(getField(row, colMap.Template))
+	if templateErr != nil {
+		allErrors = append(allErrors, templateErr)
+	}
+
+	// Date
+	dateStr := getField(row, colMap.Date)
+	if dateStr == "" {
+		allErrors = append(allErrors, errors.New(common.Tr("date column is missing or empty")))
+	} else {
+		date, dateErr := dates.Parse(dateStr)
+		if dateErr != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("failed to parse date: %w"), dateErr))
+		} else {
+			entry.Date = date
+		}
+	}
+
+	templateValues := templateData{Date: entry.Date, Amount: getField(row, colMap.Amount)}
+
+	// Name
+	entry.Name = getField(row, colMap.Name)
+	if entry.Name == "" && tmpl.Name != "" {
+		var nameErr error
+		if entry.Name, nameErr = renderTemplate(tmpl.Name, templateValues); nameErr != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("failed to build the name: %s"), nameErr))
+		}
+	}
+	if entry.Name == "" {
+		entry.Name = defaults.Name
+	}
+	entry.CheckNumber = getField(row, colMap.Check)
+
+	// Amount. May not be needed for checks allocations
+	// Without amount, the debit or credit columns also define the kind of entry.
+	amountStr := getField(row, colMap.Amount)
+	columnsKind := ""
+	if amountStr == "" {
+		debitStr := getField(row, colMap.Debit)
+		creditStr := getField(row, colMap.Credit)
+		if debitStr != "" && creditStr != "" {
+			allErrors = append(allErrors, fmt.Errorf(
+				common.Tr("has both debit ('%s') and credit ('%s') specified"), debitStr, creditStr,
+			))
+		} else if debitStr != "" {
+			amountStr = debitStr
+			columnsKind = lib.KindSpend.String()
+		} else if creditStr != "" {
+			amountStr = creditStr
+			columnsKind = lib.KindTake.String()
+		}
+	}
+	amount := 0.0
+	if amountStr != "" {
+		var amountErr error
+		amount, amountErr = common.ParseAmount(amountStr)
+		if amountErr != nil {
+			allErrors = append(allErrors,
+				fmt.Errorf(common.Tr("failed to parse amount '%s': %s"), amountStr, amountErr))
+		}
+		// Debit columns often hold negative values
+		if columnsKind != "" {
+			amount = math.Abs(amount)
+		}
+	}
+
+	// Comment
+	entry.Comment = getField(row, colMap.Comment)
+	if entry.Comment == "" && tmpl.Comment != "" {
+		var commentErr error
+		if entry.Comment, commentErr = renderTemplate(tmpl.Comment, templateValues); commentErr != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("failed to build the comment: %s"), commentErr))
+		}
+	}
+
+	// Kind
+	kind := getField(row, colMap.Kind)
+	if kind == "" {
+		kind = columnsKind
+	}
+	if kind == "" {
+		kind = defaults.Kind
+	}
+	entry.Kind = lib.NewKind(resolveAlias(kindAliases, kind))
+	if entry.Kind == lib.KindUndefined {
+		allErrors = append(allErrors, fmt.Errorf(
+			common.Tr("invalid entry type '%s', accepted values are %s, %s and %s"),
+			kind, lib.KindSpend, lib.KindTake, lib.KindAllocation,
+		))
+	}
+
+	// Budget, the accepted values are FON, ASC or AEP.
+	budgetStr := getOptionalField(row, colMap.Budget, defaults.Budget)
+	if budgetStr != "" {
+		entry.Budget = lib.NewBudgetFromString(resolveAlias(budgetAliases, budgetStr))
+	}
+	if entry.Budget == lib.BudgetUndefined {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid budget '%s'"), budgetStr))
+	}
+
+	// PaymentMethod
+	paymentMethodStr := getOptionalField(row, colMap.Payment, defaults.Payment)
+	if paymentMethodStr != "" {
+		paymentMethod := lib.NewPaymentMethodFromString(resolveAlias(paymentAliases, paymentMethodStr))
+		if paymentMethod != lib.PaymentMethodUndefined {
+			entry.PaymentMethod = paymentMethod
+		} else {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("invalid payment method '%s'"), paymentMethodStr))
+		}
+	} else {
+		allErrors = append(allErrors, errors.New(common.Tr("missing payment method")))
+	}
+
+	// Category
+	categoryName := getOptionalField(row, colMap.Category, defaults.Category)
+	var category lib.Category
+	categoryOK := false
+
+	// Only attempt category lookup if budget is valid (to avoid logging redundant errors)
+	if entry.Budget != lib.BudgetUndefined {
+		categoryKey := fmt.Sprintf("%s|%s", entry.Budget, categoryName)
+		category, categoryOK = categories[categoryKey]
+
+		if !categoryOK {
+			allErrors = append(allErrors, fmt.Errorf(
+				common.Tr("invalid category '%s' name / '%s' budget combination"),
+				categoryName, entry.Budget,
+			))
+		}
+	}
+
+	// Allocations of categories with stock, like check allocations, only need the stock.
+	// All the other entries need an amount and the stock if their category has one.
+	stock := 0
+	hasStock := categoryOK && bool(category.Stock)
+	if hasStock {
+		var stockErr error
+		stock, stockErr = parseStock(getField(row, colMap.Stock), category.Name)
+		if stockErr != nil {
+			allErrors = append(allErrors, stockErr)
+		}
+	}
+	if hasStock && entry.Kind == lib.KindAllocation {
+		// The stock column defaults to the amount one: the value is not an amount then.
+		if colMap.Stock == colMap.Amount {
+			amount = 0
+		}
+	} else if amountStr == "" {
+		allErrors = append(allErrors, fmt.Errorf(common.Tr("missing required amount value for row %d"), rowIndex))
+	}
+
+	entry.Allocation = []lib.AllocationLine{
+		{
+			CategoryID: category.ID,
+			Amount:     amount,
+			Stock:      stock,
+		},
+	}
+
+	// Party: the employee and provider fields are mutually exclusive and optional.
+	employeeStr := getField(row, colMap.Employee)
+	providerStr := getField(row, colMap.Provider)
+	if employeeStr == "" && providerStr == "" {
+		employeeStr, providerStr = tmpl.Employee, tmpl.Provider
+	}
+	if employeeStr != "" && providerStr != "" {
+		allErrors = append(allErrors, fmt.Errorf(
+			common.Tr("has both employee ('%s') and provider ('%s') specified"), employeeStr, providerStr,
+		))
+	} else {
+		if employeeStr != "" {
+			employee, ok := employees[stripDiacritics(strings.ToLower(employeeStr))]
+			if guest := findGuest(defaults.Guests, employeeStr); !ok && guest != nil {
+				entry.Guest = guest
+			} else if !ok {
+				allErrors = append(allErrors, fmt.Errorf(
+					common.Tr("unknown employee '%s', the value needs to be in the <Lastname> <Firstname> format"),
+					employeeStr,
+				))
+			} else {
+				entry.Party = &employee
+			}
+		}
+
+		if providerStr != "" {
+			provider, ok := providers[strings.ToLower(providerStr)]
+			if !ok {
+				allErrors = append(allErrors, fmt.Errorf(
+					common.Tr("unknown provider '%s', the value needs to match the name of an existing provider"),
+					providerStr,
+				))
+			} else {
+				entry.Party = &provider
+			}
+		}
+	}
+
+	// Look for the period
+	periodStr := getField(row, colMap.Period)
+	if periodStr == "" {
+		periodStr = defaults.Period
+	}
+	period, ok := periods[periodStr]
+	if !ok {
+		allErrors = append(allErrors, fmt.Errorf(
+			common.Tr("couldn't find the '%s' period. Is there a current one defined?"), periodStr,
+		))
+	} else {
+		entry.Period = period.ID
+	}
+
+	// Look for the account
+	bank := getOptionalField(row, colMap.Bank, defaults.Bank)
+	// Only try to get account if the budget was successfully determined
+	if entry.Budget != lib.BudgetUndefined {
+		account, accErr := getAccountFromBankBudget(accounts, bank, entry.Budget)
+		if accErr != nil {
+			allErrors = append(allErrors, fmt.Errorf(common.Tr("failed to find account: %w"), accErr))
+		} else {
+			entry.Account = account
+		}
+	}
+
+	// Check for collected errors
+	if len(allErrors) > 0 {
+		// Return an empty entry and the combined errors
+		return lib.Entry{}, errors.Join(allErrors...)
+	}
+
+	return entry, nil
+}
+
+// parseStock reads the stock value of a category requiring it.
+func parseStock(value string, categoryName string) (int, error) {
+	if value == "" {
+		return 0, fmt.Errorf(common.Tr("no stock defined but %s category needs it"), categoryName)
+	}
+	stock, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf(common.Tr("failed to parse '%s' stock as an integer"), value)
+	}
+	if stock <= 0 {
+		return 0, fmt.Errorf(common.Tr("stock needs to be a positive integer, got %d"), stock)
+	}
+	return stock, nil
+}
+
+func getAccountFromBankBudget(
+	accounts []lib.Account, bank string, budget lib.Budget,
+) (result lib.Account, err error) {
+	banks := []string{}
+	for _, account := range accounts {
+		if !slices.Contains(banks, account.Bank) {
+			banks = append(banks, account.Bank)
+		}
+	}
+	if bank == "" {
+		if len(banks) > 1 {
+			err = errors.New(
+				common.Tr("more than one bank found, you have to provide the name of the bank holding the account"),
+			)
+			return
+		}
+		// Using the only bank that we found by default
+		bank = banks[0]
+	}
+
+	matchingAllBudgets := []lib.Account{}
+	matching := []lib.Account{}
+	for _, account := range accounts {
+		if strings.EqualFold(account.Bank, bank) {
+			switch account.Budget {
+			case budget:
+				matching = append(matching, account)
+			case lib.BudgetUndefined:
+				// Undefined budget on an account means both ASC and FON
+				matchingAllBudgets = append(matchingAllBudgets, account)
+			}
+		}
+	}
+
+	// We may have found more than one account.
+	// The common situation would be: 1 with the expected budget and 1 with both.
+	// I don't think anything on happy-compta prevents from having more than one account for the same budget in the
+	// same bank, but this is rather unlikely to happen.
+	if len(matching) == 1 {
+		result = matching[0]
+		return
+	} else if len(matching) > 1 {
+		err = fmt.Errorf(
+			common.Tr("more than one account found for the %s budget at %s bank. This is not supported yet"),
+			budget.String(), bank,
+		)
+		return
+	} else if len(matchingAllBudgets) == 1 {
+		result = matchingAllBudgets[0]
+		return
+	} else if len(matchingAllBudgets) > 1 {
+		err = fmt.Errorf(
+			common.Tr("more than one account found for the both budgets at %s bank. This is not supported yet"), bank,
+		)
+		return
+	}
+
+	err = fmt.Errorf(common.Tr("no account found matching the %s budget at %s bank"), budget.String(), bank)
+	return
+}
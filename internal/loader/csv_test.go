@@ -3,7 +3,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package loader
 
 import (
 	"encoding/csv"
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cbosdo/happycompta-tools/internal/common"
 	"github.com/cbosdo/happycompta-tools/lib"
 )
 
@@ -50,6 +51,14 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 		{
@@ -70,6 +79,14 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 		{
@@ -90,6 +107,14 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 		{
@@ -110,6 +135,14 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 		{
@@ -130,6 +163,42 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
+			},
+		},
+		{
+			name:   "Amount and stock sharing a column",
+			header: []string{"Tx_Amount"},
+			config: CSVColumns{Amount: "Tx_Amount", Stock: "Tx_Amount"},
+			wantMap: columnMap{
+				Name:     -1,
+				Date:     -1,
+				Amount:   0,
+				Stock:    0,
+				Category: -1,
+				Comment:  -1,
+				Payment:  -1,
+				Budget:   -1,
+				Employee: -1,
+				Provider: -1,
+				Kind:     -1,
+				Period:   -1,
+				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 		{
@@ -150,6 +219,14 @@ func TestBuildColumnMap(t *testing.T) {
 				Period:   -1,
 				Stock:    -1,
 				Bank:     -1,
+				Debit:    -1,
+				Credit:   -1,
+				Balance:  -1,
+				Currency: -1,
+				Rate:     -1,
+				Template: -1,
+				Total:    -1,
+				Check:    -1,
 			},
 		},
 	}
@@ -279,7 +356,7 @@ func TestCreateEntryFromRow_Success(t *testing.T) {
 		"First National Bank", // BANK
 	}
 
-	entry, err := createEntryFromRow(row, colMap, defaults, 1, accounts,
+	entry, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
 		categoriesMap, employeesMap, providersMap, periodsMap)
 
 	if err != nil {
@@ -312,7 +389,7 @@ func TestCreateEntryFromRow_PartyMutualExclusion(t *testing.T) {
 		"TechCorp Solutions", "card", "depenses", "", "", "", "First National Bank",
 	}
 
-	_, err := createEntryFromRow(row, colMap, defaults, 1, accounts,
+	_, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
 		categoriesMap, employeesMap, providersMap, periodsMap)
 
 	if err == nil || !strings.Contains(err.Error(), "has both employee") {
@@ -341,7 +418,7 @@ func TestCreateEntryFromRow_StockRequired(t *testing.T) {
 		"check allocation", "attributions", "", "", "", "Global Reserve",
 	}
 
-	_, err := createEntryFromRow(row, colMap, defaults, 1, accounts,
+	_, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
 		categoriesMap, employeesMap, providersMap, periodsMap)
 
 	if err == nil || !strings.Contains(err.Error(), "no stock defined") {
@@ -366,11 +443,11 @@ func TestCreateEntryFromRow_DateParsingFailure(t *testing.T) {
 
 	// Invalid Date format
 	row := []string{
-		"2025-01-01", "Test", "10", "Office Supplies", "FON", "", "", "card",
+		"01.01.2025", "Test", "10", "Office Supplies", "FON", "", "", "card",
 		"depenses", "", "", "", "First National Bank",
 	}
 
-	_, err := createEntryFromRow(row, colMap, defaults, 1, accounts,
+	_, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
 		categoriesMap, employeesMap, providersMap, periodsMap)
 
 	if err == nil || !strings.Contains(err.Error(), "failed to parse date") {
@@ -394,11 +471,11 @@ func TestCreateEntryFromRow_MultipleErrors(t *testing.T) {
 	periodsMap := createPeriodsMap(getMockPeriods())
 
 	// Row with three errors:
-	// 1. Invalid Date: "2025-01-01" (needs "01/01/2025" for `lib.DateLayout`)
+	// 1. Invalid Date: "01.01.2025" (needs a layout like "01/01/2025" or "2025-01-01")
 	// 2. Both Employee and Provider set (mutual exclusion violation).
 	// 3. Invalid Budget: "INVALID_BUDGET"
 	row := []string{
-		"2025-01-01",         // DATE (Error 1)
+		"01.01.2025",         // DATE (Error 1)
 		"Test",               // NAME
 		"10",                 // AMOUNT
 		"Office Supplies",    // CATEGORY
@@ -411,7 +488,7 @@ func TestCreateEntryFromRow_MultipleErrors(t *testing.T) {
 		"First National Bank", // BANK
 	}
 
-	_, err := createEntryFromRow(row, colMap, defaults, 1, accounts,
+	_, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
 		categoriesMap, employeesMap, providersMap, periodsMap)
 
 	if err == nil {
@@ -421,7 +498,7 @@ func TestCreateEntryFromRow_MultipleErrors(t *testing.T) {
 	errorString := err.Error()
 
 	// Check for the error from Date parsing
-	if !strings.Contains(errorString, "failed to parse date '2025-01-01'") {
+	if !strings.Contains(errorString, "failed to parse date: invalid date '01.01.2025'") {
 		t.Errorf("Expected date parsing error not found in multi-error: %s", errorString)
 	}
 
@@ -580,8 +657,8 @@ DATE,NAME,AMOUNT,CATEGORY,BUDGET,PROVIDER,BANK,KIND
 	expectedName1 := "Office Supplies Tx"
 	expectedAmount2 := 20.00
 
-	entries, err := parseCSV(r, columnsCfg, defaults, accounts,
-		categories, employees, providers, periods)
+	entries, err := parseCSV(r, columnsCfg, defaults, common.DateParams{}, accounts,
+		categories, employees, providers, periods, nil)
 
 	if err != nil {
 		t.Fatalf("parseCSV failed unexpectedly: %v", err)
@@ -637,10 +714,219 @@ INVALID DATE,Error Date,,,,,
 		Date: "DATE", Name: "NAME", Amount: "AMOUNT", Category: "CATEGORY", Budget: "BUDGET", Provider: "PROVIDER", Bank: "BANK", Kind: "KIND",
 	}
 
-	_, err := parseCSV(r, columnsCfg, defaults, accounts,
-		categories, employees, providers, periods)
+	_, err := parseCSV(r, columnsCfg, defaults, common.DateParams{}, accounts,
+		categories, employees, providers, periods, nil)
 
 	if err == nil || !strings.Contains(err.Error(), "failed to process entry on row 2") {
 		t.Fatalf("Expected processing error on row 2, but got: %v", err)
 	}
 }
+
+func TestParseCSV_RaggedRows(t *testing.T) {
+	accounts := []lib.Account{
+		{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+	}
+	defaults := getBaseDefaults()
+
+	// The second row misses the trailing BANK and COMMENT fields
+	csvData := `DATE,NAME,AMOUNT,BANK,COMMENT
+01/01/2025,Full row,100,First National Bank,Some comment
+02/01/2025,Short row,20
+`
+	r := csv.NewReader(strings.NewReader(csvData))
+	r.FieldsPerRecord = -1
+
+	columnsCfg := CSVColumns{Date: "DATE", Name: "NAME", Amount: "AMOUNT", Bank: "BANK", Comment: "COMMENT"}
+
+	entries, err := parseCSV(r, columnsCfg, defaults, common.DateParams{}, accounts,
+		getMockCategories(), nil, nil, getMockPeriods(), nil)
+	if err != nil {
+		t.Fatalf("parseCSV failed unexpectedly: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[1].Comment != "" || entries[1].Account.ID != 10 {
+		t.Errorf("Padded entry mismatch. Got: %+v", entries[1])
+	}
+}
+
+func TestParseCSV_LongRows(t *testing.T) {
+	accounts := []lib.Account{
+		{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+	}
+
+	// The first row has an empty trailing field, the second one an extra value
+	csvData := `DATE,NAME,AMOUNT,BANK
+01/01/2025,Trailing separator,100,First National Bank,
+02/01/2025,Shifted row,20,First National Bank,Extra
+`
+	r := csv.NewReader(strings.NewReader(csvData))
+	r.FieldsPerRecord = -1
+
+	columnsCfg := CSVColumns{Date: "DATE", Name: "NAME", Amount: "AMOUNT", Bank: "BANK"}
+
+	_, err := parseCSV(r, columnsCfg, getBaseDefaults(), common.DateParams{}, accounts,
+		getMockCategories(), nil, nil, getMockPeriods(), nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid row 2: 5 fields, more than the 4 columns of the header") {
+		t.Fatalf("Expected a long row error on row 2, but got: %v", err)
+	}
+	if strings.Contains(err.Error(), "row 1") {
+		t.Errorf("Expected the trailing empty field of row 1 to be ignored, got: %v", err)
+	}
+}
+
+func TestCreateEntryFromRow_DebitCredit(t *testing.T) {
+	colMap := buildColumnMap(
+		[]string{"DATE", "NAME", "DEBIT", "CREDIT", "KIND"},
+		CSVColumns{Date: "DATE", Name: "NAME", Debit: "DEBIT", Credit: "CREDIT", Kind: "KIND"},
+	)
+	accounts := []lib.Account{
+		{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+	}
+	defaults := getBaseDefaults()
+	defaults.Kind = ""
+	categoriesMap := createCategoriesMap(getMockCategories())
+	periodsMap := createPeriodsMap(getMockPeriods())
+
+	tests := []struct {
+		name       string
+		row        []string
+		wantKind   lib.Kind
+		wantAmount float64
+		wantErr    string
+	}{
+		{
+			name:       "Debit is a spending",
+			row:        []string{"01/01/2025", "Paper", "-12,50", "", ""},
+			wantKind:   lib.KindSpend,
+			wantAmount: 12.50,
+		},
+		{
+			name:       "Credit is an income",
+			row:        []string{"01/01/2025", "Grant", "", "1 000,00", ""},
+			wantKind:   lib.KindTake,
+			wantAmount: 1000,
+		},
+		{
+			name:       "Kind column wins",
+			row:        []string{"01/01/2025", "Vouchers", "30", "", "attributions"},
+			wantKind:   lib.KindAllocation,
+			wantAmount: 30,
+		},
+		{
+			name:    "Both debit and credit",
+			row:     []string{"01/01/2025", "Both", "10", "20", ""},
+			wantErr: "has both debit ('10') and credit ('20') specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := createEntryFromRow(tt.row, colMap, defaults, common.DateParams{}, 1, accounts,
+				categoriesMap, nil, nil, periodsMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createEntryFromRow failed unexpectedly: %v", err)
+			}
+			if entry.Kind != tt.wantKind {
+				t.Errorf("Kind mismatch. Got: %s, Want: %s", entry.Kind, tt.wantKind)
+			}
+			if entry.Allocation[0].Amount != tt.wantAmount {
+				t.Errorf("Amount mismatch. Got: %.2f, Want: %.2f", entry.Allocation[0].Amount, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestCreateEntryFromRow_AllocationOnly(t *testing.T) {
+	accounts := []lib.Account{
+		{ID: 20, Bank: "Global Reserve", Budget: lib.BudgetASC, Abbrev: "GR"},
+	}
+	defaults := getBaseDefaults()
+	defaults.Budget = "ASC"
+	defaults.Payment = "check allocation"
+	categoriesMap := createCategoriesMap(getMockCategories())
+	periodsMap := createPeriodsMap(getMockPeriods())
+
+	separateColumns := buildColumnMap(
+		[]string{"DATE", "NAME", "AMOUNT", "STOCK", "CATEGORY", "KIND"},
+		CSVColumns{Date: "DATE", Name: "NAME", Amount: "AMOUNT", Stock: "STOCK", Category: "CATEGORY", Kind: "KIND"},
+	)
+	sharedColumn := buildColumnMap(
+		[]string{"DATE", "NAME", "AMOUNT", "CATEGORY", "KIND"},
+		CSVColumns{Date: "DATE", Name: "NAME", Amount: "AMOUNT", Stock: "AMOUNT", Category: "CATEGORY", Kind: "KIND"},
+	)
+
+	tests := []struct {
+		name       string
+		colMap     columnMap
+		row        []string
+		wantAmount float64
+		wantStock  int
+		wantErr    string
+	}{
+		{
+			name:      "Allocation with stock only",
+			colMap:    separateColumns,
+			row:       []string{"01/01/2025", "Vouchers", "", "5", "Check Alloc", "attributions"},
+			wantStock: 5,
+		},
+		{
+			name:       "Allocation with stock and amount",
+			colMap:     separateColumns,
+			row:        []string{"01/01/2025", "Vouchers", "50", "5", "Check Alloc", "attributions"},
+			wantAmount: 50,
+			wantStock:  5,
+		},
+		{
+			name:      "Allocation with stock in the amount column",
+			colMap:    sharedColumn,
+			row:       []string{"01/01/2025", "Vouchers", "3", "Check Alloc", "attributions"},
+			wantStock: 3,
+		},
+		{
+			name:    "Spending of a stock category needs an amount",
+			colMap:  separateColumns,
+			row:     []string{"01/01/2025", "Vouchers", "", "5", "Check Alloc", "depenses"},
+			wantErr: "missing required amount value for row 1",
+		},
+		{
+			name:    "Allocation without stock category needs an amount",
+			colMap:  separateColumns,
+			row:     []string{"01/01/2025", "Gift", "", "", "Gifts", "attributions"},
+			wantErr: "missing required amount value for row 1",
+		},
+		{
+			name:    "Negative stock",
+			colMap:  separateColumns,
+			row:     []string{"01/01/2025", "Vouchers", "", "-2", "Check Alloc", "attributions"},
+			wantErr: "stock needs to be a positive integer, got -2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := createEntryFromRow(tt.row, tt.colMap, defaults, common.DateParams{}, 1, accounts,
+				categoriesMap, nil, nil, periodsMap)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createEntryFromRow failed unexpectedly: %v", err)
+			}
+			line := entry.Allocation[0]
+			if line.Amount != tt.wantAmount || line.Stock != tt.wantStock {
+				t.Errorf("Allocation mismatch. Got: %+v, Want amount %.2f and stock %d", line, tt.wantAmount, tt.wantStock)
+			}
+		})
+	}
+}
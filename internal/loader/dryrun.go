@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// printDryRun writes the entries that would be added to happy-compta.
+// The numbers are the previewed accounting piece numbers of the entries, if known.
+func printDryRun(w io.Writer, entries []lib.Entry, numbers []string, categories []lib.Category) error {
+	categoryNames := make(map[int]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "#\tNUMBER\tDATE\tKIND\tNAME\tAMOUNT\tCATEGORIES\tPARTY\tACCOUNT\tRECEIPTS"); err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		var amount float64
+		names := make([]string, 0, len(entry.Allocation))
+		for _, line := range entry.Allocation {
+			amount += line.Amount
+			name := categoryNames[line.CategoryID]
+			if line.Stock != 0 {
+				name = fmt.Sprintf("%s (x%d)", name, line.Stock)
+			}
+			names = append(names, name)
+		}
+
+		number := "?"
+		if i < len(numbers) {
+			number = numbers[i]
+		}
+		_, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\t%s (%s)\t%d\n",
+			i+1, number, entry.Date.Format(lib.DateLayout), entry.Kind, entry.Name, amount,
+			strings.Join(names, ", "), partyName(entry.Party), entry.Account.Bank, entry.Account.Budget,
+			len(entry.Receipts),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "Dry run: %d entries would be added\n", len(entries)); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// partyName returns a human readable name of the entry party.
+func partyName(party lib.Party) string {
+	switch p := party.(type) {
+	case *lib.Employee:
+		return p.Firstname + " " + p.Lastname
+	case *lib.Provider:
+		return p.Name
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	entries := []lib.Entry{
+		{
+			Kind:       lib.KindSpend,
+			Date:       baseTime,
+			Name:       "Paper",
+			Allocation: []lib.AllocationLine{{CategoryID: 100, Amount: 12.5}},
+			Party:      &lib.Provider{ID: "p1", Name: "ACME"},
+			Account:    lib.Account{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON},
+			Receipts:   []string{"paper.pdf"},
+		},
+		{
+			Kind:       lib.KindAllocation,
+			Date:       baseTime,
+			Name:       "Checks",
+			Allocation: []lib.AllocationLine{{CategoryID: 201, Amount: 100, Stock: 10}},
+			Party:      &lib.Employee{ID: "e1", Firstname: "Jane", Lastname: "Doe"},
+			Account:    lib.Account{ID: 2, Bank: "Bank B", Budget: lib.BudgetASC},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := printDryRun(&out, entries, []string{"FON000012"}, getMockCategories()); err != nil {
+		t.Fatalf("printDryRun failed: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"FON000012", "01/01/2025", "depenses", "Paper", "12.50", "Office Supplies", "ACME", "Bank A (FON)",
+		"attributions", "Check Alloc (x10)", "Jane Doe", "Bank B (ASC)",
+		"Dry run: 2 entries would be added",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Dry run output mismatch. Got: %q, Want it to contain: %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// uploadedReceipt is a receipt attached to an entry added by a previous run.
+type uploadedReceipt struct {
+	File     string    `json:"file"`
+	Entry    string    `json:"entry"`
+	Uploaded time.Time `json:"uploaded"`
+}
+
+// receiptsState is the content of the state file, indexing the uploaded receipts by their SHA-256 hash.
+type receiptsState struct {
+	Receipts map[string]uploadedReceipt `json:"receipts"`
+}
+
+// loadReceiptsState reads the state file. A missing file gives an empty state.
+func loadReceiptsState(path string) (state receiptsState, err error) {
+	state.Receipts = map[string]uploadedReceipt{}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read the state file %s: %s", path, err)
+	}
+	if err = json.Unmarshal(content, &state); err != nil {
+		return state, fmt.Errorf("invalid state file %s: %s", path, err)
+	}
+	if state.Receipts == nil {
+		state.Receipts = map[string]uploadedReceipt{}
+	}
+	return state, nil
+}
+
+func (s receiptsState) save(path string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the state: %s", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write the state file %s: %s", path, err)
+	}
+	return nil
+}
+
+// record adds the receipts of the uploaded entries to the state.
+func (s receiptsState) record(entries []lib.Entry, hashes map[string]string, now time.Time) {
+	for _, entry := range entries {
+		for _, receipt := range entry.Receipts {
+			if hash, found := hashes[receipt]; found {
+				s.Receipts[hash] = uploadedReceipt{File: receipt, Entry: entry.Name, Uploaded: now}
+			}
+		}
+	}
+}
+
+// hashReceipts computes the SHA-256 hash of each receipt file of the entries, indexed by path.
+func hashReceipts(entries []lib.Entry) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, entry := range entries {
+		if err := addReceiptHashes(hashes, entry); err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+// addReceiptHashes computes the hashes of the receipts of the entry missing in the map.
+func addReceiptHashes(hashes map[string]string, entry lib.Entry) error {
+	for _, receipt := range entry.Receipts {
+		if _, found := hashes[receipt]; found {
+			continue
+		}
+		hash, err := hashFile(receipt)
+		if err != nil {
+			return err
+		}
+		hashes[receipt] = hash
+	}
+	return nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open the receipt %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("failed to read the receipt %s: %s", path, err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// findDuplicateReceipts lists the receipts with the same content as other files attached to other entries
+// or as receipts uploaded by a previous run.
+// A file shared on purpose by several entries, like the ones of an employee folder, is not a duplicate.
+func findDuplicateReceipts(entries []lib.Entry, hashes map[string]string, state receiptsState) []error {
+	var duplicates []error
+	finder := newDuplicateFinder(state)
+	for i, entry := range entries {
+		duplicates = append(duplicates, finder.check(i, entry, hashes)...)
+	}
+	return duplicates
+}
+
+// duplicateFinder finds the duplicate receipts of the entries one at a time.
+// Only the first file of each content is kept, not the entries.
+type duplicateFinder struct {
+	state    receiptsState
+	reported map[string]bool
+	// firstFiles are the first file of each content and firstEntries the entries attaching it.
+	firstFiles   map[string]string
+	firstEntries map[string][]int
+}
+
+func newDuplicateFinder(state receiptsState) *duplicateFinder {
+	return &duplicateFinder{
+		state:        state,
+		reported:     map[string]bool{},
+		firstFiles:   map[string]string{},
+		firstEntries: map[string][]int{},
+	}
+}
+
+// check returns the duplicates of the receipts of the entry at the given index.
+// The entries are expected to be checked in the order of their indexes.
+func (f *duplicateFinder) check(i int, entry lib.Entry, hashes map[string]string) []error {
+	var duplicates []error
+	for _, receipt := range entry.Receipts {
+		hash := hashes[receipt]
+		if previous, found := f.state.Receipts[hash]; found && !f.reported[receipt] {
+			f.reported[receipt] = true
+			duplicates = append(duplicates, fmt.Errorf(
+				"the receipt %s of entry %d (%s) has already been uploaded as %s for %s on %s",
+				receipt, i+1, entry.Name, previous.File, previous.Entry, previous.Uploaded.Format(time.DateTime),
+			))
+		}
+
+		first, found := f.firstFiles[hash]
+		if !found {
+			f.firstFiles[hash] = receipt
+			f.firstEntries[hash] = []int{i}
+			continue
+		}
+		// The current entry is the last one of the list if it already attaches the first file.
+		attaching := f.firstEntries[hash]
+		if first == receipt {
+			if attaching[len(attaching)-1] != i {
+				f.firstEntries[hash] = append(attaching, i)
+			}
+			continue
+		}
+		if attaching[len(attaching)-1] == i {
+			continue
+		}
+		numbers := make([]string, len(attaching))
+		for j, index := range attaching {
+			numbers[j] = fmt.Sprintf("%d", index+1)
+		}
+		duplicates = append(duplicates, fmt.Errorf(
+			"the receipt %s of entry %d (%s) has the same content as %s attached to entry %s",
+			receipt, i+1, entry.Name, first, strings.Join(numbers, ", "),
+		))
+	}
+	return duplicates
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func writeReceipt(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write the receipt: %v", err)
+	}
+	return path
+}
+
+func TestFindDuplicateReceipts(t *testing.T) {
+	dir := t.TempDir()
+	invoice := writeReceipt(t, dir, "invoice.pdf", "invoice")
+	copied := writeReceipt(t, dir, "invoice-copy.pdf", "invoice")
+	ticket := writeReceipt(t, dir, "ticket.pdf", "ticket")
+	old := writeReceipt(t, dir, "old.pdf", "old")
+
+	tests := []struct {
+		name     string
+		entries  []lib.Entry
+		messages []string
+	}{
+		{
+			name: "SharedFile",
+			entries: []lib.Entry{
+				{Name: "Taxi", Receipts: []string{invoice}},
+				{Name: "Train", Receipts: []string{invoice, ticket}},
+			},
+		},
+		{
+			name: "SameContent",
+			entries: []lib.Entry{
+				{Name: "Taxi", Receipts: []string{invoice}},
+				{Name: "Train", Receipts: []string{ticket}},
+				{Name: "Hotel", Receipts: []string{copied}},
+			},
+			messages: []string{"the receipt " + copied + " of entry 3 (Hotel) has the same content as " +
+				invoice + " attached to entry 1"},
+		},
+		{
+			name:    "SameEntry",
+			entries: []lib.Entry{{Name: "Taxi", Receipts: []string{invoice, copied}}},
+		},
+		{
+			name: "Uploaded",
+			entries: []lib.Entry{
+				{Name: "Taxi", Receipts: []string{old}},
+				{Name: "Train", Receipts: []string{old}},
+			},
+			messages: []string{"the receipt " + old + " of entry 1 (Taxi) has already been uploaded as " +
+				"previous/old.pdf for Lunch on 2025-01-02 10:00:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashes, err := hashReceipts(tt.entries)
+			if err != nil {
+				t.Fatalf("hashReceipts failed: %v", err)
+			}
+			state := receiptsState{Receipts: map[string]uploadedReceipt{}}
+			if hash, err := hashFile(old); err == nil {
+				state.Receipts[hash] = uploadedReceipt{
+					File: "previous/old.pdf", Entry: "Lunch", Uploaded: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+				}
+			}
+
+			duplicates := findDuplicateReceipts(tt.entries, hashes, state)
+			if len(duplicates) != len(tt.messages) {
+				t.Fatalf("Duplicates count mismatch. Got: %v, Want: %v", duplicates, tt.messages)
+			}
+			for i, message := range tt.messages {
+				if duplicates[i].Error() != message {
+					t.Errorf("Duplicate mismatch.\nGot:  %s\nWant: %s", duplicates[i], message)
+				}
+			}
+		})
+	}
+}
+
+func TestReceiptsState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	receipt := writeReceipt(t, dir, "invoice.pdf", "invoice")
+
+	state, err := loadReceiptsState(path)
+	if err != nil {
+		t.Fatalf("Expected an empty state for a missing file, got: %v", err)
+	}
+
+	entries := []lib.Entry{{Name: "Taxi", Receipts: []string{receipt}}}
+	hashes, err := hashReceipts(entries)
+	if err != nil {
+		t.Fatalf("hashReceipts failed: %v", err)
+	}
+	state.record(entries, hashes, time.Now())
+	if err := state.save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := loadReceiptsState(path)
+	if err != nil {
+		t.Fatalf("loadReceiptsState failed: %v", err)
+	}
+	if loaded.Receipts[hashes[receipt]].Entry != "Taxi" {
+		t.Errorf("Unexpected state: %+v", loaded)
+	}
+	if duplicates := findDuplicateReceipts(entries, hashes, loaded); len(duplicates) != 1 ||
+		!strings.Contains(duplicates[0].Error(), "already been uploaded") {
+		t.Errorf("Expected the receipt to be reported as uploaded, got: %v", duplicates)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadReceiptsState(path); err == nil {
+		t.Error("Expected an error for an invalid state file")
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// importErrorColumn is the name of the column holding the row errors in the errors CSV file.
+const importErrorColumn = "import_error"
+
+// getErrorsCSVPath returns the path of the errors CSV file for the given input file.
+// Without explicit path, the file is written next to the input one with an -errors suffix.
+// The errors of XLSX files are written as CSV.
+func getErrorsCSVPath(path string, csvPath string) string {
+	if path != "" {
+		return path
+	}
+	ext := filepath.Ext(csvPath)
+	if ext == "" || strings.EqualFold(ext, ".xlsx") {
+		ext = ".csv"
+	}
+	return strings.TrimSuffix(csvPath, filepath.Ext(csvPath)) + "-errors" + ext
+}
+
+// getErrorsCSVParams returns the settings of the errors CSV file: the separator of the imported CSV file is kept.
+func getErrorsCSVParams(params common.CSVParams, r common.RowReader) common.CSVWriterParams {
+	writerParams := common.CSVWriterParams{Comma: params.Comma, Encoding: params.Encoding}
+	if csvReader, ok := r.(*csv.Reader); ok {
+		writerParams.Comma = string(csvReader.Comma)
+	}
+	return writerParams
+}
+
+// writeErrorsCSV writes the rows with an additional column containing their error.
+// The rows with no error get an empty value in that column.
+// The file is written with the separator and encoding of the imported one to be imported again in the same way.
+func writeErrorsCSV(path string, params common.CSVWriterParams, header []string, rows []csvRow) error {
+	w, err := newErrorsCSVWriter(path, params, header)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.write(row); err != nil {
+			_ = w.close()
+			return err
+		}
+	}
+	return w.close()
+}
+
+// errorsCSVWriter writes the rows of the errors CSV file one at a time, like when the rows are streamed.
+type errorsCSVWriter struct {
+	path   string
+	header []string
+	file   *os.File
+	w      *common.CSVWriter
+}
+
+// newErrorsCSVWriter creates the errors CSV file and writes its header.
+func newErrorsCSVWriter(path string, params common.CSVWriterParams, header []string) (*errorsCSVWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the errors CSV file %s: %s", path, err)
+	}
+
+	w, err := common.NewCSVWriter(file, params, append(slices.Clone(header), importErrorColumn))
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to write the errors CSV file %s: %s", path, err)
+	}
+	return &errorsCSVWriter{path: path, header: header, file: file, w: w}, nil
+}
+
+// write adds the row with its error, or an empty value if it has none.
+func (e *errorsCSVWriter) write(row csvRow) error {
+	fields := make([]string, len(e.header), len(e.header)+1)
+	copy(fields, row.fields)
+
+	message := ""
+	if row.err != nil {
+		message = strings.ReplaceAll(row.err.Error(), "\n", "; ")
+	}
+	if err := e.w.Write(append(fields, message)); err != nil {
+		return fmt.Errorf("failed to write the errors CSV file %s: %s", e.path, err)
+	}
+	return nil
+}
+
+// close flushes the written rows and closes the file.
+func (e *errorsCSVWriter) close() error {
+	if err := e.w.Close(); err != nil {
+		_ = e.file.Close()
+		return fmt.Errorf("failed to write the errors CSV file %s: %s", e.path, err)
+	}
+	return e.file.Close()
+}
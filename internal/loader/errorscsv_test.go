@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+func TestGetErrorsCSVPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		csvPath string
+		want    string
+	}{
+		{"", "data/bank.csv", "data/bank-errors.csv"},
+		{"", "bank.txt", "bank-errors.txt"},
+		{"", "bank", "bank-errors.csv"},
+		{"", "data/export.xlsx", "data/export-errors.csv"},
+		{"out.csv", "data/bank.csv", "out.csv"},
+	}
+
+	for _, tt := range tests {
+		if got := getErrorsCSVPath(tt.path, tt.csvPath); got != tt.want {
+			t.Errorf("getErrorsCSVPath(%q, %q) mismatch. Got: %s, Want: %s", tt.path, tt.csvPath, got, tt.want)
+		}
+	}
+}
+
+func TestWriteErrorsCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.csv")
+	header := []string{"date", "name", "amount"}
+	rows := []csvRow{
+		{index: 1, fields: []string{"01/01/2025", "Paper", "12.50"}},
+		{index: 2, fields: []string{"02/01/2025", "Rent"}, err: errors.Join(errors.New("bad amount"), errors.New("bad category"))},
+		{index: 3, err: errors.New("failed to read row 3")},
+	}
+
+	if err := writeErrorsCSV(path, common.CSVWriterParams{Comma: ";"}, header, rows); err != nil {
+		t.Fatalf("writeErrorsCSV failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the errors CSV: %v", err)
+	}
+	want := "date;name;amount;import_error\n" +
+		"01/01/2025;Paper;12.50;\n" +
+		"02/01/2025;Rent;;\"bad amount; bad category\"\n" +
+		";;;failed to read row 3\n"
+	if string(data) != want {
+		t.Errorf("Errors CSV mismatch. Got: %q, Want: %q", string(data), want)
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// columnSynonyms lists the normalized header names matching each of the CSV columns.
+// The order matters: the first synonym found in the header wins.
+var columnSynonyms = []struct {
+	field    string
+	synonyms []string
+}{
+	{"name", []string{"name", "libelle", "label", "intitule", "description", "titre", "nom"}},
+	{"date", []string{"date", "date operation", "date de l operation", "date comptable", "date valeur"}},
+	{"amount", []string{"amount", "montant", "somme", "total"}},
+	{"stock", []string{"stock", "quantite", "quantity", "qte"}},
+	{"category", []string{"category", "categorie"}},
+	{"comment", []string{"comment", "commentaire", "remarque", "remarques", "notes", "note"}},
+	{"payment", []string{"payment", "paiement", "mode de paiement", "moyen de paiement", "methode de paiement"}},
+	{"budget", []string{"budget", "section"}},
+	{"employee", []string{"employee", "salarie", "personne", "beneficiaire"}},
+	{"provider", []string{"provider", "fournisseur"}},
+	{"kind", []string{"kind", "type", "nature", "type d operation"}},
+	{"period", []string{"period", "periode", "exercice"}},
+	{"bank", []string{"bank", "banque", "account", "compte"}},
+	{"debit", []string{"debit"}},
+	{"credit", []string{"credit"}},
+	{"balance", []string{"balance", "solde"}},
+	{"currency", []string{"currency", "devise"}},
+	{"rate", []string{"rate", "taux de change", "taux"}},
+	{"template", []string{"template", "modele"}},
+	{"check", []string{"check", "cheque", "numero de cheque", "n cheque", "no cheque"}},
+}
+
+var nonAlphaNumRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// normalizeHeader lowers the case and removes the accents and punctuation of a header name.
+func normalizeHeader(name string) string {
+	name = stripDiacritics(strings.ToLower(name))
+	return strings.TrimSpace(nonAlphaNumRegex.ReplaceAllString(name, " "))
+}
+
+// columnGuess is the result of guessColumns.
+type columnGuess struct {
+	Columns CSVColumns
+	// Mapping maps the column fields to the matched header names.
+	Mapping map[string]string
+	// Unmapped lists the header names that didn't match any field.
+	Unmapped []string
+}
+
+// guessColumns matches the CSV header names to the columns using synonyms.
+// The columns that could not be guessed keep the value from the configured columns.
+func guessColumns(header []string, configured CSVColumns) columnGuess {
+	guess := columnGuess{Columns: configured, Mapping: map[string]string{}}
+
+	normalized := make([]string, len(header))
+	for i, name := range header {
+		normalized[i] = normalizeHeader(name)
+	}
+
+	used := make([]bool, len(header))
+	for _, column := range columnSynonyms {
+		for _, synonym := range column.synonyms {
+			idx := findHeader(normalized, used, synonym)
+			if idx < 0 {
+				continue
+			}
+			used[idx] = true
+			guess.Mapping[column.field] = header[idx]
+			setColumn(&guess.Columns, column.field, header[idx])
+			break
+		}
+	}
+
+	for i, name := range header {
+		if !used[i] {
+			guess.Unmapped = append(guess.Unmapped, name)
+		}
+	}
+	return guess
+}
+
+// findHeader looks for the first unused header equal to the synonym or starting with it as a word.
+func findHeader(normalized []string, used []bool, synonym string) int {
+	for i, name := range normalized {
+		if !used[i] && name == synonym {
+			return i
+		}
+	}
+	for i, name := range normalized {
+		if !used[i] && strings.HasPrefix(name, synonym+" ") {
+			return i
+		}
+	}
+	return -1
+}
+
+func setColumn(columns *CSVColumns, field string, value string) {
+	switch field {
+	case "name":
+		columns.Name = value
+	case "date":
+		columns.Date = value
+	case "amount":
+		columns.Amount = value
+	case "stock":
+		columns.Stock = value
+	case "category":
+		columns.Category = value
+	case "comment":
+		columns.Comment = value
+	case "payment":
+		columns.Payment = value
+	case "budget":
+		columns.Budget = value
+	case "employee":
+		columns.Employee = value
+	case "provider":
+		columns.Provider = value
+	case "kind":
+		columns.Kind = value
+	case "period":
+		columns.Period = value
+	case "bank":
+		columns.Bank = value
+	case "debit":
+		columns.Debit = value
+	case "credit":
+		columns.Credit = value
+	case "balance":
+		columns.Balance = value
+	case "currency":
+		columns.Currency = value
+	case "rate":
+		columns.Rate = value
+	case "template":
+		columns.Template = value
+	case "check":
+		columns.Check = value
+	}
+}
+
+// print writes the guessed mapping in a human readable form.
+func (g columnGuess) print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "Guessed CSV columns mapping:"); err != nil {
+		return err
+	}
+	for _, column := range columnSynonyms {
+		if name, found := g.Mapping[column.field]; found {
+			if _, err := fmt.Fprintf(tw, "  %s\t<- %s\n", column.field, name); err != nil {
+				return err
+			}
+		}
+	}
+	if len(g.Unmapped) > 0 {
+		if _, err := fmt.Fprintf(tw, "Ignored columns: %s\n", strings.Join(g.Unmapped, ", ")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// readCSVHeader reads the first record of the CSV file.
+func readCSVHeader(params common.CSVParams, path string) ([]string, error) {
+	r, cleaner, err := common.GetRowReader(params, path)
+	if err != nil {
+		return nil, err
+	}
+	defer cleaner()
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	return header, nil
+}
+
+// applyGuessedColumns replaces the configured columns with the ones guessed from the CSV header
+// after the user confirmed them.
+func applyGuessedColumns(cfg *Config, in io.Reader, out io.Writer) error {
+	header, err := readCSVHeader(cfg.CSV.CSVParams, cfg.CSVPath)
+	if err != nil {
+		return err
+	}
+
+	guess := guessColumns(header, cfg.CSV.Columns)
+	if err := guess.print(out); err != nil {
+		return err
+	}
+
+	if !cfg.Yes && !common.Confirm(in, out, "Use this mapping?") {
+		return fmt.Errorf("guessed columns mapping rejected")
+	}
+
+	cfg.CSV.Columns = guess.Columns
+	return nil
+}
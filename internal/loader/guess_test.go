@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHeader(t *testing.T) {
+	tests := map[string]string{
+		"Libellé":              "libelle",
+		" Date de l'opération": "date de l operation",
+		"Montant (€)":          "montant",
+		"MODE_DE_PAIEMENT":     "mode de paiement",
+	}
+	for input, want := range tests {
+		if got := normalizeHeader(input); got != want {
+			t.Errorf("normalizeHeader(%q) got = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestGuessColumns(t *testing.T) {
+	configured := CSVColumns{Name: "name", Date: "date", Amount: "amount", Comment: "comment"}
+
+	tests := []struct {
+		name         string
+		header       []string
+		wantColumns  CSVColumns
+		wantUnmapped []string
+	}{
+		{
+			name:   "French bank export",
+			header: []string{"Date de l'opération", "Libellé", "Montant (€)", "Catégorie", "Référence"},
+			wantColumns: CSVColumns{
+				Name:     "Libellé",
+				Date:     "Date de l'opération",
+				Amount:   "Montant (€)",
+				Category: "Catégorie",
+				Comment:  "comment",
+			},
+			wantUnmapped: []string{"Référence"},
+		},
+		{
+			name:   "Case insensitive English names",
+			header: []string{"DATE", "Name", "AMOUNT", "Provider"},
+			wantColumns: CSVColumns{
+				Name:     "Name",
+				Date:     "DATE",
+				Amount:   "AMOUNT",
+				Provider: "Provider",
+				Comment:  "comment",
+			},
+		},
+		{
+			name:   "Debit column",
+			header: []string{"Date", "Libellé", "Débit"},
+			wantColumns: CSVColumns{
+				Name:    "Libellé",
+				Date:    "Date",
+				Amount:  "amount",
+				Debit:   "Débit",
+				Comment: "comment",
+			},
+		},
+		{
+			name:   "Debit and credit columns",
+			header: []string{"Date", "Libellé", "Débit", "Crédit"},
+			wantColumns: CSVColumns{
+				Name:    "Libellé",
+				Date:    "Date",
+				Amount:  "amount",
+				Debit:   "Débit",
+				Credit:  "Crédit",
+				Comment: "comment",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guess := guessColumns(tt.header, configured)
+			if !reflect.DeepEqual(guess.Columns, tt.wantColumns) {
+				t.Errorf("guessColumns() columns = %+v, want %+v", guess.Columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(guess.Unmapped, tt.wantUnmapped) {
+				t.Errorf("guessColumns() unmapped = %q, want %q", guess.Unmapped, tt.wantUnmapped)
+			}
+		})
+	}
+}
+
+func TestColumnGuessPrint(t *testing.T) {
+	guess := guessColumns([]string{"Libellé", "Débit", "Autre"}, CSVColumns{})
+
+	var out bytes.Buffer
+	if err := guess.print(&out); err != nil {
+		t.Fatalf("print failed unexpectedly: %v", err)
+	}
+	for _, expected := range []string{"name   <- Libellé", "debit  <- Débit", "Ignored columns: Autre"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// HooksConfig holds the commands or webhook URLs to run around the import.
+type HooksConfig struct {
+	Pre  string `mapstructure:"pre"`
+	Post string `mapstructure:"post"`
+}
+
+// hookPayload is the JSON document passed to the hooks.
+type hookPayload struct {
+	Event   string        `json:"event"`
+	Report  string        `json:"report,omitempty"`
+	Summary importSummary `json:"summary"`
+}
+
+// runHook runs the hook for the given event.
+//
+// Hooks starting with http:// or https:// get the payload posted as JSON.
+// Any other hook is run as a shell command with the payload on its standard input
+// and in the LOADER_HOOK_SUMMARY environment variable.
+func runHook(hook string, event string, report string, summary importSummary) error {
+	if hook == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(hookPayload{Event: event, Report: report, Summary: summary})
+	if err != nil {
+		return fmt.Errorf("failed to serialize the %s hook payload: %s", event, err)
+	}
+
+	if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+		return common.PostWebhook(hook, payload)
+	}
+	return runHookCommand(hook, event, report, payload)
+}
+
+func runHookCommand(command string, event string, report string, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"LOADER_HOOK_EVENT="+event,
+		"LOADER_HOOK_REPORT="+report,
+		"LOADER_HOOK_SUMMARY="+string(payload),
+	)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook command failed: %s", event, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunHookWebhook(t *testing.T) {
+	var got hookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type mismatch. Got: %s, Want: application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode the payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	summary := importSummary{CSVPath: "in.csv", Entries: 3, Added: 2}
+	if err := runHook(server.URL, "post", "report.json", summary); err != nil {
+		t.Fatalf("runHook failed: %v", err)
+	}
+
+	if got.Event != "post" || got.Report != "report.json" {
+		t.Errorf("Payload mismatch. Got: %+v", got)
+	}
+	if got.Summary.CSVPath != "in.csv" || got.Summary.Entries != 3 || got.Summary.Added != 2 {
+		t.Errorf("Summary mismatch. Got: %+v, Want: %+v", got.Summary, summary)
+	}
+}
+
+func TestRunHookWebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := runHook(server.URL, "pre", "", importSummary{}); err == nil {
+		t.Error("Expected an error for a failing webhook")
+	}
+}
+
+func TestRunHookCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	hook := `echo "$LOADER_HOOK_EVENT $LOADER_HOOK_REPORT" > ` + out + ` && cat >> ` + out
+	if err := runHook(hook, "pre", "report.json", importSummary{CSVPath: "in.csv"}); err != nil {
+		t.Fatalf("runHook failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read the hook output: %v", err)
+	}
+	lines := strings.SplitN(string(data), "\n", 2)
+	if lines[0] != "pre report.json" {
+		t.Errorf("Environment mismatch. Got: %q, Want: %q", lines[0], "pre report.json")
+	}
+	var payload hookPayload
+	if err := json.Unmarshal([]byte(lines[1]), &payload); err != nil {
+		t.Fatalf("failed to decode the hook input %q: %v", lines[1], err)
+	}
+	if payload.Summary.CSVPath != "in.csv" {
+		t.Errorf("Summary CSV mismatch. Got: %s, Want: in.csv", payload.Summary.CSVPath)
+	}
+
+	if err := runHook("exit 1", "pre", "", importSummary{}); err == nil {
+		t.Error("Expected an error for a failing command")
+	}
+}
+
+func TestRunHookEmpty(t *testing.T) {
+	if err := runHook("", "pre", "", importSummary{}); err != nil {
+		t.Errorf("Expected no error for an empty hook, got: %v", err)
+	}
+}
+
+func TestImportSummarySave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	summary := importSummary{
+		CSVPath:  "in.csv",
+		Entries:  2,
+		Added:    1,
+		Failures: []entryFailure{{Index: 1, Name: "Rent", Error: "boom"}},
+	}
+	if err := summary.save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	var got importSummary
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to decode the report: %v", err)
+	}
+	if got.Added != 1 || len(got.Failures) != 1 || got.Failures[0].Name != "Rent" {
+		t.Errorf("Report mismatch. Got: %+v, Want: %+v", got, summary)
+	}
+}
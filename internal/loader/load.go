@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// loadImpl is the main logic entry point of the tool.
+func loadImpl(cfg Config) error {
+	summary, err := runImport(cfg)
+	if err != nil || len(summary.Failures) == 0 {
+		return err
+	}
+	// Tell the scripts whether some entries need to be imported again.
+	err = fmt.Errorf(common.Tr("%d of %d entries failed to be added"), len(summary.Failures), summary.Entries)
+	if summary.Added == 0 {
+		return common.WithExitCode(common.ExitRemote, err)
+	}
+	return common.WithExitCode(common.ExitPartial, err)
+}
+
+// runImport runs the import with its hooks and report and returns its summary.
+func runImport(cfg Config) (importSummary, error) {
+	summary := importSummary{CSVPath: cfg.CSVPath, DryRun: cfg.DryRun, Started: time.Now()}
+	if err := runHook(cfg.Hooks.Pre, "pre", cfg.Report, summary); err != nil {
+		return summary, err
+	}
+
+	err := importEntries(cfg, &summary)
+	summary.Finished = time.Now()
+	if err != nil {
+		summary.Error = err.Error()
+	}
+
+	if cfg.Report != "" {
+		if err := summary.save(cfg.Report); err != nil {
+			slog.Error("failed to save the report", "error", err)
+		}
+	}
+	if err := runHook(cfg.Hooks.Post, "post", cfg.Report, summary); err != nil {
+		slog.Error("failed to run the post hook", "error", err)
+	}
+	common.Notify(cfg.Notify, summary.notification(cfg.Report))
+	return summary, err
+}
+
+// importEntries parses the CSV file and adds the entries to happy-compta.
+func importEntries(cfg Config, summary *importSummary) error {
+	manifestInput := isManifest(cfg.CSVPath)
+	if cfg.Stream {
+		if err := checkStreamConfig(cfg, manifestInput); err != nil {
+			return err
+		}
+	}
+	if cfg.GuessColumns && !manifestInput {
+		if err := applyGuessedColumns(&cfg, os.Stdin, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	throttle, err := newThrottler(cfg.Pause, cfg.Schedule)
+	if err != nil {
+		return err
+	}
+
+	rates, err := newRateProvider(cfg.Rates)
+	if err != nil {
+		return err
+	}
+
+	var client *lib.Client
+	var refs referenceData
+	if cfg.ReferenceSnapshot != "" {
+		if !cfg.DryRun {
+			return errors.New("the reference snapshot can only be used with --dry-run")
+		}
+		refs, err = loadSnapshot(cfg.ReferenceSnapshot)
+		if err != nil {
+			return err
+		}
+		slog.Info("using the reference data snapshot", "created", refs.Created.Format(time.DateTime))
+	} else {
+		client, err = login(cfg.Email, cfg.Password, cfg.CacheDir)
+		if err != nil {
+			return err
+		}
+		refs, err = fetchReferenceData(client)
+		if err != nil {
+			return err
+		}
+	}
+	if err := refs.validate(); err != nil {
+		return err
+	}
+	if cfg.Stream {
+		return streamEntries(cfg, client, refs, rates, throttle, summary)
+	}
+
+	var parser *rowParser
+	var rows []csvRow
+	var errorsPath string
+	var errorsParams common.CSVWriterParams
+	if manifestInput {
+		// The CSV rows can't hold the allocation lines and receipts of the entries: no errors CSV is written.
+		parser, rows, err = readManifest(cfg.CSVPath, cfg.Defaults, cfg.CSV.Date, refs, rates)
+		if err != nil {
+			return err
+		}
+	} else {
+		r, cleaner, err := common.GetRowReader(cfg.CSV.CSVParams, cfg.CSVPath)
+		if err != nil {
+			return err
+		}
+		defer cleaner()
+
+		parser, rows, err = readRows(
+			r, cfg.CSV.Columns, cfg.Defaults, cfg.CSV.Date,
+			refs.Accounts, refs.Categories, refs.Employees, refs.Providers, refs.Periods, rates,
+		)
+		if err != nil {
+			return err
+		}
+		errorsPath = getErrorsCSVPath(cfg.ErrorsCSV, cfg.CSVPath)
+		errorsParams = getErrorsCSVParams(cfg.CSV.CSVParams, r)
+	}
+
+	// Nothing has been uploaded on validation errors: all the rows need to be imported again.
+	failValidation := func(err error) error {
+		if errorsPath == "" {
+			return common.WithExitCode(common.ExitValidation, err)
+		}
+		if writeErr := writeErrorsCSV(errorsPath, errorsParams, parser.header, rows); writeErr != nil {
+			slog.Error("failed to write the errors CSV", "error", writeErr)
+		} else {
+			slog.Info("the rows with their errors have been written", "file", errorsPath)
+		}
+		return common.WithExitCode(common.ExitValidation, err)
+	}
+
+	var suggestions map[int]categorySuggestion
+	if cfg.SuggestCategories {
+		if client == nil {
+			slog.Warn("the categories can't be suggested without connecting to happy-compta")
+		} else {
+			history, err := fetchHistory(client, refs.Periods)
+			if err != nil {
+				return err
+			}
+			suggestions = suggestCategories(parser, rows, newCategorySuggester(history), refs.Categories)
+		}
+	}
+
+	if cfg.Review {
+		if rows, err = reviewRows(parser, rows, refs, suggestions); err != nil {
+			return err
+		}
+	} else if _, err := collectEntries(parser, rows); err != nil {
+		return failValidation(err)
+	}
+
+	var claims []expenseClaim
+	if cfg.ExpenseClaims {
+		if claims, err = applyExpenseClaims(parser.colMap, rows); err != nil {
+			return failValidation(err)
+		}
+	}
+
+	entries := make([]lib.Entry, len(rows))
+	for i, row := range rows {
+		entries[i] = row.entry
+	}
+
+	// Add the receipts to the entries
+	if err := addReceipts(cfg.Receipts, entries); err != nil {
+		return err
+	}
+	// The receipts listed in a manifest have precedence over the folder ones.
+	for i, row := range rows {
+		if len(row.entry.Receipts) > 0 {
+			entries[i].Receipts = row.entry.Receipts
+		}
+	}
+	shareClaimReceipts(claims, entries)
+
+	// Warn about the proofs that may be reused by mistake.
+	hashes, err := hashReceipts(entries)
+	if err != nil {
+		return err
+	}
+	state := receiptsState{Receipts: map[string]uploadedReceipt{}}
+	if cfg.State != "" {
+		if state, err = loadReceiptsState(cfg.State); err != nil {
+			return err
+		}
+	}
+	for _, duplicate := range findDuplicateReceipts(entries, hashes, state) {
+		slog.Warn("duplicate receipt", "error", duplicate)
+	}
+
+	var lister entriesLister
+	if client != nil {
+		lister = func(period string) ([]lib.Entry, error) {
+			return client.ListEntries(lib.EntryFilter{Period: period, Kind: lib.KindSpend})
+		}
+	}
+	budgets, err := computeBudgets(cfg.Budgets.Limits, refs.Categories, entries, lister)
+	if err != nil {
+		return err
+	}
+	budgetsErr := checkBudgets(budgets)
+	if budgetsErr != nil && !cfg.Budgets.Block {
+		slog.Warn("the import goes over some budget limits", "error", budgetsErr)
+	}
+
+	summary.Entries = len(entries)
+	if cfg.DryRun {
+		if err := writeDepositSlips(cfg.DepositSlip, entries); err != nil {
+			return err
+		}
+		// The numbers can't be previewed from a reference snapshot.
+		var numbers []string
+		if client != nil {
+			if numbers, err = previewEntryNumbers(client, entries); err != nil {
+				slog.Warn("failed to preview the entry numbers", "error", err)
+			}
+		}
+		if err := printDryRun(os.Stdout, entries, numbers, refs.Categories); err != nil {
+			return err
+		}
+		if err := printBudgets(os.Stdout, budgets); err != nil {
+			return err
+		}
+		if err := printSuggestions(os.Stdout, rows, suggestions); err != nil {
+			return err
+		}
+	}
+	if budgetsErr != nil && cfg.Budgets.Block {
+		return common.WithExitCode(common.ExitValidation, budgetsErr)
+	}
+	if cfg.DryRun {
+		return nil
+	}
+
+	// Load the entries to happy-compta
+	var failedRows []csvRow
+	var added []lib.Entry
+	for i, entry := range entries {
+		throttle.wait()
+		err := client.AddEntry(&entry)
+		if err != nil {
+			slog.Error("failed to add entry", "entry", i, "error", err)
+			summary.Failures = append(summary.Failures, entryFailure{
+				Index: i, Row: rows[i].index, Name: entry.Name, Error: err.Error(),
+			})
+			failedRow := rows[i]
+			failedRow.err = err
+			failedRows = append(failedRows, failedRow)
+			continue
+		}
+		summary.Added++
+		added = append(added, entry)
+	}
+	slog.Info("entries added", "added", summary.Added, "total", summary.Entries)
+
+	// The accounting piece numbers of the entries are expected to follow each other.
+	summary.Interleaved = findInterleavedNumbers(added)
+	if len(summary.Interleaved) > 0 {
+		slog.Warn("entries have been added by someone else during the import, the numbers are not contiguous",
+			"numbers", summary.Interleaved)
+	}
+
+	if cfg.State != "" {
+		state.record(added, hashes, time.Now())
+		if err := state.save(cfg.State); err != nil {
+			slog.Error("failed to save the uploaded receipts", "error", err)
+		}
+	}
+
+	// Only the checks of the added entries can be deposited.
+	if err := writeDepositSlips(cfg.DepositSlip, added); err != nil {
+		slog.Error("failed to write the deposit slip", "error", err)
+	}
+
+	// Only the failed rows need to be imported again.
+	if len(failedRows) > 0 && errorsPath != "" {
+		if err := writeErrorsCSV(errorsPath, errorsParams, parser.header, failedRows); err != nil {
+			return err
+		}
+		slog.Info("the rows that failed to be added have been written", "file", errorsPath)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"go.yaml.in/yaml/v3"
+)
+
+// manifest is a YAML or JSON file describing the entries to load.
+type manifest struct {
+	Entries []manifestEntry `yaml:"entries"`
+}
+
+// manifestEntry is an entry of a manifest.
+// The values are the same as the CSV columns ones. The category, amount and stock can be set directly
+// for the entries with a single allocation line.
+type manifestEntry struct {
+	Date       string         `yaml:"date"`
+	Name       string         `yaml:"name"`
+	Kind       string         `yaml:"kind"`
+	Budget     string         `yaml:"budget"`
+	Bank       string         `yaml:"bank"`
+	Payment    string         `yaml:"payment"`
+	Check      string         `yaml:"check"`
+	Employee   string         `yaml:"employee"`
+	Provider   string         `yaml:"provider"`
+	Period     string         `yaml:"period"`
+	Comment    string         `yaml:"comment"`
+	Currency   string         `yaml:"currency"`
+	Rate       float64        `yaml:"rate"`
+	Template   string         `yaml:"template"`
+	Category   string         `yaml:"category"`
+	Amount     *float64       `yaml:"amount"`
+	Stock      int            `yaml:"stock"`
+	Allocation []manifestLine `yaml:"allocation"`
+	// Receipts are the paths of the files to attach, relative to the manifest.
+	Receipts []string `yaml:"receipts"`
+}
+
+// manifestLine is an allocation line of a manifest entry.
+type manifestLine struct {
+	Category string   `yaml:"category"`
+	Amount   *float64 `yaml:"amount"`
+	Stock    int      `yaml:"stock"`
+}
+
+// manifestColumns are the columns of the rows built from the manifest entries.
+// They are named after the default CSV columns, like the columns added by the review.
+var manifestColumns = CSVColumns{
+	Name:     "name",
+	Date:     "date",
+	Amount:   "amount",
+	Stock:    "stock",
+	Category: "category",
+	Comment:  "comment",
+	Payment:  "payment",
+	Check:    "check",
+	Budget:   "budget",
+	Employee: "employee",
+	Provider: "provider",
+	Kind:     "kind",
+	Period:   "period",
+	Bank:     "account",
+	Currency: "currency",
+	Rate:     "rate",
+	Template: "template",
+}
+
+// isManifest tells whether the file to load is a YAML or JSON manifest rather than a CSV file.
+func isManifest(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// readManifest reads the entries of a YAML or JSON manifest.
+// The entries are converted into rows parsed like the CSV ones.
+func readManifest(
+	path string,
+	defaults Defaults,
+	dates common.DateParams,
+	refs referenceData,
+	rates rateProvider,
+) (parser *rowParser, rows []csvRow, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open the manifest %s: %s", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	// JSON being valid YAML, the same decoder reads both.
+	var content manifest
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&content); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the manifest %s: %s", path, err)
+	}
+	if len(content.Entries) == 0 {
+		return nil, nil, fmt.Errorf("no entry in the manifest %s", path)
+	}
+
+	header := []string{
+		manifestColumns.Date, manifestColumns.Name, manifestColumns.Kind, manifestColumns.Budget,
+		manifestColumns.Bank, manifestColumns.Payment, manifestColumns.Check, manifestColumns.Employee,
+		manifestColumns.Provider, manifestColumns.Period, manifestColumns.Comment, manifestColumns.Currency, manifestColumns.Rate,
+		manifestColumns.Template, manifestColumns.Category, manifestColumns.Amount, manifestColumns.Stock,
+	}
+	parser = &rowParser{
+		header:      header,
+		colMap:      buildColumnMap(header, manifestColumns),
+		defaults:    defaults,
+		dates:       dates,
+		accounts:    refs.Accounts,
+		categories:  createCategoriesMap(refs.Categories),
+		employees:   createEmployeesMap(refs.Employees),
+		providers:   createProvidersMap(refs.Providers),
+		periods:     createPeriodsMap(refs.Periods),
+		rates:       rates,
+		allocations: map[int][]manifestLine{},
+		receipts:    map[int][]string{},
+	}
+
+	for i, entry := range content.Entries {
+		rowIndex := i + 1
+		row := csvRow{index: rowIndex}
+
+		lines := entry.Allocation
+		if len(lines) == 0 {
+			lines = []manifestLine{{Category: entry.Category, Amount: entry.Amount, Stock: entry.Stock}}
+		} else if entry.Category != "" || entry.Amount != nil || entry.Stock != 0 {
+			row.err = fmt.Errorf("entry %d has both allocation lines and a category, amount or stock", rowIndex)
+			rows = append(rows, row)
+			continue
+		}
+
+		rate := ""
+		if entry.Rate != 0 {
+			rate = strconv.FormatFloat(entry.Rate, 'f', -1, 64)
+		}
+		row.fields = []string{
+			entry.Date, entry.Name, entry.Kind, entry.Budget, entry.Bank, entry.Payment, entry.Check, entry.Employee,
+			entry.Provider, entry.Period, entry.Comment, entry.Currency, rate, entry.Template,
+			lines[0].Category, formatManifestAmount(lines[0].Amount), formatManifestStock(lines[0].Stock),
+		}
+		parser.allocations[rowIndex] = lines[1:]
+		parser.receipts[rowIndex] = manifestReceipts(filepath.Dir(path), entry.Receipts)
+
+		row.entry, row.err = parser.parse(rowIndex, row.fields)
+		rows = append(rows, row)
+	}
+	return
+}
+
+// formatManifestAmount writes the amount with two decimals for common.ParseAmount to read it back.
+func formatManifestAmount(amount *float64) string {
+	if amount == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*amount, 'f', 2, 64)
+}
+
+func formatManifestStock(stock int) string {
+	if stock == 0 {
+		return ""
+	}
+	return strconv.Itoa(stock)
+}
+
+// manifestReceipts returns the receipt paths relative to the manifest folder.
+func manifestReceipts(dir string, receipts []string) []string {
+	var paths []string
+	for _, receipt := range receipts {
+		if !filepath.IsAbs(receipt) {
+			receipt = filepath.Join(dir, receipt)
+		}
+		paths = append(paths, receipt)
+	}
+	return paths
+}
+
+// addManifestLines adds the allocation lines and receipts of the manifest entry of a row.
+func (p *rowParser) addManifestLines(rowIndex int, entry *lib.Entry) error {
+	var allErrors []error
+	for _, line := range p.allocations[rowIndex] {
+		category, found := p.categories[fmt.Sprintf("%s|%s", entry.Budget, line.Category)]
+		if !found {
+			allErrors = append(allErrors, fmt.Errorf(
+				"invalid category '%s' name / '%s' budget combination", line.Category, entry.Budget,
+			))
+			continue
+		}
+
+		allocation := lib.AllocationLine{CategoryID: category.ID}
+		if line.Amount != nil {
+			allocation.Amount = *line.Amount
+		}
+		if category.Stock {
+			stock, err := parseStock(formatManifestStock(line.Stock), category.Name)
+			if err != nil {
+				allErrors = append(allErrors, err)
+			}
+			allocation.Stock = stock
+		}
+		if line.Amount == nil && !(category.Stock && entry.Kind == lib.KindAllocation) {
+			allErrors = append(allErrors, fmt.Errorf("missing required amount value for the %s category", category.Name))
+		}
+		entry.Allocation = append(entry.Allocation, allocation)
+	}
+
+	if receipts := p.receipts[rowIndex]; len(receipts) > 0 {
+		if err := checkReceiptFiles(receipts); err != nil {
+			allErrors = append(allErrors, err)
+		}
+		entry.Receipts = receipts
+	}
+	return errors.Join(allErrors...)
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func getManifestRefs() referenceData {
+	return referenceData{
+		Accounts: []lib.Account{
+			{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+			{ID: 20, Bank: "First National Bank", Budget: lib.BudgetASC, Abbrev: "FNB"},
+		},
+		Categories: getMockCategories(),
+		Employees:  []lib.Employee{{ID: "E10", Lastname: "Doe", Firstname: "John", Active: true}},
+		Periods:    getMockPeriods(),
+	}
+}
+
+func writeManifest(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write the manifest: %v", err)
+	}
+	return path
+}
+
+func TestIsManifest(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"entries.yaml": true,
+		"entries.YML":  true,
+		"entries.json": true,
+		"entries.csv":  false,
+		"-":            false,
+	} {
+		if actual := isManifest(path); actual != expected {
+			t.Errorf("isManifest(%s) mismatch. Got: %v, Want: %v", path, actual, expected)
+		}
+	}
+}
+
+func TestReadManifest_YAML(t *testing.T) {
+	path := writeManifest(t, "entries.yaml", `entries:
+  - date: 01/03/2025
+    name: Fournitures
+    amount: 42.5
+    receipts: [ticket.pdf]
+  - date: 2025-03-02
+    name: Cadeaux et chèques
+    budget: ASC
+    kind: attributions
+    employee: Doe John
+    payment: check allocation
+    allocation:
+      - category: Gifts
+        amount: 30
+      - category: Check Alloc
+        stock: 5
+`)
+	receipt := createTestFile(t, filepath.Dir(path), "ticket.pdf", 100)
+
+	parser, rows, err := readManifest(path, getBaseDefaults(), common.DateParams{}, getManifestRefs(), nil)
+	if err != nil {
+		t.Fatalf("readManifest failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Rows count mismatch. Got: %d, Want: 2", len(rows))
+	}
+	for _, row := range rows {
+		if row.err != nil {
+			t.Fatalf("Unexpected error on row %d: %v", row.index, row.err)
+		}
+	}
+
+	if !reflect.DeepEqual(rows[0].entry.Receipts, []string{receipt}) {
+		t.Errorf("Receipts mismatch. Got: %v, Want: [%s]", rows[0].entry.Receipts, receipt)
+	}
+	expectedLines := []lib.AllocationLine{{CategoryID: 100, Amount: 42.5}}
+	if !reflect.DeepEqual(rows[0].entry.Allocation, expectedLines) {
+		t.Errorf("Allocation mismatch. Got: %+v, Want: %+v", rows[0].entry.Allocation, expectedLines)
+	}
+
+	entry := rows[1].entry
+	expectedLines = []lib.AllocationLine{{CategoryID: 200, Amount: 30}, {CategoryID: 201, Stock: 5}}
+	if !reflect.DeepEqual(entry.Allocation, expectedLines) {
+		t.Errorf("Allocation mismatch. Got: %+v, Want: %+v", entry.Allocation, expectedLines)
+	}
+	if entry.Date != time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC) || entry.Kind != lib.KindAllocation {
+		t.Errorf("Entry mismatch. Got: %+v, Want an allocation on 2025-03-02", entry)
+	}
+	if entry.Account.ID != 20 || entry.PaymentMethod != lib.PaymentMethodCheckAllocation {
+		t.Errorf("Entry mismatch. Got: %+v, Want the ASC account and a check allocation", entry)
+	}
+	if employee, ok := entry.Party.(*lib.Employee); !ok || employee.ID != "E10" {
+		t.Errorf("Party mismatch. Got: %+v, Want: E10 employee", entry.Party)
+	}
+
+	// The rows can be written back as CSV ones.
+	if !reflect.DeepEqual(parser.header[:2], []string{"date", "name"}) || len(rows[1].fields) != len(parser.header) {
+		t.Errorf("Rows mismatch. Got: %v, %v, Want the default column names", parser.header, rows[1].fields)
+	}
+}
+
+func TestReadManifest_JSON(t *testing.T) {
+	path := writeManifest(t, "entries.json",
+		`{"entries": [{"date": "01/03/2025", "name": "Loyer", "category": "Rent", "amount": 850}]}`)
+
+	_, rows, err := readManifest(path, getBaseDefaults(), common.DateParams{}, getManifestRefs(), nil)
+	if err != nil {
+		t.Fatalf("readManifest failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].err != nil {
+		t.Fatalf("Rows mismatch. Got: %+v, Want one valid row", rows)
+	}
+	expected := []lib.AllocationLine{{CategoryID: 101, Amount: 850}}
+	if !reflect.DeepEqual(rows[0].entry.Allocation, expected) {
+		t.Errorf("Allocation mismatch. Got: %+v, Want: %+v", rows[0].entry.Allocation, expected)
+	}
+}
+
+func TestReadManifest_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"Empty", "entries: []", "no entry in the manifest"},
+		{"UnknownField", "entries:\n  - date: 01/03/2025\n    amout: 12", "field amout not found"},
+		{
+			"AllocationAndCategory",
+			"entries:\n  - date: 01/03/2025\n    category: Rent\n    allocation:\n      - category: Rent\n        amount: 1",
+			"has both allocation lines and a category",
+		},
+		{
+			"UnknownLineCategory",
+			"entries:\n  - date: 01/03/2025\n    allocation:\n      - amount: 1\n      - category: Nope\n        amount: 2",
+			"invalid category 'Nope'",
+		},
+		{"MissingReceipt", "entries:\n  - date: 01/03/2025\n    amount: 1\n    receipts: [missing.pdf]", "missing.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeManifest(t, "entries.yaml", tt.content)
+			_, rows, err := readManifest(path, getBaseDefaults(), common.DateParams{}, getManifestRefs(), nil)
+			for _, row := range rows {
+				err = row.err
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected an error containing '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
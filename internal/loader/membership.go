@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// findGuest returns the guest of the list matching the name, or nil if not found.
+// The guests are in the <Lastname> <Firstname> format: the last word is the first name.
+func findGuest(guests []string, name string) *lib.Guest {
+	key := stripDiacritics(strings.ToLower(strings.Join(strings.Fields(name), " ")))
+	for _, guest := range guests {
+		fullName := strings.Join(strings.Fields(guest), " ")
+		if stripDiacritics(strings.ToLower(fullName)) != key {
+			continue
+		}
+		lastname, firstname := fullName, ""
+		if i := strings.LastIndex(fullName, " "); i > 0 {
+			lastname, firstname = fullName[:i], fullName[i+1:]
+		}
+		return &lib.Guest{Lastname: lastname, Firstname: firstname}
+	}
+	return nil
+}
+
+// writeDepositSlips writes the deposit slips of the checks received in the entries to path,
+// one per bank account. Nothing is written if path is empty.
+func writeDepositSlips(path string, entries []lib.Entry) error {
+	if path == "" {
+		return nil
+	}
+
+	var slips []lib.DepositSlip
+	seen := map[int]bool{}
+	for _, entry := range entries {
+		if seen[entry.Account.ID] {
+			continue
+		}
+		seen[entry.Account.ID] = true
+		if slip := lib.NewDepositSlip(time.Now(), entry.Account, entries); len(slip.Checks) > 0 {
+			slips = append(slips, slip)
+		}
+	}
+	if len(slips) == 0 {
+		slog.Warn("no received check for the deposit slip")
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create the deposit slip %s: %s", path, err)
+	}
+	for i, slip := range slips {
+		if i > 0 {
+			if _, err := fmt.Fprintln(file); err != nil {
+				_ = file.Close()
+				return fmt.Errorf("failed to write the deposit slip %s: %s", path, err)
+			}
+		}
+		if err := slip.Write(file); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to write the deposit slip %s: %s", path, err)
+		}
+	}
+	slog.Info("the deposit slip has been written", "file", path)
+	return file.Close()
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestFindGuest(t *testing.T) {
+	guests := []string{"Martin Léa", "de la Tour  Jean", "Prince"}
+
+	tests := []struct {
+		name     string
+		expected *lib.Guest
+	}{
+		{"Martin Léa", &lib.Guest{Lastname: "Martin", Firstname: "Léa"}},
+		{"martin lea", &lib.Guest{Lastname: "Martin", Firstname: "Léa"}},
+		{"De La Tour Jean", &lib.Guest{Lastname: "de la Tour", Firstname: "Jean"}},
+		{"Prince", &lib.Guest{Lastname: "Prince"}},
+		{"Léa Martin", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guest := findGuest(guests, tt.name)
+			if !reflect.DeepEqual(guest, tt.expected) {
+				t.Errorf("Guest mismatch. Got: %+v, Want: %+v", guest, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateEntryFromRow_Guest(t *testing.T) {
+	accounts := []lib.Account{{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"}}
+	colMap := buildColumnMap(
+		[]string{"Date", "Nom", "Montant", "Chèque", "Banque"},
+		CSVColumns{Date: "Date", Employee: "Nom", Amount: "Montant", Check: "Chèque", Bank: "Banque"},
+	)
+	defaults := getBaseDefaults()
+	defaults.Name = "Cotisation"
+	defaults.Kind = "recettes"
+	defaults.Payment = "cheque recu"
+	defaults.Guests = []string{"Martin Léa"}
+	employeesMap := createEmployeesMap([]lib.Employee{{ID: "E10", Lastname: "DOE", Firstname: "John", Active: true}})
+
+	tests := []struct {
+		name     string
+		employee string
+		guest    *lib.Guest
+		party    lib.Party
+		errMsg   string
+	}{
+		{name: "Guest", employee: "Martin Léa", guest: &lib.Guest{Lastname: "Martin", Firstname: "Léa"}},
+		{name: "Employee", employee: "Doe John", party: &lib.Employee{
+			ID: "E10", Lastname: "DOE", Firstname: "John", Active: true,
+		}},
+		{name: "Unknown", employee: "Durand Paul", errMsg: "unknown employee 'Durand Paul'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := []string{"15/01/2025", tt.employee, "30", "1234567", "First National Bank"}
+			entry, err := createEntryFromRow(row, colMap, defaults, common.DateParams{}, 1, accounts,
+				createCategoriesMap(getMockCategories()), employeesMap, nil, createPeriodsMap(getMockPeriods()))
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected an error containing '%s', got: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createEntryFromRow failed: %v", err)
+			}
+			if entry.Name != "Cotisation" {
+				t.Errorf("Name mismatch. Got: %s, Want: Cotisation", entry.Name)
+			}
+			if entry.CheckNumber != "1234567" {
+				t.Errorf("Check number mismatch. Got: %s, Want: 1234567", entry.CheckNumber)
+			}
+			if !reflect.DeepEqual(entry.Guest, tt.guest) {
+				t.Errorf("Guest mismatch. Got: %+v, Want: %+v", entry.Guest, tt.guest)
+			}
+			if !reflect.DeepEqual(entry.Party, tt.party) {
+				t.Errorf("Party mismatch. Got: %+v, Want: %+v", entry.Party, tt.party)
+			}
+		})
+	}
+}
+
+func TestWriteDepositSlips(t *testing.T) {
+	bank := lib.Account{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON}
+	other := lib.Account{ID: 20, Bank: "Second Bank", Budget: lib.BudgetASC}
+	entries := []lib.Entry{
+		{
+			Name: "Cotisation", Account: bank, PaymentMethod: lib.PaymentMethodCheckReceived, CheckNumber: "111",
+			Guest: &lib.Guest{Lastname: "Martin", Firstname: "Léa"}, Allocation: []lib.AllocationLine{{Amount: 30}},
+		},
+		{Name: "Card", Account: other, PaymentMethod: lib.PaymentMethodCard, Allocation: []lib.AllocationLine{{Amount: 5}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "slip.txt")
+	if err := writeDepositSlips(path, entries); err != nil {
+		t.Fatalf("writeDepositSlips failed: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the deposit slip: %v", err)
+	}
+	for _, expected := range []string{"Account: First National Bank", "111", "Martin Léa", "30.00"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected the deposit slip to contain '%s', got:\n%s", expected, content)
+		}
+	}
+	if strings.Contains(string(content), "Second Bank") {
+		t.Errorf("Unexpected slip for the account without checks:\n%s", content)
+	}
+
+	// No file is written without received checks.
+	path = filepath.Join(t.TempDir(), "empty.txt")
+	if err := writeDepositSlips(path, entries[1:]); err != nil {
+		t.Fatalf("writeDepositSlips failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no deposit slip without checks, got: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// entryNumberer is the part of the happy-compta client giving the next accounting piece numbers.
+type entryNumberer interface {
+	NextEntryNumber(budget lib.Budget, kind lib.Kind) (string, int, error)
+}
+
+// previewEntryNumbers returns the accounting piece numbers the entries would get if they were added now.
+// The entries sharing a prefix are numbered in sequence from the next number given by happy-compta.
+func previewEntryNumbers(client entryNumberer, entries []lib.Entry) ([]string, error) {
+	type sequenceKey struct {
+		budget lib.Budget
+		kind   lib.Kind
+	}
+	prefixes := map[sequenceKey]string{}
+	next := map[string]int{}
+
+	numbers := make([]string, len(entries))
+	for i, entry := range entries {
+		key := sequenceKey{entry.Budget, entry.Kind}
+		prefix, found := prefixes[key]
+		if !found {
+			var number int
+			var err error
+			if prefix, number, err = client.NextEntryNumber(entry.Budget, entry.Kind); err != nil {
+				return nil, err
+			}
+			prefixes[key] = prefix
+			if _, found := next[prefix]; !found {
+				next[prefix] = number
+			}
+		}
+		numbers[i] = lib.FormatEntryID(prefix, next[prefix])
+		next[prefix]++
+	}
+	return numbers, nil
+}
+
+// findInterleavedNumbers returns the accounting piece numbers missing between those of the added entries.
+// They have been given to the entries added meanwhile, like from the website.
+func findInterleavedNumbers(added []lib.Entry) []string {
+	sequences := map[string][]int{}
+	for _, entry := range added {
+		prefix := strings.TrimRight(entry.ID, "0123456789")
+		number, err := strconv.Atoi(entry.ID[len(prefix):])
+		if err != nil {
+			continue
+		}
+		sequences[prefix] = append(sequences[prefix], number)
+	}
+
+	var missing []string
+	for _, prefix := range slices.Sorted(maps.Keys(sequences)) {
+		numbers := sequences[prefix]
+		slices.Sort(numbers)
+		for i := 1; i < len(numbers); i++ {
+			for number := numbers[i-1] + 1; number < numbers[i]; number++ {
+				missing = append(missing, lib.FormatEntryID(prefix, number))
+			}
+		}
+	}
+	return missing
+}
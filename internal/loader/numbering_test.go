@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// fakeNumberer gives the next numbers per budget, like happy-compta.
+type fakeNumberer struct {
+	next  map[lib.Budget]int
+	calls int
+	err   error
+}
+
+func (f *fakeNumberer) NextEntryNumber(budget lib.Budget, kind lib.Kind) (string, int, error) {
+	f.calls++
+	return budget.String(), f.next[budget], f.err
+}
+
+func TestPreviewEntryNumbers(t *testing.T) {
+	entries := []lib.Entry{
+		{Budget: lib.BudgetFON, Kind: lib.KindSpend},
+		{Budget: lib.BudgetASC, Kind: lib.KindSpend},
+		{Budget: lib.BudgetFON, Kind: lib.KindSpend},
+		{Budget: lib.BudgetFON, Kind: lib.KindTake},
+	}
+	numberer := &fakeNumberer{next: map[lib.Budget]int{lib.BudgetFON: 12, lib.BudgetASC: 3}}
+
+	numbers, err := previewEntryNumbers(numberer, entries)
+	if err != nil {
+		t.Fatalf("previewEntryNumbers failed: %v", err)
+	}
+	expected := []string{"FON000012", "ASC000003", "FON000013", "FON000014"}
+	if !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("Numbers mismatch. Got: %v, Want: %v", numbers, expected)
+	}
+	if numberer.calls != 3 {
+		t.Errorf("Expected one call per budget and kind, got %d", numberer.calls)
+	}
+
+	numberer.err = errors.New("session expired")
+	if _, err := previewEntryNumbers(numberer, entries); err == nil {
+		t.Error("Expected the error to be returned")
+	}
+}
+
+func TestFindInterleavedNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []string
+		expected []string
+	}{
+		{"contiguous", []string{"FON000012", "FON000013", "ASC000003", "FON000014"}, nil},
+		{"interleaved", []string{"FON000012", "ASC000003", "FON000015", "ASC000005"}, []string{
+			"ASC000004", "FON000013", "FON000014",
+		}},
+		{"unknown numbers", []string{"FON000012", "", "FON000013"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := make([]lib.Entry, len(tt.ids))
+			for i, id := range tt.ids {
+				added[i].ID = id
+			}
+			if actual := findInterleavedNumbers(added); !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Interleaved numbers mismatch. Got: %v, Want: %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/viper"
+)
+
+// profiles maps the profile names to the configuration values they set.
+// The values are set as defaults: the flags, environment variables and configuration file still override them.
+var profiles = map[string]map[string]any{
+	// Files exported from the happy-compta operations list, to re-import them in another organization.
+	"happy-compta": {
+		"csv.comma":            ";",
+		"csv.columns.date":     "Date",
+		"csv.columns.name":     "Libellé",
+		"csv.columns.amount":   "Montant",
+		"csv.columns.stock":    "Stock",
+		"csv.columns.category": "Catégorie",
+		"csv.columns.comment":  "Remarques",
+		"csv.columns.payment":  "Mode de paiement",
+		"csv.columns.budget":   "Budget",
+		"csv.columns.employee": "Salarié",
+		"csv.columns.provider": "Fournisseur",
+		"csv.columns.kind":     "Type",
+		"csv.columns.bank":     "Banque",
+	},
+	// Lists of the membership fees paid by the members, resolved against the employees or the guests.
+	"membership": {
+		"name":                 "Cotisation",
+		"kind":                 "recettes",
+		"payment":              "cheque recu",
+		"csv.columns.date":     "Date",
+		"csv.columns.name":     "Libellé",
+		"csv.columns.employee": "Nom",
+		"csv.columns.amount":   "Montant",
+		"csv.columns.payment":  "Paiement",
+		"csv.columns.check":    "Chèque",
+	},
+}
+
+// getProfileNames returns the sorted list of the profile names.
+func getProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// applyProfile sets the values of the named profile as defaults in v.
+func applyProfile(v *viper.Viper, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	values, found := profiles[strings.ToLower(name)]
+	if !found {
+		return fmt.Errorf("unknown profile '%s', accepted values are %s", name, strings.Join(getProfileNames(), ", "))
+	}
+	for key, value := range values {
+		v.SetDefault(key, value)
+	}
+	return nil
+}
+
+// Labels used by happy-compta for the values, mapped to the loader ones.
+var (
+	kindAliases = map[string]string{
+		"depense":     lib.KindSpend.String(),
+		"depenses":    lib.KindSpend.String(),
+		"recette":     lib.KindTake.String(),
+		"recettes":    lib.KindTake.String(),
+		"attribution": lib.KindAllocation.String(),
+	}
+
+	paymentAliases = map[string]string{
+		"cheque recu":        lib.PaymentMethodCheckReceived.String(),
+		"especes":            lib.PaymentMethodCash.String(),
+		"carte bancaire":     lib.PaymentMethodCard.String(),
+		"carte":              lib.PaymentMethodCard.String(),
+		"cb":                 lib.PaymentMethodCard.String(),
+		"virement":           lib.PaymentMethodTransfer.String(),
+		"prelevement":        lib.PaymentMethodDirectDebit.String(),
+		"cheque emis":        lib.PaymentMethodCheckEmitted.String(),
+		"cheque attribution": lib.PaymentMethodCheckAllocation.String(),
+	}
+
+	budgetAliases = map[string]string{
+		"fonctionnement":                    lib.BudgetFON.String(),
+		"activites sociales et culturelles": lib.BudgetASC.String(),
+	}
+)
+
+// resolveAlias returns the loader value for a happy-compta label, or the value itself if it isn't a known label.
+func resolveAlias(aliases map[string]string, value string) string {
+	if resolved, found := aliases[stripDiacritics(strings.ToLower(value))]; found {
+		return resolved
+	}
+	return value
+}
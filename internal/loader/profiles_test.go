@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/viper"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestApplyProfile(t *testing.T) {
+	v := viper.New()
+	v.Set("csv.columns.name", "Intitulé")
+
+	if err := applyProfile(v, "Happy-Compta"); err != nil {
+		t.Fatalf("applyProfile failed unexpectedly: %v", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+
+	if cfg.CSV.Comma != ";" {
+		t.Errorf("Profile comma mismatch. Got: %s", cfg.CSV.Comma)
+	}
+	if cfg.CSV.Columns.Amount != "Montant" {
+		t.Errorf("Profile amount column mismatch. Got: %s", cfg.CSV.Columns.Amount)
+	}
+	if cfg.CSV.Columns.Name != "Intitulé" {
+		t.Errorf("Explicit values should override the profile. Got: %s", cfg.CSV.Columns.Name)
+	}
+}
+
+func TestApplyProfile_Unknown(t *testing.T) {
+	if err := applyProfile(viper.New(), "unknown"); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+	if err := applyProfile(viper.New(), ""); err != nil {
+		t.Errorf("No profile should not fail: %v", err)
+	}
+}
+
+func TestCreateEntryFromRow_HappyComptaLabels(t *testing.T) {
+	colMap := getMinimalColMap()
+	accounts := []lib.Account{
+		{ID: 20, Bank: "Global Reserve", Budget: lib.BudgetASC, Abbrev: "GR"},
+	}
+	row := []string{
+		"01/01/2025", "Cadeaux", "20,00", "Gifts", "Activités sociales et culturelles", "", "",
+		"Chèque reçu", "Recettes", "", "", "", "Global Reserve",
+	}
+
+	entry, err := createEntryFromRow(row, colMap, getBaseDefaults(), common.DateParams{}, 1, accounts,
+		createCategoriesMap(getMockCategories()), nil, nil, createPeriodsMap(getMockPeriods()))
+	if err != nil {
+		t.Fatalf("createEntryFromRow failed unexpectedly: %v", err)
+	}
+	if entry.Kind != lib.KindTake {
+		t.Errorf("Kind mismatch. Got: %s", entry.Kind)
+	}
+	if entry.Budget != lib.BudgetASC {
+		t.Errorf("Budget mismatch. Got: %s", entry.Budget)
+	}
+	if entry.PaymentMethod != lib.PaymentMethodCheckReceived {
+		t.Errorf("Payment method mismatch. Got: %s", entry.PaymentMethod)
+	}
+}
+
+func TestHappyComptaProfileXLSX(t *testing.T) {
+	// The happy-compta Excel export has date cells and numeric amounts.
+	path := filepath.Join(t.TempDir(), "operations.xlsx")
+	file := excelize.NewFile()
+	rows := [][]any{
+		{"Date", "Libellé", "Montant", "Catégorie", "Budget", "Mode de paiement", "Type", "Banque"},
+		{time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), "Ramettes", 12.5, "Office Supplies", "Fonctionnement",
+			"Carte bancaire", "Dépenses", "Global Reserve"},
+		{time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), "Cadeaux", 20, "Gifts", "Activités sociales et culturelles",
+			"Chèque reçu", "Recettes", "Global Reserve"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	v := viper.New()
+	if err := applyProfile(v, "happy-compta"); err != nil {
+		t.Fatalf("applyProfile failed unexpectedly: %v", err)
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+
+	r, cleaner, err := common.GetRowReader(cfg.CSV.CSVParams, path)
+	if err != nil {
+		t.Fatalf("GetRowReader failed: %v", err)
+	}
+	defer cleaner()
+
+	accounts := []lib.Account{
+		{ID: 10, Bank: "Global Reserve", Budget: lib.BudgetFON, Abbrev: "GRF"},
+		{ID: 20, Bank: "Global Reserve", Budget: lib.BudgetASC, Abbrev: "GRA"},
+	}
+	entries, err := parseCSV(r, cfg.CSV.Columns, getBaseDefaults(), cfg.CSV.Date, accounts,
+		getMockCategories(), nil, nil, getMockPeriods(), nil)
+	if err != nil {
+		t.Fatalf("parseCSV failed unexpectedly: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	spend, take := entries[0], entries[1]
+	if !spend.Date.Equal(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)) || spend.Kind != lib.KindSpend ||
+		spend.Allocation[0].Amount != 12.5 || spend.PaymentMethod != lib.PaymentMethodCard || spend.Account.ID != 10 {
+		t.Errorf("Spending mismatch. Got: %+v", spend)
+	}
+	if take.Kind != lib.KindTake || take.Budget != lib.BudgetASC || take.Allocation[0].Amount != 20 ||
+		take.Account.ID != 20 {
+		t.Errorf("Income mismatch. Got: %+v", take)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// RatesConfig holds the exchange rates parameters.
+type RatesConfig struct {
+	Source string `mapstructure:"source"`
+}
+
+// ecbRatesURL points to the history of the euro foreign exchange reference rates published by the ECB.
+const ecbRatesURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
+
+// rateProvider looks for the exchange rate of a currency at a given date.
+// The rate is the amount of the currency for one euro.
+type rateProvider interface {
+	rate(currency string, date time.Time) (float64, error)
+}
+
+// newRateProvider creates the rate provider for the configured source.
+// An empty source results in a nil provider: the rates then need to be in the CSV file.
+func newRateProvider(cfg RatesConfig) (rateProvider, error) {
+	switch strings.ToLower(cfg.Source) {
+	case "":
+		return nil, nil
+	case "ecb":
+		return &ecbRates{url: ecbRatesURL, client: http.DefaultClient}, nil
+	}
+	return nil, fmt.Errorf("unknown exchange rates source '%s', the only accepted value is ecb", cfg.Source)
+}
+
+// ecbRates fetches the European Central Bank reference rates once and caches them.
+type ecbRates struct {
+	url    string
+	client *http.Client
+	// dates is the sorted list of the days with rates.
+	dates []string
+	// rates maps the days to the currency rates.
+	rates map[string]map[string]float64
+}
+
+// ecbEnvelope matches the structure of the ECB reference rates XML file.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func (e *ecbRates) load() error {
+	resp, err := e.client.Get(e.url)
+	if err != nil {
+		return fmt.Errorf("failed to get the ECB exchange rates: %s", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get the ECB exchange rates, got %d status code", resp.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to parse the ECB exchange rates: %s", err)
+	}
+
+	e.rates = map[string]map[string]float64{}
+	for _, day := range envelope.Days {
+		rates := map[string]float64{}
+		for _, rate := range day.Rates {
+			rates[rate.Currency] = rate.Rate
+		}
+		e.rates[day.Time] = rates
+		e.dates = append(e.dates, day.Time)
+	}
+	slices.Sort(e.dates)
+	return nil
+}
+
+// rate returns the rate of the currency for the date or the closest previous day with published rates.
+// The ECB doesn't publish rates on week-ends and bank holidays.
+func (e *ecbRates) rate(currency string, date time.Time) (float64, error) {
+	if e.rates == nil {
+		if err := e.load(); err != nil {
+			return 0, err
+		}
+	}
+
+	day := date.Format("2006-01-02")
+	idx, found := slices.BinarySearch(e.dates, day)
+	if !found {
+		idx--
+	}
+	if idx < 0 {
+		return 0, fmt.Errorf("no ECB exchange rate available before %s", day)
+	}
+
+	rate, found := e.rates[e.dates[idx]][currency]
+	if !found {
+		return 0, fmt.Errorf("no ECB exchange rate for %s on %s", currency, day)
+	}
+	return rate, nil
+}
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// convertCurrency converts the amounts of the entry to euros.
+// The rate is taken from rateStr if set or from the rates provider.
+// The original amount is recorded in the entry comment.
+func convertCurrency(entry *lib.Entry, currency string, rateStr string, rates rateProvider) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == "EUR" || currency == "€" {
+		return nil
+	}
+	if !currencyCodeRegex.MatchString(currency) {
+		return fmt.Errorf("invalid currency code '%s'", currency)
+	}
+
+	var rate float64
+	var err error
+	if rateStr != "" {
+		rate, err = strconv.ParseFloat(strings.ReplaceAll(rateStr, ",", "."), 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse exchange rate '%s': %s", rateStr, err)
+		}
+	} else if rates != nil {
+		rate, err = rates.rate(currency, entry.Date)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("no exchange rate for %s: set the rate column or an exchange rates source", currency)
+	}
+	if rate <= 0 {
+		return fmt.Errorf("invalid exchange rate %g for %s", rate, currency)
+	}
+
+	var original []string
+	for i := range entry.Allocation {
+		original = append(original, fmt.Sprintf("%.2f %s", entry.Allocation[i].Amount, currency))
+		entry.Allocation[i].Amount = math.Round(entry.Allocation[i].Amount/rate*100) / 100
+	}
+
+	note := fmt.Sprintf("Original amount: %s (rate %g %s/EUR)", strings.Join(original, ", "), rate, currency)
+	if entry.Comment != "" {
+		entry.Comment += "\n"
+	}
+	entry.Comment += note
+	return nil
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+const mockECBRates = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2025-01-06">
+			<Cube currency="USD" rate="1.04"/>
+			<Cube currency="GBP" rate="0.83"/>
+		</Cube>
+		<Cube time="2025-01-03">
+			<Cube currency="USD" rate="1.03"/>
+			<Cube currency="GBP" rate="0.82"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func newMockECBRates(t *testing.T) (*ecbRates, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(mockECBRates))
+	}))
+	t.Cleanup(server.Close)
+	return &ecbRates{url: server.URL, client: server.Client()}, &requests
+}
+
+func TestECBRates(t *testing.T) {
+	rates, requests := newMockECBRates(t)
+
+	tests := []struct {
+		name     string
+		currency string
+		date     time.Time
+		want     float64
+		wantErr  bool
+	}{
+		{"Exact day", "USD", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), 1.04, false},
+		{"Week-end uses the previous day", "GBP", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), 0.82, false},
+		{"Before the history", "USD", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 0, true},
+		{"Unknown currency", "XYZ", time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.rate(tt.currency, tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rate() got = %g, want %g", got, tt.want)
+			}
+		})
+	}
+
+	if *requests != 1 {
+		t.Errorf("The rates should be fetched only once, got %d requests", *requests)
+	}
+}
+
+func TestConvertCurrency(t *testing.T) {
+	ecb, _ := newMockECBRates(t)
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		currency    string
+		rate        string
+		rates       rateProvider
+		wantAmount  float64
+		wantComment string
+		wantErr     string
+	}{
+		{
+			name:        "Euros are unchanged",
+			currency:    "eur",
+			wantAmount:  104,
+			wantComment: "Conference",
+		},
+		{
+			name:        "Rate from the column",
+			currency:    "usd",
+			rate:        "1,3",
+			wantAmount:  80,
+			wantComment: "Conference\nOriginal amount: 104.00 USD (rate 1.3 USD/EUR)",
+		},
+		{
+			name:        "Rate from the ECB",
+			currency:    "USD",
+			rates:       ecb,
+			wantAmount:  100,
+			wantComment: "Conference\nOriginal amount: 104.00 USD (rate 1.04 USD/EUR)",
+		},
+		{
+			name:     "No rate",
+			currency: "USD",
+			wantErr:  "no exchange rate for USD",
+		},
+		{
+			name:     "Invalid currency",
+			currency: "dollars",
+			rate:     "1.2",
+			wantErr:  "invalid currency code 'DOLLARS'",
+		},
+		{
+			name:     "Invalid rate",
+			currency: "USD",
+			rate:     "0",
+			wantErr:  "invalid exchange rate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := lib.Entry{
+				Date:       date,
+				Comment:    "Conference",
+				Allocation: []lib.AllocationLine{{Amount: 104}},
+			}
+			err := convertCurrency(&entry, tt.currency, tt.rate, tt.rates)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertCurrency failed unexpectedly: %v", err)
+			}
+			if entry.Allocation[0].Amount != tt.wantAmount {
+				t.Errorf("Amount mismatch. Got: %.2f, Want: %.2f", entry.Allocation[0].Amount, tt.wantAmount)
+			}
+			if entry.Comment != tt.wantComment {
+				t.Errorf("Comment mismatch. Got: %q, Want: %q", entry.Comment, tt.wantComment)
+			}
+		})
+	}
+}
+
+func TestNewRateProvider(t *testing.T) {
+	if provider, err := newRateProvider(RatesConfig{}); err != nil || provider != nil {
+		t.Errorf("Expected no provider without source, got %v, %v", provider, err)
+	}
+	if provider, err := newRateProvider(RatesConfig{Source: "ECB"}); err != nil || provider == nil {
+		t.Errorf("Expected an ECB provider, got %v, %v", provider, err)
+	}
+	if _, err := newRateProvider(RatesConfig{Source: "bank"}); err == nil {
+		t.Error("Expected an error for an unknown source")
+	}
+}
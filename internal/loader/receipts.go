@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// maxReceiptFileSize is 2MB
+const maxReceiptFileSize = 2 * 1024 * 1024
+
+// receiptFolderSeparator separates the entry number from the description in the receipt folder names.
+const receiptFolderSeparator = " - "
+
+// checkAndGetFiles reads all files in a directory, checking file count (max 3) and size (max 2MB) constraints.
+func checkAndGetFiles(dir string) (receipts []string, err error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		err = fmt.Errorf("failed to read directory %s: %w", dir, err)
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		filePath := filepath.Join(dir, file.Name())
+		if err = checkReceiptSize(filePath); err != nil {
+			return
+		}
+
+		receipts = append(receipts, filePath)
+	}
+
+	if len(receipts) > 3 {
+		return nil, fmt.Errorf("found %d receipt files in %s, but maximum is 3 per entry", len(receipts), dir)
+	}
+
+	return
+}
+
+// checkReceiptSize checks that a receipt file exists and is not larger than 2MB.
+func checkReceiptSize(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to get file info for %s: %w", path, err)
+	}
+	if info.Size() > maxReceiptFileSize {
+		return fmt.Errorf(
+			"receipt file %s is too large (%.2fMB > 2MB)",
+			path, float64(info.Size())/float64(maxReceiptFileSize),
+		)
+	}
+	return nil
+}
+
+// checkReceiptFiles checks the count (max 3) and size (max 2MB) of the receipt files of an entry.
+func checkReceiptFiles(receipts []string) error {
+	for _, receipt := range receipts {
+		if err := checkReceiptSize(receipt); err != nil {
+			return err
+		}
+	}
+	if len(receipts) > 3 {
+		return fmt.Errorf("found %d receipt files, but maximum is 3 per entry", len(receipts))
+	}
+	return nil
+}
+
+// createEmployeeEntryMap creates a map from potential employee full name strings to a list of matching entry indices.
+// Employees can be matched lower case using either "Firstname Lastname" or the reverse.
+func createEmployeeEntryMap(entries []lib.Entry) map[string][]int {
+	employeeMap := make(map[string][]int)
+	for i, entry := range entries {
+		if emp, ok := entry.Party.(*lib.Employee); ok {
+			lnFn := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%s %s", emp.Lastname, emp.Firstname)))
+			fnLn := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%s %s", emp.Firstname, emp.Lastname)))
+
+			if lnFn != " " {
+				employeeMap[lnFn] = append(employeeMap[lnFn], i)
+			}
+			if fnLn != " " && lnFn != fnLn {
+				employeeMap[fnLn] = append(employeeMap[fnLn], i)
+			}
+		}
+	}
+	return employeeMap
+}
+
+// receiptFolderNumber returns the entry number of folders named like 3 or 003 - Gifts - John Doe.
+func receiptFolderNumber(folderName string) (int, bool) {
+	number, _, _ := strings.Cut(folderName, receiptFolderSeparator)
+	entryNum, err := strconv.Atoi(number)
+	return entryNum, err == nil
+}
+
+// addReceipts looks for receipts in the configured folder to attach to the entries.
+func addReceipts(receiptsFolder string, entries []lib.Entry) error {
+	matcher, err := newReceiptsMatcher(receiptsFolder)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if receipts := matcher.match(i+1, len(entries), entries[i]); receipts != nil {
+			entries[i].Receipts = receipts
+		}
+	}
+	return nil
+}
+
+// receiptFolder is a non empty subfolder of the receipts folder.
+type receiptFolder struct {
+	name     string
+	receipts []string
+}
+
+// receiptsMatcher finds the receipts of the entries one at a time from the content of the receipts folder.
+type receiptsMatcher struct {
+	// global are the receipts of all the entries when the folder has no subfolder.
+	global  []string
+	folders []receiptFolder
+}
+
+// newReceiptsMatcher reads and checks the receipts of the folder.
+// An empty folder path gives a matcher finding no receipt.
+func newReceiptsMatcher(receiptsFolder string) (*receiptsMatcher, error) {
+	matcher := &receiptsMatcher{}
+	if receiptsFolder == "" {
+		return matcher, nil
+	}
+
+	items, err := os.ReadDir(receiptsFolder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root receipts folder %s: %w", receiptsFolder, err)
+	}
+
+	var subfolders []os.DirEntry
+	var rootFiles []os.DirEntry
+
+	for _, item := range items {
+		if item.IsDir() {
+			subfolders = append(subfolders, item)
+		} else {
+			rootFiles = append(rootFiles, item)
+		}
+	}
+
+	// Global Receipts: no nested folder and max three files, add to all entries.
+	if len(subfolders) == 0 && len(rootFiles) > 0 {
+		if matcher.global, err = checkAndGetFiles(receiptsFolder); err != nil {
+			return nil, err
+		}
+		return matcher, nil
+	}
+
+	for _, folder := range subfolders {
+		folderName := folder.Name()
+		folderPath := filepath.Join(receiptsFolder, folderName)
+
+		// Get and validate receipts in the subfolder
+		receipts, err := checkAndGetFiles(folderPath)
+		if err != nil {
+			return nil, fmt.Errorf("error processing receipt folder %s: %w", folderName, err)
+		}
+		if len(receipts) == 0 {
+			continue // Skip empty folders
+		}
+		matcher.folders = append(matcher.folders, receiptFolder{name: folderName, receipts: receipts})
+	}
+	return matcher, nil
+}
+
+// match returns the receipts of the entry at the given position, starting from 1, or nil if none is found.
+// A negative count means the number of entries is unknown, like when the rows are streamed.
+func (m *receiptsMatcher) match(position int, count int, entry lib.Entry) []string {
+	if m.global != nil {
+		return m.global
+	}
+
+	// Receipts sorted in folders named after one of the entry number (starting from 1) or the employee's full name.
+	// The folders created by the scaffold-receipts command start with the entry number.
+	// The last matching folder wins.
+	employeeNames := createEmployeeEntryMap([]lib.Entry{entry})
+	var receipts []string
+	for _, folder := range m.folders {
+		// Try if the folder named with entry number, possibly followed by a description.
+		if entryNum, found := receiptFolderNumber(folder.name); found && entryNum >= 1 && (count < 0 || entryNum <= count) {
+			if entryNum == position {
+				receipts = folder.receipts
+			}
+			continue
+		}
+
+		// Folder name matches employee full name.
+		if _, ok := employeeNames[strings.ToLower(folder.name)]; ok {
+			receipts = folder.receipts
+		}
+	}
+	return receipts
+}
@@ -3,7 +3,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package main
+package loader
 
 import (
 	"fmt"
@@ -313,3 +313,41 @@ func TestAddReceipts_SubfolderMode_TooManyReceiptsError(t *testing.T) {
 		t.Errorf("Expected error to contain '%s', got: %v", expectedErrSubstring, err)
 	}
 }
+
+func TestReceiptsMatcher_UnknownCount(t *testing.T) {
+	root, cleanup := setupTestDir(t, "matcherroot")
+	defer cleanup()
+
+	numberDir := filepath.Join(root, "5 - Rent")
+	employeeDir := filepath.Join(root, "John Doe")
+	for _, dir := range []string{numberDir, employeeDir} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir %s: %v", dir, err)
+		}
+	}
+	rent := createTestFile(t, numberDir, "rent.pdf", 10)
+	ticket := createTestFile(t, employeeDir, "ticket.pdf", 10)
+
+	matcher, err := newReceiptsMatcher(root)
+	if err != nil {
+		t.Fatalf("newReceiptsMatcher failed: %v", err)
+	}
+	john := lib.Entry{Party: &lib.Employee{Lastname: "Doe", Firstname: "John"}}
+	tests := []struct {
+		name     string
+		position int
+		count    int
+		entry    lib.Entry
+		expected []string
+	}{
+		{"streamed number folder", 5, -1, lib.Entry{}, []string{rent}},
+		{"number folder out of range", 5, 3, lib.Entry{}, nil},
+		{"other position", 4, -1, lib.Entry{}, nil},
+		{"employee folder", 1, -1, john, []string{ticket}},
+	}
+	for _, tt := range tests {
+		if got := matcher.match(tt.position, tt.count, tt.entry); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: receipts mismatch. Got: %v, Want: %v", tt.name, got, tt.expected)
+		}
+	}
+}
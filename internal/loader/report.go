@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+// importSummary describes the outcome of an import.
+type importSummary struct {
+	CSVPath  string         `json:"csv"`
+	DryRun   bool           `json:"dry_run"`
+	Started  time.Time      `json:"started"`
+	Finished time.Time      `json:"finished,omitzero"`
+	Entries  int            `json:"entries"`
+	Added    int            `json:"added"`
+	Failures []entryFailure `json:"failures,omitempty"`
+	// Interleaved are the accounting piece numbers given to other entries during the import.
+	Interleaved []string `json:"interleaved_numbers,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// entryFailure describes an entry that could not be added to happy-compta.
+type entryFailure struct {
+	Index int    `json:"index"`
+	Row   int    `json:"row"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// save writes the summary as JSON to the given path.
+func (s importSummary) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the import report: %s", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write the import report %s: %s", path, err)
+	}
+	return nil
+}
+
+// notification returns the notification describing the outcome of the import.
+func (s importSummary) notification(report string) common.Notification {
+	n := common.Notification{Tool: "loader", Status: common.NotifySuccess, Summary: s, Report: report}
+	name := filepath.Base(s.CSVPath)
+	switch {
+	case s.Error != "":
+		n.Status = common.NotifyFailure
+		n.Text = fmt.Sprintf("Import of %s failed: %s", name, s.Error)
+	case s.DryRun:
+		n.Text = fmt.Sprintf("Dry run of the import of %s: %d entries checked", name, s.Entries)
+	default:
+		n.Text = fmt.Sprintf("Import of %s: %d of %d entries added", name, s.Added, s.Entries)
+		if len(s.Failures) > 0 {
+			n.Status = common.NotifyFailure
+			n.Text += fmt.Sprintf(", %d failed", len(s.Failures))
+		}
+		if len(s.Interleaved) > 0 {
+			n.Text += fmt.Sprintf(", %d numbers taken by other entries meanwhile", len(s.Interleaved))
+		}
+	}
+	return n
+}
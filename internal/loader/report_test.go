@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+)
+
+func TestImportSummaryNotification(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  importSummary
+		status   string
+		expected string
+	}{
+		{
+			"success",
+			importSummary{CSVPath: "/data/march.csv", Entries: 3, Added: 3},
+			common.NotifySuccess,
+			"Import of march.csv: 3 of 3 entries added",
+		},
+		{
+			"partial",
+			importSummary{CSVPath: "march.csv", Entries: 3, Added: 2, Failures: []entryFailure{{Index: 2}}},
+			common.NotifyFailure,
+			"Import of march.csv: 2 of 3 entries added, 1 failed",
+		},
+		{
+			"interleaved",
+			importSummary{CSVPath: "march.csv", Entries: 2, Added: 2, Interleaved: []string{"FON000013"}},
+			common.NotifySuccess,
+			"Import of march.csv: 2 of 2 entries added, 1 numbers taken by other entries meanwhile",
+		},
+		{
+			"dry run",
+			importSummary{CSVPath: "march.csv", Entries: 3, DryRun: true},
+			common.NotifySuccess,
+			"Dry run of the import of march.csv: 3 entries checked",
+		},
+		{
+			"error",
+			importSummary{CSVPath: "march.csv", Error: "login failed"},
+			common.NotifyFailure,
+			"Import of march.csv failed: login failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.summary.notification("report.json")
+			if n.Tool != "loader" || n.Report != "report.json" {
+				t.Errorf("Notification mismatch. Got: %+v", n)
+			}
+			if n.Status != tt.status {
+				t.Errorf("Status mismatch. Got: %s, Want: %s", n.Status, tt.status)
+			}
+			if n.Text != tt.expected {
+				t.Errorf("Text mismatch. Got: %s, Want: %s", n.Text, tt.expected)
+			}
+		})
+	}
+}
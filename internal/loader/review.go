@@ -0,0 +1,447 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reviewField is a CSV field that can be edited during the review.
+type reviewField int
+
+const (
+	reviewFieldNone reviewField = iota
+	reviewFieldCategory
+	reviewFieldEmployee
+	reviewFieldProvider
+	reviewFieldBank
+)
+
+func (f reviewField) String() string {
+	switch f {
+	case reviewFieldCategory:
+		return "category"
+	case reviewFieldEmployee:
+		return "employee"
+	case reviewFieldProvider:
+		return "provider"
+	case reviewFieldBank:
+		return "bank"
+	}
+	return ""
+}
+
+// reviewItem is a row in the review list.
+type reviewItem struct {
+	row      csvRow
+	excluded bool
+	// suggestion is the category suggested for the row, waiting for confirmation.
+	suggestion *categorySuggestion
+}
+
+func (i reviewItem) status() string {
+	if i.excluded {
+		return "excluded"
+	}
+	if i.row.err != nil {
+		return "invalid"
+	}
+	if i.suggestion != nil {
+		return "suggested"
+	}
+	return "ok"
+}
+
+// reviewModel is the terminal UI model listing the parsed rows for review.
+type reviewModel struct {
+	parser        *rowParser
+	items         []reviewItem
+	categoryNames map[int]string
+	candidates    map[reviewField][]string
+
+	cursor int
+	offset int
+	height int
+
+	editing     reviewField
+	input       string
+	completions []string
+	completion  int
+
+	approved bool
+}
+
+// reviewRows shows the rows in a terminal UI to fix or exclude them and returns the approved ones.
+// The rows with a suggested category are only approved once the suggestion is confirmed.
+func reviewRows(
+	parser *rowParser,
+	rows []csvRow,
+	refs referenceData,
+	suggestions map[int]categorySuggestion,
+) ([]csvRow, error) {
+	model := newReviewModel(parser, rows, refs)
+	for i := range model.items {
+		if suggestion, found := suggestions[model.items[i].row.index]; found {
+			model.items[i].suggestion = &suggestion
+		}
+	}
+	final, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the review: %s", err)
+	}
+
+	result := final.(*reviewModel)
+	if !result.approved {
+		return nil, errors.New("import canceled during the review")
+	}
+	if err := result.checkBalances(); err != nil {
+		return nil, common.WithExitCode(common.ExitValidation, err)
+	}
+	return result.approvedRows(), nil
+}
+
+func newReviewModel(parser *rowParser, rows []csvRow, refs referenceData) *reviewModel {
+	model := &reviewModel{
+		parser:        parser,
+		items:         make([]reviewItem, len(rows)),
+		categoryNames: map[int]string{},
+		candidates:    map[reviewField][]string{},
+		height:        20,
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row.fields))
+	}
+
+	width = max(width, len(parser.header))
+
+	// The edited fields need a column even if the CSV file doesn't have it.
+	// Those columns get the default names so that the rows can be written back.
+	for _, column := range []struct {
+		name   string
+		idxPtr *int
+	}{
+		{"category", &parser.colMap.Category},
+		{"employee", &parser.colMap.Employee},
+		{"provider", &parser.colMap.Provider},
+		{"account", &parser.colMap.Bank},
+	} {
+		if *column.idxPtr < 0 {
+			*column.idxPtr = width
+			parser.header, _ = common.PadRow(parser.header, width)
+			parser.header = append(parser.header, column.name)
+			width++
+		}
+	}
+
+	for i, row := range rows {
+		if row.fields != nil {
+			row.fields, _ = common.PadRow(row.fields, width)
+		}
+		model.items[i] = reviewItem{row: row}
+	}
+
+	for _, category := range refs.Categories {
+		model.categoryNames[category.ID] = category.Name
+		model.addCandidate(reviewFieldCategory, category.Name)
+	}
+	for _, employee := range refs.Employees {
+		model.addCandidate(reviewFieldEmployee, employee.Lastname+" "+employee.Firstname)
+	}
+	for _, provider := range refs.Providers {
+		model.addCandidate(reviewFieldProvider, provider.Name)
+	}
+	for _, account := range refs.Accounts {
+		model.addCandidate(reviewFieldBank, account.Bank)
+	}
+	for _, candidates := range model.candidates {
+		slices.Sort(candidates)
+	}
+	return model
+}
+
+func (m *reviewModel) addCandidate(field reviewField, value string) {
+	if value != "" && !slices.Contains(m.candidates[field], value) {
+		m.candidates[field] = append(m.candidates[field], value)
+	}
+}
+
+// approvedRows returns the valid rows that have not been excluded.
+func (m *reviewModel) approvedRows() []csvRow {
+	var rows []csvRow
+	for _, item := range m.items {
+		if item.status() == "ok" {
+			rows = append(rows, item.row)
+		}
+	}
+	return rows
+}
+
+// checkBalances checks the running balances of the reviewed rows.
+// The excluded rows are still bank operations changing the balance: only the invalid ones are left out.
+func (m *reviewModel) checkBalances() error {
+	var rows []csvRow
+	for _, item := range m.items {
+		if item.row.err == nil {
+			rows = append(rows, item.row)
+		}
+	}
+	return checkRowBalances(m.parser, rows)
+}
+
+func (m *reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Leave room for the title, table header, details and help lines.
+		m.height = max(msg.Height-8, 1)
+		m.scroll()
+	case tea.KeyMsg:
+		if m.editing != reviewFieldNone {
+			m.updateEdit(msg)
+			return m, nil
+		}
+		return m, m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m *reviewModel) updateList(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, len(m.items)-1)
+	case "pgup":
+		m.cursor = max(m.cursor-m.height, 0)
+	case "pgdown":
+		m.cursor = min(m.cursor+m.height, len(m.items)-1)
+	case " ", "x":
+		if len(m.items) > 0 {
+			m.items[m.cursor].excluded = !m.items[m.cursor].excluded
+		}
+	case "c":
+		m.startEdit(reviewFieldCategory)
+	case "e":
+		m.startEdit(reviewFieldEmployee)
+	case "p":
+		m.startEdit(reviewFieldProvider)
+	case "a":
+		m.startEdit(reviewFieldBank)
+	case "y":
+		if len(m.items) > 0 {
+			m.items[m.cursor].suggestion = nil
+		}
+	case "u":
+		m.approved = true
+		return tea.Quit
+	case "q", "esc", "ctrl+c":
+		return tea.Quit
+	}
+	m.scroll()
+	return nil
+}
+
+func (m *reviewModel) updateEdit(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.apply(m.editing, strings.TrimSpace(m.input))
+		m.editing = reviewFieldNone
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.editing = reviewFieldNone
+	case tea.KeyTab:
+		m.complete()
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+		m.completions = nil
+	case tea.KeyRunes, tea.KeySpace:
+		m.input += string(msg.Runes)
+		m.completions = nil
+	}
+}
+
+// startEdit opens the input line for a field of the selected row.
+func (m *reviewModel) startEdit(field reviewField) {
+	if len(m.items) == 0 || m.items[m.cursor].row.fields == nil {
+		return
+	}
+	m.editing = field
+	m.input = getField(m.items[m.cursor].row.fields, *m.fieldIndex(field))
+	m.completions = nil
+}
+
+// complete cycles through the known values starting like the input.
+func (m *reviewModel) complete() {
+	if m.completions == nil {
+		prefix := strings.ToLower(m.input)
+		for _, candidate := range m.candidates[m.editing] {
+			if strings.HasPrefix(strings.ToLower(candidate), prefix) {
+				m.completions = append(m.completions, candidate)
+			}
+		}
+		m.completion = -1
+	}
+	if len(m.completions) == 0 {
+		return
+	}
+	m.completion = (m.completion + 1) % len(m.completions)
+	m.input = m.completions[m.completion]
+}
+
+func (m *reviewModel) fieldIndex(field reviewField) *int {
+	switch field {
+	case reviewFieldCategory:
+		return &m.parser.colMap.Category
+	case reviewFieldEmployee:
+		return &m.parser.colMap.Employee
+	case reviewFieldProvider:
+		return &m.parser.colMap.Provider
+	case reviewFieldBank:
+		return &m.parser.colMap.Bank
+	}
+	return nil
+}
+
+// apply sets the field value of the selected row and validates the row again.
+func (m *reviewModel) apply(field reviewField, value string) {
+	item := &m.items[m.cursor]
+	fields := slices.Clone(item.row.fields)
+
+	// The employee and provider are mutually exclusive
+	switch field {
+	case reviewFieldEmployee:
+		fields[m.parser.colMap.Provider] = ""
+	case reviewFieldProvider:
+		fields[m.parser.colMap.Employee] = ""
+	}
+	fields[*m.fieldIndex(field)] = value
+	if field == reviewFieldCategory {
+		item.suggestion = nil
+	}
+
+	item.row.fields = fields
+	item.row.entry, item.row.err = m.parser.parse(item.row.index, fields)
+}
+
+// scroll moves the visible window to show the selected row.
+func (m *reviewModel) scroll() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+func (m *reviewModel) View() string {
+	var approved, excluded, invalid, suggested int
+	for _, item := range m.items {
+		switch item.status() {
+		case "ok":
+			approved++
+		case "excluded":
+			excluded++
+		case "invalid":
+			invalid++
+		case "suggested":
+			suggested++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Review the entries: %d approved, %d excluded, %d invalid", approved, excluded, invalid)
+	if suggested > 0 {
+		fmt.Fprintf(&b, ", %d with a suggested category", suggested)
+	}
+	b.WriteString("\n\n")
+
+	lines := m.tableLines()
+	b.WriteString("  " + lines[0] + "\n")
+	end := min(m.offset+m.height, len(m.items))
+	for i := m.offset; i < end; i++ {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		b.WriteString(prefix + lines[i+1] + "\n")
+	}
+	b.WriteString("\n")
+
+	if len(m.items) > 0 {
+		if err := m.items[m.cursor].row.err; err != nil {
+			b.WriteString(err.Error() + "\n")
+		}
+		if suggestion := m.items[m.cursor].suggestion; suggestion != nil {
+			fmt.Fprintf(&b, "%s category suggested from the similar entry '%s' (score %.2f), y: accept\n",
+				suggestion.category, suggestion.similar, suggestion.score)
+		}
+	}
+
+	if m.editing != reviewFieldNone {
+		fmt.Fprintf(&b, "%s: %s█\n", m.editing, m.input)
+		b.WriteString("enter: apply • tab: complete • esc: cancel\n")
+	} else {
+		b.WriteString("↑/↓: move • x: exclude • c: category • e: employee • p: provider • a: account bank\n")
+		fmt.Fprintf(&b, "u: upload the %d approved entries • q: quit without uploading\n", approved)
+	}
+	return b.String()
+}
+
+// tableLines renders the header and all the rows aligned in columns.
+func (m *reviewModel) tableLines() []string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ROW\tSTATUS\tDATE\tKIND\tNAME\tAMOUNT\tCATEGORY\tPARTY\tACCOUNT")
+	for _, item := range m.items {
+		row := item.row
+		if row.err != nil {
+			_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				row.index, item.status(),
+				getField(row.fields, m.parser.colMap.Date), getField(row.fields, m.parser.colMap.Kind),
+				getField(row.fields, m.parser.colMap.Name), getField(row.fields, m.parser.colMap.Amount),
+				getField(row.fields, m.parser.colMap.Category), m.rowParty(row.fields),
+				getField(row.fields, m.parser.colMap.Bank),
+			)
+			continue
+		}
+
+		var amount float64
+		names := make([]string, 0, len(row.entry.Allocation))
+		for _, line := range row.entry.Allocation {
+			amount += line.Amount
+			names = append(names, m.categoryNames[line.CategoryID])
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\t%s (%s)\n",
+			row.index, item.status(), row.entry.Date.Format(lib.DateLayout), row.entry.Kind, row.entry.Name,
+			amount, strings.Join(names, ", "), partyName(row.entry.Party),
+			row.entry.Account.Bank, row.entry.Account.Budget,
+		)
+	}
+	_ = tw.Flush()
+
+	return strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+}
+
+func (m *reviewModel) rowParty(fields []string) string {
+	if employee := getField(fields, m.parser.colMap.Employee); employee != "" {
+		return employee
+	}
+	return getField(fields, m.parser.colMap.Provider)
+}
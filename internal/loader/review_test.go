@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTestReviewModel(t *testing.T) *reviewModel {
+	refs := referenceData{
+		Accounts:   []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}},
+		Categories: getMockCategories(),
+		Employees:  []lib.Employee{{ID: "e1", Lastname: "Doe", Firstname: "Jane"}},
+		Providers:  []lib.Provider{{ID: "p1", Name: "ACME"}},
+		Periods:    getMockPeriods(),
+	}
+	defaults := getBaseDefaults()
+	defaults.Bank = "Bank A"
+
+	input := "date,name,amount,category,provider\n" +
+		"01/01/2025,Paper,12.50,Office Supplies,ACME\n" +
+		"02/01/2025,Rent,500,Unknown,\n"
+	columns := CSVColumns{Date: "date", Name: "name", Amount: "amount", Category: "category", Provider: "provider"}
+
+	parser, rows, err := readRows(
+		csv.NewReader(strings.NewReader(input)), columns, defaults, common.DateParams{},
+		refs.Accounts, refs.Categories, refs.Employees, refs.Providers, refs.Periods, nil,
+	)
+	if err != nil {
+		t.Fatalf("readRows failed: %v", err)
+	}
+	return newReviewModel(parser, rows, refs)
+}
+
+func pressKeys(m *reviewModel, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		_, cmd = m.Update(key)
+	}
+	return cmd
+}
+
+func runeKeys(s string) []tea.KeyMsg {
+	var keys []tea.KeyMsg
+	for _, r := range s {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return keys
+}
+
+func TestReviewFixCategory(t *testing.T) {
+	m := newTestReviewModel(t)
+
+	if status := m.items[1].status(); status != "invalid" {
+		t.Fatalf("Row status mismatch. Got: %s, Want: invalid", status)
+	}
+
+	pressKeys(m, runeKeys("jc")...)
+	if m.editing != reviewFieldCategory || m.input != "Unknown" {
+		t.Fatalf("Edit mismatch. Got: %s %q, Want: category \"Unknown\"", m.editing, m.input)
+	}
+
+	keys := []tea.KeyMsg{}
+	for range "Unknown" {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	keys = append(keys, runeKeys("re")...)
+	keys = append(keys, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyEnter})
+	pressKeys(m, keys...)
+
+	if m.editing != reviewFieldNone {
+		t.Errorf("Expected the edit to be done, still editing %s", m.editing)
+	}
+	if err := m.items[1].row.err; err != nil {
+		t.Fatalf("Expected the row to be fixed, got: %v", err)
+	}
+	if got := m.items[1].row.entry.Allocation[0].CategoryID; got != 101 {
+		t.Errorf("Category mismatch. Got: %d, Want: 101", got)
+	}
+}
+
+func TestReviewPartyExclusive(t *testing.T) {
+	m := newTestReviewModel(t)
+
+	// The employee column is not in the CSV file
+	if m.parser.colMap.Employee < 0 {
+		t.Fatal("Expected an employee column to be added")
+	}
+
+	keys := runeKeys("e")
+	keys = append(keys, runeKeys("Doe Jane")...)
+	keys = append(keys, tea.KeyMsg{Type: tea.KeyEnter})
+	pressKeys(m, keys...)
+
+	row := m.items[0].row
+	if row.err != nil {
+		t.Fatalf("Expected a valid row, got: %v", row.err)
+	}
+	if got := getField(row.fields, m.parser.colMap.Provider); got != "" {
+		t.Errorf("Provider mismatch. Got: %q, Want it empty", got)
+	}
+	if got := partyName(row.entry.Party); got != "Jane Doe" {
+		t.Errorf("Party mismatch. Got: %q, Want: %q", got, "Jane Doe")
+	}
+}
+
+func TestReviewExcludeAndUpload(t *testing.T) {
+	m := newTestReviewModel(t)
+
+	pressKeys(m, runeKeys("x")...)
+	if got := m.View(); !strings.Contains(got, "0 approved, 1 excluded, 1 invalid") {
+		t.Errorf("View mismatch. Got: %q", got)
+	}
+	if rows := m.approvedRows(); len(rows) != 0 {
+		t.Errorf("Approved rows mismatch. Got: %d, Want: 0", len(rows))
+	}
+
+	pressKeys(m, runeKeys("x")...)
+	if rows := m.approvedRows(); len(rows) != 1 || rows[0].entry.Name != "Paper" {
+		t.Errorf("Approved rows mismatch. Got: %+v", rows)
+	}
+
+	if cmd := pressKeys(m, runeKeys("u")...); cmd == nil || !m.approved {
+		t.Error("Expected the upload key to approve and quit")
+	}
+}
+
+func TestReviewCheckBalances(t *testing.T) {
+	refs := referenceData{
+		Accounts:   []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}},
+		Categories: getMockCategories(),
+		Periods:    getMockPeriods(),
+	}
+	defaults := getBaseDefaults()
+	defaults.Bank = "Bank A"
+	columns := CSVColumns{Date: "date", Name: "name", Amount: "amount", Category: "category", Balance: "balance"}
+
+	tests := []struct {
+		name    string
+		balance string
+		wantErr string
+	}{
+		{"consistent", "80.00", ""},
+		{"truncated", "70.00", "balance mismatch between rows 1 and 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "date,name,amount,category,balance\n" +
+				"01/01/2025,Paper,12.00,Office Supplies,88.00\n" +
+				"02/01/2025,Rent,500,Unknown,\n" +
+				"03/01/2025,Pens,8.00,Office Supplies," + tt.balance + "\n"
+			parser, rows, err := readRows(
+				csv.NewReader(strings.NewReader(input)), columns, defaults, common.DateParams{},
+				refs.Accounts, refs.Categories, refs.Employees, refs.Providers, refs.Periods, nil,
+			)
+			if err != nil {
+				t.Fatalf("readRows failed: %v", err)
+			}
+			m := newReviewModel(parser, rows, refs)
+
+			// Excluding a valid row keeps it in the balance check.
+			pressKeys(m, runeKeys("x")...)
+			err = m.checkBalances()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkBalances failed unexpectedly: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Error mismatch. Got: %v, Want: %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReviewQuit(t *testing.T) {
+	m := newTestReviewModel(t)
+
+	if cmd := pressKeys(m, runeKeys("q")...); cmd == nil || m.approved {
+		t.Error("Expected the quit key to quit without approving")
+	}
+}
+
+func TestReviewAcceptSuggestion(t *testing.T) {
+	m := newTestReviewModel(t)
+	m.items[0].suggestion = &categorySuggestion{row: 1, category: "Office Supplies", similar: "Paper", score: 0.9}
+
+	if status := m.items[0].status(); status != "suggested" {
+		t.Fatalf("Row status mismatch. Got: %s, Want: suggested", status)
+	}
+	if got := m.View(); !strings.Contains(got, "1 with a suggested category") {
+		t.Errorf("Expected the suggestions count in the view, got:\n%s", got)
+	}
+	if rows := m.approvedRows(); len(rows) != 0 {
+		t.Errorf("Expected no approved row before accepting the suggestion, got %d", len(rows))
+	}
+
+	pressKeys(m, runeKeys("y")...)
+	if rows := m.approvedRows(); len(rows) != 1 {
+		t.Errorf("Approved rows count mismatch. Got: %d, Want: 1", len(rows))
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/spf13/cobra"
+)
+
+// maxFolderDescriptionLength is the maximum number of characters of the entry name in the receipt folder names.
+const maxFolderDescriptionLength = 60
+
+var scaffoldReceiptsCmd = &cobra.Command{
+	Use:   "scaffold-receipts path/to/file.csv",
+	Short: "Create one receipts folder per row of a CSV file",
+	Long: `Create one empty folder per row of the CSV file to sort the receipts before the import.
+
+The folders are named after the entry number, name and employee, like 003 - Gifts - John Doe.
+Drop the receipts of each entry in its folder and pass the parent folder to --receipts when importing.
+The existing folders are kept. The CSV structure is read from the configuration.`,
+	Args: common.UsageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := readConfig()
+		if err != nil {
+			return err
+		}
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			return err
+		}
+
+		r, cleaner, err := common.GetRowReader(cfg.CSV.CSVParams, args[0])
+		if err != nil {
+			return err
+		}
+		defer cleaner()
+
+		count, err := scaffoldReceipts(r, cfg.CSV.Columns, out)
+		if err != nil {
+			return err
+		}
+		slog.Info("receipt folders created", "folders", count, "path", out)
+		return nil
+	},
+}
+
+func init() {
+	scaffoldReceiptsCmd.Flags().String("out", "receipts", "Folder to create the receipt folders in.")
+}
+
+// scaffoldReceipts creates a receipt folder in out for each row of the CSV reader.
+// It returns the number of folders.
+func scaffoldReceipts(r common.RowReader, columns CSVColumns, out string) (int, error) {
+	header, err := r.Read()
+	if err == io.EOF {
+		return 0, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CSV header: %s", err)
+	}
+	colMap := buildColumnMap(header, columns)
+
+	var names []string
+	for rowIndex := 1; ; rowIndex++ {
+		fields, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read row %d: %s", rowIndex, err)
+		}
+		name := getField(fields, colMap.Name)
+		employee := getField(fields, colMap.Employee)
+		names = append(names, receiptFolderName(rowIndex, name, employee))
+	}
+
+	for _, name := range names {
+		path := filepath.Join(out, name)
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return 0, fmt.Errorf("failed to create the receipt folder %s: %s", path, err)
+		}
+	}
+	return len(names), nil
+}
+
+// receiptFolderName returns the name of the receipt folder of an entry, starting with its number.
+func receiptFolderName(number int, name string, employee string) string {
+	parts := []string{fmt.Sprintf("%03d", number)}
+	for _, part := range []string{name, employee} {
+		if part = sanitizeFolderPart(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, receiptFolderSeparator)
+}
+
+// sanitizeFolderPart removes the characters not allowed in folder names on the usual systems
+// and shortens the text to keep the paths readable.
+func sanitizeFolderPart(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return ' '
+		}
+		return r
+	}, value)
+	value = strings.Join(strings.Fields(value), " ")
+
+	if runes := []rune(value); len(runes) > maxFolderDescriptionLength {
+		value = strings.TrimSpace(string(runes[:maxFolderDescriptionLength]))
+	}
+	// Windows doesn't accept the folder names ending with a dot.
+	return strings.TrimRight(value, ". ")
+}
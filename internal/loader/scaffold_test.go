@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestReceiptFolderName(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   int
+		entry    string
+		employee string
+		expected string
+	}{
+		{"Full", 3, "Cadeaux de Noël", "Jean Dupont", "003 - Cadeaux de Noël - Jean Dupont"},
+		{"NoEmployee", 12, "Facture", "", "012 - Facture"},
+		{"NumberOnly", 1000, "", "", "1000"},
+		{"Forbidden", 1, `Achat 2/3: "cartes"?`, "Jean\tDupont", "001 - Achat 2 3 cartes - Jean Dupont"},
+		{"TrailingDot", 1, "Divers...", "", "001 - Divers"},
+		{"Long", 1, strings.Repeat("a", 70), "", "001 - " + strings.Repeat("a", maxFolderDescriptionLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := receiptFolderName(tt.number, tt.entry, tt.employee)
+			if actual != tt.expected {
+				t.Errorf("Folder name mismatch. Got: %s, Want: %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReceiptFolderNumber(t *testing.T) {
+	tests := []struct {
+		folder   string
+		expected int
+		found    bool
+	}{
+		{"3", 3, true},
+		{"003 - Cadeaux - Jean Dupont", 3, true},
+		{"jean dupont", 0, false},
+		{"3 cadeaux", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.folder, func(t *testing.T) {
+			number, found := receiptFolderNumber(tt.folder)
+			if number != tt.expected || found != tt.found {
+				t.Errorf("Folder number mismatch. Got: %d, %v, Want: %d, %v", number, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestScaffoldReceipts(t *testing.T) {
+	content := "name,date,employee\nCadeaux,01/02/2025,Jean Dupont\nFacture,02/02/2025,\n"
+	out := filepath.Join(t.TempDir(), "receipts")
+
+	columns := CSVColumns{Name: "name", Employee: "employee"}
+
+	count, err := scaffoldReceipts(csv.NewReader(strings.NewReader(content)), columns, out)
+	if err != nil {
+		t.Fatalf("scaffoldReceipts failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Folders count mismatch. Got: %d, Want: 2", count)
+	}
+
+	items, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatalf("failed to read the receipts folder: %v", err)
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name())
+	}
+	expected := []string{"001 - Cadeaux - Jean Dupont", "002 - Facture"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Folders mismatch. Got: %v, Want: %v", names, expected)
+	}
+
+	// The scaffolded folders are matched to the entries when importing.
+	receipt := createTestFile(t, filepath.Join(out, expected[1]), "facture.pdf", 100)
+	entries := []lib.Entry{{Name: "Cadeaux"}, {Name: "Facture"}}
+	if err := addReceipts(out, entries); err != nil {
+		t.Fatalf("addReceipts failed: %v", err)
+	}
+	if len(entries[0].Receipts) != 0 || !reflect.DeepEqual(entries[1].Receipts, []string{receipt}) {
+		t.Errorf("Receipts mismatch. Got: %v, %v, Want: [], [%s]", entries[0].Receipts, entries[1].Receipts, receipt)
+	}
+
+	// Running it again keeps the existing folders.
+	if _, err := scaffoldReceipts(csv.NewReader(strings.NewReader(content)), columns, out); err != nil {
+		t.Errorf("scaffoldReceipts failed on existing folders: %v", err)
+	}
+	if _, err := os.Stat(receipt); err != nil {
+		t.Errorf("The receipt has been removed: %v", err)
+	}
+}
+
+func TestScaffoldReceiptsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.csv")
+	err := scaffoldReceiptsCmd.RunE(scaffoldReceiptsCmd, []string{path})
+	if err == nil || !strings.Contains(err.Error(), "failed to open CSV file") {
+		t.Errorf("Expected a missing file error, got: %v", err)
+	}
+}
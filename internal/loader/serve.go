@@ -0,0 +1,393 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maxUploadSize is the maximum size of an import request: the CSV file and the receipts archive.
+const maxUploadSize = 64 * 1024 * 1024
+
+// finishedJobRetention is the duration during which the finished imports can be queried.
+const finishedJobRetention = time.Hour
+
+// importState is the processing state of an uploaded import.
+type importState string
+
+const (
+	importQueued  importState = "queued"
+	importRunning importState = "running"
+	importDone    importState = "done"
+	importFailed  importState = "failed"
+)
+
+// importJob is an import uploaded to the server.
+type importJob struct {
+	ID        string        `json:"id"`
+	State     importState   `json:"state"`
+	Summary   importSummary `json:"summary"`
+	Error     string        `json:"error,omitempty"`
+	ErrorsCSV string        `json:"errors_csv,omitempty"`
+
+	cfg       Config
+	workDir   string
+	errorsCSV []byte
+	finished  time.Time
+}
+
+// importServer accepts CSV uploads and imports them one after the other.
+type importServer struct {
+	cfg   Config
+	token string
+	run   func(Config) (importSummary, error)
+	now   func() time.Time
+
+	mutex sync.Mutex
+	jobs  map[string]*importJob
+	queue chan *importJob
+}
+
+func newImportServer(cfg Config, token string) *importServer {
+	// There is nobody to answer questions in the server.
+	cfg.Yes = true
+	cfg.Review = false
+
+	return &importServer{
+		cfg:   cfg,
+		token: token,
+		run:   runImport,
+		now:   time.Now,
+		jobs:  map[string]*importJob{},
+		queue: make(chan *importJob, 100),
+	}
+}
+
+// handler returns the HTTP handler of the server.
+func (s *importServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /imports", s.handleUpload)
+	mux.HandleFunc("GET /imports/{id}", s.handleStatus)
+	mux.HandleFunc("GET /imports/{id}/errors.csv", s.handleErrorsCSV)
+	return common.RequireToken(s.token, mux, func(w http.ResponseWriter) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// work processes the queued imports until the queue is closed.
+func (s *importServer) work() {
+	for job := range s.queue {
+		s.setState(job, importRunning)
+
+		summary, err := s.run(job.cfg)
+		errorsCSV, readErr := os.ReadFile(job.cfg.ErrorsCSV)
+		if readErr != nil && !errors.Is(readErr, os.ErrNotExist) {
+			slog.Error("failed to read the errors CSV", "import", job.ID, "error", readErr)
+		}
+		if err := os.RemoveAll(job.workDir); err != nil {
+			slog.Error("failed to remove the import folder", "folder", job.workDir, "error", err)
+		}
+
+		s.mutex.Lock()
+		job.Summary = summary
+		job.State = importDone
+		job.finished = s.now()
+		if err != nil {
+			job.State = importFailed
+			job.Error = err.Error()
+		}
+		if len(errorsCSV) > 0 {
+			job.errorsCSV = errorsCSV
+			job.ErrorsCSV = "/imports/" + job.ID + "/errors.csv"
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// prune forgets the imports finished for longer than finishedJobRetention.
+// The caller needs to hold the mutex.
+func (s *importServer) prune() {
+	for id, job := range s.jobs {
+		if !job.finished.IsZero() && s.now().Sub(job.finished) > finishedJobRetention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *importServer) setState(job *importJob, state importState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	job.State = state
+}
+
+func (s *importServer) handleUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		http.Error(w, fmt.Sprintf("invalid upload: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.newJob(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	s.prune()
+	s.jobs[job.ID] = job
+	s.mutex.Unlock()
+
+	select {
+	case s.queue <- job:
+	default:
+		s.mutex.Lock()
+		delete(s.jobs, job.ID)
+		s.mutex.Unlock()
+		_ = os.RemoveAll(job.workDir)
+		http.Error(w, "too many pending imports", http.StatusServiceUnavailable)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w.Header().Set("Location", "/imports/"+job.ID)
+	s.writeJob(w, http.StatusAccepted, job)
+}
+
+// newJob stores the uploaded files in a new folder and prepares the import configuration.
+func (s *importServer) newJob(r *http.Request) (job *importJob, err error) {
+	id, err := newImportID()
+	if err != nil {
+		return
+	}
+
+	workDir, err := os.MkdirTemp("", "loader-import-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the import folder: %s", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(workDir)
+		}
+	}()
+
+	cfg := s.cfg
+	cfg.CSVPath = filepath.Join(workDir, "import.csv")
+	cfg.ErrorsCSV = filepath.Join(workDir, "errors.csv")
+	cfg.Receipts = ""
+	cfg.DryRun = r.FormValue("dry_run") == "true"
+	// The configured credentials are only used for the clients authenticated with the token.
+	if email := r.FormValue("email"); email != "" || s.token == "" {
+		cfg.Email = email
+		cfg.Password = r.FormValue("password")
+	}
+	if cfg.Email == "" || cfg.Password == "" {
+		return nil, errors.New("email and password are required")
+	}
+
+	csvFile, _, err := r.FormFile("csv")
+	if err != nil {
+		return nil, fmt.Errorf("missing csv file: %s", err)
+	}
+	defer func() { _ = csvFile.Close() }()
+	if err = saveUpload(csvFile, cfg.CSVPath); err != nil {
+		return
+	}
+
+	receipts, _, err := r.FormFile("receipts")
+	if err == nil {
+		defer func() { _ = receipts.Close() }()
+		cfg.Receipts = filepath.Join(workDir, "receipts")
+		if err = extractReceipts(receipts, cfg.Receipts); err != nil {
+			return
+		}
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		return nil, fmt.Errorf("invalid receipts archive: %s", err)
+	}
+
+	job = &importJob{ID: id, State: importQueued, cfg: cfg, workDir: workDir}
+	job.Summary.DryRun = cfg.DryRun
+	return job, nil
+}
+
+func (s *importServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, found := s.jobs[r.PathValue("id")]
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	s.writeJob(w, http.StatusOK, job)
+}
+
+func (s *importServer) handleErrorsCSV(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	job, found := s.jobs[r.PathValue("id")]
+	if !found || job.errorsCSV == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	_, _ = w.Write(job.errorsCSV)
+}
+
+// writeJob sends the job as JSON. The caller needs to hold the mutex.
+func (s *importServer) writeJob(w http.ResponseWriter, status int, job *importJob) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		slog.Error("failed to send the import status", "import", job.ID, "error", err)
+	}
+}
+
+func newImportID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate the import ID: %s", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func saveUpload(r io.Reader, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to save the uploaded file: %s", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to save the uploaded file: %s", err)
+	}
+	return file.Close()
+}
+
+// extractReceipts extracts the receipts zip archive in the dest folder.
+// The archive structure is kept as the receipts are looked up in folders named after the entries.
+func extractReceipts(r io.ReaderAt, dest string) error {
+	size, err := readerSize(r)
+	if err != nil {
+		return err
+	}
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("invalid receipts archive: %s", err)
+	}
+
+	for _, file := range archive.File {
+		path := filepath.Join(dest, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in the receipts archive: %s", file.Name)
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return fmt.Errorf("failed to extract %s: %s", file.Name, err)
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to extract %s: %s", file.Name, err)
+		}
+		content, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %s", file.Name, err)
+		}
+		// The receipts are limited in size anyway: stop reading too large files.
+		err = saveUpload(io.LimitReader(content, maxReceiptFileSize+1), path)
+		_ = content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readerSize gets the size of the uploaded file.
+func readerSize(r io.ReaderAt) (int64, error) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("cannot get the receipts archive size")
+	}
+	size, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get the receipts archive size: %s", err)
+	}
+	return size, nil
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run an HTTP server importing the uploaded CSV files",
+	Long: `Run an HTTP server importing the uploaded CSV files in the background.
+
+POST /imports takes a multipart form with the following fields:
+  csv       the CSV file to import (required)
+  receipts  a zip archive with the receipts folders
+  email     the happy-compta user email, defaults to the configured one when the server has a token
+  password  the happy-compta user password
+  dry_run   true to only validate the CSV file
+
+The response contains the import ID to get its status from GET /imports/<id>.
+When rows have failed, their errors can be downloaded from GET /imports/<id>/errors.csv.
+
+The server listens on the loopback interface by default. A token is required to listen on other addresses.
+The finished imports are forgotten after an hour.
+
+The CSV structure, default values and other import settings are read from the configuration.`,
+	Args: common.UsageArgs(cobra.NoArgs),
+	// The flags are only bound when running the command to keep them out of the other commands configuration.
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Flags().VisitAll(common.BindFlagsToViper)
+		return common.WithExitCode(common.ExitConfig, common.LoadSecretFile("LOADER_SERVE_TOKEN"))
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := readConfig()
+		if err != nil {
+			return err
+		}
+		// The clients can pass their own credentials: the configured ones are optional.
+		credentials := common.ReadCredentials()
+		cfg.Email, cfg.Password = credentials.Email, credentials.Password
+
+		listen, token := viper.GetString("serve.listen"), viper.GetString("serve.token")
+		if err := common.CheckListenAddress(listen, token); err != nil {
+			return err
+		}
+		server := newImportServer(cfg, token)
+		go server.work()
+
+		slog.Info("listening", "address", listen)
+		return http.ListenAndServe(listen, server.handler())
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("serve-listen", "127.0.0.1:8080", "Address to listen on.")
+	serveCmd.Flags().String("serve-token", "", "Token the clients need to pass as an Authorization bearer header.")
+}
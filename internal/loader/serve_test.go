@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newUploadRequest builds a multipart import request with the given files and fields.
+func newUploadRequest(t *testing.T, files map[string][]byte, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, content := range files {
+		part, err := w.CreateFormFile(name, name)
+		if err != nil {
+			t.Fatalf("failed to create form file: %v", err)
+		}
+		_, _ = part.Write(content)
+	}
+	for name, value := range fields {
+		_ = w.WriteField(name, value)
+	}
+	_ = w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/imports", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func newZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+		_, _ = f.Write([]byte(content))
+	}
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func waitForJob(t *testing.T, handler http.Handler, id string) importJob {
+	for range 100 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/imports/"+id, nil))
+		var job importJob
+		if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+			t.Fatalf("failed to decode the status: %v", err)
+		}
+		if job.State == importDone || job.State == importFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("the import never finished")
+	return importJob{}
+}
+
+func TestServeImport(t *testing.T) {
+	server := newImportServer(Config{Email: "admin@example.com", Password: "secret"}, "")
+	var got Config
+	server.run = func(cfg Config) (importSummary, error) {
+		got = cfg
+		csvData, err := os.ReadFile(cfg.CSVPath)
+		if err != nil {
+			t.Errorf("failed to read the uploaded CSV: %v", err)
+		}
+		if string(csvData) != "date,name\n" {
+			t.Errorf("CSV mismatch. Got: %q", csvData)
+		}
+		receipt, err := os.ReadFile(filepath.Join(cfg.Receipts, "Paper", "invoice.pdf"))
+		if err != nil || string(receipt) != "pdf" {
+			t.Errorf("Receipt mismatch. Got: %q, error: %v", receipt, err)
+		}
+		_ = os.WriteFile(cfg.ErrorsCSV, []byte("date,name,import_error\n"), 0o644)
+		return importSummary{Entries: 2, Added: 1}, errors.New("boom")
+	}
+	go server.work()
+	defer close(server.queue)
+	handler := server.handler()
+
+	req := newUploadRequest(t,
+		map[string][]byte{
+			"csv":      []byte("date,name\n"),
+			"receipts": newZip(t, map[string]string{"Paper/invoice.pdf": "pdf"}),
+		},
+		map[string]string{"email": "treasurer@example.com", "password": "pass", "dry_run": "true"},
+	)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Status code mismatch. Got: %d, Want: %d: %s", rec.Code, http.StatusAccepted, rec.Body)
+	}
+	var queued importJob
+	if err := json.NewDecoder(rec.Body).Decode(&queued); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+
+	job := waitForJob(t, handler, queued.ID)
+	if job.State != importFailed || job.Error != "boom" || job.Summary.Added != 1 {
+		t.Errorf("Job mismatch. Got: %+v", job)
+	}
+	if got.Email != "treasurer@example.com" || got.Password != "pass" || !got.DryRun || !got.Yes {
+		t.Errorf("Config mismatch. Got: %+v", got)
+	}
+	if _, err := os.Stat(filepath.Dir(got.CSVPath)); !os.IsNotExist(err) {
+		t.Errorf("Expected the import folder to be removed, got: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, job.ErrorsCSV, nil))
+	if body, _ := io.ReadAll(rec.Body); string(body) != "date,name,import_error\n" {
+		t.Errorf("Errors CSV mismatch. Got: %q", body)
+	}
+}
+
+func TestServeUploadErrors(t *testing.T) {
+	server := newImportServer(Config{}, "")
+	handler := server.handler()
+
+	tests := []struct {
+		name   string
+		files  map[string][]byte
+		fields map[string]string
+	}{
+		{"no credentials", map[string][]byte{"csv": []byte("a\n")}, nil},
+		{"no csv", nil, map[string]string{"email": "a@example.com", "password": "pass"}},
+		{
+			"unsafe receipts path",
+			map[string][]byte{"csv": []byte("a\n"), "receipts": newZip(t, map[string]string{"../evil": "x"})},
+			map[string]string{"email": "a@example.com", "password": "pass"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newUploadRequest(t, tt.files, tt.fields))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Status code mismatch. Got: %d, Want: %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestServeToken(t *testing.T) {
+	handler := newImportServer(Config{}, "s3cret").handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/imports/missing", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Status code mismatch. Got: %d, Want: %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/imports/missing", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Status code mismatch. Got: %d, Want: %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServeConfiguredCredentials(t *testing.T) {
+	cfg := Config{Email: "admin@example.com", Password: "secret"}
+	files := map[string][]byte{"csv": []byte("date,name\n")}
+
+	// Without token, anybody could import with the configured account.
+	rec := httptest.NewRecorder()
+	newImportServer(cfg, "").handler().ServeHTTP(rec, newUploadRequest(t, files, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Status code mismatch without token. Got: %d, Want: %d", rec.Code, http.StatusBadRequest)
+	}
+
+	server := newImportServer(cfg, "s3cret")
+	req := newUploadRequest(t, files, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	server.handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Status code mismatch with token. Got: %d, Want: %d: %s", rec.Code, http.StatusAccepted, rec.Body)
+	}
+	job := <-server.queue
+	_ = os.RemoveAll(job.workDir)
+	if job.cfg.Email != "admin@example.com" || job.cfg.Password != "secret" {
+		t.Errorf("Credentials mismatch. Got: %s / %s", job.cfg.Email, job.cfg.Password)
+	}
+}
+
+func TestServePruneJobs(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	server := newImportServer(Config{}, "")
+	server.now = func() time.Time { return now }
+	server.jobs = map[string]*importJob{
+		"old":     {ID: "old", State: importDone, finished: now.Add(-2 * time.Hour)},
+		"recent":  {ID: "recent", State: importFailed, finished: now.Add(-time.Minute)},
+		"running": {ID: "running", State: importRunning},
+	}
+
+	server.prune()
+	if _, found := server.jobs["old"]; found || len(server.jobs) != 2 {
+		t.Errorf("Jobs mismatch. Got: %v, Want: recent and running", server.jobs)
+	}
+}
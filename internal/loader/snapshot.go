@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// referenceData holds the happy-compta data the CSV rows are resolved against.
+type referenceData struct {
+	Created    time.Time      `json:"created"`
+	Accounts   []lib.Account  `json:"accounts"`
+	Categories []lib.Category `json:"categories"`
+	Employees  []lib.Employee `json:"employees"`
+	Providers  []lib.Provider `json:"providers"`
+	Periods    []lib.Period   `json:"periods"`
+}
+
+// fetchReferenceData gets all the reference data from happy-compta.
+func fetchReferenceData(client *lib.Client) (refs referenceData, err error) {
+	refs.Created = time.Now()
+
+	if refs.Accounts, err = client.ListAccounts(); err != nil {
+		return
+	}
+	if refs.Categories, err = client.ListCategories(); err != nil {
+		return
+	}
+	if refs.Employees, err = client.ListEmployees(); err != nil {
+		return
+	}
+	if refs.Providers, err = client.ListProviders(); err != nil {
+		return
+	}
+	refs.Periods, err = client.ListPeriods()
+	return
+}
+
+// validate checks that the reference data has what is needed to create entries.
+func (r referenceData) validate() error {
+	if len(r.Accounts) == 0 {
+		return errors.New("no bank account defined in happy-compta")
+	}
+	if len(r.Periods) == 0 {
+		return errors.New("no accounting period defined in happy-compta")
+	}
+	return nil
+}
+
+// saveSnapshot writes the reference data to a JSON file.
+func saveSnapshot(path string, refs referenceData) error {
+	data, err := json.MarshalIndent(refs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize the reference data: %s", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the reference snapshot %s: %s", path, err)
+	}
+	return nil
+}
+
+// loadSnapshot reads the reference data from a JSON file written by saveSnapshot.
+func loadSnapshot(path string) (refs referenceData, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("failed to read the reference snapshot %s: %s", path, err)
+		return
+	}
+	if err = json.Unmarshal(data, &refs); err != nil {
+		err = fmt.Errorf("failed to parse the reference snapshot %s: %s", path, err)
+	}
+	return
+}
+
+// login creates a happy-compta client and logs it in.
+// The pages are cached in a folder per email in cacheDir if not empty.
+func login(email string, password string, cacheDir string) (*lib.Client, error) {
+	client, err := lib.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	if cacheDir != "" {
+		if err := client.EnableCache(filepath.Join(cacheDir, url.PathEscape(email))); err != nil {
+			return nil, err
+		}
+	}
+	if err := client.Login(email, password); err != nil {
+		return nil, common.WithExitCode(common.ExitAuth, err)
+	}
+	return client, nil
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot path/to/snapshot.json",
+	Short: "Save the happy-compta reference data to a file for offline dry runs",
+	Long: `Save the accounts, categories, employees, providers and accounting periods to a JSON file.
+The file can then be passed to --reference-snapshot to validate a CSV file with --dry-run
+without connecting to happy-compta.`,
+	Args: common.UsageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		credentials := common.ReadCredentials()
+		if err := credentials.Validate(); err != nil {
+			return err
+		}
+
+		client, err := login(credentials.Email, credentials.Password, viper.GetString("cache.dir"))
+		if err != nil {
+			return err
+		}
+
+		refs, err := fetchReferenceData(client)
+		if err != nil {
+			return err
+		}
+		return saveSnapshot(args[0], refs)
+	},
+}
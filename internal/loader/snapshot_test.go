@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	refs := referenceData{
+		Created: baseTime,
+		Accounts: []lib.Account{
+			{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON, Abbrev: "BA"},
+		},
+		Categories: getMockCategories(),
+		Employees: []lib.Employee{
+			{ID: "e1", Firstname: "Jane", Lastname: "Doe", Active: true},
+		},
+		Providers: []lib.Provider{
+			{ID: "p1", Name: "ACME", City: "Paris"},
+		},
+		Periods: getMockPeriods(),
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := saveSnapshot(path, refs); err != nil {
+		t.Fatalf("saveSnapshot failed: %v", err)
+	}
+
+	got, err := loadSnapshot(path)
+	if err != nil {
+		t.Fatalf("loadSnapshot failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, refs) {
+		t.Errorf("Snapshot mismatch. Got: %+v, Want: %+v", got, refs)
+	}
+}
+
+func TestLoadSnapshotMissing(t *testing.T) {
+	if _, err := loadSnapshot(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing snapshot file")
+	}
+}
+
+func TestReferenceDataValidate(t *testing.T) {
+	accounts := []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}}
+
+	tests := []struct {
+		name    string
+		refs    referenceData
+		wantErr bool
+	}{
+		{"complete", referenceData{Accounts: accounts, Periods: getMockPeriods()}, false},
+		{"no account", referenceData{Periods: getMockPeriods()}, true},
+		{"no period", referenceData{Accounts: accounts}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.refs.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error mismatch. Got: %v, Want error: %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// SplitRule spreads the amount of the matching rows on several categories, like a payment covering several charges.
+// The entries get one allocation line per category instead of the category of the row.
+type SplitRule struct {
+	// Match is the regular expression the name of the row needs to match, ignoring the case.
+	Match string `mapstructure:"match"`
+	// Budget restricts the rule to the rows of the budget, if set.
+	Budget string `mapstructure:"budget"`
+	// Lines are the percentages of the amount indexed by category name.
+	Lines map[string]float64 `mapstructure:"lines"`
+
+	regex *regexp.Regexp
+}
+
+// compileSplitRules checks the split rules and compiles their regular expressions.
+func compileSplitRules(rules map[string]SplitRule) (map[string]SplitRule, error) {
+	var allErrors []error
+	compiled := make(map[string]SplitRule, len(rules))
+	for name, rule := range rules {
+		regex, err := regexp.Compile("(?i)" + rule.Match)
+		if rule.Match == "" || err != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid match expression '%s' of the %s split rule", rule.Match, name))
+			continue
+		}
+		rule.regex = regex
+
+		if rule.Budget != "" && lib.NewBudgetFromString(rule.Budget) == lib.BudgetUndefined {
+			allErrors = append(allErrors, fmt.Errorf("invalid budget '%s' of the %s split rule", rule.Budget, name))
+		}
+		if len(rule.Lines) < 2 {
+			allErrors = append(allErrors, fmt.Errorf("the %s split rule needs at least two categories", name))
+		}
+		total := 0.0
+		for category, percent := range rule.Lines {
+			if percent <= 0 {
+				allErrors = append(allErrors, fmt.Errorf(
+					"the percentage of the %s category of the %s split rule needs to be positive", category, name,
+				))
+			}
+			total += percent
+		}
+		if math.Abs(total-100) > 0.001 {
+			allErrors = append(allErrors, fmt.Errorf(
+				"the percentages of the %s split rule sum to %g instead of 100", name, total,
+			))
+		}
+		compiled[name] = rule
+	}
+	return compiled, errors.Join(allErrors...)
+}
+
+// findSplitRule returns the name of the first rule, in alphabetical order, matching the row name and budget.
+func findSplitRule(rules map[string]SplitRule, name string, budget lib.Budget) (string, bool) {
+	for _, ruleName := range slices.Sorted(maps.Keys(rules)) {
+		rule := rules[ruleName]
+		if rule.regex == nil || !rule.regex.MatchString(name) {
+			continue
+		}
+		if rule.Budget != "" && lib.NewBudgetFromString(rule.Budget) != budget {
+			continue
+		}
+		return ruleName, true
+	}
+	return "", false
+}
+
+// matchSplitRule returns the name of the split rule applying to the row.
+// The rows with a category, a template or manifest allocation lines are not split.
+func (p *rowParser) matchSplitRule(rowIndex int, fields []string) (string, bool) {
+	if len(p.defaults.Splits) == 0 || getField(fields, p.colMap.Category) != "" ||
+		getField(fields, p.colMap.Template) != "" || len(p.allocations[rowIndex]) > 0 {
+		return "", false
+	}
+	budget := p.rowBudget(fields)
+	if budget == lib.BudgetUndefined {
+		// Let the entry creation report the missing budget.
+		return "", false
+	}
+	name := firstNonEmpty(getField(fields, p.colMap.Name), p.defaults.Name)
+	return findSplitRule(p.defaults.Splits, name, budget)
+}
+
+// rowBudget returns the budget of the row or the default one.
+func (p *rowParser) rowBudget(fields []string) lib.Budget {
+	return lib.NewBudgetFromString(firstNonEmpty(getField(fields, p.colMap.Budget), p.defaults.Budget))
+}
+
+// splitCategories returns the categories of the rule lines for the budget, in alphabetical order.
+// The configuration keys being lower case, the categories are matched ignoring the case and accents.
+func (p *rowParser) splitCategories(ruleName string, budget lib.Budget) ([]lib.Category, []float64, error) {
+	rule := p.defaults.Splits[ruleName]
+	var allErrors []error
+	var categories []lib.Category
+	var percents []float64
+	for _, name := range slices.Sorted(maps.Keys(rule.Lines)) {
+		key := stripDiacritics(strings.ToLower(name))
+		var category lib.Category
+		found := false
+		for _, candidate := range p.categories {
+			if candidate.Budget == budget && stripDiacritics(strings.ToLower(candidate.Name)) == key {
+				category, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			allErrors = append(allErrors, fmt.Errorf(
+				"invalid category '%s' name / '%s' budget combination in the %s split rule", name, budget, ruleName,
+			))
+			continue
+		}
+		if category.Stock {
+			allErrors = append(allErrors, fmt.Errorf(
+				"the %s category of the %s split rule has a stock and can't be split", category.Name, ruleName,
+			))
+			continue
+		}
+		categories = append(categories, category)
+		percents = append(percents, rule.Lines[name])
+	}
+	return categories, percents, errors.Join(allErrors...)
+}
+
+// splitAmount spreads the amount on the percentages, rounded to the cent.
+// The last line gets the rounding difference for the lines to sum to the amount.
+func splitAmount(amount float64, percents []float64) []float64 {
+	cents := math.Round(amount * 100)
+	amounts := make([]float64, len(percents))
+	allocated := 0.0
+	for i, percent := range percents {
+		if i == len(percents)-1 {
+			amounts[i] = (cents - allocated) / 100
+			break
+		}
+		line := math.Round(cents * percent / 100)
+		amounts[i] = line / 100
+		allocated += line
+	}
+	return amounts
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestCompileSplitRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SplitRule
+		wantErr string
+	}{
+		{"Valid", SplitRule{Match: "urssaf", Lines: map[string]float64{"rent": 70, "office supplies": 30}}, ""},
+		{"NoMatch", SplitRule{Lines: map[string]float64{"rent": 70, "office supplies": 30}}, "invalid match"},
+		{"InvalidMatch", SplitRule{Match: "urssaf(", Lines: map[string]float64{"rent": 100}}, "invalid match"},
+		{
+			"InvalidBudget",
+			SplitRule{Match: "urssaf", Budget: "XYZ", Lines: map[string]float64{"rent": 70, "office supplies": 30}},
+			"invalid budget",
+		},
+		{"OneLine", SplitRule{Match: "urssaf", Lines: map[string]float64{"rent": 100}}, "at least two categories"},
+		{
+			"BadSum",
+			SplitRule{Match: "urssaf", Lines: map[string]float64{"rent": 70, "office supplies": 20}},
+			"sum to 90 instead of 100",
+		},
+		{
+			"Negative",
+			SplitRule{Match: "urssaf", Lines: map[string]float64{"rent": 110, "office supplies": -10}},
+			"needs to be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := compileSplitRules(map[string]SplitRule{"urssaf": tt.rule})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("compileSplitRules failed: %v", err)
+				}
+				if rules["urssaf"].regex == nil {
+					t.Error("Expected the match expression to be compiled")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Error mismatch. Got: %v, Want: %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		percents []float64
+		expected []float64
+	}{
+		{"Even", 100, []float64{70, 30}, []float64{70, 30}},
+		{"Rounded", 100, []float64{33.33, 33.33, 33.34}, []float64{33.33, 33.33, 33.34}},
+		{"Remainder", 10, []float64{33, 33, 34}, []float64{3.3, 3.3, 3.4}},
+		{"Cents", 123.45, []float64{70, 30}, []float64{86.42, 37.03}},
+		{"Negative", -100, []float64{50, 50}, []float64{-50, -50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := splitAmount(tt.amount, tt.percents)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Amounts mismatch. Got: %v, Want: %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadRows_Splits(t *testing.T) {
+	defaults := getBaseDefaults()
+	defaults.Bank = "Bank A"
+	defaults.Category = ""
+	var err error
+	defaults.Splits, err = compileSplitRules(map[string]SplitRule{
+		"urssaf": {Match: "^urssaf", Lines: map[string]float64{"rent": 70, "office supplies": 30}},
+		"gifts":  {Match: "cadeaux", Budget: "ASC", Lines: map[string]float64{"gifts": 50, "unused": 50}},
+		"stock":  {Match: "cheques", Lines: map[string]float64{"check alloc": 50, "gifts": 50}},
+	})
+	if err != nil {
+		t.Fatalf("compileSplitRules failed: %v", err)
+	}
+
+	input := "date,name,amount,category,budget\n" +
+		"01/03/2025,URSSAF T1,1000.00,,\n" +
+		"02/03/2025,URSSAF T2,500.00,Rent,\n" +
+		"03/03/2025,Cadeaux,100.00,,\n" +
+		"04/03/2025,Cheques,100.00,,ASC\n"
+	columns := CSVColumns{Date: "date", Name: "name", Amount: "amount", Category: "category", Budget: "budget"}
+	accounts := []lib.Account{
+		{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON},
+		{ID: 2, Bank: "Bank A", Budget: lib.BudgetASC},
+	}
+	_, rows, err := readRows(
+		csv.NewReader(strings.NewReader(input)), columns, defaults, common.DateParams{},
+		accounts, getMockCategories(), nil, nil, getMockPeriods(), nil,
+	)
+	if err != nil {
+		t.Fatalf("readRows failed: %v", err)
+	}
+
+	// The categories are sorted by name: office supplies before rent.
+	expected := []lib.AllocationLine{{CategoryID: 100, Amount: 300}, {CategoryID: 101, Amount: 700}}
+	if rows[0].err != nil || !reflect.DeepEqual(rows[0].entry.Allocation, expected) {
+		t.Errorf("Split allocation mismatch. Got: %+v, %v, Want: %+v", rows[0].entry.Allocation, rows[0].err, expected)
+	}
+
+	expected = []lib.AllocationLine{{CategoryID: 101, Amount: 500}}
+	if rows[1].err != nil || !reflect.DeepEqual(rows[1].entry.Allocation, expected) {
+		t.Errorf("Row with category mismatch. Got: %+v, %v, Want: %+v", rows[1].entry.Allocation, rows[1].err, expected)
+	}
+
+	// The gifts rule only applies to the ASC budget.
+	if rows[2].err == nil || !strings.Contains(rows[2].err.Error(), "invalid category") {
+		t.Errorf("Expected the FON row not to be split, got: %+v, %v", rows[2].entry.Allocation, rows[2].err)
+	}
+
+	if rows[3].err == nil || !strings.Contains(rows[3].err.Error(), "can't be split") {
+		t.Errorf("Expected an error for the stock category, got: %v", rows[3].err)
+	}
+}
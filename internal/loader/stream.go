@@ -0,0 +1,290 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// checkStreamConfig rejects the options needing all the rows before uploading the first one.
+func checkStreamConfig(cfg Config, manifestInput bool) error {
+	var options []string
+	if manifestInput {
+		options = append(options, "manifests")
+	}
+	if cfg.Review {
+		options = append(options, "--review")
+	}
+	if cfg.ExpenseClaims {
+		options = append(options, "--expense-claims")
+	}
+	if cfg.SuggestCategories {
+		options = append(options, "--suggest-categories")
+	}
+	if cfg.Budgets.Block {
+		options = append(options, "--budgets-block")
+	}
+	if len(options) > 0 {
+		return common.WithExitCode(common.ExitUsage,
+			fmt.Errorf("the rows can't be streamed with %s", strings.Join(options, ", ")))
+	}
+	return nil
+}
+
+// entryAdder is the part of the happy-compta client adding the entries.
+type entryAdder interface {
+	AddEntry(entry *lib.Entry) error
+}
+
+// entryStream imports the rows one at a time: each row is validated, gets its receipts and is added
+// before the next one is read.
+// Only the data needed for the final checks and reports are kept, not the rows or their entries.
+type entryStream struct {
+	matcher  *receiptsMatcher
+	adder    entryAdder
+	throttle *throttler
+	summary  *importSummary
+
+	state      receiptsState
+	hashes     map[string]string
+	duplicates *duplicateFinder
+
+	// The errors CSV file is only created for the first failed row.
+	errorsPath   string
+	errorsParams common.CSVWriterParams
+	header       []string
+	errorsCSV    *errorsCSVWriter
+
+	balanceColumn int
+	previous      *balancePoint
+	forward       []error
+	backward      []error
+
+	// keepChecks tells whether the received checks are kept for the deposit slip.
+	keepChecks bool
+	checks     []lib.Entry
+	// added only holds the accounting piece numbers of the added entries.
+	added   []lib.Entry
+	valid   int
+	invalid int
+}
+
+// process validates the row at the given position, starting from 1, and adds its entry.
+// The entry is only validated if no adder is set, like for the dry runs.
+func (s *entryStream) process(position int, row csvRow) error {
+	s.summary.Entries++
+	if row.err != nil {
+		s.invalid++
+		slog.Error("invalid row", "row", row.index, "error", row.err)
+		return s.fail(position, row, row.err, s.adder != nil)
+	}
+	entry := row.entry
+	s.checkBalance(row)
+
+	if receipts := s.matcher.match(position, -1, entry); receipts != nil {
+		entry.Receipts = receipts
+	}
+	if err := addReceiptHashes(s.hashes, entry); err != nil {
+		s.invalid++
+		slog.Error("invalid row", "row", row.index, "error", err)
+		return s.fail(position, row, err, s.adder != nil)
+	}
+	for _, duplicate := range s.duplicates.check(position-1, entry, s.hashes) {
+		slog.Warn("duplicate receipt", "error", duplicate)
+	}
+
+	if s.adder == nil {
+		s.valid++
+		s.keepCheck(entry)
+		return nil
+	}
+
+	s.throttle.wait()
+	if err := s.adder.AddEntry(&entry); err != nil {
+		slog.Error("failed to add entry", "entry", position-1, "error", err)
+		return s.fail(position, row, err, true)
+	}
+	s.summary.Added++
+	s.added = append(s.added, lib.Entry{ID: entry.ID})
+	s.state.record([]lib.Entry{entry}, s.hashes, time.Now())
+	s.keepCheck(entry)
+	return nil
+}
+
+// fail writes the row to the errors CSV file and records it in the import failures if needed.
+func (s *entryStream) fail(position int, row csvRow, err error, record bool) error {
+	if record {
+		s.summary.Failures = append(s.summary.Failures, entryFailure{
+			Index: position - 1, Row: row.index, Name: row.entry.Name, Error: err.Error(),
+		})
+	}
+	if s.errorsPath == "" {
+		return nil
+	}
+	if s.errorsCSV == nil {
+		var createErr error
+		if s.errorsCSV, createErr = newErrorsCSVWriter(s.errorsPath, s.errorsParams, s.header); createErr != nil {
+			return createErr
+		}
+	}
+	row.err = err
+	return s.errorsCSV.write(row)
+}
+
+// checkBalance compares the running balance of the row with the one of the previous row having one.
+// Both chronological and reverse chronological orders are checked as the order of the file is unknown.
+func (s *entryStream) checkBalance(row csvRow) {
+	balanceStr := getField(row.fields, s.balanceColumn)
+	if balanceStr == "" {
+		return
+	}
+	balance, err := common.ParseAmount(balanceStr)
+	if err != nil {
+		slog.Warn("failed to parse the balance", "row", row.index, "error", err)
+		return
+	}
+	point := newBalancePoint(row.index, row.entry, balance)
+	if s.previous != nil {
+		pair := []balancePoint{*s.previous, point}
+		s.forward = append(s.forward, balanceMismatches(pair, false)...)
+		s.backward = append(s.backward, balanceMismatches(pair, true)...)
+	}
+	s.previous = &point
+}
+
+// keepCheck keeps the received checks for the deposit slip.
+func (s *entryStream) keepCheck(entry lib.Entry) {
+	if s.keepChecks && entry.PaymentMethod == lib.PaymentMethodCheckReceived {
+		s.checks = append(s.checks, entry)
+	}
+}
+
+// streamEntries imports the rows of the CSV file one at a time, so the memory used by the import doesn't
+// grow with the size of the file.
+// Unlike the default import, the rows errors are reported as soon as they are read, the valid rows are added
+// even if other rows are invalid and only the failed rows are written to the errors CSV file.
+// A dry run only validates the rows.
+func streamEntries(
+	cfg Config, client *lib.Client, refs referenceData, rates rateProvider, throttle *throttler, summary *importSummary,
+) error {
+	if len(cfg.Budgets.Limits) > 0 {
+		slog.Warn("the budget limits are not checked when streaming the rows")
+	}
+
+	r, cleaner, err := common.GetRowReader(cfg.CSV.CSVParams, cfg.CSVPath)
+	if err != nil {
+		return err
+	}
+	defer cleaner()
+
+	parser, err := newRowParser(
+		r, cfg.CSV.Columns, cfg.Defaults, cfg.CSV.Date,
+		refs.Accounts, refs.Categories, refs.Employees, refs.Providers, refs.Periods, rates,
+	)
+	if err != nil {
+		return err
+	}
+	matcher, err := newReceiptsMatcher(cfg.Receipts)
+	if err != nil {
+		return err
+	}
+	state := receiptsState{Receipts: map[string]uploadedReceipt{}}
+	if cfg.State != "" {
+		if state, err = loadReceiptsState(cfg.State); err != nil {
+			return err
+		}
+	}
+
+	stream := &entryStream{
+		matcher:       matcher,
+		throttle:      throttle,
+		summary:       summary,
+		state:         state,
+		hashes:        map[string]string{},
+		duplicates:    newDuplicateFinder(state),
+		errorsPath:    getErrorsCSVPath(cfg.ErrorsCSV, cfg.CSVPath),
+		errorsParams:  getErrorsCSVParams(cfg.CSV.CSVParams, r),
+		header:        parser.header,
+		balanceColumn: parser.colMap.Balance,
+		keepChecks:    cfg.DepositSlip != "",
+	}
+	if !cfg.DryRun {
+		stream.adder = client
+	}
+
+	position := 0
+	var streamErr error
+	for row := range parser.rows(r) {
+		position++
+		if streamErr = stream.process(position, row); streamErr != nil {
+			break
+		}
+	}
+	return errors.Join(streamErr, stream.finish(cfg, os.Stdout))
+}
+
+// finish writes the reports of the streamed import once all the rows have been processed.
+func (s *entryStream) finish(cfg Config, w io.Writer) error {
+	var allErrors []error
+	if s.errorsCSV != nil {
+		if err := s.errorsCSV.close(); err != nil {
+			allErrors = append(allErrors, err)
+		} else {
+			slog.Info("the rows that failed have been written", "file", s.errorsPath)
+		}
+	}
+	balancesErr := balancesError(s.forward, s.backward)
+
+	if cfg.DryRun {
+		if err := writeDepositSlips(cfg.DepositSlip, s.checks); err != nil {
+			allErrors = append(allErrors, err)
+		}
+		if _, err := fmt.Fprintf(w, "Dry run: %d entries would be added\n", s.valid); err != nil {
+			allErrors = append(allErrors, err)
+		}
+		if balancesErr != nil {
+			allErrors = append(allErrors, balancesErr)
+		}
+		if s.invalid > 0 {
+			allErrors = append(allErrors, fmt.Errorf("%d of %d rows are invalid", s.invalid, s.summary.Entries))
+		}
+		if err := errors.Join(allErrors...); err != nil {
+			return common.WithExitCode(common.ExitValidation, err)
+		}
+		return nil
+	}
+
+	if balancesErr != nil {
+		slog.Warn("the entries have been added but the running balances don't match", "error", balancesErr)
+	}
+	slog.Info("entries added", "added", s.summary.Added, "total", s.summary.Entries)
+
+	// The accounting piece numbers of the entries are expected to follow each other.
+	s.summary.Interleaved = findInterleavedNumbers(s.added)
+	if len(s.summary.Interleaved) > 0 {
+		slog.Warn("entries have been added by someone else during the import, the numbers are not contiguous",
+			"numbers", s.summary.Interleaved)
+	}
+
+	if cfg.State != "" {
+		if err := s.state.save(cfg.State); err != nil {
+			slog.Error("failed to save the uploaded receipts", "error", err)
+		}
+	}
+	if err := writeDepositSlips(cfg.DepositSlip, s.checks); err != nil {
+		slog.Error("failed to write the deposit slip", "error", err)
+	}
+	return errors.Join(allErrors...)
+}
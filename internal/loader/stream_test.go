@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestCheckStreamConfig(t *testing.T) {
+	if err := checkStreamConfig(Config{DryRun: true, ErrorsCSV: "errors.csv"}, false); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	cfg := Config{Review: true, SuggestCategories: true, Budgets: BudgetsConfig{Block: true}}
+	err := checkStreamConfig(cfg, true)
+	expected := "the rows can't be streamed with manifests, --review, --suggest-categories, --budgets-block"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error mismatch. Got: %v, Want: %s", err, expected)
+	}
+	if common.ExitCode(err) != common.ExitUsage {
+		t.Errorf("Exit code mismatch. Got: %d, Want: %d", common.ExitCode(err), common.ExitUsage)
+	}
+}
+
+type fakeEntryAdder struct {
+	added []lib.Entry
+}
+
+func (f *fakeEntryAdder) AddEntry(entry *lib.Entry) error {
+	ids := map[string]string{"Paper": "FON000001", "Rent": "FON000003"}
+	id, found := ids[entry.Name]
+	if !found {
+		return errors.New("HTTP 500")
+	}
+	entry.ID = id
+	f.added = append(f.added, *entry)
+	return nil
+}
+
+// newTestStream creates a stream of the test rows with the receipts of the second entry.
+func newTestStream(t *testing.T, adder entryAdder) (*entryStream, *rowParser, *csv.Reader) {
+	dir := t.TempDir()
+	receiptsDir := filepath.Join(dir, "receipts", "2 - Rent")
+	if err := os.MkdirAll(receiptsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(receiptsDir, "invoice.pdf"), []byte("invoice"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	input := "date,name,amount,category,balance\n" +
+		"01/01/2025,Paper,12.50,Office Supplies,100\n" +
+		"02/01/2025,Rent,500,Rent,-400\n" +
+		"03/01/2025,Gifts,abc,Unknown,\n" +
+		"04/01/2025,Ink,20,Office Supplies,-420\n"
+	columns := CSVColumns{Date: "date", Name: "name", Amount: "amount", Category: "category", Balance: "balance"}
+	defaults := getBaseDefaults()
+	defaults.Bank = "Bank A"
+	r := csv.NewReader(strings.NewReader(input))
+	parser, err := newRowParser(
+		r, columns, defaults, common.DateParams{}, []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}},
+		getMockCategories(), nil, nil, getMockPeriods(), nil,
+	)
+	if err != nil {
+		t.Fatalf("newRowParser failed: %v", err)
+	}
+	matcher, err := newReceiptsMatcher(filepath.Join(dir, "receipts"))
+	if err != nil {
+		t.Fatalf("newReceiptsMatcher failed: %v", err)
+	}
+	throttle, err := newThrottler(PauseConfig{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := receiptsState{Receipts: map[string]uploadedReceipt{}}
+	stream := &entryStream{
+		matcher:       matcher,
+		adder:         adder,
+		throttle:      throttle,
+		summary:       &importSummary{},
+		state:         state,
+		hashes:        map[string]string{},
+		duplicates:    newDuplicateFinder(state),
+		errorsPath:    filepath.Join(dir, "errors.csv"),
+		header:        parser.header,
+		balanceColumn: parser.colMap.Balance,
+		keepChecks:    true,
+	}
+	return stream, parser, r
+}
+
+func processRows(t *testing.T, stream *entryStream, parser *rowParser, r *csv.Reader) {
+	position := 0
+	for row := range parser.rows(r) {
+		position++
+		if err := stream.process(position, row); err != nil {
+			t.Fatalf("process failed on row %d: %v", row.index, err)
+		}
+	}
+}
+
+func TestEntryStream(t *testing.T) {
+	adder := &fakeEntryAdder{}
+	stream, parser, r := newTestStream(t, adder)
+	processRows(t, stream, parser, r)
+
+	var out bytes.Buffer
+	if err := stream.finish(Config{}, &out); err != nil {
+		t.Fatalf("finish failed: %v", err)
+	}
+
+	if len(adder.added) != 2 {
+		t.Fatalf("Added entries mismatch. Got: %+v, Want: Paper and Rent", adder.added)
+	}
+	if adder.added[0].Receipts != nil {
+		t.Errorf("Expected no receipt for the first entry, got: %v", adder.added[0].Receipts)
+	}
+	if receipts := adder.added[1].Receipts; len(receipts) != 1 || filepath.Base(receipts[0]) != "invoice.pdf" {
+		t.Errorf("Receipts of the second entry mismatch. Got: %v, Want: invoice.pdf", receipts)
+	}
+
+	summary := stream.summary
+	if summary.Entries != 4 || summary.Added != 2 {
+		t.Errorf("Summary counts mismatch. Got: %d entries and %d added, Want: 4 and 2", summary.Entries, summary.Added)
+	}
+	var failedRows []int
+	for _, failure := range summary.Failures {
+		failedRows = append(failedRows, failure.Row)
+	}
+	if expected := []int{3, 4}; !reflect.DeepEqual(failedRows, expected) {
+		t.Errorf("Failed rows mismatch. Got: %v, Want: %v", failedRows, expected)
+	}
+	if expected := []string{"FON000002"}; !reflect.DeepEqual(summary.Interleaved, expected) {
+		t.Errorf("Interleaved numbers mismatch. Got: %v, Want: %v", summary.Interleaved, expected)
+	}
+	if len(stream.state.Receipts) != 1 {
+		t.Errorf("Expected the uploaded receipt in the state, got: %v", stream.state.Receipts)
+	}
+
+	data, err := os.ReadFile(stream.errorsPath)
+	if err != nil {
+		t.Fatalf("failed to read the errors CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || lines[0] != "date,name,amount,category,balance,import_error" ||
+		!strings.HasPrefix(lines[1], "03/01/2025,Gifts,abc,Unknown,,") ||
+		lines[2] != "04/01/2025,Ink,20,Office Supplies,-420,HTTP 500" {
+		t.Errorf("Errors CSV mismatch. Got:\n%s", data)
+	}
+}
+
+func TestEntryStreamDryRun(t *testing.T) {
+	stream, parser, r := newTestStream(t, nil)
+	processRows(t, stream, parser, r)
+
+	var out bytes.Buffer
+	err := stream.finish(Config{DryRun: true}, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 of 4 rows are invalid") {
+		t.Errorf("Expected an error for the invalid row, got: %v", err)
+	}
+	if common.ExitCode(err) != common.ExitValidation {
+		t.Errorf("Exit code mismatch. Got: %d, Want: %d", common.ExitCode(err), common.ExitValidation)
+	}
+	if expected := "Dry run: 3 entries would be added\n"; out.String() != expected {
+		t.Errorf("Output mismatch. Got: %q, Want: %q", out.String(), expected)
+	}
+	// Nothing is added by a dry run: the invalid rows are not failures.
+	if len(stream.summary.Failures) != 0 || stream.summary.Added != 0 {
+		t.Errorf("Expected no failure and no added entry, got: %+v", stream.summary)
+	}
+}
+
+func TestEntryStreamBalances(t *testing.T) {
+	stream := &entryStream{balanceColumn: 1}
+	entry := func(amount float64) lib.Entry {
+		return lib.Entry{Kind: lib.KindSpend, Allocation: []lib.AllocationLine{{Amount: amount}}}
+	}
+	stream.checkBalance(csvRow{index: 1, fields: []string{"Paper", "100"}, entry: entry(10)})
+	stream.checkBalance(csvRow{index: 2, fields: []string{"Rent", "50"}, entry: entry(50)})
+	stream.checkBalance(csvRow{index: 3, fields: []string{"Gifts", ""}, entry: entry(5)})
+	stream.checkBalance(csvRow{index: 4, fields: []string{"Ink", "40"}, entry: entry(20)})
+
+	err := balancesError(stream.forward, stream.backward)
+	if err == nil || !strings.Contains(err.Error(), "balance mismatch between rows 2 and 4: expected 30.00, got 40.00") {
+		t.Errorf("Expected a balance mismatch between rows 2 and 4, got: %v", err)
+	}
+}
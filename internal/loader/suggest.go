@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// suggestionHistoryPeriods is the number of the latest accounting periods learned from.
+const suggestionHistoryPeriods = 2
+
+// minSuggestionScore is the minimum similarity between the names to suggest a category.
+const minSuggestionScore = 0.5
+
+// categorySuggestion is the category suggested for a row from a similar past entry.
+type categorySuggestion struct {
+	row      int
+	category string
+	// similar is the name of the past entry the category comes from.
+	similar string
+	score   float64
+}
+
+// suggestionDoc is a past entry indexed for the suggestions.
+type suggestionDoc struct {
+	name     string
+	category int
+	budget   lib.Budget
+	kind     lib.Kind
+	vector   map[string]float64
+	norm     float64
+}
+
+// categorySuggester finds the past entry with the most similar name using TF-IDF weighted words.
+type categorySuggester struct {
+	docs []suggestionDoc
+	idf  map[string]float64
+}
+
+// nameTokens splits an entry name into lower case words without accents.
+// The numbers are ignored as they are usually references or dates changing for each entry.
+func nameTokens(name string) []string {
+	var tokens []string
+	for _, token := range strings.Fields(normalizeHeader(name)) {
+		isNumber := strings.IndexFunc(token, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+		if len(token) > 1 && !isNumber {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// newCategorySuggester indexes the past entries names with the category of their main allocation line.
+func newCategorySuggester(history []lib.Entry) *categorySuggester {
+	suggester := &categorySuggester{idf: map[string]float64{}}
+
+	frequencies := map[string]int{}
+	var tokenLists [][]string
+	for _, entry := range history {
+		if len(entry.Allocation) == 0 {
+			continue
+		}
+		tokens := nameTokens(entry.Name)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		main := entry.Allocation[0]
+		for _, line := range entry.Allocation[1:] {
+			if math.Abs(line.Amount) > math.Abs(main.Amount) {
+				main = line
+			}
+		}
+		suggester.docs = append(suggester.docs, suggestionDoc{
+			name: entry.Name, category: main.CategoryID, budget: entry.Budget, kind: entry.Kind,
+		})
+		tokenLists = append(tokenLists, tokens)
+
+		seen := map[string]bool{}
+		for _, token := range tokens {
+			if !seen[token] {
+				seen[token] = true
+				frequencies[token]++
+			}
+		}
+	}
+
+	// Smoothed inverse document frequency: the words of all the entries still weigh a little.
+	count := float64(len(suggester.docs))
+	for token, frequency := range frequencies {
+		suggester.idf[token] = math.Log((count+1)/(float64(frequency)+1)) + 1
+	}
+	for i, tokens := range tokenLists {
+		suggester.docs[i].vector, suggester.docs[i].norm = suggester.vectorize(tokens)
+	}
+	return suggester
+}
+
+// vectorize computes the TF-IDF vector of the words and its norm. Unknown words are ignored.
+func (s *categorySuggester) vectorize(tokens []string) (map[string]float64, float64) {
+	vector := map[string]float64{}
+	for _, token := range tokens {
+		if idf, found := s.idf[token]; found {
+			vector[token] += idf
+		}
+	}
+	norm := 0.0
+	for _, weight := range vector {
+		norm += weight * weight
+	}
+	return vector, math.Sqrt(norm)
+}
+
+// suggest returns the past entry most similar to the name, restricted to the budget and kind if defined.
+func (s *categorySuggester) suggest(name string, budget lib.Budget, kind lib.Kind) (suggestionDoc, float64, bool) {
+	vector, norm := s.vectorize(nameTokens(name))
+	if norm == 0 {
+		return suggestionDoc{}, 0, false
+	}
+
+	var best suggestionDoc
+	bestScore := 0.0
+	for _, doc := range s.docs {
+		if budget != lib.BudgetUndefined && doc.budget != budget || kind != lib.KindUndefined && doc.kind != kind {
+			continue
+		}
+		dot := 0.0
+		for token, weight := range vector {
+			dot += weight * doc.vector[token]
+		}
+		if score := dot / (norm * doc.norm); score > bestScore {
+			best, bestScore = doc, score
+		}
+	}
+	return best, bestScore, bestScore >= minSuggestionScore
+}
+
+// fetchHistory lists the entries of the latest accounting periods.
+func fetchHistory(client *lib.Client, periods []lib.Period) ([]lib.Entry, error) {
+	sorted := slices.SortedFunc(slices.Values(periods), func(a, b lib.Period) int {
+		return b.Start.Compare(a.Start)
+	})
+
+	var history []lib.Entry
+	for _, period := range sorted[:min(suggestionHistoryPeriods, len(sorted))] {
+		slog.Info("fetching the past entries for the category suggestions", "period", period.ID)
+		entries, err := client.ListEntries(lib.EntryFilter{Period: period.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the entries of the %s period: %s", period.ID, err)
+		}
+		history = append(history, entries...)
+	}
+	return history, nil
+}
+
+// suggestCategories sets the category of the rows without one from the most similar past entry.
+// The rows are parsed again with the suggested category. The suggestions are indexed by the rows index.
+func suggestCategories(
+	parser *rowParser,
+	rows []csvRow,
+	suggester *categorySuggester,
+	categories []lib.Category,
+) map[int]categorySuggestion {
+	categoryNames := make(map[int]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	// The suggested categories need a column even if the CSV file doesn't have it.
+	if parser.colMap.Category < 0 {
+		parser.colMap.Category = len(parser.header)
+		parser.header = append(parser.header, "category")
+	}
+
+	suggestions := map[int]categorySuggestion{}
+	for i := range rows {
+		row := &rows[i]
+		// The split rows have their categories from their rule.
+		if row.fields == nil || getField(row.fields, parser.colMap.Category) != "" || len(row.entry.Allocation) > 1 {
+			continue
+		}
+
+		name := firstNonEmpty(getField(row.fields, parser.colMap.Name), parser.defaults.Name)
+		budget := lib.NewBudgetFromString(firstNonEmpty(getField(row.fields, parser.colMap.Budget), parser.defaults.Budget))
+		kind := lib.NewKind(firstNonEmpty(getField(row.fields, parser.colMap.Kind), parser.defaults.Kind))
+		doc, score, found := suggester.suggest(name, budget, kind)
+		if !found || categoryNames[doc.category] == "" {
+			continue
+		}
+
+		suggestion := categorySuggestion{
+			row: row.index, category: categoryNames[doc.category], similar: doc.name, score: score,
+		}
+		row.fields, _ = common.PadRow(row.fields, len(parser.header))
+		row.fields[parser.colMap.Category] = suggestion.category
+		row.entry, row.err = parser.parse(row.index, row.fields)
+		suggestions[row.index] = suggestion
+		slog.Info("suggested category", "row", row.index, "category", suggestion.category, "similar", doc.name)
+	}
+	return suggestions
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// printSuggestions writes the suggested categories for confirmation.
+func printSuggestions(w io.Writer, rows []csvRow, suggestions map[int]categorySuggestion) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "\nROW\tNAME\tSUGGESTED CATEGORY\tSIMILAR ENTRY\tSCORE"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		suggestion, found := suggestions[row.index]
+		if !found {
+			continue
+		}
+		_, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%.2f\n",
+			row.index, row.entry.Name, suggestion.category, suggestion.similar, suggestion.score,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newHistoryEntry(name string, budget lib.Budget, categoryID int) lib.Entry {
+	return lib.Entry{
+		Name: name, Budget: budget, Kind: lib.KindSpend,
+		Allocation: []lib.AllocationLine{{CategoryID: categoryID, Amount: 10}},
+	}
+}
+
+func getMockHistory() []lib.Entry {
+	return []lib.Entry{
+		newHistoryEntry("Loyer janvier 2024", lib.BudgetFON, 101),
+		newHistoryEntry("Loyer février 2024", lib.BudgetFON, 101),
+		newHistoryEntry("Ramettes papier Bureau Vallée", lib.BudgetFON, 100),
+		newHistoryEntry("Papier imprimante", lib.BudgetFON, 100),
+		newHistoryEntry("Cadeaux de Noël", lib.BudgetASC, 200),
+	}
+}
+
+func TestNameTokens(t *testing.T) {
+	tokens := nameTokens("Facture n°1234 - Électricité  2025/03 à Paris")
+	expected := []string{"facture", "electricite", "paris"}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Tokens mismatch. Got: %v, Want: %v", tokens, expected)
+	}
+}
+
+func TestCategorySuggester(t *testing.T) {
+	suggester := newCategorySuggester(getMockHistory())
+
+	tests := []struct {
+		name     string
+		budget   lib.Budget
+		category int
+		found    bool
+	}{
+		{name: "Loyer mars 2025", budget: lib.BudgetFON, category: 101, found: true},
+		{name: "PAPIER A4", category: 100, found: true},
+		{name: "Assurance locaux"},
+		{name: "Cadeaux de Noël", budget: lib.BudgetFON},
+		{name: "2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, score, found := suggester.suggest(tt.name, tt.budget, lib.KindSpend)
+			if found != tt.found {
+				t.Fatalf("Found mismatch. Got: %v (score %.2f), Want: %v", found, score, tt.found)
+			}
+			if found && doc.category != tt.category {
+				t.Errorf("Category mismatch. Got: %d, Want: %d", doc.category, tt.category)
+			}
+		})
+	}
+}
+
+func TestSuggestCategories(t *testing.T) {
+	refs := referenceData{
+		Accounts:   []lib.Account{{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON}},
+		Categories: getMockCategories(),
+		Periods:    getMockPeriods(),
+	}
+	defaults := getBaseDefaults()
+	defaults.Bank = "Bank A"
+	defaults.Category = ""
+
+	input := "date,name,amount\n" +
+		"01/03/2025,Loyer mars,500\n" +
+		"02/03/2025,Assurance locaux,120\n"
+	columns := CSVColumns{Date: "date", Name: "name", Amount: "amount", Category: "category"}
+	parser, rows, err := readRows(
+		csv.NewReader(strings.NewReader(input)), columns, defaults, common.DateParams{},
+		refs.Accounts, refs.Categories, refs.Employees, refs.Providers, refs.Periods, nil,
+	)
+	if err != nil {
+		t.Fatalf("readRows failed: %v", err)
+	}
+
+	suggestions := suggestCategories(parser, rows, newCategorySuggester(getMockHistory()), refs.Categories)
+
+	if len(suggestions) != 1 {
+		t.Fatalf("Suggestions count mismatch. Got: %d, Want: 1", len(suggestions))
+	}
+	suggestion := suggestions[1]
+	if suggestion.category != "Rent" || !strings.HasPrefix(suggestion.similar, "Loyer") {
+		t.Errorf("Suggestion mismatch. Got: %+v", suggestion)
+	}
+	if rows[0].err != nil {
+		t.Fatalf("Expected the suggested row to be valid, got: %v", rows[0].err)
+	}
+	if got := rows[0].entry.Allocation[0].CategoryID; got != 101 {
+		t.Errorf("Category mismatch. Got: %d, Want: 101", got)
+	}
+	if got := parser.header[parser.colMap.Category]; got != "category" {
+		t.Errorf("Category column mismatch. Got: %s, Want: category", got)
+	}
+	if rows[1].err == nil {
+		t.Error("Expected the row without suggestion to stay invalid")
+	}
+
+	var buf bytes.Buffer
+	if err := printSuggestions(&buf, rows, suggestions); err != nil {
+		t.Fatalf("printSuggestions failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Loyer mars") || !strings.Contains(buf.String(), "Rent") {
+		t.Errorf("Unexpected suggestions summary:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// EntryTemplate holds the values of recurring entries, like a monthly rent.
+// The rows reference it by name in the template column and only need the date and amount.
+// The values of the row columns have precedence over the template ones.
+type EntryTemplate struct {
+	// Name is the text/template of the entry name, like Loyer {{.Date.Format "01/2006"}}.
+	Name     string `mapstructure:"name"`
+	Kind     string `mapstructure:"kind"`
+	Budget   string `mapstructure:"budget"`
+	Bank     string `mapstructure:"bank"`
+	Category string `mapstructure:"category"`
+	Payment  string `mapstructure:"payment"`
+	Employee string `mapstructure:"employee"`
+	Provider string `mapstructure:"provider"`
+	// Comment is the text/template of the entry comment.
+	Comment string `mapstructure:"comment"`
+}
+
+// templateData is the data the name and comment templates are executed with.
+type templateData struct {
+	Date   time.Time
+	Amount string
+}
+
+// withTemplate returns the defaults with the values of the named template and the template.
+// An empty name returns the defaults and an empty template.
+func (d Defaults) withTemplate(name string) (Defaults, EntryTemplate, error) {
+	if name == "" {
+		return d, EntryTemplate{}, nil
+	}
+
+	// The configuration keys are lower case.
+	tmpl, found := d.Templates[strings.ToLower(name)]
+	if !found {
+		names := strings.Join(slices.Sorted(maps.Keys(d.Templates)), ", ")
+		return d, tmpl, fmt.Errorf("unknown template '%s', accepted values are %s", name, names)
+	}
+
+	if tmpl.Kind != "" {
+		d.Kind = tmpl.Kind
+	}
+	if tmpl.Budget != "" {
+		d.Budget = tmpl.Budget
+	}
+	if tmpl.Bank != "" {
+		d.Bank = tmpl.Bank
+	}
+	if tmpl.Category != "" {
+		d.Category = tmpl.Category
+	}
+	if tmpl.Payment != "" {
+		d.Payment = tmpl.Payment
+	}
+	return d, tmpl, nil
+}
+
+// renderTemplate executes a name or comment template.
+func renderTemplate(text string, data templateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("entry").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template '%s': %s", text, err)
+	}
+	var result strings.Builder
+	if err := tmpl.Execute(&result, data); err != nil {
+		return "", fmt.Errorf("failed to execute template '%s': %s", text, err)
+	}
+	return strings.TrimSpace(result.String()), nil
+}
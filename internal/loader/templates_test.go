@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := templateData{Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Amount: "850"}
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+		wantErr  bool
+	}{
+		{"Plain", "Loyer", "Loyer", false},
+		{"Date", `Loyer {{.Date.Format "01/2006"}}`, "Loyer 03/2025", false},
+		{"Amount", "Montant: {{.Amount}}", "Montant: 850", false},
+		{"Invalid", "Loyer {{.Date", "", true},
+		{"UnknownField", "Loyer {{.Month}}", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := renderTemplate(tt.text, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if actual != tt.expected {
+				t.Errorf("Rendered text mismatch. Got: %s, Want: %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithTemplate(t *testing.T) {
+	defaults := getBaseDefaults()
+	defaults.Templates = map[string]EntryTemplate{
+		"monthly-rent": {Category: "Rent", Payment: "transfer"},
+		"gifts":        {},
+	}
+
+	actual, tmpl, err := defaults.withTemplate("Monthly-Rent")
+	if err != nil {
+		t.Fatalf("withTemplate failed: %v", err)
+	}
+	if actual.Category != "Rent" || actual.Payment != "transfer" || actual.Budget != defaults.Budget {
+		t.Errorf("Defaults mismatch. Got: %+v, Want the rent category and transfer payment", actual)
+	}
+	if !reflect.DeepEqual(tmpl, defaults.Templates["monthly-rent"]) {
+		t.Errorf("Template mismatch. Got: %+v, Want: %+v", tmpl, defaults.Templates["monthly-rent"])
+	}
+
+	if actual, _, err := defaults.withTemplate(""); err != nil || !reflect.DeepEqual(actual, defaults) {
+		t.Errorf("Expected the defaults without template, got: %+v, %v", actual, err)
+	}
+
+	_, _, err = defaults.withTemplate("unknown")
+	if err == nil || !strings.Contains(err.Error(), "accepted values are gifts, monthly-rent") {
+		t.Errorf("Expected an error listing the templates, got: %v", err)
+	}
+}
+
+func TestCreateEntryFromRow_Template(t *testing.T) {
+	colMap := buildColumnMap([]string{"date", "amount", "template", "comment"}, CSVColumns{
+		Date:     "date",
+		Amount:   "amount",
+		Template: "template",
+		Comment:  "comment",
+	})
+	accounts := []lib.Account{
+		{ID: 10, Bank: "First National Bank", Budget: lib.BudgetFON, Abbrev: "FNB"},
+	}
+	defaults := getBaseDefaults()
+	defaults.Templates = map[string]EntryTemplate{
+		"monthly-rent": {
+			Name:     `Loyer {{.Date.Format "01/2006"}}`,
+			Category: "Rent",
+			Payment:  "transfer",
+			Provider: "TechCorp Solutions",
+			Comment:  "Loyer mensuel",
+		},
+	}
+	providers := []lib.Provider{{ID: "P50", Name: "TechCorp Solutions"}}
+
+	expected := lib.Entry{
+		Period:        "12345",
+		Kind:          lib.KindSpend,
+		Date:          time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Name:          "Loyer 02/2025",
+		Budget:        lib.BudgetFON,
+		PaymentMethod: lib.PaymentMethodTransfer,
+		Account:       accounts[0],
+		Comment:       "Loyer mensuel",
+		Party:         &providers[0],
+		Allocation:    []lib.AllocationLine{{CategoryID: 101, Amount: 850}},
+	}
+
+	entry, err := createEntryFromRow([]string{"01/02/2025", "850", "monthly-rent", ""}, colMap, defaults,
+		common.DateParams{}, 1, accounts, createCategoriesMap(getMockCategories()),
+		createEmployeesMap(nil), createProvidersMap(providers), createPeriodsMap(getMockPeriods()))
+	if err != nil {
+		t.Fatalf("createEntryFromRow failed unexpectedly: %v", err)
+	}
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("Entry mismatch.\nGot:  %+v\nWant: %+v", entry, expected)
+	}
+
+	// The row values have precedence over the template ones.
+	entry, err = createEntryFromRow([]string{"01/02/2025", "850", "monthly-rent", "Régularisation"}, colMap,
+		defaults, common.DateParams{}, 1, accounts, createCategoriesMap(getMockCategories()),
+		createEmployeesMap(nil), createProvidersMap(providers), createPeriodsMap(getMockPeriods()))
+	if err != nil {
+		t.Fatalf("createEntryFromRow failed unexpectedly: %v", err)
+	}
+	if entry.Comment != "Régularisation" {
+		t.Errorf("Comment mismatch. Got: %s, Want: Régularisation", entry.Comment)
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// PauseConfig holds the parameters to pause the import after a number of entries.
+type PauseConfig struct {
+	Every   int `mapstructure:"every"`
+	Seconds int `mapstructure:"seconds"`
+}
+
+// scheduleWindow is a daily time window during which entries can be uploaded.
+// The window may span midnight, like 22:00-06:00.
+type scheduleWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// parseSchedule reads a HH:MM-HH:MM window.
+// An empty value means no restriction and results in a nil window.
+func parseSchedule(value string) (*scheduleWindow, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	startStr, endStr, found := strings.Cut(value, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid schedule '%s', expected format is HH:MM-HH:MM", value)
+	}
+
+	start, err := parseTimeOfDay(startStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule start: %w", err)
+	}
+	end, err := parseTimeOfDay(endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule end: %w", err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid schedule '%s': start and end are identical", value)
+	}
+	return &scheduleWindow{start: start, end: end}, nil
+}
+
+// parseTimeOfDay converts a HH:MM string into the duration since midnight.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse time '%s': %w", value, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// sinceMidnight returns the time elapsed since the midnight before t.
+func sinceMidnight(t time.Time) time.Duration {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return t.Sub(midnight)
+}
+
+// contains indicates whether t is inside the window.
+func (w *scheduleWindow) contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	// The window spans midnight
+	return offset >= w.start || offset < w.end
+}
+
+// waitDuration computes how long to wait for the window to open at t.
+func (w *scheduleWindow) waitDuration(t time.Time) time.Duration {
+	if w.contains(t) {
+		return 0
+	}
+	wait := w.start - sinceMidnight(t)
+	if wait < 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
+// throttler spreads the uploads over time to avoid tripping the server-side abuse protection.
+type throttler struct {
+	pause  PauseConfig
+	window *scheduleWindow
+	count  int
+	now    func() time.Time
+	sleep  func(time.Duration)
+}
+
+func newThrottler(pause PauseConfig, schedule string) (*throttler, error) {
+	if pause.Every < 0 || pause.Seconds < 0 {
+		return nil, fmt.Errorf("pause values cannot be negative")
+	}
+
+	window, err := parseSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &throttler{
+		pause:  pause,
+		window: window,
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}, nil
+}
+
+// wait blocks until the next entry can be uploaded.
+// It needs to be called before each upload.
+func (t *throttler) wait() {
+	if t.count > 0 && t.pause.Every > 0 && t.pause.Seconds > 0 && t.count%t.pause.Every == 0 {
+		slog.Info("pausing", "seconds", t.pause.Seconds, "entries", t.count)
+		t.sleep(time.Duration(t.pause.Seconds) * time.Second)
+	}
+
+	if t.window != nil {
+		if wait := t.window.waitDuration(t.now()); wait > 0 {
+			slog.Info("waiting for the schedule window to open", "wait", wait.Round(time.Second))
+			t.sleep(wait)
+		}
+	}
+	t.count++
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *scheduleWindow
+		wantErr bool
+	}{
+		{
+			name:  "Empty",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "Same day window",
+			value: "09:30-17:00",
+			want:  &scheduleWindow{start: 9*time.Hour + 30*time.Minute, end: 17 * time.Hour},
+		},
+		{
+			name:  "Midnight spanning window",
+			value: "22:00 - 06:00",
+			want:  &scheduleWindow{start: 22 * time.Hour, end: 6 * time.Hour},
+		},
+		{
+			name:    "Missing separator",
+			value:   "22:00",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid time",
+			value:   "25:00-06:00",
+			wantErr: true,
+		},
+		{
+			name:    "Empty window",
+			value:   "06:00-06:00",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSchedule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("parseSchedule() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleWindowWaitDuration(t *testing.T) {
+	night := &scheduleWindow{start: 22 * time.Hour, end: 6 * time.Hour}
+	day := &scheduleWindow{start: 9 * time.Hour, end: 17 * time.Hour}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		window *scheduleWindow
+		now    time.Time
+		want   time.Duration
+	}{
+		{"Night window, before midnight", night, at(23, 0), 0},
+		{"Night window, after midnight", night, at(2, 0), 0},
+		{"Night window, closed", night, at(12, 0), 10 * time.Hour},
+		{"Night window, at closing time", night, at(6, 0), 16 * time.Hour},
+		{"Day window, open", day, at(10, 0), 0},
+		{"Day window, before opening", day, at(8, 30), 30 * time.Minute},
+		{"Day window, after closing", day, at(18, 0), 15 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.waitDuration(tt.now); got != tt.want {
+				t.Errorf("waitDuration() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThrottlerWait(t *testing.T) {
+	throttle, err := newThrottler(PauseConfig{Every: 2, Seconds: 30}, "22:00-06:00")
+	if err != nil {
+		t.Fatalf("newThrottler failed unexpectedly: %v", err)
+	}
+
+	now := time.Date(2025, 6, 1, 21, 0, 0, 0, time.UTC)
+	var sleeps []time.Duration
+	throttle.now = func() time.Time { return now }
+	throttle.sleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+	}
+
+	for range 5 {
+		throttle.wait()
+	}
+
+	// Wait for the window to open, then pause after entries 2 and 4.
+	want := []time.Duration{time.Hour, 30 * time.Second, 30 * time.Second}
+	if len(sleeps) != len(want) {
+		t.Fatalf("Expected %d sleeps, got %v", len(want), sleeps)
+	}
+	for i := range want {
+		if sleeps[i] != want[i] {
+			t.Errorf("Sleep %d mismatch. Got: %s, Want: %s", i, sleeps[i], want[i])
+		}
+	}
+}
+
+func TestNewThrottler_Invalid(t *testing.T) {
+	if _, err := newThrottler(PauseConfig{Every: -1}, ""); err == nil {
+		t.Error("Expected an error for a negative pause")
+	}
+	if _, err := newThrottler(PauseConfig{}, "invalid"); err == nil {
+		t.Error("Expected an error for an invalid schedule")
+	}
+}
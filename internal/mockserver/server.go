@@ -0,0 +1,645 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mockserver emulates the happy-compta pages and ajax endpoints used by lib.
+// It allows testing the tools flows without real credentials.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+// Token is the CSRF token of the forms served by the mock server.
+const Token = "mock-token"
+
+// sessionCookie is the name of the cookie holding the session of the logged in clients.
+const sessionCookie = "happycompta_session"
+
+// Data is the content of the organization served by the mock server.
+type Data struct {
+	// Email and Password are the credentials accepted by the login form.
+	Email    string
+	Password string
+
+	Accounts   []lib.Account
+	Categories []lib.Category
+	Employees  []lib.Employee
+	Providers  []lib.Provider
+	Sites      []lib.Site
+	Users      []lib.User
+	Periods    []lib.Period
+	Entries    []lib.Entry
+	// Receipts maps the receipt file names to their content.
+	Receipts map[string]string
+}
+
+// Server is a running mock happy-compta instance.
+// The lib clients created with its URL can log in, list the data and add entries.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	data     Data
+	sessions map[string]bool
+}
+
+// New starts a mock server serving the data. It needs to be closed after use.
+func New(data Data) *Server {
+	s := &Server{data: data, sessions: map[string]bool{}}
+	s.data.Entries = slices.Clone(data.Entries)
+	s.data.Receipts = map[string]string{}
+	for name, content := range data.Receipts {
+		s.data.Receipts[name] = content
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /auth/login", s.handleLoginPage)
+	mux.HandleFunc("POST /auth/login", s.handleLogin)
+	mux.HandleFunc("GET /ajax/get-comptes", s.authenticated(s.handleAccounts))
+	mux.HandleFunc("GET /ajax/get-categories", s.authenticated(s.handleCategories))
+	mux.HandleFunc("GET /operations/index", s.authenticated(s.handlePeriods))
+	mux.HandleFunc("GET /fournisseurs/index/{filter}", s.authenticated(s.handleProviders))
+	mux.HandleFunc("POST /salaries/ajax_table", s.authenticated(s.handleEmployees))
+	mux.HandleFunc("GET /sites/index", s.authenticated(s.handleSites))
+	mux.HandleFunc("GET /utilisateurs/index", s.authenticated(s.handleUsers))
+	mux.HandleFunc("POST /ajax/list_operations", s.authenticated(s.handleEntries))
+	mux.HandleFunc("GET /operations/edit/{index}", s.authenticated(s.handleEntry))
+	mux.HandleFunc("GET /operations/create/{kind}", s.authenticated(s.handleCreatePage))
+	mux.HandleFunc("POST /ajax/get-numero-pc", s.authenticated(s.handleNextNumber))
+	mux.HandleFunc("POST /operations/store", s.authenticated(s.handleStore))
+	mux.HandleFunc("POST /operations/update/{index}", s.authenticated(s.handleUpdate))
+	mux.HandleFunc("GET /storage/justificatifs/{index}/{name}", s.authenticated(s.handleReceipt))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Entries returns a copy of the entries of the server, including the added ones.
+func (s *Server) Entries() []lib.Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.data.Entries)
+}
+
+// Receipt returns the content of a receipt, either given in the data or uploaded with an entry.
+func (s *Server) Receipt(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, found := s.data.Receipts[name]
+	return content, found
+}
+
+// authenticated redirects the requests without a valid session to the login page, like happy-compta.
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		s.mu.Lock()
+		valid := err == nil && s.sessions[cookie.Value]
+		s.mu.Unlock()
+		if !valid {
+			http.Redirect(w, r, "/auth/login", http.StatusFound)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleLoginPage(w http.ResponseWriter, r *http.Request) {
+	writeTokenForm(w, "Connectez-vous")
+}
+
+func (s *Server) handleCreatePage(w http.ResponseWriter, r *http.Request) {
+	writeTokenForm(w, "Nouvelle opération")
+}
+
+// writeTokenForm writes a page with a form holding the CSRF token.
+func writeTokenForm(w http.ResponseWriter, title string) {
+	writeHTML(w, `<html><body><h1>`+title+`</h1><form method="post">`+
+		`<input name="_token" type="hidden" value="`+Token+`"></form></body></html>`)
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.PostForm.Get("_token") != Token {
+		http.Error(w, "invalid token", http.StatusUnprocessableEntity)
+		return
+	}
+
+	s.mu.Lock()
+	valid := r.PostForm.Get("email") == s.data.Email && r.PostForm.Get("password") == s.data.Password
+	session := ""
+	if valid {
+		session = fmt.Sprintf("session-%d", len(s.sessions)+1)
+		s.sessions[session] = true
+	}
+	s.mu.Unlock()
+
+	if !valid {
+		s.handleLoginPage(w, r)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: session, Path: "/", HttpOnly: true})
+	writeHTML(w, "<html><body><h1>Tableau de bord</h1></body></html>")
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, s.data.Accounts)
+}
+
+func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, s.data.Categories)
+}
+
+func (s *Server) handlePeriods(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<html><body><select name="exercice_id">`)
+	for _, period := range s.data.Periods {
+		fmt.Fprintf(&builder, `<option value="%s">Du %s au %s [%s]</option>`, html.EscapeString(period.ID),
+			period.Start.Format(lib.DateLayout), period.End.Format(lib.DateLayout), periodStatusText(period.Status))
+	}
+	builder.WriteString(`</select></body></html>`)
+	writeHTML(w, builder.String())
+}
+
+// periodStatusText returns the label of the period status in the periods select.
+func periodStatusText(status lib.PeriodStatus) string {
+	switch status {
+	case lib.PeriodStatusCurrent:
+		return "En cours"
+	case lib.PeriodStatusProvisionallyClosed:
+		return "Clôture provisoire"
+	case lib.PeriodStatusDefinitelyClosed:
+		return "Clôture définitive"
+	}
+	return "Inconnu"
+}
+
+func (s *Server) handleProviders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<html><body><table><tbody>`)
+	for _, provider := range s.data.Providers {
+		builder.WriteString("<tr>")
+		for _, value := range []string{
+			provider.Name, provider.Address, provider.ZipCode, provider.City, provider.Phone, provider.Email,
+			provider.Comment, "",
+		} {
+			fmt.Fprintf(&builder, "<td>%s</td>", html.EscapeString(value))
+		}
+		archive := "0"
+		if provider.Archived {
+			archive = "1"
+		}
+		fmt.Fprintf(&builder, `<td><a href="#" data-id="%s"></a><a href="#" data-archive="%s"></a></td></tr>`,
+			html.EscapeString(provider.ID), archive)
+	}
+	builder.WriteString(`</tbody></table></body></html>`)
+	writeHTML(w, builder.String())
+}
+
+func (s *Server) handleEmployees(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<table><tbody>`)
+	for _, employee := range s.data.Employees {
+		active := "0"
+		if employee.Active {
+			active = "1"
+		}
+		fmt.Fprintf(&builder, `<tr><td></td><td><span class="hide">%s</span></td><td></td><td></td><td></td>`+
+			`<td>%s</td><td>%s</td><td></td><td></td><td></td><td><a href="%s/salaries/edit/%s">Modifier</a></td></tr>`,
+			active, html.EscapeString(employee.Lastname), html.EscapeString(employee.Firstname),
+			s.URL, url.PathEscape(employee.ID))
+	}
+	builder.WriteString(`</tbody></table>`)
+	writeJSON(w, map[string]string{"view": builder.String()})
+}
+
+func (s *Server) handleSites(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<html><body><table><tbody>`)
+	for _, site := range s.data.Sites {
+		builder.WriteString("<tr>")
+		for _, value := range []string{site.Name, site.Address, site.ZipCode, site.City} {
+			fmt.Fprintf(&builder, "<td>%s</td>", html.EscapeString(value))
+		}
+		fmt.Fprintf(&builder, `<td><a href="#" data-id="%s"></a></td></tr>`, html.EscapeString(site.ID))
+	}
+	builder.WriteString(`</tbody></table></body></html>`)
+	writeHTML(w, builder.String())
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<html><body><table><tbody>`)
+	for _, user := range s.data.Users {
+		status := "Inactif"
+		if user.Active {
+			status = "Actif"
+		}
+		builder.WriteString("<tr>")
+		for _, value := range []string{
+			user.Lastname, user.Firstname, user.Email, user.Role, strings.Join(user.Sites, ", "), status,
+		} {
+			fmt.Fprintf(&builder, "<td>%s</td>", html.EscapeString(value))
+		}
+		fmt.Fprintf(&builder, `<td><a href="#" data-id="%s"></a></td></tr>`, html.EscapeString(user.ID))
+	}
+	builder.WriteString(`</tbody></table></body></html>`)
+	writeHTML(w, builder.String())
+}
+
+func (s *Server) handleEntries(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	matches, err := newEntryMatcher(r.PostForm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString(`<table><tbody>`)
+	for i, entry := range s.data.Entries {
+		if !matches(entry) {
+			continue
+		}
+		fmt.Fprintf(&builder, `<tr><td>%s</td><td><a href="%s/operations/edit/%d">Modifier</a></td></tr>`,
+			html.EscapeString(entry.Name), s.URL, i+1)
+	}
+	builder.WriteString(`</tbody></table>`)
+	writeJSON(w, map[string]string{"view": builder.String()})
+}
+
+// newEntryMatcher returns a function matching the entries selected by the operations list form.
+func newEntryMatcher(form url.Values) (func(lib.Entry) bool, error) {
+	period := form.Get("exercice_id")
+	kind := form.Get("type")
+	budget, err := strconv.Atoi(form.Get("budget"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget: %s", err)
+	}
+	var from, to time.Time
+	if value := form.Get("begin"); value != "" {
+		if from, err = time.Parse(lib.DateLayout, value); err != nil {
+			return nil, fmt.Errorf("invalid begin date: %s", err)
+		}
+	}
+	if value := form.Get("end"); value != "" {
+		if to, err = time.Parse(lib.DateLayout, value); err != nil {
+			return nil, fmt.Errorf("invalid end date: %s", err)
+		}
+	}
+
+	return func(entry lib.Entry) bool {
+		return entry.Period == period &&
+			(kind == "type" || entry.Kind.String() == kind) &&
+			(budget == 0 || int(entry.Budget) == budget) &&
+			(from.IsZero() || !entry.Date.Before(from)) &&
+			(to.IsZero() || !entry.Date.After(to))
+	}, nil
+}
+
+// entryAt returns the entry matching the 1-based index path value of the request.
+func (s *Server) entryAt(r *http.Request) (int, lib.Entry, bool) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || index < 1 || index > len(s.data.Entries) {
+		return 0, lib.Entry{}, false
+	}
+	return index, s.data.Entries[index-1], true
+}
+
+func (s *Server) handleEntry(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, entry, found := s.entryAt(r)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	operation, err := newOperationJSON(entry)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString(`<html><body><div class="justificatifs">`)
+	for _, name := range entry.Receipts {
+		fmt.Fprintf(&builder, `<a href="%s/storage/justificatifs/%d/%s">%s</a>`,
+			s.URL, index, url.PathEscape(name), html.EscapeString(name))
+	}
+	builder.WriteString("</div>\n")
+	fmt.Fprintf(&builder, `<form method="POST" action="/operations/update/%d">`+
+		`<input name="_token" type="hidden" value="%s"></form>`, index, Token)
+	builder.WriteString("\n<script>\nconst operation = JSON.parse(String(\"")
+	// The JSON is stored as a JavaScript string literal in the page.
+	builder.WriteString(strings.ReplaceAll(strings.ReplaceAll(operation, `\`, `\\`), `"`, `\"`))
+	builder.WriteString("\"));\nconst edit = true;\n</script></body></html>")
+	writeHTML(w, builder.String())
+}
+
+// newOperationJSON converts an entry into the operation JSON of the happy-compta entry pages.
+func newOperationJSON(entry lib.Entry) (string, error) {
+	period, err := strconv.Atoi(entry.Period)
+	if err != nil {
+		return "", fmt.Errorf("invalid period ID %s: %s", entry.Period, err)
+	}
+	identifier, number := splitEntryID(entry.ID)
+
+	var provider any
+	employee := 0
+	switch party := entry.Party.(type) {
+	case *lib.Provider:
+		provider = party.ID
+	case *lib.Employee:
+		employee, _ = strconv.Atoi(party.ID)
+	}
+
+	type allocation struct {
+		CategoryID int     `json:"category_id"`
+		Amount     float64 `json:"amount"`
+		Stock      int     `json:"stock"`
+	}
+	allocations := []allocation{}
+	for _, line := range entry.Allocation {
+		allocations = append(allocations, allocation{CategoryID: line.CategoryID, Amount: line.Amount, Stock: line.Stock})
+	}
+
+	guest := lib.Guest{}
+	if entry.Guest != nil {
+		guest = *entry.Guest
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"name":             entry.Name,
+		"date":             entry.Date.Format("2006-01-02"),
+		"type":             entry.Kind.String(),
+		"budget":           int(entry.Budget),
+		"exercice_id":      period,
+		"compte_id":        entry.Account.ID,
+		"method_paiement":  int(entry.PaymentMethod),
+		"fournisseur_id":   provider,
+		"personne_id":      employee,
+		"remarques_libres": entry.Comment,
+		"no_cheque":        entry.CheckNumber,
+		"nom_invite":       guest.Lastname,
+		"prenom_invite":    guest.Firstname,
+		"filename_temp":    strings.Join(entry.Receipts, ";"),
+		"ventilations":     allocations,
+		"identifiant_pc":   identifier,
+		"numero_pc":        number,
+	})
+	return string(data), err
+}
+
+// splitEntryID splits an entry ID like ASC000012 into its prefix and number.
+func splitEntryID(id string) (string, int) {
+	prefix := strings.TrimRight(id, "0123456789")
+	number, _ := strconv.Atoi(id[len(prefix):])
+	return prefix, number
+}
+
+func (s *Server) handleNextNumber(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	budget, err := strconv.Atoi(r.PostForm.Get("budget"))
+	if err != nil {
+		http.Error(w, "invalid budget", http.StatusBadRequest)
+		return
+	}
+	prefix := lib.NewBudget(budget).String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := 1
+	for _, entry := range s.data.Entries {
+		if entryPrefix, number := splitEntryID(entry.ID); entryPrefix == prefix && number >= next {
+			next = number + 1
+		}
+	}
+	writeJSON(w, map[string]string{"identifiant": prefix, "numero": strconv.Itoa(next)})
+}
+
+func (s *Server) handleStore(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	form := r.MultipartForm
+	if r.FormValue("_token") != Token {
+		http.Error(w, "invalid token", http.StatusUnprocessableEntity)
+		return
+	}
+
+	entry, err := parseStoreForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	names, receipts, err := readUploadedReceipts(form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entry.Receipts = append(entry.Receipts, names...)
+
+	s.mu.Lock()
+	s.data.Entries = append(s.data.Entries, entry)
+	maps.Copy(s.data.Receipts, receipts)
+	s.mu.Unlock()
+
+	http.Redirect(w, r, "/operations/index", http.StatusFound)
+}
+
+// handleUpdate replaces an entry with the posted one.
+// The receipts listed in filename_temp are kept and the uploaded files are added to them.
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("_token") != Token {
+		http.Error(w, "invalid token", http.StatusUnprocessableEntity)
+		return
+	}
+
+	entry, err := parseStoreForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	names, receipts, err := readUploadedReceipts(r.MultipartForm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if kept := r.FormValue("filename_temp"); kept != "" {
+		entry.Receipts = strings.Split(kept, ";")
+	}
+	entry.Receipts = append(entry.Receipts, names...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index, _, found := s.entryAt(r)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	s.data.Entries[index-1] = entry
+	maps.Copy(s.data.Receipts, receipts)
+
+	http.Redirect(w, r, "/operations/index", http.StatusFound)
+}
+
+// readUploadedReceipts returns the names of the uploaded receipt files and their content indexed by name.
+func readUploadedReceipts(form *multipart.Form) ([]string, map[string]string, error) {
+	var names []string
+	receipts := map[string]string{}
+	for _, header := range form.File["fichiers[]"] {
+		file, err := header.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := io.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, header.Filename)
+		receipts[header.Filename] = string(content)
+	}
+	return names, receipts, nil
+}
+
+// parseStoreForm builds the entry from the fields of the entry creation form.
+func parseStoreForm(r *http.Request) (entry lib.Entry, err error) {
+	if entry.Name = r.FormValue("name"); entry.Name == "" {
+		return entry, fmt.Errorf("missing name")
+	}
+	if entry.Date, err = time.Parse(lib.DateLayout, r.FormValue("date")); err != nil {
+		return entry, fmt.Errorf("invalid date: %s", err)
+	}
+	number, err := strconv.Atoi(r.FormValue("numero_pc"))
+	if err != nil {
+		return entry, fmt.Errorf("invalid entry number: %s", err)
+	}
+	entry.ID = fmt.Sprintf("%s%06d", r.FormValue("identifiant_pc"), number)
+	entry.Period = r.FormValue("exercice_id")
+	entry.Kind = lib.NewKind(r.FormValue("type"))
+	entry.Comment = r.FormValue("remarques_libres")
+	entry.CheckNumber = r.FormValue("no_cheque")
+	if lastname, firstname := r.FormValue("nom_invite"), r.FormValue("prenom_invite"); lastname != "" || firstname != "" {
+		entry.Guest = &lib.Guest{Lastname: lastname, Firstname: firstname}
+	}
+
+	values := map[string]int{}
+	for _, field := range []string{"budget", "method_paiement", "compte_id"} {
+		if values[field], err = strconv.Atoi(r.FormValue(field)); err != nil {
+			return entry, fmt.Errorf("invalid %s: %s", field, err)
+		}
+	}
+	entry.Budget = lib.NewBudget(values["budget"])
+	entry.PaymentMethod = lib.PaymentMethod(values["method_paiement"])
+	entry.Account = lib.Account{ID: values["compte_id"]}
+	if id := r.FormValue("fournisseur_id"); id != "0" {
+		entry.Party = &lib.Provider{ID: id}
+	} else if id := r.FormValue("personne_id"); id != "0" {
+		entry.Party = &lib.Employee{ID: id}
+	}
+
+	categories := r.MultipartForm.Value["category_id[]"]
+	amounts := r.MultipartForm.Value["amount[]"]
+	stocks := r.MultipartForm.Value["stock[]"]
+	if len(amounts) != len(categories) || len(stocks) != len(categories) {
+		return entry, fmt.Errorf("the allocation lines have missing fields")
+	}
+	for i := range categories {
+		var line lib.AllocationLine
+		if line.CategoryID, err = strconv.Atoi(categories[i]); err != nil {
+			return entry, fmt.Errorf("invalid category: %s", err)
+		}
+		if line.Amount, err = strconv.ParseFloat(strings.Replace(amounts[i], ",", ".", 1), 64); err != nil {
+			return entry, fmt.Errorf("invalid amount: %s", err)
+		}
+		if stocks[i] != "" {
+			if line.Stock, err = strconv.Atoi(stocks[i]); err != nil {
+				return entry, fmt.Errorf("invalid stock: %s", err)
+			}
+		}
+		entry.Allocation = append(entry.Allocation, line)
+	}
+	return entry, nil
+}
+
+func (s *Server) handleReceipt(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, entry, found := s.entryAt(r)
+	name := r.PathValue("name")
+	content, stored := s.data.Receipts[name]
+	if !found || !stored || !slices.Contains(entry.Receipts, name) {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = io.WriteString(w, content)
+}
+
+func writeHTML(w http.ResponseWriter, content string) {
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	_, _ = io.WriteString(w, content)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
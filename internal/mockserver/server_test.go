@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mockserver
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newTestData() Data {
+	return Data{
+		Email:      "treasurer@example.com",
+		Password:   "secret",
+		Accounts:   []lib.Account{{ID: 1, Bank: "Crédit Mutuel", Budget: lib.BudgetASC, Abbrev: "CM"}},
+		Categories: []lib.Category{{ID: 10, Kind: lib.KindSpend, Name: "Cadeaux", Budget: lib.BudgetASC, Stock: true}},
+		Employees:  []lib.Employee{{ID: "100001", Lastname: "Dupont", Firstname: "Jean", Active: true}},
+		Providers: []lib.Provider{
+			{ID: "p1", Name: "Boutique", City: "Paris"},
+			{ID: "p2", Name: "Ancien fournisseur", Archived: true},
+		},
+		Sites: []lib.Site{{ID: "1", Name: "Siège", ZipCode: "75002", City: "Paris"}},
+		Users: []lib.User{
+			{ID: "7", Lastname: "Martin", Firstname: "Léa", Email: "treasurer@example.com", Role: "Trésorier", Active: true},
+			{ID: "8", Lastname: "Durand", Firstname: "Paul", Role: "Lecteur", Sites: []string{"Siège"}},
+		},
+		Periods: []lib.Period{
+			{
+				ID:     "42",
+				Status: lib.PeriodStatusCurrent,
+				Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Entries: []lib.Entry{
+			{
+				ID:            "ASC000001",
+				Period:        "42",
+				Kind:          lib.KindSpend,
+				Date:          time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+				Name:          "Cadeaux de \"Noël\"",
+				Budget:        lib.BudgetASC,
+				Allocation:    []lib.AllocationLine{{CategoryID: 10, Amount: 12.5, Stock: 2}},
+				Party:         &lib.Provider{ID: "p1"},
+				PaymentMethod: lib.PaymentMethodCard,
+				Account:       lib.Account{ID: 1},
+				Receipts:      []string{"invoice.pdf"},
+			},
+		},
+		Receipts: map[string]string{"invoice.pdf": "invoice content"},
+	}
+}
+
+func newTestClient(t *testing.T, server *Server) *lib.Client {
+	client, err := lib.NewClientWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	if err := client.Login("treasurer@example.com", "secret"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	return client
+}
+
+func TestLogin(t *testing.T) {
+	server := New(newTestData())
+	defer server.Close()
+
+	client, err := lib.NewClientWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	if err := client.Login("treasurer@example.com", "wrong"); err == nil {
+		t.Error("Expected an error for a wrong password")
+	}
+	if _, err := client.ListAccounts(); err == nil {
+		t.Error("Expected an error without being logged in")
+	}
+	if err := client.Login("treasurer@example.com", "secret"); err != nil {
+		t.Errorf("Login failed: %v", err)
+	}
+}
+
+func TestReferenceData(t *testing.T) {
+	data := newTestData()
+	server := New(data)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	accounts, err := client.ListAccounts()
+	if err != nil {
+		t.Fatalf("ListAccounts failed: %v", err)
+	}
+	if !reflect.DeepEqual(accounts, data.Accounts) {
+		t.Errorf("Accounts mismatch. Got: %+v, Want: %+v", accounts, data.Accounts)
+	}
+
+	categories, err := client.ListCategories()
+	if err != nil {
+		t.Fatalf("ListCategories failed: %v", err)
+	}
+	if !reflect.DeepEqual(categories, data.Categories) {
+		t.Errorf("Categories mismatch. Got: %+v, Want: %+v", categories, data.Categories)
+	}
+
+	employees, err := client.ListEmployees()
+	if err != nil {
+		t.Fatalf("ListEmployees failed: %v", err)
+	}
+	if !reflect.DeepEqual(employees, data.Employees) {
+		t.Errorf("Employees mismatch. Got: %+v, Want: %+v", employees, data.Employees)
+	}
+
+	providers, err := client.ListProviders()
+	if err != nil {
+		t.Fatalf("ListProviders failed: %v", err)
+	}
+	if !reflect.DeepEqual(providers, data.Providers) {
+		t.Errorf("Providers mismatch. Got: %+v, Want: %+v", providers, data.Providers)
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		t.Fatalf("ListPeriods failed: %v", err)
+	}
+	if !reflect.DeepEqual(periods, data.Periods) {
+		t.Errorf("Periods mismatch. Got: %+v, Want: %+v", periods, data.Periods)
+	}
+
+	sites, err := client.ListSites()
+	if err != nil {
+		t.Fatalf("ListSites failed: %v", err)
+	}
+	if !reflect.DeepEqual(sites, data.Sites) {
+		t.Errorf("Sites mismatch. Got: %+v, Want: %+v", sites, data.Sites)
+	}
+
+	users, err := client.ListUsers()
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if !reflect.DeepEqual(users, data.Users) {
+		t.Errorf("Users mismatch. Got: %+v, Want: %+v", users, data.Users)
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	data := newTestData()
+	server := New(data)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	entries, err := client.ListEntries(lib.EntryFilter{Period: "42"})
+	if err != nil {
+		t.Fatalf("ListEntries failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Entries count mismatch. Got: %d, Want: 1", len(entries))
+	}
+	entry := entries[0]
+	if len(entry.ReceiptLinks) != 1 {
+		t.Fatalf("Receipt links mismatch. Got: %v, Want one link", entry.ReceiptLinks)
+	}
+
+	var content bytes.Buffer
+	if err := client.DownloadReceipt(entry.ReceiptLinks[0], &content); err != nil {
+		t.Fatalf("DownloadReceipt failed: %v", err)
+	}
+	if content.String() != "invoice content" {
+		t.Errorf("Receipt content mismatch. Got: %s, Want: invoice content", content.String())
+	}
+
+	if expected := server.URL + "/operations/edit/1"; entry.URL != expected {
+		t.Errorf("URL mismatch. Got: %s, Want: %s", entry.URL, expected)
+	}
+	entry.ReceiptLinks = nil
+	entry.URL = ""
+	if !reflect.DeepEqual(entry, data.Entries[0]) {
+		t.Errorf("Entry mismatch. Got: %+v, Want: %+v", entry, data.Entries[0])
+	}
+
+	if entries, err := client.ListEntries(lib.EntryFilter{Period: "43"}); err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entry for another period, got: %v, %v", entries, err)
+	}
+
+	march := func(day int) time.Time { return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC) }
+	filters := []struct {
+		filter   lib.EntryFilter
+		expected int
+	}{
+		{lib.EntryFilter{Period: "42", Budget: lib.BudgetASC, Kind: lib.KindSpend, From: march(14), To: march(14)}, 1},
+		{lib.EntryFilter{Period: "42", Budget: lib.BudgetFON}, 0},
+		{lib.EntryFilter{Period: "42", Kind: lib.KindTake}, 0},
+		{lib.EntryFilter{Period: "42", From: march(15)}, 0},
+		{lib.EntryFilter{Period: "42", To: march(13)}, 0},
+	}
+	for _, test := range filters {
+		if entries, err := client.ListEntries(test.filter); err != nil || len(entries) != test.expected {
+			t.Errorf("Entries of filter %+v mismatch. Got: %v, %v, Want: %d entries", test.filter, entries, err, test.expected)
+		}
+	}
+}
+
+func TestAddEntry(t *testing.T) {
+	server := New(newTestData())
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	receipt := filepath.Join(t.TempDir(), "ticket.jpg")
+	if err := os.WriteFile(receipt, []byte("ticket content"), 0600); err != nil {
+		t.Fatalf("failed to write the receipt: %v", err)
+	}
+	entry := lib.Entry{
+		Period:        "42",
+		Kind:          lib.KindAllocation,
+		Date:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Name:          "Chèques",
+		Budget:        lib.BudgetASC,
+		Allocation:    []lib.AllocationLine{{CategoryID: 10, Amount: 50.25, Stock: 5}},
+		Party:         &lib.Employee{ID: "100001"},
+		PaymentMethod: lib.PaymentMethodCheckAllocation,
+		Account:       lib.Account{ID: 1},
+		Receipts:      []string{receipt},
+	}
+	if prefix, number, err := client.NextEntryNumber(lib.BudgetASC, lib.KindAllocation); err != nil ||
+		prefix != "ASC" || number != 2 {
+		t.Errorf("Next entry number mismatch. Got: %s %d %v, Want: ASC 2", prefix, number, err)
+	}
+	if err := client.AddEntry(&entry); err != nil {
+		t.Fatalf("AddEntry failed: %v", err)
+	}
+	if entry.ID != "ASC000002" {
+		t.Errorf("Added entry ID mismatch. Got: %s, Want: ASC000002", entry.ID)
+	}
+
+	entries := server.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Entries count mismatch. Got: %d, Want: 2", len(entries))
+	}
+	expected := entry
+	expected.ID = "ASC000002"
+	expected.Receipts = []string{"ticket.jpg"}
+	if !reflect.DeepEqual(entries[1], expected) {
+		t.Errorf("Entry mismatch. Got: %+v, Want: %+v", entries[1], expected)
+	}
+	if content, found := server.Receipt("ticket.jpg"); !found || content != "ticket content" {
+		t.Errorf("Receipt mismatch. Got: %s, Want: ticket content", content)
+	}
+}
+
+func TestAttachReceipts(t *testing.T) {
+	data := newTestData()
+	// Re-posting the edit form mustn't lose the fields that aren't changed.
+	data.Entries[0].Comment = "Christmas market"
+	data.Entries[0].CheckNumber = "1234567"
+	data.Entries[0].Guest = &lib.Guest{Lastname: "Doe", Firstname: "Jane"}
+	server := New(data)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	receipt := filepath.Join(t.TempDir(), "late.pdf")
+	if err := os.WriteFile(receipt, []byte("late content"), 0600); err != nil {
+		t.Fatalf("failed to write the receipt: %v", err)
+	}
+	entries, err := client.ListEntries(lib.EntryFilter{Period: "42"})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ListEntries failed: %v, %v", entries, err)
+	}
+	if err := client.AttachReceipts(&entries[0], []string{receipt}); err != nil {
+		t.Fatalf("AttachReceipts failed: %v", err)
+	}
+
+	updated := server.Entries()
+	if len(updated) != 1 {
+		t.Fatalf("Entries count mismatch. Got: %d, Want: 1", len(updated))
+	}
+	expected := data.Entries[0]
+	expected.Receipts = []string{"invoice.pdf", "late.pdf"}
+	if !reflect.DeepEqual(updated[0], expected) {
+		t.Errorf("Entry mismatch. Got: %+v, Want: %+v", updated[0], expected)
+	}
+	if content, found := server.Receipt("late.pdf"); !found || content != "late content" {
+		t.Errorf("Receipt mismatch. Got: %s, Want: late content", content)
+	}
+
+	if err := client.AttachReceipts(&lib.Entry{ID: "ASC000001"}, []string{receipt}); err == nil {
+		t.Error("Expected an error for an entry without edit page")
+	}
+}
+
+func TestUpdateEntry(t *testing.T) {
+	data := newTestData()
+	data.Entries[0].Comment = "Christmas market"
+	data.Entries[0].CheckNumber = "1234567"
+	data.Entries[0].Guest = &lib.Guest{Lastname: "Doe", Firstname: "Jane"}
+	server := New(data)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	entries, err := client.ListEntries(lib.EntryFilter{Period: "42"})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ListEntries failed: %v, %v", entries, err)
+	}
+	if entries[0].Comment != "Christmas market" {
+		t.Errorf("Listed comment mismatch. Got: %s, Want: Christmas market", entries[0].Comment)
+	}
+	if entries[0].CheckNumber != "1234567" || !reflect.DeepEqual(entries[0].Guest, data.Entries[0].Guest) {
+		t.Errorf("Listed check number and guest mismatch. Got: %s, %+v", entries[0].CheckNumber, entries[0].Guest)
+	}
+	entries[0].Comment += "\nPaid"
+	if err := client.UpdateEntry(&entries[0]); err != nil {
+		t.Fatalf("UpdateEntry failed: %v", err)
+	}
+
+	expected := data.Entries[0]
+	expected.Comment = "Christmas market\nPaid"
+	if updated := server.Entries(); !reflect.DeepEqual(updated, []lib.Entry{expected}) {
+		t.Errorf("Entries mismatch. Got: %+v, Want: %+v", updated, expected)
+	}
+}
@@ -21,7 +21,7 @@ type Account struct {
 
 // ListAccounts lists all the bank accounts of the organization.
 func (c *Client) ListAccounts() (accounts []Account, err error) {
-	resp, err := c.client.Get(url_base + "/ajax/get-comptes")
+	resp, err := c.client.Get(c.baseURL + "/ajax/get-comptes")
 	if err != nil {
 		err = fmt.Errorf("failed to get the accounts: %s", err)
 		return
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// EnableCache stores the pages with an ETag or Last-Modified header in dir.
+// They are then revalidated with conditional requests instead of being downloaded again.
+// The cached pages hold the organization data: dir should not be shared between accounts.
+func (c *Client) EnableCache(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create the cache directory %s: %s", dir, err)
+	}
+	next := c.client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.client.Transport = &cacheTransport{dir: dir, next: next}
+	return nil
+}
+
+// cachedPage is a page stored in the cache with its validators.
+type cachedPage struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	ContentType  string `json:"content_type"`
+	Body         []byte `json:"body"`
+}
+
+// cacheTransport is an HTTP transport caching the responses to the GET requests on disk.
+type cacheTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	cached := t.load(path)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		return cached.response(req, resp), nil
+	}
+
+	page := cachedPage{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentType:  resp.Header.Get("Content-Type"),
+	}
+	if resp.StatusCode != http.StatusOK || (page.ETag == "" && page.LastModified == "") {
+		return resp, nil
+	}
+
+	page.Body, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(page.Body))
+	// The cache is only an optimization: failing to write it is not a problem.
+	t.store(path, page)
+	return resp, nil
+}
+
+// path returns the path of the cache file of a request.
+func (t *cacheTransport) path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.String()))
+	return filepath.Join(t.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads a cached page, returning nil if not found or invalid.
+func (t *cacheTransport) load(path string) *cachedPage {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var page cachedPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil
+	}
+	return &page
+}
+
+func (t *cacheTransport) store(path string, page cachedPage) {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// response builds the response to the request from the cached page.
+// The headers of the not modified response are kept, like the cookies.
+func (p *cachedPage) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := notModified.Header.Clone()
+	if p.ContentType != "" {
+		header.Set("Content-Type", p.ContentType)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(p.Body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(p.Body)),
+		ContentLength: int64(len(p.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheTransport(t *testing.T) {
+	downloads := 0
+	revalidations := 0
+	content := "first"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/etag":
+			etag := `"` + content + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				revalidations++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads++
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, content)
+		case "/plain":
+			downloads++
+			_, _ = io.WriteString(w, content)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the client: %s", err)
+	}
+	if err := client.EnableCache(t.TempDir()); err != nil {
+		t.Fatalf("EnableCache failed: %s", err)
+	}
+
+	get := func(path string) string {
+		resp, err := client.client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %s", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Status mismatch for %s. Got: %d, Want: 200", path, resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	tests := []struct {
+		path          string
+		content       string
+		expected      string
+		downloads     int
+		revalidations int
+	}{
+		{"/etag", "first", "first", 1, 0},
+		{"/etag", "first", "first", 1, 1},
+		{"/etag", "second", "second", 2, 1},
+		{"/plain", "second", "second", 3, 1},
+		{"/plain", "second", "second", 4, 1},
+	}
+	for i, test := range tests {
+		content = test.content
+		if actual := get(test.path); actual != test.expected {
+			t.Errorf("Body mismatch for request %d. Got: %s, Want: %s", i, actual, test.expected)
+		}
+		if downloads != test.downloads || revalidations != test.revalidations {
+			t.Errorf("Requests mismatch for request %d. Got: %d downloads and %d revalidations, Want: %d and %d",
+				i, downloads, revalidations, test.downloads, test.revalidations)
+		}
+	}
+}
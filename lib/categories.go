@@ -22,7 +22,7 @@ type Category struct {
 
 // ListCategories gets all the operation categories defined for the organization.
 func (c *Client) ListCategories() (categories []Category, err error) {
-	resp, err := c.client.Get(url_base + "/ajax/get-categories")
+	resp, err := c.client.Get(c.baseURL + "/ajax/get-categories")
 	if err != nil {
 		err = fmt.Errorf("failed to get the categories: %s", err)
 		return
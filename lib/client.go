@@ -6,8 +6,11 @@
 package lib
 
 import (
+	"cmp"
 	"net/http"
 	"net/http/cookiejar"
+	"os"
+	"strings"
 
 	"golang.org/x/net/publicsuffix"
 )
@@ -17,17 +20,25 @@ const (
 )
 
 type Client struct {
-	client *http.Client
+	client  *http.Client
+	baseURL string
 }
 
 // NemClient sets up a new happy-compta client.
+// The HAPPYCOMPTA_URL environment variable overrides the happy-compta address, for instance for tests.
 func NewClient() (client *Client, err error) {
+	return NewClientWithURL(cmp.Or(os.Getenv("HAPPYCOMPTA_URL"), url_base))
+}
+
+// NewClientWithURL sets up a new client for the happy-compta instance at baseURL.
+func NewClientWithURL(baseURL string) (client *Client, err error) {
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 	if err != nil {
 		return
 	}
 	client = &Client{
-		client: &http.Client{Jar: jar},
+		client:  &http.Client{Jar: jar},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
 	}
 	return
 }
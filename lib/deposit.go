@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// DepositCheck is a received check listed on a deposit slip.
+type DepositCheck struct {
+	Number string
+	// Drawer is the name of the person or organization who wrote the check.
+	Drawer string
+	Amount float64
+	// EntryName is the name of the entry the check has been received for.
+	EntryName string
+}
+
+// DepositSlip lists the received checks deposited together to a bank account.
+type DepositSlip struct {
+	Date    time.Time
+	Account Account
+	Checks  []DepositCheck
+}
+
+// NewDepositSlip builds the deposit slip of the checks received in the entries of the account.
+// The entries of other accounts or paid with other methods are ignored.
+func NewDepositSlip(date time.Time, account Account, entries []Entry) DepositSlip {
+	slip := DepositSlip{Date: date, Account: account}
+	for _, entry := range entries {
+		if entry.PaymentMethod != PaymentMethodCheckReceived || entry.Account.ID != account.ID {
+			continue
+		}
+		check := DepositCheck{Number: entry.CheckNumber, Drawer: entryPartyName(entry), EntryName: entry.Name}
+		for _, line := range entry.Allocation {
+			check.Amount += line.Amount
+		}
+		slip.Checks = append(slip.Checks, check)
+	}
+	return slip
+}
+
+// entryPartyName returns the name of the guest or party of an entry.
+func entryPartyName(entry Entry) string {
+	if entry.Guest != nil {
+		return strings.TrimSpace(entry.Guest.Lastname + " " + entry.Guest.Firstname)
+	}
+	switch party := entry.Party.(type) {
+	case *Employee:
+		return strings.TrimSpace(party.Lastname + " " + party.Firstname)
+	case *Provider:
+		return party.Name
+	}
+	return ""
+}
+
+// Total returns the sum of the checks amounts.
+func (s DepositSlip) Total() float64 {
+	total := 0.0
+	for _, check := range s.Checks {
+		total += check.Amount
+	}
+	return total
+}
+
+// Write prints the deposit slip as a text table.
+func (s DepositSlip) Write(w io.Writer) error {
+	var errs []error
+	printf := func(w io.Writer, format string, args ...any) {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	printf(w, "Check deposit slip of %s\n", s.Date.Format(DateLayout))
+	printf(w, "Account: %s (%s)\n\n", s.Account.Bank, s.Account.Budget)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	printf(tw, "#\tCheck\tDrawer\tEntry\tAmount\t\n")
+	for i, check := range s.Checks {
+		printf(tw, "%d\t%s\t%s\t%s\t%.2f\t\n", i+1, check.Number, check.Drawer, check.EntryName, check.Amount)
+	}
+	printf(tw, "\t\t\tTotal (%d checks)\t%.2f\t\n", len(s.Checks), s.Total())
+	if err := tw.Flush(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
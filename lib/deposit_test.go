@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewDepositSlip(t *testing.T) {
+	account := Account{ID: 1, Bank: "Bank", Budget: BudgetFON}
+	entries := []Entry{
+		{
+			Name: "Fee", Account: account, PaymentMethod: PaymentMethodCheckReceived, CheckNumber: "111",
+			Party: &Employee{Lastname: "Doe", Firstname: "John"}, Allocation: []AllocationLine{{Amount: 20}, {Amount: 10}},
+		},
+		{
+			Name: "Gift", Account: account, PaymentMethod: PaymentMethodCheckReceived, CheckNumber: "222",
+			Guest: &Guest{Lastname: "Martin", Firstname: "Léa"}, Allocation: []AllocationLine{{Amount: 15.5}},
+		},
+		{Name: "Cash", Account: account, PaymentMethod: PaymentMethodCash, Allocation: []AllocationLine{{Amount: 5}}},
+		{
+			Name: "Other", Account: Account{ID: 2}, PaymentMethod: PaymentMethodCheckReceived,
+			Allocation: []AllocationLine{{Amount: 7}},
+		},
+	}
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	slip := NewDepositSlip(date, account, entries)
+
+	expected := []DepositCheck{
+		{Number: "111", Drawer: "Doe John", Amount: 30, EntryName: "Fee"},
+		{Number: "222", Drawer: "Martin Léa", Amount: 15.5, EntryName: "Gift"},
+	}
+	if !reflect.DeepEqual(slip.Checks, expected) {
+		t.Errorf("Checks mismatch. Got: %+v, Want: %+v", slip.Checks, expected)
+	}
+	if slip.Total() != 45.5 {
+		t.Errorf("Total mismatch. Got: %.2f, Want: 45.50", slip.Total())
+	}
+
+	var buf bytes.Buffer
+	if err := slip.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	for _, line := range []string{"Check deposit slip of 01/03/2025", "Total (2 checks)", "45.50"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("Expected the slip to contain '%s', got:\n%s", line, buf.String())
+		}
+	}
+}
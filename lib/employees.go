@@ -41,7 +41,7 @@ func (c *Client) ListEmployees() (employees []Employee, err error) {
 	values.Set("site_id", "0")
 	values.Set("sexe", "")
 	values.Set("situation_familiale", "0")
-	req, err := http.NewRequest("POST", url_base+"/salaries/ajax_table", strings.NewReader(values.Encode()))
+	req, err := http.NewRequest("POST", c.baseURL+"/salaries/ajax_table", strings.NewReader(values.Encode()))
 	if err != nil {
 		err = fmt.Errorf("failed to create the request: %s", err)
 		return
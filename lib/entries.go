@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -38,6 +39,12 @@ type Party interface {
 	GetID() string
 }
 
+// Guest is a person outside of the employees.
+type Guest struct {
+	Lastname  string
+	Firstname string
+}
+
 // Entry represents an entry in the bookkeeping system.
 type Entry struct {
 	ID            string
@@ -52,27 +59,64 @@ type Entry struct {
 	Account       Account
 	Comment       string
 	Receipts      []string
+	// CheckNumber is the number of the check of the entry, like the one of a received membership fee.
+	CheckNumber string
+	// Guest is the person paying or paid who is not an employee, like a member of the organization.
+	Guest *Guest
+	// URL is the edit page of the listed entries.
+	URL string
+	// ReceiptLinks are the download URLs of the receipts of listed entries.
+	// They are in the same order as the receipts and are empty if not found.
+	ReceiptLinks []string
+}
+
+// EntryFilter selects the entries returned by ListEntries.
+// The zero values of the fields don't filter the entries.
+type EntryFilter struct {
+	// Period is the ID of the accounting period of the entries.
+	Period string
+	Budget Budget
+	Kind   Kind
+	// From and To are the first and last days of the entries, included.
+	From time.Time
+	To   time.Time
 }
 
-// ListEntries returns all the entries for a given period.
-func (c *Client) ListEntries(periodID string) (result []Entry, err error) {
-	// TODO Allow more filtering
+// values returns the form values of the happy-compta operations list for the filter.
+func (f EntryFilter) values() url.Values {
 	values := url.Values{}
 	values.Set("statut", "toutes_operations")
 	values.Set("type", "type")
-	values.Set("budget", "0")
+	if f.Kind != KindUndefined {
+		values.Set("type", f.Kind.String())
+	}
+	values.Set("budget", strconv.Itoa(int(f.Budget)))
 	values.Set("compte_id", "0")
 	values.Set("method_paiement", "0")
 	values.Set("cheque", "")
 	values.Set("category_id", "0")
-	values.Set("exercice_id", periodID)
-	values.Set("begin", "")
-	values.Set("end", "")
+	values.Set("exercice_id", f.Period)
+	values.Set("begin", formatFilterDate(f.From))
+	values.Set("end", formatFilterDate(f.To))
 	values.Set("montant", "")
 	values.Set("fournisseur_id", "0")
 	values.Set("personne_id", "0")
 	values.Set("pieces_jointes", "avec_sans_pj")
-	req, err := http.NewRequest("POST", url_base+"/ajax/list_operations", strings.NewReader(values.Encode()))
+	return values
+}
+
+func formatFilterDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(DateLayout)
+}
+
+// ListEntries returns the entries matching the filter.
+// The filtering is done by happy-compta: only the entries it returns are fetched.
+func (c *Client) ListEntries(filter EntryFilter) (result []Entry, err error) {
+	values := filter.values()
+	req, err := http.NewRequest("POST", c.baseURL+"/ajax/list_operations", strings.NewReader(values.Encode()))
 	if err != nil {
 		err = fmt.Errorf("failed to create the request: %s", err)
 		return
@@ -121,7 +165,11 @@ func (c *Client) getEntry(url string) (entry Entry, err error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	return parseEntryResponse(resp.Body)
+	if entry, err = parseEntryResponse(resp.Body); err != nil {
+		return
+	}
+	entry.URL = url
+	return
 }
 
 // parseEntryResponse parses the operation data.
@@ -141,6 +189,10 @@ func parseEntryResponse(r io.Reader) (entry Entry, err error) {
 	// 2. Map JSON fields to the Entry struct
 	entry.Name = opData.Name
 	entry.Comment = opData.RemarquesLibres
+	entry.CheckNumber = opData.NoCheque
+	if opData.NomInvite != "" || opData.PrenomInvite != "" {
+		entry.Guest = &Guest{Lastname: opData.NomInvite, Firstname: opData.PrenomInvite}
+	}
 	entry.Period = fmt.Sprintf("%d", opData.ExerciceID)
 	entry.Kind = NewKind(opData.Type)
 	entry.Budget = NewBudget(opData.Budget)
@@ -174,11 +226,53 @@ func parseEntryResponse(r io.Reader) (entry Entry, err error) {
 		entry.Receipts = strings.Split(opData.FilenameTemp, ";")
 	}
 
-	entry.ID = fmt.Sprintf("%s%06d", opData.IdentifiantPC, opData.NumeroPC)
+	entry.ReceiptLinks = findReceiptLinks(doc, entry.Receipts)
+
+	entry.ID = FormatEntryID(opData.IdentifiantPC, opData.NumeroPC)
 
 	return entry, nil
 }
 
+// findReceiptLinks returns the links to the receipts found in the entry page.
+// The links are matched using the file names of the receipts.
+func findReceiptLinks(doc *html.Node, receipts []string) []string {
+	links := make([]string, len(receipts))
+	base, _ := url.Parse(url_base)
+
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode || n.Data != "a" {
+			continue
+		}
+		href, err := url.Parse(getAttr(n, "href"))
+		if err != nil || href.Path == "" {
+			continue
+		}
+		for i, name := range receipts {
+			if links[i] == "" && path.Base(href.Path) == name {
+				links[i] = base.ResolveReference(href).String()
+			}
+		}
+	}
+	return links
+}
+
+// DownloadReceipt writes the content of the receipt at the link to w.
+func (c *Client) DownloadReceipt(link string, w io.Writer) error {
+	resp, err := c.client.Get(link)
+	if err != nil {
+		return fmt.Errorf("failed to download the receipt %s: %s", link, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download the receipt %s: %s", link, resp.Status)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download the receipt %s: %s", link, err)
+	}
+	return nil
+}
+
 // Internal struct matching the JSON structure in the HTML script
 type jsonOperation struct {
 	Name            string `json:"name"`
@@ -191,6 +285,9 @@ type jsonOperation struct {
 	FournisseurID   any    `json:"fournisseur_id"` // Can be null
 	PersonneID      int    `json:"personne_id"`
 	RemarquesLibres string `json:"remarques_libres"`
+	NoCheque        string `json:"no_cheque"`
+	NomInvite       string `json:"nom_invite"`
+	PrenomInvite    string `json:"prenom_invite"`
 	FilenameTemp    string `json:"filename_temp"`
 	Ventilations    []struct {
 		CategoryID int     `json:"category_id"`
@@ -252,216 +349,366 @@ func getEntriesURLs(n *html.Node) []string {
 }
 
 // AddEntry adds a new entry to the bookkeeping system.
+// The ID of the entry is set to the accounting piece number it has been given, like FON000012.
 func (c *Client) AddEntry(operation *Entry) error {
 	entryID, entryIDNumber, err := c.getNextEntryNumber(operation.Budget, operation.Kind)
 	if err != nil {
 		return err
 	}
 
-	token, err := c.getToken(url_base + "/operations/create/depenses")
+	token, err := c.getToken(c.baseURL + "/operations/create/depenses")
 	if err != nil {
 		return err
 	}
 
-	reader, writer := io.Pipe()
-	formWriter := multipart.NewWriter(writer)
+	err = c.postEntryForm(
+		c.baseURL+"/operations/store", operation, token, entryID, entryIDNumber, operation.Receipts, nil,
+	)
+	if err != nil {
+		return err
+	}
+	if number, err := strconv.Atoi(entryIDNumber); err == nil {
+		operation.ID = FormatEntryID(entryID, number)
+	}
+	return nil
+}
 
-	go func() {
-		defer func() { _ = writer.Close() }()
-		defer func() { _ = formWriter.Close() }()
+// NextEntryNumber returns the accounting piece prefix and number the next entry of a budget and kind would get,
+// like FON and 12.
+// The number is only taken when the entry is added: the entries added meanwhile, like from the website, get it.
+func (c *Client) NextEntryNumber(budget Budget, kind Kind) (string, int, error) {
+	prefix, number, err := c.getNextEntryNumber(budget, kind)
+	if err != nil {
+		return "", 0, err
+	}
+	value, err := strconv.Atoi(number)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid next entry number '%s'", number)
+	}
+	return prefix, value, nil
+}
 
-		if err := formWriter.WriteField("_token", token); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing _token: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("exercice_id", operation.Period); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing exercice_id: %w", err))
-			return
-		}
+// FormatEntryID returns the ID of an entry from its accounting piece prefix and number, like FON000012.
+func FormatEntryID(prefix string, number int) string {
+	return fmt.Sprintf("%s%06d", prefix, number)
+}
 
-		if err := formWriter.WriteField("type", operation.Kind.String()); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing type: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("budget", strconv.Itoa(int(operation.Budget))); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing budget: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("date", operation.Date.Format(DateLayout)); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing date: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("name", operation.Name); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing name: %w", err))
-			return
-		}
+// AttachReceipts uploads receipt files to an entry listed by ListEntries.
+// The entry is posted back to its edit form with its listed values: the receipts already attached are kept.
+func (c *Client) AttachReceipts(entry *Entry, receipts []string) error {
+	return c.editEntry(entry, receipts)
+}
 
-		for _, line := range operation.Allocation {
-			if err := formWriter.WriteField("category_id[]", strconv.Itoa(line.CategoryID)); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing category_id[]: %w", err))
-				return
-			}
-			amountStr := fmt.Sprintf("%.2f", line.Amount)
-			amount := bytes.Replace([]byte(amountStr), []byte("."), []byte(","), 1)
-			if err := formWriter.WriteField("amount[]", string(amount)); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing amount[]: %w", err))
-				return
-			}
-			if line.Stock != 0 {
-				if err := formWriter.WriteField("stock[]", strconv.Itoa(line.Stock)); err != nil {
-					writer.CloseWithError(fmt.Errorf("error writing stock[]: %w", err))
-					return
-				}
-			} else {
-				// Write an empty stock if none set
-				if err := formWriter.WriteField("stock[]", ""); err != nil {
-					writer.CloseWithError(fmt.Errorf("error writing empty stock[]: %w", err))
-					return
-				}
-			}
+// EntryUpdater saves the changes of entries listed by ListEntries, like the Client.
+// The tools marking the entries depend on it to be tested without happy-compta.
+type EntryUpdater interface {
+	UpdateEntry(entry *Entry) error
+}
 
-			// TODO Handle the preorder date feature
-			if err := formWriter.WriteField("date_remise_precommande", ""); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing date_remise_precommande: %w", err))
-				return
-			}
-			// This is field is set, but what is it used for?
-			if err := formWriter.WriteField("ventilation_id[]", ""); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing ventilation_id[]: %w", err))
-				return
-			}
-		}
+// UpdateEntry posts the values of an entry listed by ListEntries to its edit form, like a changed comment.
+// The receipts already attached are kept.
+func (c *Client) UpdateEntry(entry *Entry) error {
+	return c.editEntry(entry, nil)
+}
 
-		providerID := "0"
-		employeeID := "0"
+// editEntry posts the entry to its edit form, uploading the files as new receipts.
+func (c *Client) editEntry(entry *Entry, receipts []string) error {
+	if entry.URL == "" {
+		return fmt.Errorf("no edit page for entry %s", entry.ID)
+	}
+	action, token, err := c.getEditForm(entry.URL)
+	if err != nil {
+		return err
+	}
+	prefix, number := splitEntryID(entry.ID)
+	return c.postEntryForm(action, entry, token, prefix, number, receipts, entry.Receipts)
+}
 
-		if _, ok := operation.Party.(*Provider); ok {
-			if err := formWriter.WriteField("activateFournisseur", "on"); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing activateSalarie: %w", err))
-				return
-			}
-			providerID = operation.Party.GetID()
-		} else if _, ok := operation.Party.(*Employee); ok {
-			if err := formWriter.WriteField("activateSalarie", "on"); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing activateSalarie: %w", err))
-				return
-			}
-			employeeID = operation.Party.GetID()
-		}
+// getEditForm returns the absolute action URL and CSRF token of the form of an entry edit page.
+func (c *Client) getEditForm(pageURL string) (action string, token string, err error) {
+	resp, err := c.client.Get(pageURL)
+	if err != nil {
+		err = fmt.Errorf("failed to get the entry edit page: %s", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("failed to get the entry edit page, HTTP err: %d", resp.StatusCode)
+		return
+	}
 
-		if err := formWriter.WriteField("fournisseur_id", providerID); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing default fournisseur_id: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("personne_id", employeeID); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing default personne_id: %w", err))
-			return
-		}
+	doc, err := html.Parse(resp.Body)
+	if err != nil {
+		err = fmt.Errorf("failed to parse the entry edit page: %s", err)
+		return
+	}
+	form := findNodeWithTagName(doc, "form")
+	if form == nil || getAttr(form, "action") == "" {
+		err = fmt.Errorf("failed to find the form of the entry edit page %s", pageURL)
+		return
+	}
+	tokenInput := findNodeWithKeyValueAttr(form, "name", "_token")
+	if tokenInput == nil {
+		err = fmt.Errorf("failed to find the token of the entry edit page %s", pageURL)
+		return
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return
+	}
+	actionURL, err := base.Parse(getAttr(form, "action"))
+	if err != nil {
+		err = fmt.Errorf("invalid form action in the entry edit page: %s", err)
+		return
+	}
+	return actionURL.String(), getAttr(tokenInput, "value"), nil
+}
+
+// splitEntryID splits an entry ID like FON000012 into its prefix and number, like FON and 12.
+func splitEntryID(id string) (string, string) {
+	prefix := strings.TrimRight(id, "0123456789")
+	number, err := strconv.Atoi(id[len(prefix):])
+	if err != nil {
+		return prefix, ""
+	}
+	return prefix, strconv.Itoa(number)
+}
+
+// postEntryForm posts the entry to the creation or edition form URL.
+// The files are uploaded as new receipts while keptReceipts are the names of the ones already attached.
+func (c *Client) postEntryForm(
+	formURL string,
+	operation *Entry,
+	token, entryID, entryIDNumber string,
+	files []string,
+	keptReceipts []string,
+) error {
+	reader, writer := io.Pipe()
+	formWriter := multipart.NewWriter(writer)
+
+	go writeEntryForm(writer, formWriter, operation, token, entryID, entryIDNumber, files, keptReceipts)
+
+	c.followRedirects(false)
+	resp, err := c.client.Post(formURL, formWriter.FormDataContentType(), reader)
+	c.followRedirects(true)
+	if err != nil {
+		_, _ = io.Copy(io.Discard, reader)
+		return fmt.Errorf("HTTP POST failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusFound {
+		responseBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return nil
+}
+
+// writeEntryForm writes the fields of the entry form. The errors are reported by closing the pipe writer.
+func writeEntryForm(
+	writer *io.PipeWriter,
+	formWriter *multipart.Writer,
+	operation *Entry,
+	token, entryID, entryIDNumber string,
+	files []string,
+	keptReceipts []string,
+) {
+	defer func() { _ = writer.Close() }()
+	defer func() { _ = formWriter.Close() }()
+
+	if err := formWriter.WriteField("_token", token); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing _token: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("exercice_id", operation.Period); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing exercice_id: %w", err))
+		return
+	}
+
+	if err := formWriter.WriteField("type", operation.Kind.String()); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing type: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("budget", strconv.Itoa(int(operation.Budget))); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing budget: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("date", operation.Date.Format(DateLayout)); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing date: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("name", operation.Name); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing name: %w", err))
+		return
+	}
 
-		if err := formWriter.WriteField("method_paiement", strconv.Itoa(int(operation.PaymentMethod))); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing method_paiement: %w", err))
+	for _, line := range operation.Allocation {
+		if err := formWriter.WriteField("category_id[]", strconv.Itoa(line.CategoryID)); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing category_id[]: %w", err))
 			return
 		}
-		if err := formWriter.WriteField("compte_id", strconv.Itoa(operation.Account.ID)); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing compte_id: %w", err))
+		amountStr := fmt.Sprintf("%.2f", line.Amount)
+		amount := bytes.Replace([]byte(amountStr), []byte("."), []byte(","), 1)
+		if err := formWriter.WriteField("amount[]", string(amount)); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing amount[]: %w", err))
 			return
 		}
-
-		// File attachments (Receipts)
-		for _, filePath := range operation.Receipts {
-			file, err := os.Open(filePath)
-			if err != nil {
-				writer.CloseWithError(fmt.Errorf("error opening file %s: %w", filePath, err))
+		if line.Stock != 0 {
+			if err := formWriter.WriteField("stock[]", strconv.Itoa(line.Stock)); err != nil {
+				writer.CloseWithError(fmt.Errorf("error writing stock[]: %w", err))
 				return
 			}
-			defer func() { _ = file.Close() }()
-
-			filename := filepath.Base(filePath)
-
-			part, err := formWriter.CreateFormFile("fichiers[]", filename)
-			if err != nil {
-				writer.CloseWithError(fmt.Errorf("error creating form file part for %s: %w", filename, err))
-				return
-			}
-
-			if _, err := io.Copy(part, file); err != nil {
-				writer.CloseWithError(fmt.Errorf("error writing file content for %s: %w", filename, err))
+		} else {
+			// Write an empty stock if none set
+			if err := formWriter.WriteField("stock[]", ""); err != nil {
+				writer.CloseWithError(fmt.Errorf("error writing empty stock[]: %w", err))
 				return
 			}
 		}
 
-		if err := formWriter.WriteField("identifiant_pc", entryID); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing identifiant_pc: %w", err))
+		// TODO Handle the preorder date feature
+		if err := formWriter.WriteField("date_remise_precommande", ""); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing date_remise_precommande: %w", err))
 			return
 		}
-		if err := formWriter.WriteField("numero_pc", entryIDNumber); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing numero_pc: %w", err))
+		// This is field is set, but what is it used for?
+		if err := formWriter.WriteField("ventilation_id[]", ""); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing ventilation_id[]: %w", err))
 			return
 		}
+	}
 
-		// TODO Features not supported yet
-		if err := formWriter.WriteField("nom_invite", ""); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing nom_invite: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("prenom_invite", ""); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing prenom_invite: %w", err))
-			return
-		}
+	providerID := "0"
+	employeeID := "0"
 
-		if err := formWriter.WriteField("no_cheque", ""); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing no_cheque: %w", err))
+	if _, ok := operation.Party.(*Provider); ok {
+		if err := formWriter.WriteField("activateFournisseur", "on"); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing activateSalarie: %w", err))
 			return
 		}
-		if err := formWriter.WriteField("banque", ""); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing banque: %w", err))
-			return
-		}
-		if err := formWriter.WriteField("date_remise_souhaitee", ""); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing date_remise_souhaitee: %w", err))
+		providerID = operation.Party.GetID()
+	} else if _, ok := operation.Party.(*Employee); ok {
+		if err := formWriter.WriteField("activateSalarie", "on"); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing activateSalarie: %w", err))
 			return
 		}
+		employeeID = operation.Party.GetID()
+	}
 
-		// Activation switches, may be they can be dropped
-		if err := formWriter.WriteField("activateUpload", "on"); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing activateUpload: %w", err))
+	if err := formWriter.WriteField("fournisseur_id", providerID); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing default fournisseur_id: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("personne_id", employeeID); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing default personne_id: %w", err))
+		return
+	}
+
+	if err := formWriter.WriteField("method_paiement", strconv.Itoa(int(operation.PaymentMethod))); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing method_paiement: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("compte_id", strconv.Itoa(operation.Account.ID)); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing compte_id: %w", err))
+		return
+	}
+
+	// The receipts already attached to an edited entry
+	if len(keptReceipts) > 0 {
+		if err := formWriter.WriteField("filename_temp", strings.Join(keptReceipts, ";")); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing filename_temp: %w", err))
 			return
 		}
-		if err := formWriter.WriteField("activateRemarques", "on"); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing activateRemarques: %w", err))
+	}
+
+	// File attachments (Receipts)
+	for _, filePath := range files {
+		file, err := os.Open(filePath)
+		if err != nil {
+			writer.CloseWithError(fmt.Errorf("error opening file %s: %w", filePath, err))
 			return
 		}
+		defer func() { _ = file.Close() }()
+
+		filename := filepath.Base(filePath)
 
-		// Static fields
-		if err := formWriter.WriteField("confirm", "0"); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing confirm: %w", err))
+		part, err := formWriter.CreateFormFile("fichiers[]", filename)
+		if err != nil {
+			writer.CloseWithError(fmt.Errorf("error creating form file part for %s: %w", filename, err))
 			return
 		}
-		if err := formWriter.WriteField("submit_value", "enregistrer"); err != nil {
-			writer.CloseWithError(fmt.Errorf("error writing confirm: %w", err))
+
+		if _, err := io.Copy(part, file); err != nil {
+			writer.CloseWithError(fmt.Errorf("error writing file content for %s: %w", filename, err))
 			return
 		}
+	}
 
-		if err := formWriter.Close(); err != nil {
-			writer.CloseWithError(fmt.Errorf("error closing form writer: %w", err))
-		}
-	}()
+	if err := formWriter.WriteField("identifiant_pc", entryID); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing identifiant_pc: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("numero_pc", entryIDNumber); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing numero_pc: %w", err))
+		return
+	}
 
-	c.followRedirects(false)
-	resp, err := c.client.Post(url_base+"/operations/store", formWriter.FormDataContentType(), reader)
-	c.followRedirects(true)
-	if err != nil {
-		_, _ = io.Copy(io.Discard, reader)
-		return fmt.Errorf("HTTP POST failed: %w", err)
+	guest := Guest{}
+	if operation.Guest != nil {
+		guest = *operation.Guest
+	}
+	if err := formWriter.WriteField("nom_invite", guest.Lastname); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing nom_invite: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("prenom_invite", guest.Firstname); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing prenom_invite: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("no_cheque", operation.CheckNumber); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing no_cheque: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("remarques_libres", operation.Comment); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing remarques_libres: %w", err))
+		return
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusFound {
-		responseBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(responseBody))
+	// TODO Features not supported yet
+	if err := formWriter.WriteField("banque", ""); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing banque: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("date_remise_souhaitee", ""); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing date_remise_souhaitee: %w", err))
+		return
 	}
 
-	return nil
+	// Activation switches, may be they can be dropped
+	if err := formWriter.WriteField("activateUpload", "on"); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing activateUpload: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("activateRemarques", "on"); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing activateRemarques: %w", err))
+		return
+	}
+
+	// Static fields
+	if err := formWriter.WriteField("confirm", "0"); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing confirm: %w", err))
+		return
+	}
+	if err := formWriter.WriteField("submit_value", "enregistrer"); err != nil {
+		writer.CloseWithError(fmt.Errorf("error writing confirm: %w", err))
+		return
+	}
+
+	if err := formWriter.Close(); err != nil {
+		writer.CloseWithError(fmt.Errorf("error closing form writer: %w", err))
+	}
 }
 
 func (c *Client) getNextEntryNumber(budget Budget, kind Kind) (id string, number string, err error) {
@@ -469,7 +716,7 @@ func (c *Client) getNextEntryNumber(budget Budget, kind Kind) (id string, number
 	values.Set("operationId", "0")
 	values.Set("operationType", kind.String())
 	values.Set("budget", fmt.Sprintf("%d", int(budget)))
-	req, err := http.NewRequest("POST", url_base+"/ajax/get-numero-pc", strings.NewReader(values.Encode()))
+	req, err := http.NewRequest("POST", c.baseURL+"/ajax/get-numero-pc", strings.NewReader(values.Encode()))
 	if err != nil {
 		err = fmt.Errorf("failed to create the request: %s", err)
 		return
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEntryResponse(t *testing.T) {
+	page := `<html><body>
+<div class="justificatifs">
+	<a href="/storage/justificatifs/42/invoice.pdf" target="_blank">invoice.pdf</a>
+	<a href="https://files.example.com/42/ticket%20march.jpg?token=abc">ticket march.jpg</a>
+	<a href="/operations/index">Back</a>
+</div>
+<script>
+const operation = JSON.parse(String("{\"name\":\"Gifts\",\"date\":\"2025-03-14\",\"type\":\"depenses\",` +
+		`\"budget\":2,\"exercice_id\":12345,\"compte_id\":7,\"method_paiement\":14,\"fournisseur_id\":null,` +
+		`\"personne_id\":3,\"remarques_libres\":\"\",\"no_cheque\":\"1234567\",\"nom_invite\":\"Doe\",` +
+		`\"prenom_invite\":\"Jane\",\"filename_temp\":\"invoice.pdf;ticket march.jpg;lost.pdf\",` +
+		`\"ventilations\":[{\"category_id\":10,\"amount\":12.5,\"stock\":0}],` +
+		`\"identifiant_pc\":\"ASC\",\"numero_pc\":12}"));
+const edit = true;
+</script>
+</body></html>`
+
+	entry, err := parseEntryResponse(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("parseEntryResponse failed: %v", err)
+	}
+
+	if entry.ID != "ASC000012" {
+		t.Errorf("ID mismatch. Got: %s, Want: ASC000012", entry.ID)
+	}
+	wantReceipts := []string{"invoice.pdf", "ticket march.jpg", "lost.pdf"}
+	if !reflect.DeepEqual(entry.Receipts, wantReceipts) {
+		t.Errorf("Receipts mismatch. Got: %v, Want: %v", entry.Receipts, wantReceipts)
+	}
+	wantLinks := []string{
+		url_base + "/storage/justificatifs/42/invoice.pdf",
+		"https://files.example.com/42/ticket%20march.jpg?token=abc",
+		"",
+	}
+	if !reflect.DeepEqual(entry.ReceiptLinks, wantLinks) {
+		t.Errorf("Receipt links mismatch. Got: %v, Want: %v", entry.ReceiptLinks, wantLinks)
+	}
+	if party, ok := entry.Party.(*Employee); !ok || party.ID != "3" {
+		t.Errorf("Party mismatch. Got: %+v", entry.Party)
+	}
+	if entry.CheckNumber != "1234567" {
+		t.Errorf("Check number mismatch. Got: %s, Want: 1234567", entry.CheckNumber)
+	}
+	if wantGuest := (Guest{Lastname: "Doe", Firstname: "Jane"}); entry.Guest == nil || *entry.Guest != wantGuest {
+		t.Errorf("Guest mismatch. Got: %+v, Want: %+v", entry.Guest, wantGuest)
+	}
+}
+
+func TestDownloadReceipt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/invoice.pdf" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("PDF content"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := client.DownloadReceipt(server.URL+"/invoice.pdf", &out); err != nil {
+		t.Fatalf("DownloadReceipt failed: %v", err)
+	}
+	if out.String() != "PDF content" {
+		t.Errorf("Content mismatch. Got: %q, Want: %q", out.String(), "PDF content")
+	}
+
+	if err := client.DownloadReceipt(server.URL+"/missing.pdf", &out); err == nil {
+		t.Error("Expected an error for a missing receipt")
+	}
+}
+
+func TestEntryFilterValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   EntryFilter
+		expected map[string]string
+	}{
+		{
+			name:     "period only",
+			filter:   EntryFilter{Period: "42"},
+			expected: map[string]string{"exercice_id": "42", "type": "type", "budget": "0", "begin": "", "end": ""},
+		},
+		{
+			name: "all filters",
+			filter: EntryFilter{
+				Period: "42",
+				Budget: BudgetASC,
+				Kind:   KindTake,
+				From:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expected: map[string]string{
+				"exercice_id": "42", "type": "recettes", "budget": "2", "begin": "01/03/2025", "end": "31/03/2025",
+			},
+		},
+	}
+	for _, test := range tests {
+		values := test.filter.values()
+		for key, expected := range test.expected {
+			if actual := values.Get(key); actual != expected {
+				t.Errorf("%s: %s value mismatch. Got: %q, Want: %q", test.name, key, actual, expected)
+			}
+		}
+		if actual := values.Get("statut"); actual != "toutes_operations" {
+			t.Errorf("%s: statut value mismatch. Got: %q, Want: toutes_operations", test.name, actual)
+		}
+	}
+}
@@ -119,3 +119,14 @@ func parseHtmlViewResponse(r io.Reader) (doc *html.Node, err error) {
 	htmlReader := strings.NewReader(htmlContent)
 	return html.ParseWithOptions(htmlReader, html.ParseOptionEnableScripting(false))
 }
+
+// rowCells returns the <td> cells of a table row.
+func rowCells(row *html.Node) []*html.Node {
+	cells := []*html.Node{}
+	for cell := row.FirstChild; cell != nil; cell = cell.NextSibling {
+		if cell.Type == html.ElementNode && cell.Data == "td" {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
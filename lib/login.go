@@ -17,7 +17,7 @@ import (
 
 // Login authenticates on happy-compta with given credentials.
 func (c *Client) Login(email string, password string) error {
-	token, err := c.getToken(url_base + "/auth/login")
+	token, err := c.getToken(c.baseURL + "/auth/login")
 	if err != nil {
 		return err
 	}
@@ -30,7 +30,7 @@ func (c *Client) Login(email string, password string) error {
 	values.Set("type", "0")
 	values.Set("submit", "Connexion")
 
-	resp, err := c.client.PostForm(url_base+"/auth/login", values)
+	resp, err := c.client.PostForm(c.baseURL+"/auth/login", values)
 	if err != nil {
 		return err
 	}
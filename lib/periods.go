@@ -23,9 +23,36 @@ type Period struct {
 	End    time.Time
 }
 
+// FindPeriod returns the ID of the period matching the value.
+// The value can be a period ID or the year of the period start.
+// An empty value selects the current period.
+func FindPeriod(periods []Period, value string) (string, error) {
+	for _, period := range periods {
+		if value == "" && period.Status == PeriodStatusCurrent {
+			return period.ID, nil
+		}
+		if period.ID == value {
+			return period.ID, nil
+		}
+	}
+
+	if year, err := strconv.Atoi(value); err == nil {
+		for _, period := range periods {
+			if period.Start.Year() == year {
+				return period.ID, nil
+			}
+		}
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("no current accounting period")
+	}
+	return "", fmt.Errorf("no accounting period matching %s", value)
+}
+
 // ListPeriods gets the data of all the accounting periods of the organization.
 func (c *Client) ListPeriods() (periods []Period, err error) {
-	resp, err := c.client.Get(url_base + "/operations/index")
+	resp, err := c.client.Get(c.baseURL + "/operations/index")
 	if err != nil {
 		err = fmt.Errorf("failed to get the operations page: %s", err)
 		return
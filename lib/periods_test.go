@@ -136,3 +136,36 @@ func TestParsePeriods(t *testing.T) {
 		t.Errorf("Period 3 (Closed, No ID) mismatch. Got %+v, Expected %+v", periods[2], expectedP3)
 	}
 }
+
+func TestFindPeriod(t *testing.T) {
+	periods := []Period{
+		{ID: "100", Status: PeriodStatusDefinitelyClosed, Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "101", Status: PeriodStatusCurrent, Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "101", false},
+		{"100", "100", false},
+		{"2024", "100", false},
+		{"2023", "", true},
+		{"foo", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := FindPeriod(periods, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FindPeriod(%q) error mismatch. Got: %v, Want error: %t", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("FindPeriod(%q) mismatch. Got: %s, Want: %s", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := FindPeriod(periods[:1], ""); err == nil {
+		t.Error("Expected an error without current period")
+	}
+}
@@ -32,7 +32,7 @@ func (p *Provider) GetID() string {
 
 // ListProviders queries the data of all the providers of the organization, included archived ones.
 func (c *Client) ListProviders() (providers []Provider, err error) {
-	resp, err := c.client.Get(url_base + "/fournisseurs/index/archiv%C3%A9s")
+	resp, err := c.client.Get(c.baseURL + "/fournisseurs/index/archiv%C3%A9s")
 	if err != nil {
 		err = fmt.Errorf("failed to get the providers: %s", err)
 		return
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sepa generates SEPA credit transfer initiation files in the pain.001.001.03 format.
+//
+// A transfer initiation is built with NewTransferInitiation, filled with payments
+// holding the transactions and written with its Write method:
+//
+//	debtor := &sepa.Party{Name: "Association", IBAN: "FR76...", BIC: "AGRIFRPP"}
+//	transfer := sepa.NewTransferInitiation("BATCH-2025-06", debtor)
+//	payment := &sepa.Payment{}
+//	payment.AddTransaction(&sepa.Transaction{
+//		EndToEndID: "REFUND-1", Amount: 1250, Currency: "EUR", Purpose: "REFU",
+//		Creditor: sepa.Party{Name: "Jean Dupont", IBAN: "FR76..."},
+//	})
+//	transfer.AddPayment(payment)
+//	err := transfer.Write(os.Stdout)
+//
+// The values are not validated: the callers need to ensure they match the SEPA rules.
+package sepa
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// timestampLayout formats the UTC creation times.
+	timestampLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// NewTransferInitiation creates a transfer initiation with the given message ID.
+// The initiator is also the debtor of the payments without one.
+func NewTransferInitiation(ID string, initiator *Party) CustomerCreditTransferInitiation {
+	now := time.Now()
+	return CustomerCreditTransferInitiation{
+		ID:            ID,
+		Timestamp:     now.UTC().Format(timestampLayout),
+		ExecutionDate: now.Format(dateLayout),
+		Initiator:     initiator,
+		ChargeBearer:  "SLEV",
+	}
+}
+
+// CustomerCreditTransferInitiation is a SEPA transfer file holding one or more payments.
+type CustomerCreditTransferInitiation struct {
+	ID            string
+	Timestamp     string
+	ExecutionDate string
+	Initiator     *Party
+	Payments      []*Payment
+	// Instant requests SEPA instant credit transfers (SCT Inst) for all the payments.
+	Instant bool
+	// ChargeBearer tells who pays the transfer fees, SLEV following the SEPA rules.
+	ChargeBearer string
+}
+
+// AddPayment appends a payment, defaulting its debtor, execution date and ID from the transfer initiation.
+func (c *CustomerCreditTransferInitiation) AddPayment(payment *Payment) {
+	if payment.Debtor == nil {
+		payment.Debtor = c.Initiator
+	}
+	if payment.ExecutionDate == "" {
+		payment.ExecutionDate = c.ExecutionDate
+	}
+	if payment.ID == "" {
+		payment.ID = fmt.Sprintf("%s/%d", c.ID, len(c.Payments)+1)
+	}
+	c.Payments = append(c.Payments, payment)
+}
+
+// SetTimestamp sets the creation time of the file.
+func (c *CustomerCreditTransferInitiation) SetTimestamp(timestamp time.Time) {
+	c.Timestamp = timestamp.UTC().Format(timestampLayout)
+}
+
+// SetExecutionDate sets the default requested execution date of the payments.
+func (c *CustomerCreditTransferInitiation) SetExecutionDate(date time.Time) {
+	c.ExecutionDate = date.Format(dateLayout)
+}
+
+// Count returns the number of transactions of all the payments.
+func (c *CustomerCreditTransferInitiation) Count() int {
+	count := 0
+	for _, payment := range c.Payments {
+		count += len(payment.Transactions)
+	}
+	return count
+}
+
+// Sum returns the total amount of all the payments.
+func (c *CustomerCreditTransferInitiation) Sum() Amount {
+	var sum Amount
+	for _, payment := range c.Payments {
+		sum += payment.Sum()
+	}
+	return sum
+}
+
+// Payment groups the transactions of a debtor account requested for the same execution date.
+type Payment struct {
+	ID            string
+	ExecutionDate string
+	Debtor        *Party
+	Transactions  []*Transaction
+}
+
+// AddTransaction appends a transaction to the payment.
+func (p *Payment) AddTransaction(transaction *Transaction) {
+	p.Transactions = append(p.Transactions, transaction)
+}
+
+// Sum returns the total amount of the payment transactions.
+func (p Payment) Sum() Amount {
+	var sum Amount
+	for _, transaction := range p.Transactions {
+		sum += transaction.Amount
+	}
+	return sum
+}
+
+// Amount is an amount of money in cents to avoid the floating point rounding errors.
+type Amount int64
+
+// String formats the amount with exactly two decimals as required in the SEPA files.
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign = "-"
+		a = -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/100, a%100)
+}
+
+// MarshalText formats the amount for the XML file.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// Party is a debtor or creditor with its account.
+type Party struct {
+	Name    string
+	IBAN    string
+	BIC     string
+	Address PostalAddress
+}
+
+// PostalAddress is the structured address of a party.
+type PostalAddress struct {
+	Street   string
+	PostCode string
+	City     string
+	Country  string
+}
+
+// Transaction is a credit transfer to a creditor.
+type Transaction struct {
+	EndToEndID string
+	Amount     Amount
+	// Currency is the ISO 4217 code of the amount.
+	Currency string
+	Creditor Party
+	Purpose  string
+	Info     string
+	// Reference is the ISO 11649 creditor reference.
+	Reference string
+	// UltimateDebtor is the name of the party on behalf of which the debtor pays, if any.
+	UltimateDebtor string
+	// UltimateCreditor is the name of the party the creditor receives the money for, if any.
+	UltimateCreditor string
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sepa
+
+import (
+	"testing"
+)
+
+func TestPaymentSum(t *testing.T) {
+	payment := Payment{}
+	for _, amount := range []Amount{10, 20, 1210} {
+		payment.AddTransaction(&Transaction{Amount: amount})
+	}
+
+	if actual := payment.Sum().String(); actual != "12.40" {
+		t.Errorf("Sum mismatch. Got: %s, Want: 12.40", actual)
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		expected string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{123456, "1234.56"},
+		{-1050, "-10.50"},
+	}
+
+	for _, test := range tests {
+		if actual := test.amount.String(); actual != test.expected {
+			t.Errorf("Amount mismatch. Got: %s, Want: %s", actual, test.expected)
+		}
+	}
+}
+
+func TestAddPayment(t *testing.T) {
+	debtor := &Party{Name: "Issuer"}
+	transfer := NewTransferInitiation("batch", debtor)
+	transfer.ExecutionDate = "2025-06-12"
+
+	transfer.AddPayment(&Payment{})
+	transfer.AddPayment(&Payment{ID: "custom", ExecutionDate: "2025-06-13", Debtor: &Party{Name: "Other"}})
+
+	first, second := transfer.Payments[0], transfer.Payments[1]
+	if first.ID != "batch/1" || first.ExecutionDate != "2025-06-12" || first.Debtor != debtor {
+		t.Errorf("Default payment mismatch. Got: %s %s %v", first.ID, first.ExecutionDate, first.Debtor)
+	}
+	if second.ID != "custom" || second.ExecutionDate != "2025-06-13" || second.Debtor.Name != "Other" {
+		t.Errorf("Payment mismatch. Got: %s %s %v", second.ID, second.ExecutionDate, second.Debtor)
+	}
+}
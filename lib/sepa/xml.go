@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sepa
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const (
+	xmlHeader      = `<?xml version="1.0" encoding="utf-8"?>` + "\n"
+	pain001        = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+	schemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// The following types map the pain.001.001.03 XML elements.
+
+type document struct {
+	XMLName        xml.Name   `xml:"Document"`
+	Namespace      string     `xml:"xmlns,attr"`
+	XSI            string     `xml:"xmlns:xsi,attr"`
+	SchemaLocation string     `xml:"xsi:schemaLocation,attr"`
+	Initiation     initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type initiation struct {
+	Header   groupHeader   `xml:"GrpHdr"`
+	Payments []paymentInfo `xml:"PmtInf"`
+}
+
+type groupHeader struct {
+	MessageID     string `xml:"MsgId"`
+	CreationTime  string `xml:"CreDtTm"`
+	Count         int    `xml:"NbOfTxs"`
+	Sum           Amount `xml:"CtrlSum"`
+	InitiatorName string `xml:"InitgPty>Nm"`
+}
+
+type paymentInfo struct {
+	ID            string           `xml:"PmtInfId"`
+	Method        string           `xml:"PmtMtd"`
+	BatchBooking  bool             `xml:"BtchBookg"`
+	Count         int              `xml:"NbOfTxs"`
+	Sum           Amount           `xml:"CtrlSum"`
+	Type          *paymentType     `xml:"PmtTpInf,omitempty"`
+	ExecutionDate string           `xml:"ReqdExctnDt"`
+	DebtorName    string           `xml:"Dbtr>Nm"`
+	DebtorIBAN    string           `xml:"DbtrAcct>Id>IBAN"`
+	DebtorBIC     string           `xml:"DbtrAgt>FinInstnId>BIC"`
+	Transactions  []creditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type paymentType struct {
+	ServiceLevel    string `xml:"SvcLvl>Cd"`
+	LocalInstrument string `xml:"LclInstrm>Cd"`
+}
+
+type creditTransfer struct {
+	EndToEndID       string           `xml:"PmtId>EndToEndId"`
+	Amount           instructedAmount `xml:"Amt>InstdAmt"`
+	ChargeBearer     string           `xml:"ChrgBr"`
+	UltimateDebtor   *partyName       `xml:"UltmtDbtr,omitempty"`
+	CreditorAgent    *agent           `xml:"CdtrAgt,omitempty"`
+	Creditor         creditor         `xml:"Cdtr"`
+	CreditorIBAN     string           `xml:"CdtrAcct>Id>IBAN"`
+	UltimateCreditor *partyName       `xml:"UltmtCdtr,omitempty"`
+	Purpose          string           `xml:"Purp>Cd"`
+	Remittance       remittance       `xml:"RmtInf"`
+}
+
+type instructedAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    Amount `xml:",chardata"`
+}
+
+type agent struct {
+	BIC string `xml:"FinInstnId>BIC"`
+}
+
+type creditor struct {
+	Name    string         `xml:"Nm"`
+	Address *postalAddress `xml:"PstlAdr,omitempty"`
+}
+
+type partyName struct {
+	Name string `xml:"Nm"`
+}
+
+type postalAddress struct {
+	Street   string `xml:"StrtNm,omitempty"`
+	PostCode string `xml:"PstCd,omitempty"`
+	City     string `xml:"TwnNm"`
+	Country  string `xml:"Ctry"`
+}
+
+type remittance struct {
+	Structured   *structuredRemittance `xml:"Strd,omitempty"`
+	Unstructured *string               `xml:"Ustrd,omitempty"`
+}
+
+type structuredRemittance struct {
+	Code      string `xml:"CdtrRefInf>Tp>CdOrPrtry>Cd"`
+	Issuer    string `xml:"CdtrRefInf>Tp>Issr"`
+	Reference string `xml:"CdtrRefInf>Ref"`
+}
+
+// Write writes the transfer initiation as a pain.001.001.03 XML file.
+func (c *CustomerCreditTransferInitiation) Write(wr io.Writer) error {
+	doc := document{
+		Namespace:      pain001,
+		XSI:            schemaInstance,
+		SchemaLocation: pain001 + " pain.001.001.03.xsd",
+		Initiation: initiation{
+			Header: groupHeader{
+				MessageID:    c.ID,
+				CreationTime: c.Timestamp,
+				Count:        c.Count(),
+				Sum:          c.Sum(),
+			},
+		},
+	}
+	if c.Initiator != nil {
+		doc.Initiation.Header.InitiatorName = c.Initiator.Name
+	}
+	for _, payment := range c.Payments {
+		doc.Initiation.Payments = append(doc.Initiation.Payments, c.newPaymentInfo(payment))
+	}
+
+	if _, err := io.WriteString(wr, xmlHeader); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(wr)
+	encoder.Indent("", "    ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write the SEPA file: %s", err)
+	}
+	_, err := io.WriteString(wr, "\n")
+	return err
+}
+
+func (c *CustomerCreditTransferInitiation) newPaymentInfo(payment *Payment) paymentInfo {
+	info := paymentInfo{
+		ID:            payment.ID,
+		Method:        "TRF",
+		Count:         len(payment.Transactions),
+		Sum:           payment.Sum(),
+		ExecutionDate: payment.ExecutionDate,
+	}
+	if c.Instant {
+		info.Type = &paymentType{ServiceLevel: "SEPA", LocalInstrument: "INST"}
+	}
+	if payment.Debtor != nil {
+		info.DebtorName = payment.Debtor.Name
+		info.DebtorIBAN = payment.Debtor.IBAN
+		info.DebtorBIC = payment.Debtor.BIC
+	}
+	for _, transaction := range payment.Transactions {
+		info.Transactions = append(info.Transactions, c.newCreditTransfer(transaction))
+	}
+	return info
+}
+
+func (c *CustomerCreditTransferInitiation) newCreditTransfer(transaction *Transaction) creditTransfer {
+	transfer := creditTransfer{
+		EndToEndID:   transaction.EndToEndID,
+		Amount:       instructedAmount{Currency: transaction.Currency, Value: transaction.Amount},
+		ChargeBearer: c.ChargeBearer,
+		Creditor:     creditor{Name: transaction.Creditor.Name},
+		CreditorIBAN: transaction.Creditor.IBAN,
+		Purpose:      transaction.Purpose,
+	}
+	if transaction.Creditor.BIC != "" {
+		transfer.CreditorAgent = &agent{BIC: transaction.Creditor.BIC}
+	}
+	if transaction.UltimateDebtor != "" {
+		transfer.UltimateDebtor = &partyName{Name: transaction.UltimateDebtor}
+	}
+	if transaction.UltimateCreditor != "" {
+		transfer.UltimateCreditor = &partyName{Name: transaction.UltimateCreditor}
+	}
+	// The town and country are mandatory in the structured addresses.
+	if address := transaction.Creditor.Address; address.Country != "" {
+		transfer.Creditor.Address = &postalAddress{
+			Street: address.Street, PostCode: address.PostCode, City: address.City, Country: address.Country,
+		}
+	}
+	// SEPA only allows one of the structured and unstructured remittance information.
+	if transaction.Reference != "" {
+		transfer.Remittance.Structured = &structuredRemittance{
+			Code: "SCOR", Issuer: "ISO", Reference: transaction.Reference,
+		}
+	} else {
+		transfer.Remittance.Unstructured = &transaction.Info
+	}
+	return transfer
+}
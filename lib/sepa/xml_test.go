@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sepa
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	debtor := &Party{Name: "Asso & Co", IBAN: "FR7420041010058652109911007", BIC: "PMXNCXV94RH"}
+	transfer := NewTransferInitiation("BATCH-1", debtor)
+	transfer.SetTimestamp(time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC))
+	transfer.SetExecutionDate(time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC))
+	transfer.Instant = true
+
+	payment := &Payment{}
+	payment.AddTransaction(&Transaction{
+		EndToEndID: "E1", Amount: 1250, Currency: "EUR", Purpose: "REFU", Info: "Refund",
+		Creditor: Party{
+			Name: "Jean Dupont", IBAN: "FR5120041010051631529138143",
+			Address: PostalAddress{City: "Paris", Country: "FR"},
+		},
+	})
+	payment.AddTransaction(&Transaction{
+		EndToEndID: "E2", Amount: 5, Currency: "CHF", Purpose: "REFU", Reference: "RF18539007547034",
+		Creditor: Party{Name: "Marie Martin", IBAN: "CH9300762011623852957", BIC: "POFICHBEXXX"},
+	})
+	transfer.AddPayment(payment)
+
+	var buf bytes.Buffer
+	if err := transfer.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="utf-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03" ` +
+		`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
+		`xsi:schemaLocation="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 pain.001.001.03.xsd">
+    <CstmrCdtTrfInitn>
+        <GrpHdr>
+            <MsgId>BATCH-1</MsgId>
+            <CreDtTm>2025-06-11T10:00:00.000Z</CreDtTm>
+            <NbOfTxs>2</NbOfTxs>
+            <CtrlSum>12.55</CtrlSum>
+            <InitgPty>
+                <Nm>Asso &amp; Co</Nm>
+            </InitgPty>
+        </GrpHdr>
+        <PmtInf>
+            <PmtInfId>BATCH-1/1</PmtInfId>
+            <PmtMtd>TRF</PmtMtd>
+            <BtchBookg>false</BtchBookg>
+            <NbOfTxs>2</NbOfTxs>
+            <CtrlSum>12.55</CtrlSum>
+            <PmtTpInf>
+                <SvcLvl>
+                    <Cd>SEPA</Cd>
+                </SvcLvl>
+                <LclInstrm>
+                    <Cd>INST</Cd>
+                </LclInstrm>
+            </PmtTpInf>
+            <ReqdExctnDt>2025-06-12</ReqdExctnDt>
+            <Dbtr>
+                <Nm>Asso &amp; Co</Nm>
+            </Dbtr>
+            <DbtrAcct>
+                <Id>
+                    <IBAN>FR7420041010058652109911007</IBAN>
+                </Id>
+            </DbtrAcct>
+            <DbtrAgt>
+                <FinInstnId>
+                    <BIC>PMXNCXV94RH</BIC>
+                </FinInstnId>
+            </DbtrAgt>
+            <CdtTrfTxInf>
+                <PmtId>
+                    <EndToEndId>E1</EndToEndId>
+                </PmtId>
+                <Amt>
+                    <InstdAmt Ccy="EUR">12.50</InstdAmt>
+                </Amt>
+                <ChrgBr>SLEV</ChrgBr>
+                <Cdtr>
+                    <Nm>Jean Dupont</Nm>
+                    <PstlAdr>
+                        <TwnNm>Paris</TwnNm>
+                        <Ctry>FR</Ctry>
+                    </PstlAdr>
+                </Cdtr>
+                <CdtrAcct>
+                    <Id>
+                        <IBAN>FR5120041010051631529138143</IBAN>
+                    </Id>
+                </CdtrAcct>
+                <Purp>
+                    <Cd>REFU</Cd>
+                </Purp>
+                <RmtInf>
+                    <Ustrd>Refund</Ustrd>
+                </RmtInf>
+            </CdtTrfTxInf>
+            <CdtTrfTxInf>
+                <PmtId>
+                    <EndToEndId>E2</EndToEndId>
+                </PmtId>
+                <Amt>
+                    <InstdAmt Ccy="CHF">0.05</InstdAmt>
+                </Amt>
+                <ChrgBr>SLEV</ChrgBr>
+                <CdtrAgt>
+                    <FinInstnId>
+                        <BIC>POFICHBEXXX</BIC>
+                    </FinInstnId>
+                </CdtrAgt>
+                <Cdtr>
+                    <Nm>Marie Martin</Nm>
+                </Cdtr>
+                <CdtrAcct>
+                    <Id>
+                        <IBAN>CH9300762011623852957</IBAN>
+                    </Id>
+                </CdtrAcct>
+                <Purp>
+                    <Cd>REFU</Cd>
+                </Purp>
+                <RmtInf>
+                    <Strd>
+                        <CdtrRefInf>
+                            <Tp>
+                                <CdOrPrtry>
+                                    <Cd>SCOR</Cd>
+                                </CdOrPrtry>
+                                <Issr>ISO</Issr>
+                            </Tp>
+                            <Ref>RF18539007547034</Ref>
+                        </CdtrRefInf>
+                    </Strd>
+                </RmtInf>
+            </CdtTrfTxInf>
+        </PmtInf>
+    </CstmrCdtTrfInitn>
+</Document>
+`
+	if buf.String() != expected {
+		t.Errorf("XML mismatch. Got:\n%s\nWant:\n%s", buf.String(), expected)
+	}
+}
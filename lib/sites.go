@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/net/html"
+)
+
+// Site is one of the establishments of a multi-site organization.
+type Site struct {
+	ID      string
+	Name    string
+	Address string
+	ZipCode string
+	City    string
+}
+
+// ListSites queries the sites of the organization.
+// The page is only accessible to the administrators.
+func (c *Client) ListSites() (sites []Site, err error) {
+	resp, err := c.client.Get(c.baseURL + "/sites/index")
+	if err != nil {
+		err = fmt.Errorf("failed to get the sites: %s", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("failed to get the sites, got %d status code", resp.StatusCode)
+		return
+	}
+
+	return parseSites(resp.Body)
+}
+
+func parseSites(r io.Reader) (sites []Site, err error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		err = fmt.Errorf("failed to parse HTML: %w", err)
+		return
+	}
+
+	tbody := findNodeWithTagName(doc, "tbody")
+	if tbody == nil {
+		err = fmt.Errorf("could not find the table listing the sites")
+		return
+	}
+
+	const (
+		columnName    = 0
+		columnAddress = 1
+		columnZipCode = 2
+		columnCity    = 3
+		columnActions = 4
+	)
+
+	for row := tbody.FirstChild; row != nil; row = row.NextSibling {
+		if row.Type != html.ElementNode || row.Data != "tr" {
+			continue
+		}
+
+		cells := rowCells(row)
+		if len(cells) <= columnActions {
+			continue
+		}
+
+		sites = append(sites, Site{
+			ID:      extractIDFromActionsCell(cells[columnActions]),
+			Name:    extractTextContent(cells[columnName]),
+			Address: extractTextContent(cells[columnAddress]),
+			ZipCode: extractTextContent(cells[columnZipCode]),
+			City:    extractTextContent(cells[columnCity]),
+		})
+	}
+	return
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mockSitesHTML = `
+<html><body>
+<table id="dt_basic">
+    <thead>
+        <tr><th>Nom</th><th>Adresse</th><th>Code postal</th><th>Ville</th><th class="noPdf"></th></tr>
+    </thead>
+    <tbody>
+        <tr>
+            <td>Siège</td>
+            <td>1 rue de la Paix</td>
+            <td>75002</td>
+            <td>Paris</td>
+            <td class="hidden-xs"><a data-id="3" href="/sites/edit/3">Edit</a></td>
+        </tr>
+        <tr>
+            <td>Usine</td>
+            <td></td>
+            <td>69007</td>
+            <td>Lyon</td>
+            <td class="hidden-xs"><a data-id="4" href="/sites/edit/4">Edit</a></td>
+        </tr>
+        <!-- Invalid row structure (should be skipped by length check) -->
+        <tr><td>Only one cell</td></tr>
+    </tbody>
+</table>
+</body></html>`
+
+func TestParseSites(t *testing.T) {
+	sites, err := parseSites(strings.NewReader(mockSitesHTML))
+	if err != nil {
+		t.Fatalf("parseSites failed unexpectedly: %v", err)
+	}
+
+	want := []Site{
+		{ID: "3", Name: "Siège", Address: "1 rue de la Paix", ZipCode: "75002", City: "Paris"},
+		{ID: "4", Name: "Usine", ZipCode: "69007", City: "Lyon"},
+	}
+	if !reflect.DeepEqual(sites, want) {
+		t.Errorf("Sites mismatch. Got: %+v, Want: %+v", sites, want)
+	}
+}
+
+func TestParseSitesNoTable(t *testing.T) {
+	if _, err := parseSites(strings.NewReader("<html><body></body></html>")); err == nil {
+		t.Error("Expected an error without the sites table")
+	}
+}
@@ -110,6 +110,14 @@ func (k *Kind) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaler interface using the happy-compta value.
+func (k Kind) MarshalJSON() ([]byte, error) {
+	if k == KindUndefined {
+		return json.Marshal("")
+	}
+	return json.Marshal(k.String())
+}
+
 func NewKind(s string) Kind {
 	switch s {
 	case "depenses":
@@ -256,3 +264,11 @@ func (b *IntBool) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b IntBool) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte("1"), nil
+	}
+	return []byte("0"), nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestKindJSON(t *testing.T) {
+	for _, kind := range []Kind{KindUndefined, KindSpend, KindTake, KindAllocation} {
+		data, err := json.Marshal(kind)
+		if err != nil {
+			t.Fatalf("failed to marshal %s: %v", kind, err)
+		}
+		var got Kind
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", data, err)
+		}
+		if got != kind {
+			t.Errorf("Kind round trip mismatch. Got: %s, Want: %s", got, kind)
+		}
+	}
+}
+
+func TestIntBoolJSON(t *testing.T) {
+	for _, value := range []IntBool{true, false} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("failed to marshal %t: %v", value, err)
+		}
+		var got IntBool
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", data, err)
+		}
+		if got != value {
+			t.Errorf("IntBool round trip mismatch. Got: %t, Want: %t", got, value)
+		}
+	}
+}
+
+func TestCategoryJSON(t *testing.T) {
+	category := Category{ID: 12, ParentID: 3, Kind: KindAllocation, Name: "Chèques", Budget: BudgetASC, Stock: true}
+
+	data, err := json.Marshal(category)
+	if err != nil {
+		t.Fatalf("failed to marshal category: %v", err)
+	}
+	var got Category
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(got, category) {
+		t.Errorf("Category round trip mismatch. Got: %+v, Want: %+v", got, category)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// User is an account allowed to log in happy-compta for the organization.
+type User struct {
+	ID        string
+	Lastname  string
+	Firstname string
+	Email     string
+	// Role is the label of the user's role, like Administrateur or Trésorier.
+	Role string
+	// Sites are the names of the sites the user has access to, empty for all of them.
+	Sites  []string
+	Active bool
+}
+
+// userActiveStatus is the text of the status column for the active users.
+const userActiveStatus = "Actif"
+
+// ListUsers queries the user accounts of the organization with their roles, including the inactive ones.
+// The page is only accessible to the administrators.
+func (c *Client) ListUsers() (users []User, err error) {
+	resp, err := c.client.Get(c.baseURL + "/utilisateurs/index")
+	if err != nil {
+		err = fmt.Errorf("failed to get the users: %s", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("failed to get the users, got %d status code", resp.StatusCode)
+		return
+	}
+
+	return parseUsers(resp.Body)
+}
+
+func parseUsers(r io.Reader) (users []User, err error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		err = fmt.Errorf("failed to parse HTML: %w", err)
+		return
+	}
+
+	tbody := findNodeWithTagName(doc, "tbody")
+	if tbody == nil {
+		err = fmt.Errorf("could not find the table listing the users")
+		return
+	}
+
+	const (
+		columnLastname  = 0
+		columnFirstname = 1
+		columnEmail     = 2
+		columnRole      = 3
+		columnSites     = 4
+		columnStatus    = 5
+		columnActions   = 6
+	)
+
+	for row := tbody.FirstChild; row != nil; row = row.NextSibling {
+		if row.Type != html.ElementNode || row.Data != "tr" {
+			continue
+		}
+
+		cells := rowCells(row)
+		if len(cells) <= columnActions {
+			continue
+		}
+
+		user := User{
+			ID:        extractIDFromActionsCell(cells[columnActions]),
+			Lastname:  extractTextContent(cells[columnLastname]),
+			Firstname: extractTextContent(cells[columnFirstname]),
+			Email:     extractTextContent(cells[columnEmail]),
+			Role:      extractTextContent(cells[columnRole]),
+			Active:    extractTextContent(cells[columnStatus]) == userActiveStatus,
+		}
+		for _, site := range strings.Split(extractTextContent(cells[columnSites]), ",") {
+			if site = strings.TrimSpace(site); site != "" {
+				user.Sites = append(user.Sites, site)
+			}
+		}
+		users = append(users, user)
+	}
+	return
+}
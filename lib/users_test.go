@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lib
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mockUsersHTML = `
+<html><body>
+<table id="dt_basic">
+    <thead>
+        <tr>
+            <th>Nom</th><th>Prénom</th><th>Email</th><th>Rôle</th><th>Sites</th><th>Statut</th>
+            <th class="noPdf"></th>
+        </tr>
+    </thead>
+    <tbody>
+        <tr>
+            <td>Doe</td>
+            <td>Jane</td>
+            <td>jane@example.com</td>
+            <td>Administrateur</td>
+            <td></td>
+            <td><span class="label label-success">Actif</span></td>
+            <td class="hidden-xs"><a data-id="12" href="/utilisateurs/edit/12">Edit</a></td>
+        </tr>
+        <tr>
+            <td>Smith</td>
+            <td>John</td>
+            <td>john@example.com</td>
+            <td>Lecteur</td>
+            <td>Siège, Usine</td>
+            <td><span class="label label-danger">Inactif</span></td>
+            <td class="hidden-xs"><a data-id="15" href="/utilisateurs/edit/15">Edit</a></td>
+        </tr>
+        <!-- Invalid row structure (should be skipped by length check) -->
+        <tr><td>Only one cell</td></tr>
+    </tbody>
+</table>
+</body></html>`
+
+func TestParseUsers(t *testing.T) {
+	users, err := parseUsers(strings.NewReader(mockUsersHTML))
+	if err != nil {
+		t.Fatalf("parseUsers failed unexpectedly: %v", err)
+	}
+
+	want := []User{
+		{ID: "12", Lastname: "Doe", Firstname: "Jane", Email: "jane@example.com", Role: "Administrateur", Active: true},
+		{
+			ID: "15", Lastname: "Smith", Firstname: "John", Email: "john@example.com", Role: "Lecteur",
+			Sites: []string{"Siège", "Usine"},
+		},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Users mismatch. Got: %+v, Want: %+v", users, want)
+	}
+}
+
+func TestParseUsersNoTable(t *testing.T) {
+	if _, err := parseUsers(strings.NewReader("<html><body></body></html>")); err == nil {
+		t.Error("Expected an error without the users table")
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/csvtosepa"
+	"github.com/cbosdo/happycompta-tools/internal/dumper"
+	"github.com/cbosdo/happycompta-tools/internal/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// maskedPassword replaces the password in the printed configuration.
+const maskedPassword = "********"
+
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Print the configuration",
+		Long: `Print the configuration read from the configuration file and the shared environment variables.
+The password is masked.
+
+With the check flag, the configuration file is validated against the options of all the commands instead.
+The unknown keys, values of the wrong type and flag names used instead of nested keys are reported.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := viper.ConfigFileUsed()
+			check, err := cmd.Flags().GetBool("check")
+			if err != nil {
+				return err
+			}
+			if check {
+				return checkConfig(cmd.OutOrStdout(), file, cmd.Root().Commands())
+			}
+
+			if file != "" {
+				slog.Info("configuration file found", "file", file)
+			} else {
+				slog.Info("no configuration file found")
+			}
+			return writeConfig(cmd.OutOrStdout(), viper.AllSettings())
+		},
+	}
+	addSharedFlags(configCmd)
+	configCmd.Flags().Bool("check", false, "Validate the configuration file instead of printing it.")
+	return configCmd
+}
+
+// checkConfig writes the problems of the configuration file for the given commands.
+func checkConfig(w io.Writer, path string, cmds []*cobra.Command) error {
+	if path == "" {
+		return errors.New("no configuration file to check")
+	}
+	content, err := common.ReadConfigFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the configuration file: %s", err)
+	}
+
+	keys := common.ConfigKeys{}
+	for _, toolKeys := range []common.ConfigKeys{loader.ConfigKeys, dumper.ConfigKeys, csvtosepa.ConfigKeys} {
+		maps.Copy(keys, toolKeys)
+	}
+	problems, err := common.ValidateConfig(content, keys, cmds...)
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %s", path, err)
+	}
+	for _, problem := range problems {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", path, problem); err != nil {
+			return err
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf(common.Tr("problems found in the configuration file: %d"), len(problems))
+	}
+	return nil
+}
+
+// writeConfig writes the configuration settings as YAML, masking the password.
+func writeConfig(w io.Writer, settings map[string]any) error {
+	if password, ok := settings["password"].(string); ok && password != "" {
+		settings["password"] = maskedPassword
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(settings); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteConfig(t *testing.T) {
+	settings := map[string]any{
+		"email":    "user@example.com",
+		"password": "secret",
+		"csv":      map[string]any{"comma": ";"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeConfig(&buf, settings); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := `csv:
+  comma: ;
+email: user@example.com
+password: '********'
+`
+	if buf.String() != expected {
+		t.Errorf("Configuration mismatch. Got:\n%s\nWant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestRootCommands(t *testing.T) {
+	var names []string
+	for _, cmd := range newRootCmd().Commands() {
+		names = append(names, cmd.Name())
+	}
+	for _, name := range []string{"config", "dump", "load", "login", "sepa"} {
+		found := false
+		for _, actual := range names {
+			found = found || actual == name
+		}
+		if !found {
+			t.Errorf("Missing %s command in: %v", name, names)
+		}
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `email: user@example.com
+receipts: receipts
+organization: Asso
+debtors:
+  main:
+    iban: FR76
+formt: yaml
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err := checkConfig(&buf, path, newRootCmd().Commands())
+	if err == nil || err.Error() != "problems found in the configuration file: 1" {
+		t.Errorf("Error mismatch. Got: %v", err)
+	}
+	expected := path + ": line 7: unknown key formt, did you mean format?\n"
+	if buf.String() != expected {
+		t.Errorf("Problems mismatch. Got: %s, Want: %s", buf.String(), expected)
+	}
+}
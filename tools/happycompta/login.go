@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+)
+
+func newLoginCmd() *cobra.Command {
+	loginCmd := &cobra.Command{
+		Use:   "login",
+		Short: "Check the happy-compta credentials",
+		Long: `Log in to happy-compta to check the credentials.
+The credentials are read from the flags, the HAPPYCOMPTA_EMAIL and HAPPYCOMPTA_PASSWORD variables,
+the configuration file or the system keyring for the password, like for the other commands.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+
+			client, err := lib.NewClient()
+			if err != nil {
+				return err
+			}
+			if err := client.Login(credentials.Email, credentials.Password); err != nil {
+				return common.WithExitCode(common.ExitAuth, err)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), common.Tr("Logged in to happy-compta as %s\n"), credentials.Email)
+			return err
+		},
+	}
+	addSharedFlags(loginCmd)
+	return loginCmd
+}
+
+// addSharedFlags adds the configuration and credential flags of the commands not belonging to a former tool.
+// Their environment variables use the shared prefix.
+func addSharedFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("config", "c", "", "Configuration file path")
+	common.AddLogFlags(cmd)
+	cmd.PersistentFlags().String("email", "", "User email address")
+	cmd.PersistentFlags().String("password", "", "User password")
+	cmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+		common.SetupCommand(cmd, common.SharedEnvPrefix, nil)
+	}
+}
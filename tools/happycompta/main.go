@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/csvtosepa"
+	"github.com/cbosdo/happycompta-tools/internal/dumper"
+	"github.com/cbosdo/happycompta-tools/internal/loader"
+	"github.com/spf13/cobra"
+)
+
+func newRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "happycompta",
+		Short: "Tools to work with happy-compta",
+		Long: `Tools to work with happy-compta.
+
+All the commands read the same config.yaml configuration file.
+The credentials can be shared between the commands using the HAPPYCOMPTA_EMAIL and HAPPYCOMPTA_PASSWORD variables.
+The other environment variables are prefixed with the name of the former tool: LOADER_, DUMPER_ or CSV_SEPA_.`,
+		Version: common.FullVersion(),
+	}
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
+	rootCmd.SetFlagErrorFunc(common.FlagError)
+	common.AddLanguageFlag(rootCmd)
+
+	rootCmd.AddCommand(loader.NewCommand("load"))
+	rootCmd.AddCommand(dumper.NewCommand("dump"))
+	rootCmd.AddCommand(csvtosepa.NewCommand("sepa"))
+	rootCmd.AddCommand(csvtosepa.NewReimburseCommand("reimburse"))
+	rootCmd.AddCommand(newLoginCmd())
+	rootCmd.AddCommand(newSyncCmd())
+	rootCmd.AddCommand(newServeCmd())
+	rootCmd.AddCommand(dumper.NewReportCommand("report"))
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(common.NewGenDocsCommand())
+	return rootCmd
+}
+
+func main() {
+	common.Execute(newRootCmd())
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/csvtosepa"
+	"github.com/cbosdo/happycompta-tools/internal/dumper"
+	"github.com/cbosdo/happycompta-tools/internal/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestFrenchShortHelp ensures the translations follow the changes of the commands descriptions.
+func TestFrenchHelp(t *testing.T) {
+	var check func(cmd *cobra.Command)
+	check = func(cmd *cobra.Command) {
+		if !common.HasTranslation(common.LanguageFrench, cmd.Short) {
+			t.Errorf("Missing French translation for %s: %s", cmd.CommandPath(), cmd.Short)
+		}
+		if cmd.Long != "" && !common.HasTranslation(common.LanguageFrench, cmd.Long) {
+			t.Errorf("Missing French translation for the %s long help: %s", cmd.CommandPath(), cmd.Long)
+		}
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			if !common.HasTranslation(common.LanguageFrench, flag.Usage) {
+				t.Errorf("Missing French translation for the %s %s flag: %s", cmd.CommandPath(), flag.Name, flag.Usage)
+			}
+		})
+		for _, child := range cmd.Commands() {
+			check(child)
+		}
+	}
+	check(newRootCmd())
+}
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      func() *cobra.Command
+		args     []string
+		expected []string
+	}{
+		{"happycompta dump format", newRootCmd, []string{"dump", "--format", ""}, []string{"text", "table", "yaml"}},
+		{"happycompta load kind", newRootCmd, []string{"load", "--kind", ""}, []string{"depenses", "attributions"}},
+		{"happycompta report format", newRootCmd, []string{"report", "--format", ""}, []string{"markdown", "html"}},
+		{
+			"happycompta dump reconcile format", newRootCmd, []string{"dump", "reconcile", "--format", ""},
+			[]string{"text", "table", "yaml"},
+		},
+		{"happycompta lang", newRootCmd, []string{"login", "--lang", ""}, []string{"en", "fr"}},
+		{
+			"dumper budget", func() *cobra.Command { return dumper.NewCommand("dumper") },
+			[]string{"--budget", ""}, []string{"FON", "ASC"},
+		},
+		{
+			"loader payment", func() *cobra.Command { return loader.NewCommand("loader") },
+			[]string{"--payment", ""}, []string{"card", "check allocation"},
+		},
+		{
+			"csv-to-sepa charge bearer", func() *cobra.Command { return csvtosepa.NewCommand("csv-to-sepa") },
+			[]string{"--charge-bearer", ""}, []string{"SLEV", "SHAR"},
+		},
+		{
+			"csv-to-sepa completion command", func() *cobra.Command { return csvtosepa.NewCommand("csv-to-sepa") },
+			[]string{""}, []string{"completion"},
+		},
+		{
+			"dumper completion command", func() *cobra.Command { return dumper.NewCommand("dumper") },
+			[]string{""}, []string{"completion"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := test.cmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, test.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("completion failed: %s", err)
+			}
+			for _, expected := range test.expected {
+				if !strings.Contains(out.String(), expected+"\n") && !strings.Contains(out.String(), expected+"\t") {
+					t.Errorf("Completion mismatch. Got: %s, Want it to contain: %s", out.String(), expected)
+				}
+			}
+		})
+	}
+}
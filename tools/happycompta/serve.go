@@ -0,0 +1,508 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maxEntryUploadSize is the maximum size of an entry creation request with its receipts.
+const maxEntryUploadSize = 32 * 1024 * 1024
+
+func newServeCmd() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run an HTTP server exposing happy-compta as a JSON API",
+		Long: `Run an HTTP server exposing happy-compta as a JSON API for the tools that can't use the Go library.
+
+The following endpoints are available:
+  GET  /employees    the employees
+  GET  /providers    the providers, including the archived ones
+  GET  /categories   the categories of the entries
+  GET  /accounts     the bank accounts
+  GET  /periods      the accounting periods
+  POST /entries      creates an entry from a multipart form with the following fields:
+    date          the date of the entry, like 2025-03-14 or 14/03/2025 (required)
+    name          the name of the entry (required)
+    budget        FON or ASC (required)
+    kind          depenses, recettes or attributions, defaults to depenses
+    category_id   the category ID (required), repeated for each allocation line
+    amount        the amount, repeated for each allocation line
+    stock         the stock of the categories having one, repeated for each allocation line
+    payment       the payment method, like card or transfer (required)
+    account_id    the bank account ID (required)
+    period_id     the accounting period ID, defaults to the current one
+    employee_id   the employee ID
+    provider_id   the provider ID
+    comment       the comment
+    check         the check number
+    receipts      the receipt files, up to 3
+
+The happy-compta sessions are kept between the requests and logged in again when they expire or fail.
+The errors are returned as a JSON object with an error field.
+
+The server listens on the loopback interface by default. A token is required to listen on other addresses.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.WithExitCode(common.ExitConfig, common.LoadSecretFile("HAPPYCOMPTA_API_TOKEN"))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			credentials := common.ReadCredentials()
+			if err := credentials.Validate(); err != nil {
+				return err
+			}
+
+			listen, token := viper.GetString("api.listen"), viper.GetString("api.token")
+			if err := common.CheckListenAddress(listen, token); err != nil {
+				return err
+			}
+			pool := newSessionPool(credentials, viper.GetInt("api.sessions"), lib.NewClient)
+			server := &apiServer{pool: pool, token: token}
+
+			slog.Info("listening", "address", listen)
+			return http.ListenAndServe(listen, server.handler())
+		},
+	}
+	addSharedFlags(serveCmd)
+	serveCmd.Flags().String("api-listen", "127.0.0.1:8080", "Address to listen on.")
+	serveCmd.Flags().String("api-token", "", `Token the clients need to pass as an Authorization bearer header.
+Can also be set with the HAPPYCOMPTA_API_TOKEN variable or the file of the HAPPYCOMPTA_API_TOKEN_FILE one.`)
+	serveCmd.Flags().Int("api-sessions", 2, "Maximum number of happy-compta sessions used at the same time.")
+	return serveCmd
+}
+
+// sessionMaxAge is the duration after which a session is logged in again, before happy-compta expires it.
+const sessionMaxAge = 30 * time.Minute
+
+// session is a logged in happy-compta client.
+type session struct {
+	client   *lib.Client
+	loggedIn time.Time
+}
+
+// sessionPool shares logged in clients between the requests.
+// A client is only used by one request at a time as it is not safe for concurrent use.
+type sessionPool struct {
+	credentials common.Credentials
+	newClient   func() (*lib.Client, error)
+	now         func() time.Time
+	// sessions holds the idle sessions. The empty ones still need to log in.
+	sessions chan session
+}
+
+func newSessionPool(credentials common.Credentials, size int, newClient func() (*lib.Client, error)) *sessionPool {
+	size = max(size, 1)
+	pool := &sessionPool{
+		credentials: credentials,
+		newClient:   newClient,
+		now:         time.Now,
+		sessions:    make(chan session, size),
+	}
+	for range size {
+		pool.sessions <- session{}
+	}
+	return pool
+}
+
+// with runs fn with an idle session, waiting for one if they are all used.
+// The session is discarded if fn fails as the failure may come from an expired session.
+func (p *sessionPool) with(fn func(client *lib.Client) error) error {
+	s := <-p.sessions
+	if s.client == nil || p.now().Sub(s.loggedIn) > sessionMaxAge {
+		client, err := p.newClient()
+		if err == nil {
+			err = client.Login(p.credentials.Email, p.credentials.Password)
+		}
+		if err != nil {
+			p.sessions <- session{}
+			return err
+		}
+		s = session{client: client, loggedIn: p.now()}
+	}
+
+	err := fn(s.client)
+	if err != nil {
+		s = session{}
+	}
+	p.sessions <- s
+	return err
+}
+
+// apiServer exposes the lib functions as JSON endpoints.
+type apiServer struct {
+	pool  *sessionPool
+	token string
+}
+
+// apiError is an error with the HTTP status to return.
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e apiError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...any) error {
+	return apiError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+type apiEmployee struct {
+	ID        string `json:"id"`
+	Lastname  string `json:"lastname"`
+	Firstname string `json:"firstname"`
+	Active    bool   `json:"active"`
+}
+
+type apiProvider struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	ZipCode  string `json:"zip_code"`
+	City     string `json:"city"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email"`
+	Comment  string `json:"comment"`
+	Archived bool   `json:"archived"`
+}
+
+type apiCategory struct {
+	ID       int    `json:"id"`
+	ParentID int    `json:"parent_id"`
+	Name     string `json:"name"`
+	Budget   string `json:"budget"`
+	Kind     string `json:"kind"`
+	Stock    bool   `json:"stock"`
+}
+
+type apiAccount struct {
+	ID     int    `json:"id"`
+	Bank   string `json:"bank"`
+	Budget string `json:"budget"`
+	Abbrev string `json:"abbrev"`
+}
+
+type apiPeriod struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+// apiEntry describes the created entry.
+type apiEntry struct {
+	Date     string   `json:"date"`
+	Name     string   `json:"name"`
+	Budget   string   `json:"budget"`
+	Kind     string   `json:"kind"`
+	Period   string   `json:"period_id"`
+	Receipts []string `json:"receipts,omitempty"`
+}
+
+// handler returns the HTTP handler of the API.
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /employees", s.list(func(client *lib.Client) (any, error) {
+		employees, err := client.ListEmployees()
+		result := make([]apiEmployee, len(employees))
+		for i, e := range employees {
+			result[i] = apiEmployee{ID: e.ID, Lastname: e.Lastname, Firstname: e.Firstname, Active: e.Active}
+		}
+		return result, err
+	}))
+	mux.HandleFunc("GET /providers", s.list(func(client *lib.Client) (any, error) {
+		providers, err := client.ListProviders()
+		result := make([]apiProvider, len(providers))
+		for i, p := range providers {
+			result[i] = apiProvider{
+				ID: p.ID, Name: p.Name, Address: p.Address, ZipCode: p.ZipCode, City: p.City,
+				Phone: p.Phone, Email: p.Email, Comment: p.Comment, Archived: p.Archived,
+			}
+		}
+		return result, err
+	}))
+	mux.HandleFunc("GET /categories", s.list(func(client *lib.Client) (any, error) {
+		categories, err := client.ListCategories()
+		result := make([]apiCategory, len(categories))
+		for i, c := range categories {
+			result[i] = apiCategory{
+				ID: c.ID, ParentID: c.ParentID, Name: c.Name, Budget: c.Budget.String(), Kind: c.Kind.String(),
+				Stock: bool(c.Stock),
+			}
+		}
+		return result, err
+	}))
+	mux.HandleFunc("GET /accounts", s.list(func(client *lib.Client) (any, error) {
+		accounts, err := client.ListAccounts()
+		result := make([]apiAccount, len(accounts))
+		for i, a := range accounts {
+			result[i] = apiAccount{ID: a.ID, Bank: a.Bank, Budget: a.Budget.String(), Abbrev: a.Abbrev}
+		}
+		return result, err
+	}))
+	mux.HandleFunc("GET /periods", s.list(func(client *lib.Client) (any, error) {
+		periods, err := client.ListPeriods()
+		result := make([]apiPeriod, len(periods))
+		for i, p := range periods {
+			result[i] = apiPeriod{
+				ID: p.ID, Status: p.Status.String(),
+				Start: p.Start.Format(time.DateOnly), End: p.End.Format(time.DateOnly),
+			}
+		}
+		return result, err
+	}))
+	mux.HandleFunc("POST /entries", s.handleCreateEntry)
+	return common.RequireToken(s.token, mux, func(w http.ResponseWriter) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	})
+}
+
+// list returns a handler writing the result of fetch as JSON.
+func (s *apiServer) list(fetch func(client *lib.Client) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var result any
+		err := s.pool.with(func(client *lib.Client) (err error) {
+			result, err = fetch(client)
+			return
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func (s *apiServer) handleCreateEntry(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxEntryUploadSize)
+	if err := r.ParseMultipartForm(maxEntryUploadSize); err != nil {
+		writeError(w, badRequest("invalid multipart form: %s", err))
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	entry, err := parseEntryForm(r.MultipartForm)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "happycompta-receipts-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	if entry.Receipts, err = saveReceipts(r.MultipartForm.File["receipts"], dir); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = s.pool.with(func(client *lib.Client) error {
+		if err := validateEntryReferences(client, &entry); err != nil {
+			return err
+		}
+		return client.AddEntry(&entry)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	created := apiEntry{
+		Date: entry.Date.Format(time.DateOnly), Name: entry.Name, Budget: entry.Budget.String(),
+		Kind: entry.Kind.String(), Period: entry.Period,
+	}
+	for _, receipt := range entry.Receipts {
+		created.Receipts = append(created.Receipts, filepath.Base(receipt))
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// parseEntryForm builds an entry from the fields of the creation form.
+// The IDs are only checked against happy-compta by validateEntryReferences.
+func parseEntryForm(form *multipart.Form) (entry lib.Entry, err error) {
+	value := func(name string) string {
+		if values := form.Value[name]; len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+	var allErrors []error
+
+	if entry.Date, err = common.ParseDate(value("date"), nil); err != nil {
+		allErrors = append(allErrors, fmt.Errorf("invalid date: %s", err))
+	}
+	if entry.Name = value("name"); entry.Name == "" {
+		allErrors = append(allErrors, errors.New("missing name"))
+	}
+	if entry.Budget = lib.NewBudgetFromString(value("budget")); entry.Budget == lib.BudgetUndefined {
+		allErrors = append(allErrors, fmt.Errorf("invalid budget '%s', accepted values are FON and ASC", value("budget")))
+	}
+	entry.Kind = lib.KindSpend
+	if kind := value("kind"); kind != "" {
+		if entry.Kind = lib.NewKind(kind); entry.Kind == lib.KindUndefined {
+			allErrors = append(allErrors, fmt.Errorf("invalid kind '%s'", kind))
+		}
+	}
+	if entry.PaymentMethod = lib.NewPaymentMethodFromString(value("payment")); entry.PaymentMethod == lib.PaymentMethodUndefined {
+		allErrors = append(allErrors, fmt.Errorf("invalid payment method '%s'", value("payment")))
+	}
+	if entry.Account.ID, err = strconv.Atoi(value("account_id")); err != nil {
+		allErrors = append(allErrors, fmt.Errorf("invalid account_id '%s'", value("account_id")))
+	}
+	entry.Period = value("period_id")
+	entry.Comment = value("comment")
+	entry.CheckNumber = value("check")
+
+	employee, provider := value("employee_id"), value("provider_id")
+	switch {
+	case employee != "" && provider != "":
+		allErrors = append(allErrors, errors.New("employee_id and provider_id can't be both set"))
+	case employee != "":
+		entry.Party = &lib.Employee{ID: employee}
+	case provider != "":
+		entry.Party = &lib.Provider{ID: provider}
+	}
+
+	categories, amounts, stocks := form.Value["category_id"], form.Value["amount"], form.Value["stock"]
+	if len(categories) == 0 {
+		allErrors = append(allErrors, errors.New("missing category_id"))
+	}
+	for i, categoryID := range categories {
+		var line lib.AllocationLine
+		if line.CategoryID, err = strconv.Atoi(strings.TrimSpace(categoryID)); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid category_id '%s'", categoryID))
+		}
+		if i < len(amounts) && strings.TrimSpace(amounts[i]) != "" {
+			if line.Amount, err = common.ParseAmount(strings.TrimSpace(amounts[i])); err != nil {
+				allErrors = append(allErrors, fmt.Errorf("invalid amount '%s': %s", amounts[i], err))
+			}
+		}
+		if i < len(stocks) && strings.TrimSpace(stocks[i]) != "" {
+			if line.Stock, err = strconv.Atoi(strings.TrimSpace(stocks[i])); err != nil {
+				allErrors = append(allErrors, fmt.Errorf("invalid stock '%s'", stocks[i]))
+			}
+		}
+		entry.Allocation = append(entry.Allocation, line)
+	}
+
+	if receipts := form.File["receipts"]; len(receipts) > 3 {
+		allErrors = append(allErrors, fmt.Errorf("%d receipts, but maximum is 3 per entry", len(receipts)))
+	}
+
+	if len(allErrors) > 0 {
+		return entry, apiError{status: http.StatusBadRequest, err: errors.Join(allErrors...)}
+	}
+	return entry, nil
+}
+
+// validateEntryReferences checks that the account and categories exist for the budget of the entry
+// and sets the default period.
+func validateEntryReferences(client *lib.Client, entry *lib.Entry) error {
+	accounts, err := client.ListAccounts()
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(accounts, func(a lib.Account) bool { return a.ID == entry.Account.ID })
+	if index < 0 || accounts[index].Budget != entry.Budget {
+		return badRequest("no %s account with ID %d", entry.Budget, entry.Account.ID)
+	}
+	entry.Account = accounts[index]
+
+	categories, err := client.ListCategories()
+	if err != nil {
+		return err
+	}
+	for _, line := range entry.Allocation {
+		index := slices.IndexFunc(categories, func(c lib.Category) bool { return c.ID == line.CategoryID })
+		if index < 0 || categories[index].Budget != entry.Budget {
+			return badRequest("no %s category with ID %d", entry.Budget, line.CategoryID)
+		}
+	}
+
+	periods, err := client.ListPeriods()
+	if err != nil {
+		return err
+	}
+	if entry.Period, err = lib.FindPeriod(periods, entry.Period); err != nil {
+		return apiError{status: http.StatusBadRequest, err: err}
+	}
+	return nil
+}
+
+// saveReceipts writes the uploaded receipts in dir with their original names.
+func saveReceipts(files []*multipart.FileHeader, dir string) ([]string, error) {
+	var paths []string
+	for _, header := range files {
+		name := filepath.Base(filepath.Clean("/" + header.Filename))
+		if name == "/" || name == "." {
+			return nil, badRequest("invalid receipt name '%s'", header.Filename)
+		}
+		path := filepath.Join(dir, name)
+		if slices.Contains(paths, path) {
+			return nil, badRequest("duplicate receipt name '%s'", name)
+		}
+
+		src, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		dst, err := os.Create(path)
+		if err != nil {
+			_ = src.Close()
+			return nil, err
+		}
+		_, err = io.Copy(dst, src)
+		_ = src.Close()
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save the receipt %s: %s", name, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeError writes the error as JSON. The errors without status are happy-compta failures.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var apiErr apiError
+	if errors.As(err, &apiErr) {
+		status = apiErr.status
+	} else {
+		slog.Error("request failed", "error", err)
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		slog.Error("failed to write the response", "error", err)
+	}
+}
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/mockserver"
+	"github.com/cbosdo/happycompta-tools/lib"
+)
+
+func newServeMock() *mockserver.Server {
+	return mockserver.New(mockserver.Data{
+		Email:    "treasurer@example.com",
+		Password: "secret",
+		Accounts: []lib.Account{
+			{ID: 1, Bank: "Bank A", Budget: lib.BudgetFON},
+			{ID: 2, Bank: "Bank A", Budget: lib.BudgetASC},
+		},
+		Categories: []lib.Category{
+			{ID: 100, Name: "Office supplies", Budget: lib.BudgetFON, Kind: lib.KindSpend},
+			{ID: 200, Name: "Gifts", Budget: lib.BudgetASC, Kind: lib.KindSpend},
+		},
+		Employees: []lib.Employee{{ID: "100001", Lastname: "Doe", Firstname: "Jane", Active: true}},
+		Providers: []lib.Provider{{ID: "200001", Name: "Traiteur", City: "Paris"}},
+		Periods: []lib.Period{{
+			ID:     "12345",
+			Status: lib.PeriodStatusCurrent,
+			Start:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+	})
+}
+
+// newTestAPI returns the handler of an API server using the mock and the number of clients it created.
+func newTestAPI(t *testing.T, mock *mockserver.Server, token string) (http.Handler, *int) {
+	t.Helper()
+	created := 0
+	credentials := common.Credentials{Email: "treasurer@example.com", Password: "secret"}
+	pool := newSessionPool(credentials, 1, func() (*lib.Client, error) {
+		created++
+		return lib.NewClientWithURL(mock.URL)
+	})
+	return (&apiServer{pool: pool, token: token}).handler(), &created
+}
+
+func TestServeList(t *testing.T) {
+	mock := newServeMock()
+	defer mock.Close()
+	handler, created := newTestAPI(t, mock, "")
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/employees", `[{"id":"100001","lastname":"Doe","firstname":"Jane","active":true}]`},
+		{
+			"/providers",
+			`[{"id":"200001","name":"Traiteur","address":"","zip_code":"","city":"Paris","phone":"","email":"",` +
+				`"comment":"","archived":false}]`,
+		},
+		{"/accounts", `[{"id":1,"bank":"Bank A","budget":"FON","abbrev":""},` +
+			`{"id":2,"bank":"Bank A","budget":"ASC","abbrev":""}]`},
+		{"/periods", `[{"id":"12345","status":"current","start":"2025-01-01","end":"2025-12-31"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Status mismatch. Got: %d, Want: %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			if actual := strings.TrimSpace(rec.Body.String()); actual != tt.expected {
+				t.Errorf("Body mismatch. Got: %s, Want: %s", actual, tt.expected)
+			}
+		})
+	}
+
+	if *created != 1 {
+		t.Errorf("Expected the session to be reused, got %d clients", *created)
+	}
+}
+
+func TestServeAuthentication(t *testing.T) {
+	mock := newServeMock()
+	defer mock.Close()
+	handler, _ := newTestAPI(t, mock, "s3cret")
+
+	tests := []struct {
+		name     string
+		header   string
+		expected int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", "Bearer nope", http.StatusUnauthorized},
+		{"valid", "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Errorf("Status mismatch. Got: %d, Want: %d", rec.Code, tt.expected)
+			}
+		})
+	}
+}
+
+// entryRequest builds a multipart entry creation request.
+func entryRequest(t *testing.T, fields [][2]string, receipts map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, field := range fields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range receipts {
+		part, err := writer.CreateFormFile("receipts", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/entries", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestServeCreateEntry(t *testing.T) {
+	mock := newServeMock()
+	defer mock.Close()
+	handler, _ := newTestAPI(t, mock, "")
+
+	req := entryRequest(t, [][2]string{
+		{"date", "14/03/2025"},
+		{"name", "Paper"},
+		{"budget", "FON"},
+		{"payment", "card"},
+		{"account_id", "1"},
+		{"category_id", "100"},
+		{"amount", "42.50"},
+		{"provider_id", "200001"},
+	}, map[string]string{"paper.pdf": "paper"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Status mismatch. Got: %d, Want: %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	var created apiEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse the response: %v", err)
+	}
+	expected := apiEntry{
+		Date: "2025-03-14", Name: "Paper", Budget: "FON", Kind: "depenses", Period: "12345",
+		Receipts: []string{"paper.pdf"},
+	}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("Created entry mismatch. Got: %+v, Want: %+v", created, expected)
+	}
+
+	entries := mock.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry on the server, got %d", len(entries))
+	}
+	if entries[0].Name != "Paper" || !reflect.DeepEqual(entries[0].Receipts, []string{"paper.pdf"}) {
+		t.Errorf("Server entry mismatch. Got: %+v", entries[0])
+	}
+	if content, found := mock.Receipt("paper.pdf"); !found || content != "paper" {
+		t.Errorf("Receipt content mismatch. Got: %s, Want: paper", content)
+	}
+}
+
+func TestServeCreateEntryErrors(t *testing.T) {
+	mock := newServeMock()
+	defer mock.Close()
+	handler, _ := newTestAPI(t, mock, "")
+
+	valid := [][2]string{
+		{"date", "2025-03-14"},
+		{"name", "Paper"},
+		{"payment", "card"},
+		{"category_id", "100"},
+		{"amount", "10.00"},
+	}
+	tests := []struct {
+		name     string
+		fields   [][2]string
+		receipts map[string]string
+		wantErr  string
+	}{
+		{"missing fields", [][2]string{{"name", "Paper"}}, nil, "invalid budget"},
+		{
+			"both parties",
+			append(valid, [2]string{"budget", "FON"}, [2]string{"account_id", "1"},
+				[2]string{"employee_id", "100001"}, [2]string{"provider_id", "200001"}),
+			nil,
+			"employee_id and provider_id can't be both set",
+		},
+		{
+			"wrong account budget",
+			append(valid, [2]string{"budget", "FON"}, [2]string{"account_id", "2"}),
+			nil,
+			"no FON account with ID 2",
+		},
+		{
+			"wrong category budget",
+			append(valid, [2]string{"budget", "ASC"}, [2]string{"account_id", "2"}),
+			nil,
+			"no ASC category with ID 100",
+		},
+		{
+			"too many receipts",
+			append(valid, [2]string{"budget", "FON"}, [2]string{"account_id", "1"}),
+			map[string]string{"a.pdf": "a", "b.pdf": "b", "c.pdf": "c", "d.pdf": "d"},
+			"4 receipts, but maximum is 3 per entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, entryRequest(t, tt.fields, tt.receipts))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Status mismatch. Got: %d, Want: %d", rec.Code, http.StatusBadRequest)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to parse the response: %v", err)
+			}
+			if !strings.Contains(body["error"], tt.wantErr) {
+				t.Errorf("Error mismatch. Got: %s, Want: %s", body["error"], tt.wantErr)
+			}
+		})
+	}
+
+	if entries := mock.Entries(); len(entries) != 0 {
+		t.Errorf("Expected no entry to be created, got %d", len(entries))
+	}
+}
+
+func TestSessionPool(t *testing.T) {
+	mock := newServeMock()
+	defer mock.Close()
+
+	created := 0
+	credentials := common.Credentials{Email: "treasurer@example.com", Password: "secret"}
+	pool := newSessionPool(credentials, 1, func() (*lib.Client, error) {
+		created++
+		return lib.NewClientWithURL(mock.URL)
+	})
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	pool.now = func() time.Time { return now }
+	noop := func(client *lib.Client) error { return nil }
+
+	if err := pool.with(noop); err != nil {
+		t.Fatalf("First call failed: %v", err)
+	}
+	if err := pool.with(noop); err != nil || created != 1 {
+		t.Errorf("Expected the session to be reused, got %d clients: %v", created, err)
+	}
+
+	if err := pool.with(func(client *lib.Client) error { return errors.New("expired") }); err == nil {
+		t.Error("Expected the error to be returned")
+	}
+	if err := pool.with(noop); err != nil || created != 2 {
+		t.Errorf("Expected a new session after the failure, got %d clients: %v", created, err)
+	}
+
+	now = now.Add(sessionMaxAge + time.Minute)
+	if err := pool.with(noop); err != nil || created != 3 {
+		t.Errorf("Expected a new session after expiration, got %d clients: %v", created, err)
+	}
+
+	pool.credentials.Password = "wrong"
+	pool.sessions = make(chan session, 1)
+	pool.sessions <- session{}
+	if err := pool.with(noop); err == nil {
+		t.Error("Expected the login to fail")
+	}
+	if s := <-pool.sessions; s.client != nil {
+		t.Error("Expected the failed session to be released empty")
+	}
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/cbosdo/happycompta-tools/internal/dumper"
+	"github.com/cbosdo/happycompta-tools/internal/loader"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Subfolders of the inbox the processed files are moved to.
+const (
+	importedFolder = "imported"
+	failedFolder   = "failed"
+)
+
+func newSyncCmd() *cobra.Command {
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Periodically import the inbox files and refresh the local mirror",
+		Long: `Periodically import the files dropped in the inbox folder and refresh the local mirror of the happy-compta data.
+
+At each run:
+  - the CSV files and YAML or JSON manifests of the inbox are imported like with the load command and --yes,
+    using the load settings of the configuration file, like the profile of the bank statements,
+  - the imported files are moved to the imported subfolder and the failed ones to the failed subfolder,
+    with their errors CSV file listing the rows to fix and import again,
+  - the mirror file is refreshed with the data of the dump command in YAML.
+
+Without interval, the command runs once and can be scheduled with cron.
+With an interval, the GET /healthz endpoint answers 200 when the last successful run is recent enough and 503 otherwise.
+It listens on the loopback interface by default as the errors of the runs are returned.
+
+The camt.053 and OFX bank statements, IMAP mailboxes and SQLite mirrors are not supported.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			inbox, err := flags.GetString("inbox")
+			if err != nil {
+				return err
+			}
+			if inbox == "" {
+				return common.WithExitCode(common.ExitUsage, errors.New("the inbox folder is required"))
+			}
+			mirror, err := flags.GetString("mirror")
+			if err != nil {
+				return err
+			}
+			interval, err := flags.GetDuration("interval")
+			if err != nil {
+				return err
+			}
+			listen, err := flags.GetString("listen")
+			if err != nil {
+				return err
+			}
+
+			s := newSyncer(inbox, mirror, viper.ConfigFileUsed())
+			// The tools commands reset the settings: pass them the credentials of the sync command.
+			s.credentials = common.ReadCredentials()
+			if err := s.credentials.Validate(); err != nil {
+				return err
+			}
+			if interval == 0 {
+				return s.runOnce()
+			}
+
+			if listen != "" {
+				go func() {
+					slog.Info("listening", "address", listen)
+					if err := http.ListenAndServe(listen, s.handler(interval, time.Now)); err != nil {
+						slog.Error("the health server stopped", "error", err)
+					}
+				}()
+			}
+			for {
+				if err := s.runOnce(); err != nil {
+					slog.Error("failed to sync", "error", err)
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+	addSharedFlags(syncCmd)
+	syncCmd.Flags().String("inbox", "", "Folder to import the new files from (REQUIRED).")
+	syncCmd.Flags().String("mirror", "mirror.yaml", "Path of the file to write the dumped data to. Empty disables it.")
+	syncCmd.Flags().Duration("interval", 0, "Time between two runs, like 1h or 30m. 0 runs only once.")
+	syncCmd.Flags().String("listen", "127.0.0.1:8081", "Address of the health endpoint. Empty disables it.")
+	return syncCmd
+}
+
+// syncStatus is the result of the last runs, returned by the health endpoint.
+type syncStatus struct {
+	Status      string    `json:"status"`
+	LastRun     time.Time `json:"last_run,omitzero"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	Imported    int       `json:"imported"`
+	Failed      int       `json:"failed"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// syncer imports the inbox files and refreshes the mirror with the load and dump commands.
+type syncer struct {
+	inbox  string
+	mirror string
+	config string
+	// credentials are passed to the tools commands, if set.
+	credentials common.Credentials
+	// execute runs a tool command with the arguments.
+	execute func(cmd *cobra.Command, args []string) error
+
+	mutex  sync.Mutex
+	status syncStatus
+}
+
+func newSyncer(inbox string, mirror string, config string) *syncer {
+	return &syncer{inbox: inbox, mirror: mirror, config: config, execute: executeTool}
+}
+
+// executeTool runs a tool command as if called from the command line.
+// The viper settings are reset as each tool binds its own flags and environment variables.
+func executeTool(cmd *cobra.Command, args []string) error {
+	viper.Reset()
+	cmd.SetArgs(args)
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	return cmd.Execute()
+}
+
+// inboxFiles lists the files of the inbox the load command can import, in alphabetical order.
+func inboxFiles(inbox string) ([]string, error) {
+	items, err := os.ReadDir(inbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the inbox folder %s: %s", inbox, err)
+	}
+	var files []string
+	for _, item := range items {
+		switch strings.ToLower(filepath.Ext(item.Name())) {
+		case ".csv", ".yaml", ".yml", ".json":
+			if !item.IsDir() {
+				files = append(files, filepath.Join(inbox, item.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+// runOnce imports the inbox files and refreshes the mirror.
+// The failure of a file doesn't prevent importing the other ones.
+func (s *syncer) runOnce() error {
+	started := time.Now()
+	status := syncStatus{LastRun: started}
+	var allErrors []error
+
+	files, err := inboxFiles(s.inbox)
+	if err != nil {
+		allErrors = append(allErrors, err)
+	}
+	for _, file := range files {
+		if err := s.importFile(file); err != nil {
+			status.Failed++
+			allErrors = append(allErrors, err)
+		} else {
+			status.Imported++
+		}
+	}
+
+	if s.mirror != "" {
+		if err := s.execute(dumper.NewCommand("dump"), s.withConfig("--format", "yaml", "--output", s.mirror)); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("failed to refresh the mirror: %s", err))
+		}
+	}
+
+	err = errors.Join(allErrors...)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status.LastSuccess = s.status.LastSuccess
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LastSuccess = started
+	}
+	s.status = status
+	slog.Info("sync done", "imported", status.Imported, "failed", status.Failed)
+	return err
+}
+
+// importFile imports a file of the inbox and moves it to the imported or failed subfolder.
+func (s *syncer) importFile(file string) error {
+	name := filepath.Base(file)
+	folder := filepath.Join(s.inbox, importedFolder)
+	errorsCSV := filepath.Join(s.inbox, failedFolder, strings.TrimSuffix(name, filepath.Ext(name))+"-errors.csv")
+	for _, dir := range []string{folder, filepath.Dir(errorsCSV)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %s", dir, err)
+		}
+	}
+
+	slog.Info("importing", "file", file)
+	importErr := s.execute(loader.NewCommand("load"), s.withConfig(file, "--yes", "--errors-csv", errorsCSV))
+	if importErr != nil {
+		folder = filepath.Dir(errorsCSV)
+		importErr = fmt.Errorf("failed to import %s: %s", name, importErr)
+	}
+	if err := os.Rename(file, filepath.Join(folder, name)); err != nil {
+		return errors.Join(importErr, fmt.Errorf("failed to move %s: %s", file, err))
+	}
+	return importErr
+}
+
+// withConfig adds the configuration file and credentials of the sync command to the arguments of a tool command.
+func (s *syncer) withConfig(args ...string) []string {
+	if s.config != "" {
+		args = append(args, "--config", s.config)
+	}
+	if s.credentials.Email != "" {
+		args = append(args, "--email", s.credentials.Email)
+	}
+	if s.credentials.Password != "" {
+		args = append(args, "--password", s.credentials.Password)
+	}
+	return args
+}
+
+// handler returns the HTTP handler of the health endpoint.
+// The service is healthy if the last successful run is less than two intervals old.
+func (s *syncer) handler(interval time.Duration, now func() time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		status := s.status
+		s.mutex.Unlock()
+
+		code := http.StatusOK
+		status.Status = "ok"
+		if status.LastSuccess.IsZero() || now().Sub(status.LastSuccess) > 2*interval {
+			code = http.StatusServiceUnavailable
+			status.Status = "unhealthy"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			slog.Error("failed to write the health status", "error", err)
+		}
+	})
+	return mux
+}
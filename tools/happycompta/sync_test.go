@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/spf13/cobra"
+)
+
+func TestSyncRunOnce(t *testing.T) {
+	inbox := t.TempDir()
+	for _, name := range []string{"a.csv", "b.yaml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(inbox, name), []byte("content"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(inbox, "folder.csv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls [][]string
+	s := newSyncer(inbox, "mirror.yaml", "config.yaml")
+	s.credentials = common.Credentials{Email: "jdoe@example.com", Password: "secret"}
+	s.execute = func(cmd *cobra.Command, args []string) error {
+		calls = append(calls, append([]string{cmd.Name()}, args...))
+		if strings.HasSuffix(args[0], "b.yaml") {
+			return errors.New("invalid entries")
+		}
+		return nil
+	}
+
+	err := s.runOnce()
+	if err == nil || !strings.Contains(err.Error(), "failed to import b.yaml: invalid entries") {
+		t.Errorf("Expected the b.yaml import error, got: %v", err)
+	}
+
+	credentials := []string{"--config", "config.yaml", "--email", "jdoe@example.com", "--password", "secret"}
+	expected := [][]string{
+		append([]string{"load", filepath.Join(inbox, "a.csv"), "--yes",
+			"--errors-csv", filepath.Join(inbox, "failed", "a-errors.csv")}, credentials...),
+		append([]string{"load", filepath.Join(inbox, "b.yaml"), "--yes",
+			"--errors-csv", filepath.Join(inbox, "failed", "b-errors.csv")}, credentials...),
+		append([]string{"dump", "--format", "yaml", "--output", "mirror.yaml"}, credentials...),
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Calls mismatch. Got: %v, Want: %v", calls, expected)
+	}
+
+	for _, path := range []string{"imported/a.csv", "failed/b.yaml", "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(inbox, path)); err != nil {
+			t.Errorf("Expected %s in the inbox: %v", path, err)
+		}
+	}
+
+	if s.status.Imported != 1 || s.status.Failed != 1 || !s.status.LastSuccess.IsZero() || s.status.Error == "" {
+		t.Errorf("Status mismatch. Got: %+v", s.status)
+	}
+}
+
+func TestSyncHealth(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		lastSuccess time.Time
+		expected    int
+	}{
+		{"NeverRun", time.Time{}, http.StatusServiceUnavailable},
+		{"Recent", now.Add(-90 * time.Minute), http.StatusOK},
+		{"Old", now.Add(-3 * time.Hour), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSyncer("inbox", "", "")
+			s.status.LastSuccess = tt.lastSuccess
+
+			recorder := httptest.NewRecorder()
+			handler := s.handler(time.Hour, func() time.Time { return now })
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if recorder.Code != tt.expected {
+				t.Errorf("Status code mismatch. Got: %d, Want: %d", recorder.Code, tt.expected)
+			}
+			if !strings.Contains(recorder.Body.String(), `"status"`) {
+				t.Errorf("Expected a JSON status, got: %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestSyncDefaults(t *testing.T) {
+	flags := newSyncCmd().Flags()
+	// Without interval, the command runs once.
+	if interval, err := flags.GetDuration("interval"); err != nil || interval != 0 {
+		t.Errorf("Interval mismatch. Got: %v, Want: 0", interval)
+	}
+	if listen, err := flags.GetString("listen"); err != nil || !common.IsLoopbackAddress(listen) {
+		t.Errorf("Expected the health endpoint to listen on loopback, got: %s", listen)
+	}
+}
@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/cbosdo/happycompta-tools/internal/common"
+	"github.com/spf13/cobra"
+)
+
+// releaseChecksums is the name of the release file listing the SHA-256 checksums of the archives.
+const releaseChecksums = "checksums.txt"
+
+// releasesURL is the GitHub API endpoint describing the latest release of the tools.
+var releasesURL = "https://api.github.com/repos/cbosdo/happycompta-tools/releases/latest"
+
+// release holds the fields of the GitHub release used to check for updates.
+type release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func newVersionCmd() *cobra.Command {
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version and check for updates",
+		Long: `Print the version of the tools.
+
+With the check flag, the latest release is looked up on GitHub to report whether a newer version exists.
+With the download flag, the archive of the newer release for this system is downloaded in the given directory
+and verified against the SHA-256 checksums of the release.`,
+		Args: common.UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			if _, err := fmt.Fprintln(out, common.FullVersion()); err != nil {
+				return err
+			}
+
+			check, err := cmd.Flags().GetBool("check")
+			if err != nil {
+				return err
+			}
+			download, err := cmd.Flags().GetString("download")
+			if err != nil {
+				return err
+			}
+			if !check && download == "" {
+				return nil
+			}
+			return checkVersion(out, common.Version(), download)
+		},
+	}
+	versionCmd.Flags().Bool("check", false, "Check on GitHub whether a newer release is available.")
+	versionCmd.Flags().String("download", "", `Directory to download the newer release for this system to.
+It implies the check flag. The installed program is not replaced.`)
+	return versionCmd
+}
+
+// checkVersion reports whether the latest release is newer than the current version
+// and downloads its archive to the download directory if not empty.
+func checkVersion(w io.Writer, current string, download string) error {
+	latest, err := getLatestRelease()
+	if err != nil {
+		return err
+	}
+	latestVersion := strings.TrimPrefix(latest.TagName, "v")
+
+	// The builds outside of the releases don't have a version to compare.
+	switch {
+	case current == "dev":
+		message := common.Tr("The version is unknown and can't be compared to the latest release: %s\n%s\n")
+		if _, err := fmt.Fprintf(w, message, latestVersion, latest.HTMLURL); err != nil {
+			return err
+		}
+	case compareVersions(current, latestVersion) >= 0:
+		_, err := fmt.Fprintf(w, common.Tr("The version is up to date, the latest release is %s\n"), latestVersion)
+		return err
+	default:
+		message := common.Tr("A newer release is available: %s\n%s\n")
+		if _, err := fmt.Fprintf(w, message, latestVersion, latest.HTMLURL); err != nil {
+			return err
+		}
+	}
+	if download == "" {
+		return nil
+	}
+
+	archive := releaseArchiveName(runtime.GOOS, runtime.GOARCH)
+	archiveURL := latest.assetURL(archive)
+	if archiveURL == "" {
+		return fmt.Errorf("no file of the %s release for %s/%s", latestVersion, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumsURL := latest.assetURL(releaseChecksums)
+	if checksumsURL == "" {
+		return fmt.Errorf("no %s file in the %s release", releaseChecksums, latestVersion)
+	}
+	checksum, err := getChecksum(checksumsURL, archive)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(download, archive)
+	if err := downloadFile(archiveURL, path, checksum); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, common.Tr("Downloaded %s\n"), path)
+	return err
+}
+
+// releaseArchiveName returns the name of the release archive for the system, following the goreleaser name template.
+func releaseArchiveName(goos string, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+	extension := ".tar.gz"
+	if goos == "windows" {
+		extension = ".zip"
+	}
+	return fmt.Sprintf("happycompta-tools_%s_%s%s", strings.ToUpper(goos[:1])+goos[1:], arch, extension)
+}
+
+// assetURL returns the download URL of the release file with the given name or an empty string.
+func (r release) assetURL(name string) string {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.DownloadURL
+		}
+	}
+	return ""
+}
+
+// getChecksum downloads the checksums file of the release and returns the SHA-256 checksum of the named file.
+func getChecksum(url string, name string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, common.WithExitCode(common.ExitRemote, fmt.Errorf("failed to download %s: %s", url, resp.Status))
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %s", url, err)
+	}
+
+	for line := range strings.Lines(string(content)) {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == name {
+			checksum, err := hex.DecodeString(fields[0])
+			if err != nil || len(checksum) != sha256.Size {
+				return nil, common.WithExitCode(common.ExitRemote,
+					fmt.Errorf("invalid checksum of %s in %s: %s", name, releaseChecksums, fields[0]))
+			}
+			return checksum, nil
+		}
+	}
+	return nil, common.WithExitCode(common.ExitRemote, fmt.Errorf("no checksum of %s in %s", name, releaseChecksums))
+}
+
+// getLatestRelease queries the GitHub API for the latest release.
+func getLatestRelease() (latest release, err error) {
+	req, err := http.NewRequest("GET", releasesURL, nil)
+	if err != nil {
+		err = fmt.Errorf("failed to create the request: %s", err)
+		return
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		err = fmt.Errorf("failed to get the latest release: %w", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		err = common.WithExitCode(common.ExitRemote,
+			fmt.Errorf("failed to get the latest release, got %d status code", resp.StatusCode))
+		return
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		err = common.WithExitCode(common.ExitRemote, fmt.Errorf("failed to parse the latest release: %s", err))
+	}
+	return
+}
+
+// downloadFile writes the content at the URL to path and checks its SHA-256 checksum.
+// The file is removed if the checksum doesn't match.
+func downloadFile(url string, path string, checksum []byte) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return common.WithExitCode(common.ExitRemote, fmt.Errorf("failed to download %s: %s", url, resp.Status))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", path, err)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to download %s: %s", url, err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to write %s: %s", path, err)
+	}
+	if actual := hash.Sum(nil); !bytes.Equal(actual, checksum) {
+		_ = os.Remove(path)
+		return common.WithExitCode(common.ExitRemote,
+			fmt.Errorf("checksum mismatch for %s: got %x, expected %x", url, actual, checksum))
+	}
+	return nil
+}
+
+// compareVersions compares two dotted versions like 1.2.10, ignoring the pre-release suffixes.
+// It returns a negative number if a is older than b, 0 if they are the same and a positive number otherwise.
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(strings.SplitN(strings.TrimPrefix(a, "v"), "-", 2)[0], ".")
+	bParts := strings.Split(strings.SplitN(strings.TrimPrefix(b, "v"), "-", 2)[0], ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aValue, bValue int
+		if i < len(aParts) {
+			aValue, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bValue, _ = strconv.Atoi(bParts[i])
+		}
+		if aValue != bValue {
+			return aValue - bValue
+		}
+	}
+	return 0
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 SUSE LLC
+// SPDX-FileContributor: Cédric Bosdonnat
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"v1.2.0", "1.2.0", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"1.3", "1.2.9", 1},
+		{"1.2.0-rc1", "1.2", 0},
+		{"2.0.0", "1.99.99", 1},
+	}
+
+	for _, test := range tests {
+		actual := compareVersions(test.a, test.b)
+		if (actual < 0 && test.expected >= 0) || (actual > 0 && test.expected <= 0) ||
+			(actual == 0 && test.expected != 0) {
+			t.Errorf("Comparison mismatch for %s and %s. Got: %d, Want sign of: %d", test.a, test.b, actual, test.expected)
+		}
+	}
+}
+
+func TestReleaseArchiveName(t *testing.T) {
+	tests := []struct {
+		goos     string
+		goarch   string
+		expected string
+	}{
+		{"linux", "amd64", "happycompta-tools_Linux_x86_64.tar.gz"},
+		{"linux", "arm64", "happycompta-tools_Linux_arm64.tar.gz"},
+		{"darwin", "arm64", "happycompta-tools_Darwin_arm64.tar.gz"},
+		{"windows", "amd64", "happycompta-tools_Windows_x86_64.zip"},
+		{"linux", "386", "happycompta-tools_Linux_i386.tar.gz"},
+	}
+	for _, test := range tests {
+		if actual := releaseArchiveName(test.goos, test.goarch); actual != test.expected {
+			t.Errorf("Archive name mismatch for %s/%s. Got: %s, Want: %s", test.goos, test.goarch, actual, test.expected)
+		}
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	assetName := releaseArchiveName(runtime.GOOS, runtime.GOARCH)
+	archive := "archive content"
+	checksum := sha256.Sum256([]byte(archive))
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			fmt.Fprintf(w, `{"tag_name": "v1.3.0", "html_url": "https://example.com/v1.3.0", "assets": [`+
+				`{"name": "checksums.txt", "browser_download_url": "%[1]s/checksums.txt"},`+
+				`{"name": "happycompta-tools_Linux_armv7.tar.gz", "browser_download_url": "%[1]s/other"},`+
+				`{"name": "%[2]s", "browser_download_url": "%[1]s/archive"}]}`, server.URL, assetName)
+		case "/checksums.txt":
+			fmt.Fprintf(w, "%x  happycompta-tools_Linux_armv7.tar.gz\n%x  %s\n", sha256.Sum256(nil), checksum, assetName)
+		case "/archive":
+			fmt.Fprint(w, archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	previous := releasesURL
+	releasesURL = server.URL + "/latest"
+	t.Cleanup(func() { releasesURL = previous })
+
+	var out bytes.Buffer
+	if err := checkVersion(&out, "1.3.0", ""); err != nil {
+		t.Fatalf("checkVersion failed: %s", err)
+	}
+	if !strings.Contains(out.String(), "up to date") {
+		t.Errorf("Output mismatch. Got: %s, Want an up to date message", out.String())
+	}
+
+	out.Reset()
+	if err := checkVersion(&out, "1.2.5", ""); err != nil {
+		t.Fatalf("checkVersion failed: %s", err)
+	}
+	if !strings.Contains(out.String(), "A newer release is available: 1.3.0\nhttps://example.com/v1.3.0") {
+		t.Errorf("Output mismatch. Got: %s, Want a newer release message", out.String())
+	}
+
+	dir := t.TempDir()
+	out.Reset()
+	if err := checkVersion(&out, "dev", dir); err != nil {
+		t.Fatalf("checkVersion failed: %s", err)
+	}
+	if !strings.Contains(out.String(), "The version is unknown and can't be compared to the latest release: 1.3.0") {
+		t.Errorf("Output mismatch. Got: %s, Want an unknown version message", out.String())
+	}
+	content, err := os.ReadFile(filepath.Join(dir, assetName))
+	if err != nil {
+		t.Fatalf("failed to read the downloaded file: %s", err)
+	}
+	if string(content) != "archive content" {
+		t.Errorf("Downloaded content mismatch. Got: %s, Want: archive content", content)
+	}
+
+	// A corrupted download is reported and removed.
+	archive = "corrupted content"
+	dir = t.TempDir()
+	if err := checkVersion(&out, "dev", dir); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected a checksum mismatch error, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, assetName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the corrupted download to be removed, got: %v", err)
+	}
+}